@@ -24,8 +24,11 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/wait"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -35,6 +38,7 @@ import (
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -199,6 +203,15 @@ func Run(c *config.CompletedConfig, stopCh <-chan struct{}) error {
 		Logger: setupLog,
 		Cache: cache.Options{
 			DefaultTransform: trimManagedFields,
+			ByObject: map[client.Object]cache.ByObject{
+				// only leases renewed by leader hubs are list/watched by yurt-manager.
+				&coordinationv1.Lease{}: {
+					Namespaces: map[string]cache.Config{
+						c.ComponentConfig.HubLeaderConfigController.HubLeaderNamespace: {},
+					},
+					Label: hubLeaderLeaseSelector(),
+				},
+			},
 		},
 	})
 	if err != nil {
@@ -263,3 +276,9 @@ func setRestConfig(c *rest.Config, config *config.CompletedConfig) {
 
 	c.UserAgent = YurtManager
 }
+
+// hubLeaderLeaseSelector selects leases which are renewed by leader hubs.
+func hubLeaderLeaseSelector() labels.Selector {
+	req, _ := labels.NewRequirement(projectinfo.GetHubLeaderLeaseLabel(), selection.Exists, nil)
+	return labels.NewSelector().Add(*req)
+}
@@ -50,6 +50,8 @@ type Config struct {
 	ServerCount                 int
 	ProxyStrategy               string
 	InterceptorServerUDSFile    string
+	TracingEndpoint             string
+	TracingSamplingRate         int32
 }
 
 type completedConfig struct {
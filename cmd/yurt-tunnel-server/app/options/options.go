@@ -33,6 +33,7 @@ import (
 	"github.com/openyurtio/openyurt/pkg/util/certmanager"
 	utilip "github.com/openyurtio/openyurt/pkg/util/ip"
 	"github.com/openyurtio/openyurt/pkg/util/iptables"
+	"github.com/openyurtio/openyurt/pkg/util/tracing"
 	"github.com/openyurtio/openyurt/pkg/yurttunnel/constants"
 	kubeutil "github.com/openyurtio/openyurt/pkg/yurttunnel/kubernetes"
 )
@@ -57,6 +58,8 @@ type ServerOptions struct {
 	MetaPort               string
 	ServerCount            int
 	ProxyStrategy          string
+	TracingEndpoint        string
+	TracingSamplingRate    int32
 }
 
 // NewServerOptions creates a new ServerOptions
@@ -83,6 +86,9 @@ func (o *ServerOptions) Validate() error {
 		return fmt.Errorf("%s's bind address can't be empty",
 			projectinfo.GetServerName())
 	}
+	if o.TracingSamplingRate < 0 || o.TracingSamplingRate > tracing.MaxSamplingRatePerMillion {
+		return fmt.Errorf("tracing sampling rate(%d) should be in range [0, %d]", o.TracingSamplingRate, tracing.MaxSamplingRatePerMillion)
+	}
 	if len(o.InsecureBindAddr) == 0 {
 		o.InsecureBindAddr = utilip.MustGetLoopbackIP(utilnet.IsIPv6String(o.BindAddr))
 	}
@@ -109,6 +115,8 @@ func (o *ServerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.SecurePort, "secure-port", o.SecurePort, "The port on which to serve HTTPS requests from cloud clients like prometheus")
 	fs.StringVar(&o.InsecurePort, "insecure-port", o.InsecurePort, "The port on which to serve HTTP requests from cloud clients like metrics-server")
 	fs.StringVar(&o.MetaPort, "meta-port", o.MetaPort, "The port on which to serve HTTP requests like profiling, metrics")
+	fs.StringVar(&o.TracingEndpoint, "tracing-endpoint", o.TracingEndpoint, "The endpoint of OTLP grpc collector(like localhost:4317) to which spans of requests are exported. spans are not exported if it's not set, but W3C trace context is still propagated over the tunnel.")
+	fs.Int32Var(&o.TracingSamplingRate, "tracing-sampling-rate-per-million", o.TracingSamplingRate, "The number of samples to collect per million requests, requests whose trace context is sampled by clients are always sampled.")
 }
 
 func (o *ServerOptions) Config() (*config.Config, error) {
@@ -124,6 +132,8 @@ func (o *ServerOptions) Config() (*config.Config, error) {
 		CertDir:               o.CertDir,
 		ServerCount:           o.ServerCount,
 		ProxyStrategy:         o.ProxyStrategy,
+		TracingEndpoint:       o.TracingEndpoint,
+		TracingSamplingRate:   o.TracingSamplingRate,
 	}
 
 	if o.CertDNSNames != "" {
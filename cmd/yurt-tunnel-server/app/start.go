@@ -37,6 +37,7 @@ import (
 	"github.com/openyurtio/openyurt/pkg/projectinfo"
 	"github.com/openyurtio/openyurt/pkg/util/certmanager"
 	certfactory "github.com/openyurtio/openyurt/pkg/util/certmanager/factory"
+	"github.com/openyurtio/openyurt/pkg/util/tracing"
 	"github.com/openyurtio/openyurt/pkg/yurttunnel/constants"
 	"github.com/openyurtio/openyurt/pkg/yurttunnel/handlerwrapper/initializer"
 	"github.com/openyurtio/openyurt/pkg/yurttunnel/handlerwrapper/wraphandler"
@@ -188,6 +189,16 @@ func Run(cfg *config.CompletedConfig, stopCh <-chan struct{}) error {
 	}
 
 	// 7. start the server
+	tp, err := tracing.NewTracerProvider(context.Background(), cfg.TracingEndpoint, cfg.TracingSamplingRate, projectinfo.GetServerName(), constants.YurtTunnelServerNodeName)
+	if err != nil {
+		return fmt.Errorf("could not create tracer provider, %w", err)
+	}
+	defer func() {
+		// flush spans in batch before exit.
+		if err := tp.Shutdown(context.Background()); err != nil {
+			klog.Errorf("could not shutdown tracer provider, %v", err)
+		}
+	}()
 	ts := server.NewTunnelServer(
 		cfg.EgressSelectorEnabled,
 		cfg.InterceptorServerUDSFile,
@@ -198,7 +209,8 @@ func Run(cfg *config.CompletedConfig, stopCh <-chan struct{}) error {
 		tlsCfg,
 		proxyClientTLSCfg,
 		wrappers,
-		cfg.ProxyStrategy)
+		cfg.ProxyStrategy,
+		tp)
 	if err := ts.Run(); err != nil {
 		return err
 	}
@@ -22,10 +22,10 @@ import (
 	"fmt"
 	"net"
 	"net/url"
-	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -41,31 +41,40 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/component-base/tracing"
 	"k8s.io/klog/v2"
 
 	"github.com/openyurtio/openyurt/cmd/yurthub/app/options"
 	"github.com/openyurtio/openyurt/pkg/projectinfo"
 	pkgutil "github.com/openyurtio/openyurt/pkg/util"
 	utiloptions "github.com/openyurtio/openyurt/pkg/util/kubernetes/apiserver/options"
+	yurttracing "github.com/openyurtio/openyurt/pkg/util/tracing"
+	"github.com/openyurtio/openyurt/pkg/yurthub/audit"
 	"github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
 	"github.com/openyurtio/openyurt/pkg/yurthub/certificate"
 	certificatemgr "github.com/openyurtio/openyurt/pkg/yurthub/certificate/manager"
 	"github.com/openyurtio/openyurt/pkg/yurthub/configuration"
+	"github.com/openyurtio/openyurt/pkg/yurthub/dynamicconfig"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter/initializer"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter/manager"
 	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/meta"
 	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
 	"github.com/openyurtio/openyurt/pkg/yurthub/network"
+	"github.com/openyurtio/openyurt/pkg/yurthub/policy"
 	"github.com/openyurtio/openyurt/pkg/yurthub/proxy/remote"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
 	"github.com/openyurtio/openyurt/pkg/yurthub/tenant"
 	"github.com/openyurtio/openyurt/pkg/yurthub/transport"
 	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/writequeue"
 )
 
 // YurtHubConfiguration represents configuration of yurthub
 type YurtHubConfiguration struct {
 	LBMode                          string
+	CloudCompression                []string
+	PreferProtobuf                  bool
 	RemoteServers                   []*url.URL
 	TenantKasService                string // ip:port, used in local mode
 	GCFrequency                     int
@@ -74,8 +83,13 @@ type YurtHubConfiguration struct {
 	HeartbeatHealthyThreshold       int
 	HeartbeatTimeoutSeconds         int
 	HeartbeatIntervalSeconds        int
+	HealthCheckSignals              []string
+	DegradedLatencyThreshold        time.Duration
+	DegradedErrorRateThreshold      float64
+	EnableDegradedMode              bool
 	EnableProfiling                 bool
 	StorageWrapper                  cachemanager.StorageWrapper
+	CacheManager                    cachemanager.CacheManager
 	SerializerManager               *serializer.SerializerManager
 	RESTMapperManager               *meta.RESTMapperManager
 	SharedFactory                   informers.SharedInformerFactory
@@ -92,13 +106,33 @@ type YurtHubConfiguration struct {
 	YurtHubSecureProxyServerServing *apiserver.SecureServingInfo
 	YurtHubMultiplexerServerServing *apiserver.SecureServingInfo
 	DiskCachePath                   string
+	StorageBackend                  string
+	DiskCacheFormatVersion          int
+	CacheEncryptionKeyFile          string
+	CacheEncryptionKMSSocket        string
+	CacheEncryptionResources        []schema.GroupVersionResource
+	CacheQuota                      *util.CacheQuotaConfig
+	WatchHistorySize                int
+	OfflineWriteResources           []string
+	OfflineWriteQueue               *writequeue.Queue
+	Auditor                         *audit.Auditor
+	TracerProvider                  tracing.TracerProvider
 	ConfigManager                   *configuration.Manager
 	TenantManager                   tenant.Interface
 	TransportAndDirectClientManager transport.Interface
+	LoadBalancer                    remote.Server
 	LoadBalancerForLeaderHub        remote.Server
 	PoolScopeResources              []schema.GroupVersionResource
 	PortForMultiplexer              int
+	LeaderHeartbeatInterval         time.Duration
+	MultiplexerCacheMemory          int64
+	MultiplexerCacheStore           storage.Store
 	NodePoolName                    string
+	YurtHubNamespace                string
+	DynamicConfigFile               string
+	DynamicConfigConfigMap          string
+	ConfigRollbackTimeout           time.Duration
+	DynamicConfigManager            *dynamicconfig.Manager
 }
 
 // Complete converts *options.YurtHubOptions to *YurtHubConfiguration
@@ -113,7 +147,7 @@ func Complete(options *options.YurtHubOptions, stopCh <-chan struct{}) (*YurtHub
 	case util.WorkingModeLocal:
 		// if yurthub is in local mode, cfg.TenantKasService is used to represented as the service address (ip:port) of multiple apiserver daemonsets
 		cfg.TenantKasService = options.ServerAddr
-		_, sharedFactory, _, err := createClientAndSharedInformerFactories(string(cfg.WorkingMode), options.HostControlPlaneAddr, "")
+		_, _, sharedFactory, _, err := createClientAndSharedInformerFactories(string(cfg.WorkingMode), options.HostControlPlaneAddr, "")
 		if err != nil {
 			return nil, err
 		}
@@ -131,16 +165,30 @@ func Complete(options *options.YurtHubOptions, stopCh <-chan struct{}) (*YurtHub
 		sharedFactory.InformerFor(&corev1.Endpoints{}, newEndpointsInformer)
 		cfg.SharedFactory = sharedFactory
 	case util.WorkingModeCloud, util.WorkingModeEdge:
-		us, err := parseRemoteServers(options.ServerAddr)
+		us, err := util.ParseRemoteServers(options.ServerAddr)
 		if err != nil {
 			return nil, err
 		}
 		cfg.RemoteServers = us
 		cfg.LBMode = options.LBMode
+		cfg.CloudCompression = options.CloudCompression
+		cfg.PreferProtobuf = options.PreferProtobuf
 		tenantNamespace := util.ParseTenantNsFromOrgs(options.YurtHubCertOrganizations)
 		cfg.PoolScopeResources = options.PoolScopeResources
 		cfg.PortForMultiplexer = options.PortForMultiplexer
+		cfg.LeaderHeartbeatInterval = options.LeaderHeartbeatInterval
+		if len(options.MultiplexerCacheMemory) != 0 {
+			q, err := resource.ParseQuantity(options.MultiplexerCacheMemory)
+			if err != nil {
+				return nil, err
+			}
+			cfg.MultiplexerCacheMemory = q.Value()
+		}
 		cfg.NodePoolName = options.NodePoolName
+		cfg.YurtHubNamespace = options.YurtHubNamespace
+		cfg.DynamicConfigFile = options.DynamicConfigFile
+		cfg.DynamicConfigConfigMap = options.DynamicConfigConfigMap
+		cfg.ConfigRollbackTimeout = options.ConfigRollbackTimeout
 
 		// prepare some basic configurations as following:
 		// - serializer manager: used for managing serializer for encoding or decoding response from kube-apiserver.
@@ -154,7 +202,7 @@ func Complete(options *options.YurtHubOptions, stopCh <-chan struct{}) (*YurtHub
 			return nil, err
 		}
 
-		proxiedClient, sharedFactory, dynamicSharedFactory, err := createClientAndSharedInformerFactories(
+		proxiedClient, dynamicClient, sharedFactory, dynamicSharedFactory, err := createClientAndSharedInformerFactories(
 			string(cfg.WorkingMode),
 			fmt.Sprintf("%s:%d", options.YurtHubProxyHost, options.YurtHubProxyPort),
 			options.NodePoolName,
@@ -212,28 +260,48 @@ func Complete(options *options.YurtHubOptions, stopCh <-chan struct{}) (*YurtHub
 		cfg.TenantManager = tenant.New(tenantNamespace, sharedFactory, stopCh)
 
 		// create feature configurations for both cloud and edge working mode as following:
-		// - configuration manager: monitor yurt-hub-cfg configmap and adopting changes dynamically.
-		// - filter finder: filter response from kube-apiserver according to request.
+		// - configuration manager: monitor yurt-hub-cfg configmap and cache policies, and adopting changes dynamically.
+		// - filter finder: filter response from kube-apiserver according to request and filter policies.
 		// - multiplexer: aggregating requests for pool scope metadata in order to reduce overhead of cloud kube-apiserver
 		// - network manager: ensuring a dummy interface in order to serve tls requests on the node.
 		// - others: prepare server servings.
-		configManager := configuration.NewConfigurationManager(options.NodeName, sharedFactory)
-		filterFinder, err := manager.NewFilterManager(
+		policyInformers := policy.NewInformers(dynamicClient, options.NodePoolName)
+		configManager := configuration.NewConfigurationManagerWithCachePolicy(options.NodeName, sharedFactory, policyInformers)
+		filterFinder, err := manager.NewFilterManagerWithFilterPolicy(
 			options,
 			sharedFactory,
 			dynamicSharedFactory,
 			proxiedClient,
 			cfg.SerializerManager,
 			configManager,
+			policyInformers,
 		)
 		if err != nil {
 			klog.Errorf("could not create filter manager, %v", err)
 			return nil, err
 		}
 
+		// informers of policies are started after all of policy managers have registered their handlers.
+		policyInformers.Start(stopCh)
+
 		cfg.ConfigManager = configManager
 		cfg.FilterFinder = filterFinder
 
+		if len(options.AuditPolicyFile) != 0 {
+			auditor, err := newAuditor(options)
+			if err != nil {
+				return nil, fmt.Errorf("could not create auditor, %w", err)
+			}
+			auditor.Run(stopCh)
+			cfg.Auditor = auditor
+		}
+
+		tp, err := yurttracing.NewTracerProvider(context.Background(), options.TracingEndpoint, options.TracingSamplingRate, projectinfo.GetHubName(), options.NodeName)
+		if err != nil {
+			return nil, fmt.Errorf("could not create tracer provider, %w", err)
+		}
+		cfg.TracerProvider = tp
+
 		if options.EnableDummyIf {
 			klog.V(2).
 				Infof("create dummy network interface %s(%s)", options.HubAgentDummyIfName, options.HubAgentDummyIfIP)
@@ -250,11 +318,27 @@ func Complete(options *options.YurtHubOptions, stopCh <-chan struct{}) (*YurtHub
 
 		// following parameter is only used on edge working mode
 		cfg.DiskCachePath = options.DiskCachePath
+		cfg.StorageBackend = options.StorageBackend
+		cfg.DiskCacheFormatVersion = options.DiskCacheFormatVersion
+		cfg.CacheEncryptionKeyFile = options.CacheEncryptionKeyFile
+		cfg.CacheEncryptionKMSSocket = options.CacheEncryptionKMSSocket
+		if cfg.CacheEncryptionResources, err = util.ParseGroupVersionResources(options.CacheEncryptionResources); err != nil {
+			return nil, err
+		}
+		if cfg.CacheQuota, err = util.NewCacheQuotaConfig(options.NodeName, options.CacheComponentQuotas, options.CacheResourceQuotas, options.CacheEvictionPolicy, options.CacheResourcePriorities); err != nil {
+			return nil, err
+		}
+		cfg.WatchHistorySize = options.WatchHistorySize
+		cfg.OfflineWriteResources = options.OfflineWriteResources
 		cfg.GCFrequency = options.GCFrequency
 		cfg.HeartbeatFailedRetry = options.HeartbeatFailedRetry
 		cfg.HeartbeatHealthyThreshold = options.HeartbeatHealthyThreshold
 		cfg.HeartbeatTimeoutSeconds = options.HeartbeatTimeoutSeconds
 		cfg.HeartbeatIntervalSeconds = options.HeartbeatIntervalSeconds
+		cfg.HealthCheckSignals = options.HealthCheckSignals
+		cfg.DegradedLatencyThreshold = options.DegradedLatencyThreshold
+		cfg.DegradedErrorRateThreshold = options.DegradedErrorRateThreshold
+		cfg.EnableDegradedMode = options.EnableDegradedMode
 		cfg.KubeletHealthGracePeriod = options.KubeletHealthGracePeriod
 		cfg.MinRequestTimeout = options.MinRequestTimeout
 	default:
@@ -264,40 +348,38 @@ func Complete(options *options.YurtHubOptions, stopCh <-chan struct{}) (*YurtHub
 	return cfg, nil
 }
 
-func parseRemoteServers(serverAddr string) ([]*url.URL, error) {
-	if serverAddr == "" {
-		return make([]*url.URL, 0), fmt.Errorf("--server-addr should be set for hub agent")
+// newAuditor creates an auditor with audit policy file, and audit events are written into
+// log file or posted to webhook.
+func newAuditor(options *options.YurtHubOptions) (*audit.Auditor, error) {
+	policy, err := audit.LoadPolicy(options.AuditPolicyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	backends := make([]audit.Backend, 0, 2)
+	if len(options.AuditLogPath) != 0 {
+		backends = append(backends, audit.NewLogBackend(options.AuditLogPath, options.AuditLogMaxSize, options.AuditLogMaxBackups))
 	}
-	servers := strings.Split(serverAddr, ",")
-	us := make([]*url.URL, 0, len(servers))
-	remoteServers := make([]string, 0, len(servers))
-	for _, server := range servers {
-		u, err := url.Parse(server)
+	if len(options.AuditWebhookURL) != 0 {
+		backend, err := audit.NewWebhookBackend(audit.WebhookConfig{
+			URL:       options.AuditWebhookURL,
+			CAFile:    options.AuditWebhookCAFile,
+			CertFile:  options.AuditWebhookCertFile,
+			KeyFile:   options.AuditWebhookKeyFile,
+			TokenFile: options.AuditWebhookTokenFile,
+		})
 		if err != nil {
-			klog.Errorf("could not parse server address %q, %v", server, err)
-			return us, err
-		}
-		if u.Scheme == "" {
-			u.Scheme = "https"
-		} else if u.Scheme != "https" {
-			return us, fmt.Errorf("only https scheme is supported for server address(%s)", serverAddr)
+			return nil, err
 		}
-		us = append(us, u)
-		remoteServers = append(remoteServers, u.String())
+		backends = append(backends, backend)
 	}
-
-	if len(us) < 1 {
-		return us, fmt.Errorf("no server address is set, can not connect remote server")
-	}
-	klog.Infof("%s would connect remote servers: %s", projectinfo.GetHubName(), strings.Join(remoteServers, ","))
-
-	return us, nil
+	return audit.NewAuditor(policy, backends...), nil
 }
 
-// createClientAndSharedInformerFactories create client and sharedInformers from the given proxyAddr.
+// createClientAndSharedInformerFactories create clients and sharedInformers from the given proxyAddr.
 func createClientAndSharedInformerFactories(
 	workingMode, serverAddr, nodePoolName string,
-) (kubernetes.Interface, informers.SharedInformerFactory, dynamicinformer.DynamicSharedInformerFactory, error) {
+) (kubernetes.Interface, dynamic.Interface, informers.SharedInformerFactory, dynamicinformer.DynamicSharedInformerFactory, error) {
 	var serverURL string
 	if workingMode == "local" {
 		serverURL = fmt.Sprintf("https://%s", serverAddr)
@@ -307,7 +389,7 @@ func createClientAndSharedInformerFactories(
 
 	kubeConfig, err := clientcmd.BuildConfigFromFlags(serverURL, "")
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	if workingMode == "local" {
@@ -317,12 +399,12 @@ func createClientAndSharedInformerFactories(
 
 	client, err := kubernetes.NewForConfig(kubeConfig)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	dynamicClient, err := dynamic.NewForConfig(kubeConfig)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	dynamicInformerFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 24*time.Hour)
@@ -337,7 +419,7 @@ func createClientAndSharedInformerFactories(
 		)
 	}
 
-	return client, informers.NewSharedInformerFactory(client, 24*time.Hour), dynamicInformerFactory, nil
+	return client, dynamicClient, informers.NewSharedInformerFactory(client, 24*time.Hour), dynamicInformerFactory, nil
 }
 
 // registerInformers reconstruct configmap/secret/pod/service informers on cloud and edge working mode.
@@ -20,18 +20,25 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	apinet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
 
 	"github.com/openyurtio/openyurt/pkg/projectinfo"
+	"github.com/openyurtio/openyurt/pkg/util/tracing"
+	"github.com/openyurtio/openyurt/pkg/yurthub/audit"
 	"github.com/openyurtio/openyurt/pkg/yurthub/certificate"
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
 	"github.com/openyurtio/openyurt/pkg/yurthub/storage/disk"
 	"github.com/openyurtio/openyurt/pkg/yurthub/util"
 )
@@ -45,84 +52,133 @@ const (
 
 // YurtHubOptions is the main settings for the yurthub
 type YurtHubOptions struct {
-	ServerAddr                string
-	YurtHubHost               string // YurtHub server host (e.g.: expose metrics API)
-	YurtHubProxyHost          string // YurtHub proxy server host
-	YurtHubPort               int
-	YurtHubProxyPort          int
-	YurtHubProxySecurePort    int
-	YurtHubNamespace          string
-	GCFrequency               int
-	YurtHubCertOrganizations  []string
-	NodeName                  string
-	NodePoolName              string
-	LBMode                    string
-	HeartbeatFailedRetry      int
-	HeartbeatHealthyThreshold int
-	HeartbeatTimeoutSeconds   int
-	HeartbeatIntervalSeconds  int
-	MaxRequestInFlight        int
-	JoinToken                 string
-	BootstrapMode             string
-	BootstrapFile             string
-	RootDir                   string
-	Version                   bool
-	EnableProfiling           bool
-	EnableDummyIf             bool
-	EnableIptables            bool
-	HubAgentDummyIfIP         string
-	HubAgentDummyIfName       string
-	HostControlPlaneAddr      string
-	DiskCachePath             string
-	EnableResourceFilter      bool
-	DisabledResourceFilters   []string
-	WorkingMode               string
-	KubeletHealthGracePeriod  time.Duration
-	EnableNodePool            bool
-	MinRequestTimeout         time.Duration
-	CACertHashes              []string
-	UnsafeSkipCAVerification  bool
-	ClientForTest             kubernetes.Interface
-	EnablePoolServiceTopology bool
-	PoolScopeResources        PoolScopeMetadatas
-	PortForMultiplexer        int
-	NodeIP                    string
+	ServerAddr                 string
+	YurtHubHost                string // YurtHub server host (e.g.: expose metrics API)
+	YurtHubProxyHost           string // YurtHub proxy server host
+	YurtHubPort                int
+	YurtHubProxyPort           int
+	YurtHubProxySecurePort     int
+	YurtHubNamespace           string
+	GCFrequency                int
+	YurtHubCertOrganizations   []string
+	NodeName                   string
+	NodePoolName               string
+	LBMode                     string
+	CloudCompression           []string
+	PreferProtobuf             bool
+	HeartbeatFailedRetry       int
+	HeartbeatHealthyThreshold  int
+	HeartbeatTimeoutSeconds    int
+	HeartbeatIntervalSeconds   int
+	HealthCheckSignals         []string
+	DegradedLatencyThreshold   time.Duration
+	DegradedErrorRateThreshold float64
+	EnableDegradedMode         bool
+	MaxRequestInFlight         int
+	JoinToken                  string
+	BootstrapMode              string
+	BootstrapFile              string
+	RootDir                    string
+	Version                    bool
+	EnableProfiling            bool
+	EnableDummyIf              bool
+	EnableIptables             bool
+	HubAgentDummyIfIP          string
+	HubAgentDummyIfName        string
+	HostControlPlaneAddr       string
+	DiskCachePath              string
+	StorageBackend             string
+	DiskCacheFormatVersion     int
+	CacheEncryptionKeyFile     string
+	CacheEncryptionKMSSocket   string
+	CacheEncryptionResources   []string
+	CacheComponentQuotas       map[string]string
+	CacheResourceQuotas        map[string]string
+	CacheEvictionPolicy        string
+	CacheResourcePriorities    map[string]string
+	WatchHistorySize           int
+	OfflineWriteResources      []string
+	AuditPolicyFile            string
+	AuditLogPath               string
+	AuditLogMaxSize            int
+	AuditLogMaxBackups         int
+	AuditWebhookURL            string
+	AuditWebhookCAFile         string
+	AuditWebhookCertFile       string
+	AuditWebhookKeyFile        string
+	AuditWebhookTokenFile      string
+	TracingEndpoint            string
+	TracingSamplingRate        int32
+	DynamicConfigFile          string
+	DynamicConfigConfigMap     string
+	ConfigRollbackTimeout      time.Duration
+	EnableResourceFilter       bool
+	DisabledResourceFilters    []string
+	WorkingMode                string
+	KubeletHealthGracePeriod   time.Duration
+	EnableNodePool             bool
+	MinRequestTimeout          time.Duration
+	CACertHashes               []string
+	UnsafeSkipCAVerification   bool
+	ClientForTest              kubernetes.Interface
+	EnablePoolServiceTopology  bool
+	PoolScopeResources         PoolScopeMetadatas
+	PortForMultiplexer         int
+	LeaderHeartbeatInterval    time.Duration
+	MultiplexerCacheMemory     string
+	NodeIP                     string
 }
 
 // NewYurtHubOptions creates a new YurtHubOptions with a default config.
 func NewYurtHubOptions() *YurtHubOptions {
 	o := &YurtHubOptions{
-		YurtHubHost:               "127.0.0.1",
-		YurtHubProxyHost:          "127.0.0.1",
-		YurtHubProxyPort:          util.YurtHubProxyPort,
-		YurtHubPort:               util.YurtHubPort,
-		YurtHubProxySecurePort:    util.YurtHubProxySecurePort,
-		PortForMultiplexer:        util.YurtHubMultiplexerPort,
-		YurtHubNamespace:          util.YurtHubNamespace,
-		GCFrequency:               120,
-		YurtHubCertOrganizations:  make([]string, 0),
-		LBMode:                    "rr",
-		HeartbeatFailedRetry:      3,
-		HeartbeatHealthyThreshold: 2,
-		HeartbeatTimeoutSeconds:   2,
-		HeartbeatIntervalSeconds:  10,
-		MaxRequestInFlight:        250,
-		BootstrapMode:             certificate.TokenBootstrapMode,
-		RootDir:                   filepath.Join("/var/lib/", projectinfo.GetHubName()),
-		EnableProfiling:           true,
-		EnableDummyIf:             true,
-		EnableIptables:            false,
-		HubAgentDummyIfName:       "hub-dummy0",
-		DiskCachePath:             disk.CacheBaseDir,
-		EnableResourceFilter:      true,
-		DisabledResourceFilters:   make([]string, 0),
-		WorkingMode:               string(util.WorkingModeEdge),
-		KubeletHealthGracePeriod:  time.Second * 40,
-		EnableNodePool:            true,
-		MinRequestTimeout:         time.Second * 1800,
-		CACertHashes:              make([]string, 0),
-		UnsafeSkipCAVerification:  true,
-		EnablePoolServiceTopology: false,
+		YurtHubHost:                "127.0.0.1",
+		YurtHubProxyHost:           "127.0.0.1",
+		YurtHubProxyPort:           util.YurtHubProxyPort,
+		YurtHubPort:                util.YurtHubPort,
+		YurtHubProxySecurePort:     util.YurtHubProxySecurePort,
+		PortForMultiplexer:         util.YurtHubMultiplexerPort,
+		LeaderHeartbeatInterval:    5 * time.Second,
+		YurtHubNamespace:           util.YurtHubNamespace,
+		GCFrequency:                120,
+		YurtHubCertOrganizations:   make([]string, 0),
+		LBMode:                     "rr",
+		HeartbeatFailedRetry:       3,
+		HeartbeatHealthyThreshold:  2,
+		HeartbeatTimeoutSeconds:    2,
+		HeartbeatIntervalSeconds:   10,
+		HealthCheckSignals:         []string{healthchecker.SignalLease},
+		DegradedLatencyThreshold:   5 * time.Second,
+		DegradedErrorRateThreshold: 0.5,
+		MaxRequestInFlight:         250,
+		BootstrapMode:              certificate.TokenBootstrapMode,
+		RootDir:                    filepath.Join("/var/lib/", projectinfo.GetHubName()),
+		EnableProfiling:            true,
+		EnableDummyIf:              true,
+		EnableIptables:             false,
+		HubAgentDummyIfName:        "hub-dummy0",
+		DiskCachePath:              disk.CacheBaseDir,
+		StorageBackend:             util.StorageBackendDisk,
+		DiskCacheFormatVersion:     int(disk.FormatVersionPlain),
+		CacheEncryptionResources:   []string{"/v1/secrets", "/v1/configmaps"},
+		CacheComponentQuotas:       make(map[string]string),
+		CacheResourceQuotas:        make(map[string]string),
+		CacheEvictionPolicy:        string(util.EvictionPolicyLRU),
+		CacheResourcePriorities:    make(map[string]string),
+		WatchHistorySize:           100,
+		OfflineWriteResources:      []string{"events", "pods/status", "nodes/status"},
+		AuditLogMaxSize:            100,
+		AuditLogMaxBackups:         3,
+		ConfigRollbackTimeout:      time.Minute,
+		EnableResourceFilter:       true,
+		DisabledResourceFilters:    make([]string, 0),
+		WorkingMode:                string(util.WorkingModeEdge),
+		KubeletHealthGracePeriod:   time.Second * 40,
+		EnableNodePool:             true,
+		MinRequestTimeout:          time.Second * 1800,
+		CACertHashes:               make([]string, 0),
+		UnsafeSkipCAVerification:   true,
+		EnablePoolServiceTopology:  false,
 		PoolScopeResources: []schema.GroupVersionResource{
 			{Group: "", Version: "v1", Resource: "services"},
 			{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"},
@@ -157,10 +213,79 @@ func (o *YurtHubOptions) Validate() error {
 			}
 		}
 
+		// local storage is only used for caching data in edge working mode.
+		if o.WorkingMode == string(util.WorkingModeEdge) {
+			if !util.IsSupportedStorageBackend(o.StorageBackend) {
+				return fmt.Errorf("storage backend(%s) is not supported", o.StorageBackend)
+			}
+
+			if o.StorageBackend == util.StorageBackendDisk && !disk.IsSupportedFormatVersion(o.DiskCacheFormatVersion) {
+				return fmt.Errorf("disk cache format version(%d) is not supported", o.DiskCacheFormatVersion)
+			}
+
+			if _, err := util.NewCacheQuotaConfig(o.NodeName, o.CacheComponentQuotas, o.CacheResourceQuotas, o.CacheEvictionPolicy, o.CacheResourcePriorities); err != nil {
+				return fmt.Errorf("cache quota is invalid, %w", err)
+			}
+
+			if o.WatchHistorySize < 0 {
+				return fmt.Errorf("watch history size(%d) should not be negative", o.WatchHistorySize)
+			}
+
+			if err := o.validateHealthCheckSignals(); err != nil {
+				return err
+			}
+
+			for _, r := range o.OfflineWriteResources {
+				if resource, subresource, found := strings.Cut(r, "/"); len(resource) == 0 || (found && (len(subresource) == 0 || strings.Contains(subresource, "/"))) {
+					return fmt.Errorf("offline write resource(%s) is invalid, the format should be resource[/subresource]", r)
+				}
+			}
+		}
+
+		if len(o.CacheEncryptionKeyFile) != 0 && len(o.CacheEncryptionKMSSocket) != 0 {
+			return fmt.Errorf("cache-encryption-key-file and cache-encryption-kms-socket can not be set at the same time")
+		}
+
+		if _, err := util.ParseGroupVersionResources(o.CacheEncryptionResources); err != nil {
+			return fmt.Errorf("cache-encryption-resources is invalid, %w", err)
+		}
+
 		if !util.IsSupportedLBMode(o.LBMode) {
 			return fmt.Errorf("lb mode(%s) is not supported", o.LBMode)
 		}
 
+		for _, encoding := range o.CloudCompression {
+			if !util.IsSupportedCompression(encoding) {
+				return fmt.Errorf("cloud compression(%s) is not supported", encoding)
+			}
+		}
+
+		if err := o.validateAudit(); err != nil {
+			return err
+		}
+
+		if o.TracingSamplingRate < 0 || o.TracingSamplingRate > tracing.MaxSamplingRatePerMillion {
+			return fmt.Errorf("tracing sampling rate(%d) should be in range [0, %d]", o.TracingSamplingRate, tracing.MaxSamplingRatePerMillion)
+		}
+
+		if len(o.DynamicConfigFile) != 0 && len(o.DynamicConfigConfigMap) != 0 {
+			return fmt.Errorf("dynamic-config-file and dynamic-config-configmap can not be set at the same time")
+		}
+
+		if (len(o.DynamicConfigFile) != 0 || len(o.DynamicConfigConfigMap) != 0) && o.ConfigRollbackTimeout <= 0 {
+			return fmt.Errorf("dynamic config rollback timeout(%v) should be positive", o.ConfigRollbackTimeout)
+		}
+
+		if o.LeaderHeartbeatInterval < 0 {
+			return fmt.Errorf("leader heartbeat interval(%v) should not be negative", o.LeaderHeartbeatInterval)
+		}
+
+		if len(o.MultiplexerCacheMemory) != 0 {
+			if q, err := resource.ParseQuantity(o.MultiplexerCacheMemory); err != nil || q.Sign() <= 0 {
+				return fmt.Errorf("multiplexer cache memory(%s) should be a positive quantity", o.MultiplexerCacheMemory)
+			}
+		}
+
 		if err := o.verifyDummyIP(); err != nil {
 			return fmt.Errorf("dummy ip %s is not invalid, %w", o.HubAgentDummyIfIP, err)
 		}
@@ -198,17 +323,25 @@ func (o *YurtHubOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&o.YurtHubProxyPort, "proxy-port", o.YurtHubProxyPort, "the port on which to proxy HTTP requests to kube-apiserver")
 	fs.IntVar(&o.YurtHubProxySecurePort, "proxy-secure-port", o.YurtHubProxySecurePort, "the port on which to proxy HTTPS requests to kube-apiserver")
 	fs.IntVar(&o.PortForMultiplexer, "multiplexer-port", o.PortForMultiplexer, "the port on which to proxy HTTPS requests to multiplexer in yurthub")
+	fs.StringVar(&o.MultiplexerCacheMemory, "multiplexer-cache-memory", o.MultiplexerCacheMemory, "the max size of objects and recent events kept in memory by multiplexer cache of each pool scope resource, like 200Mi, and a quarter of it is used for recent events. objects beyond it are spilled to local disk and loaded when they are requested, and they are encrypted like local cache when cache encryption is enabled. objects are all kept in memory when it's not set.")
+	fs.DurationVar(&o.LeaderHeartbeatInterval, "leader-heartbeat-interval", o.LeaderHeartbeatInterval, "the interval at which leader yurthub renews its lease when multiplexer is ready, and follower yurthubs probe leader yurthubs. leader yurthub is demoted when its lease is not renewed for three intervals. a temporary leader yurthub is elected at this interval when the pool is disconnected from cloud. set 0 to disable both the lease and temporary election of leader yurthub.")
 	fs.StringVar(&o.YurtHubNamespace, "namespace", o.YurtHubNamespace, "the namespace of YurtHub Server")
 	fs.StringVar(&o.ServerAddr, "server-addr", o.ServerAddr, "the address of Kubernetes kube-apiserver, the format is: \"server1,server2,...\"; when yurthub is in local mode, server-addr represents the service address of apiservers, the format is: \"ip:port\".")
 	fs.StringSliceVar(&o.YurtHubCertOrganizations, "hub-cert-organizations", o.YurtHubCertOrganizations, "Organizations that will be added into hub's apiserver client certificate, the format is: certOrg1,certOrg2,...")
 	fs.IntVar(&o.GCFrequency, "gc-frequency", o.GCFrequency, "the frequency to gc cache in storage(unit: minute).")
 	fs.StringVar(&o.NodeName, "node-name", o.NodeName, "the name of node that runs hub agent")
 	fs.StringVar(&o.NodeIP, "node-ip", o.NodeIP, "the same IP address of the node which used by kubelet. if unset, node's default IPv4 address will be used.")
-	fs.StringVar(&o.LBMode, "lb-mode", o.LBMode, "the mode of load balancer to connect remote servers(round-robin, priority)")
+	fs.StringVar(&o.LBMode, "lb-mode", o.LBMode, "the mode of load balancer to connect remote servers(round-robin, priority, latency-aware)")
+	fs.StringSliceVar(&o.CloudCompression, "cloud-compression", o.CloudCompression, "the content encodings(gzip, zstd) in preference order which are accepted for responses from kube-apiserver, responses are decompressed in yurthub for clients which don't accept the encoding, and zstd responses are always decompressed in yurthub. compression is disabled by default.")
+	fs.BoolVar(&o.PreferProtobuf, "prefer-protobuf", o.PreferProtobuf, "request protobuf from kube-apiserver for built-in resources even when clients ask for json, and responses are converted into json in yurthub in order to reduce traffic on the link to cloud.")
 	fs.IntVar(&o.HeartbeatFailedRetry, "heartbeat-failed-retry", o.HeartbeatFailedRetry, "number of heartbeat request retry after having failed.")
 	fs.IntVar(&o.HeartbeatHealthyThreshold, "heartbeat-healthy-threshold", o.HeartbeatHealthyThreshold, "minimum consecutive successes for the heartbeat to be considered healthy after having failed.")
 	fs.IntVar(&o.HeartbeatTimeoutSeconds, "heartbeat-timeout-seconds", o.HeartbeatTimeoutSeconds, " number of seconds after which the heartbeat times out.")
 	fs.IntVar(&o.HeartbeatIntervalSeconds, "heartbeat-interval-seconds", o.HeartbeatIntervalSeconds, " number of seconds for omitting one time heartbeat to remote server.")
+	fs.StringSliceVar(&o.HealthCheckSignals, "health-check-signals", o.HealthCheckSignals, "the signals for checking whether cloud kube-apiserver is reachable(lease, request, tcp). lease means updating node lease, request means latency and error rate of proxied requests, and tcp means tcp-level probes. lease is always required.")
+	fs.DurationVar(&o.DegradedLatencyThreshold, "degraded-latency-threshold", o.DegradedLatencyThreshold, "the average latency of proxied requests above which kube-apiserver is considered as degraded, only used when request signal is enabled. set 0 to disable it.")
+	fs.Float64Var(&o.DegradedErrorRateThreshold, "degraded-error-rate-threshold", o.DegradedErrorRateThreshold, "the error rate(0, 1] of proxied requests above which kube-apiserver is considered as degraded, only used when request signal is enabled.")
+	fs.BoolVar(&o.EnableDegradedMode, "enable-degraded-mode", o.EnableDegradedMode, "enable degraded mode in which read requests are served from local cache and write requests are still forwarded to degraded kube-apiserver. if disabled, degraded kube-apiserver is considered as unhealthy.")
 	fs.IntVar(&o.MaxRequestInFlight, "max-requests-in-flight", o.MaxRequestInFlight, "the maximum number of parallel requests.")
 	fs.MarkDeprecated("max-requests-in-flight", "It is planned to be removed from OpenYurt in the version v1.9, because multiplexer can aggregate requests.")
 	fs.StringVar(&o.JoinToken, "join-token", o.JoinToken, "the Join token for bootstrapping hub agent.")
@@ -224,6 +357,31 @@ func (o *YurtHubOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.HubAgentDummyIfIP, "dummy-if-ip", o.HubAgentDummyIfIP, "the ip address of dummy interface that used for container connect hub agent(exclusive ips: 169.254.31.0/24, 169.254.1.1/32)")
 	fs.StringVar(&o.HubAgentDummyIfName, "dummy-if-name", o.HubAgentDummyIfName, "the name of dummy interface that is used for hub agent")
 	fs.StringVar(&o.DiskCachePath, "disk-cache-path", o.DiskCachePath, "the path for kubernetes to storage metadata")
+	fs.StringVar(&o.StorageBackend, "storage-backend", o.StorageBackend, "the backend for caching metadata under disk-cache-path(disk, bolt). when switching to bolt, the existing disk cache will be imported into bolt database and removed on the first start.")
+	fs.IntVar(&o.DiskCacheFormatVersion, "disk-cache-format-version", o.DiskCacheFormatVersion, "the format version of object files in disk storage backend(1, 2). version 2 writes a checksum with each object for detecting corrupted files, and version 1 should be set before downgrading to a yurthub which does not support version 2. the existing cache will be converted on start when the version is changed.")
+	fs.StringVar(&o.CacheEncryptionKeyFile, "cache-encryption-key-file", o.CacheEncryptionKeyFile, "the file of keys for encrypting cached resources on local disk. each line is in the format of <name>:<base64 encoded aes key>, the first key is used for encryption and others are only used for decryption.")
+	fs.StringVar(&o.CacheEncryptionKMSSocket, "cache-encryption-kms-socket", o.CacheEncryptionKMSSocket, "the unix socket of kms v2 plugin for encrypting cached resources on local disk. it can not be set together with --cache-encryption-key-file.")
+	fs.StringSliceVar(&o.CacheEncryptionResources, "cache-encryption-resources", o.CacheEncryptionResources, "the resources which will be encrypted on local disk when cache encryption is enabled, the format is: Group/Version/Resource,...")
+	fs.StringToStringVar(&o.CacheComponentQuotas, "cache-component-quotas", o.CacheComponentQuotas, "the quotas of local cache for components, the format is: component=<bytes>[:<objects>],... for example: kubelet=200Mi:10000,kube-proxy=:1000")
+	fs.StringToStringVar(&o.CacheResourceQuotas, "cache-resource-quotas", o.CacheResourceQuotas, "the quotas of local cache for resources of all components, the format is: Group/Version/Resource=<bytes>[:<objects>],... for example: /v1/events=10Mi")
+	fs.StringVar(&o.CacheEvictionPolicy, "cache-eviction-policy", o.CacheEvictionPolicy, "the policy for evicting cached objects when cache quota is exceeded(lru, priority). objects needed for node autonomy are never evicted.")
+	fs.StringToStringVar(&o.CacheResourcePriorities, "cache-resource-priorities", o.CacheResourcePriorities, "the priorities of resources for priority eviction policy, objects of resources with lower priority are evicted first, the format is: Group/Version/Resource=<priority>,...")
+	fs.IntVar(&o.WatchHistorySize, "watch-history-size", o.WatchHistorySize, "the max number of recent events kept in memory for each watch stream, so watch requests can be resumed from local history after reconnecting to cloud and only the delta events are fetched from kube-apiserver. set 0 to disable it.")
+	fs.StringSliceVar(&o.OfflineWriteResources, "offline-write-resources", o.OfflineWriteResources, "the resources whose mutating requests made while cloud kube-apiserver is unreachable are recorded into a durable queue on local disk, and replayed in order after reconnecting to cloud, the format is: resource[/subresource],... records are replayed with the identity of yurthub instead of the original requester. set empty to disable it.")
+	fs.StringVar(&o.AuditPolicyFile, "audit-policy-file", o.AuditPolicyFile, "the audit policy file which decides how requests served by yurthub are audited, rules are matched by component, verb, resource and namespace like the audit policy of kube-apiserver. audit is disabled if it's not set.")
+	fs.StringVar(&o.AuditLogPath, "audit-log-path", o.AuditLogPath, "the file into which audit events are written in json lines.")
+	fs.IntVar(&o.AuditLogMaxSize, "audit-log-maxsize", o.AuditLogMaxSize, "the maximum size in megabytes of the audit log file before it gets rotated.")
+	fs.IntVar(&o.AuditLogMaxBackups, "audit-log-maxbackup", o.AuditLogMaxBackups, "the maximum number of rotated audit log files to retain.")
+	fs.StringVar(&o.AuditWebhookURL, "audit-webhook-url", o.AuditWebhookURL, "the url of webhook to which audit events are posted in batches.")
+	fs.StringVar(&o.AuditWebhookCAFile, "audit-webhook-ca-file", o.AuditWebhookCAFile, "the ca file for verifying the serving certificate of audit webhook. system certificates are used if it's not set.")
+	fs.StringVar(&o.AuditWebhookCertFile, "audit-webhook-cert-file", o.AuditWebhookCertFile, "the client certificate file for authenticating to audit webhook.")
+	fs.StringVar(&o.AuditWebhookKeyFile, "audit-webhook-key-file", o.AuditWebhookKeyFile, "the client key file for authenticating to audit webhook.")
+	fs.StringVar(&o.AuditWebhookTokenFile, "audit-webhook-token-file", o.AuditWebhookTokenFile, "the file of bearer token for authenticating to audit webhook, it's read before each request so the rotated token can be used.")
+	fs.StringVar(&o.TracingEndpoint, "tracing-endpoint", o.TracingEndpoint, "the endpoint of OTLP grpc collector(like localhost:4317) to which spans of requests are exported. spans are not exported if it's not set, but W3C trace context is still propagated to kube-apiserver.")
+	fs.Int32Var(&o.TracingSamplingRate, "tracing-sampling-rate-per-million", o.TracingSamplingRate, "the number of samples to collect per million requests, requests whose trace context is sampled by clients are always sampled.")
+	fs.StringVar(&o.DynamicConfigFile, "dynamic-config-file", o.DynamicConfigFile, "the path of versioned configuration file(usually mounted from a ConfigMap) which is watched and applied at runtime, server addresses, lb mode, heartbeat, disabled resource filters and pool scope resources can be changed without restarting yurthub.")
+	fs.StringVar(&o.DynamicConfigConfigMap, "dynamic-config-configmap", o.DynamicConfigConfigMap, "the name of ConfigMap in yurthub namespace whose config.yaml is the versioned configuration which is watched and applied at runtime like dynamic-config-file, it can not be set together with dynamic-config-file.")
+	fs.DurationVar(&o.ConfigRollbackTimeout, "dynamic-config-rollback-timeout", o.ConfigRollbackTimeout, "the duration to wait for cloud kube-apiserver to be healthy after servers or heartbeat in dynamic config file are applied, the previous configuration is restored if cloud kube-apiserver is still unreachable.")
 	fs.BoolVar(&o.EnableResourceFilter, "enable-resource-filter", o.EnableResourceFilter, "enable to filter response that comes back from reverse proxy")
 	fs.StringSliceVar(&o.DisabledResourceFilters, "disabled-resource-filters", o.DisabledResourceFilters, "disable resource filters to handle response")
 	fs.StringVar(&o.NodePoolName, "nodepool-name", o.NodePoolName, "the name of node pool that runs hub agent")
@@ -239,6 +397,57 @@ func (o *YurtHubOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.Var(&o.PoolScopeResources, "pool-scope-resources", "The list/watch requests for these resources will be multiplexered in yurthub in order to reduce overhead of kube-apiserver. comma-separated list of GroupVersionResource in the format Group/Version/Resource")
 }
 
+// validateHealthCheckSignals verifies signals for checking cloud kube-apiserver and thresholds of degraded state.
+func (o *YurtHubOptions) validateHealthCheckSignals() error {
+	signals := sets.New[string]()
+	for _, signal := range o.HealthCheckSignals {
+		switch signal {
+		case healthchecker.SignalLease, healthchecker.SignalRequest, healthchecker.SignalTCP:
+			signals.Insert(signal)
+		default:
+			return fmt.Errorf("health check signal(%s) is not supported", signal)
+		}
+	}
+	if !signals.Has(healthchecker.SignalLease) {
+		return fmt.Errorf("health check signal(%s) is required for renewing node lease", healthchecker.SignalLease)
+	}
+
+	if signals.Has(healthchecker.SignalRequest) {
+		if o.DegradedLatencyThreshold < 0 {
+			return fmt.Errorf("degraded latency threshold(%v) should not be negative", o.DegradedLatencyThreshold)
+		}
+		if o.DegradedErrorRateThreshold <= 0 || o.DegradedErrorRateThreshold > 1 {
+			return fmt.Errorf("degraded error rate threshold(%v) should be in (0, 1]", o.DegradedErrorRateThreshold)
+		}
+	}
+	return nil
+}
+
+// validateAudit verifies the audit policy file and backends of audit events.
+func (o *YurtHubOptions) validateAudit() error {
+	if len(o.AuditPolicyFile) == 0 {
+		return nil
+	}
+	if len(o.AuditLogPath) == 0 && len(o.AuditWebhookURL) == 0 {
+		return fmt.Errorf("audit-log-path or audit-webhook-url should be set when audit policy file is specified")
+	}
+	if _, err := audit.LoadPolicy(o.AuditPolicyFile); err != nil {
+		return err
+	}
+	if len(o.AuditLogPath) != 0 && (o.AuditLogMaxSize <= 0 || o.AuditLogMaxBackups < 0) {
+		return fmt.Errorf("audit log max size(%d) should be positive and max backups(%d) should not be negative", o.AuditLogMaxSize, o.AuditLogMaxBackups)
+	}
+	if len(o.AuditWebhookURL) != 0 {
+		if u, err := url.Parse(o.AuditWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return fmt.Errorf("audit webhook url(%s) is invalid", o.AuditWebhookURL)
+		}
+		if (len(o.AuditWebhookCertFile) == 0) != (len(o.AuditWebhookKeyFile) == 0) {
+			return fmt.Errorf("audit-webhook-cert-file and audit-webhook-key-file should be set together")
+		}
+	}
+	return nil
+}
+
 // verifyDummyIP verify the specified ip is valid or not and set the default ip if empty
 func (o *YurtHubOptions) verifyDummyIP() error {
 	if o.HubAgentDummyIfIP == "" {
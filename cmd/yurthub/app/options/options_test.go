@@ -32,36 +32,52 @@ import (
 
 func TestNewYurtHubOptions(t *testing.T) {
 	expectOptions := YurtHubOptions{
-		YurtHubHost:               "127.0.0.1",
-		YurtHubProxyHost:          "127.0.0.1",
-		YurtHubProxyPort:          util.YurtHubProxyPort,
-		YurtHubPort:               util.YurtHubPort,
-		YurtHubProxySecurePort:    util.YurtHubProxySecurePort,
-		PortForMultiplexer:        util.YurtHubMultiplexerPort,
-		YurtHubNamespace:          util.YurtHubNamespace,
-		GCFrequency:               120,
-		YurtHubCertOrganizations:  make([]string, 0),
-		LBMode:                    "rr",
-		HeartbeatFailedRetry:      3,
-		HeartbeatHealthyThreshold: 2,
-		HeartbeatTimeoutSeconds:   2,
-		HeartbeatIntervalSeconds:  10,
-		MaxRequestInFlight:        250,
-		BootstrapMode:             "token",
-		RootDir:                   filepath.Join("/var/lib/", projectinfo.GetHubName()),
-		EnableProfiling:           true,
-		EnableDummyIf:             true,
-		EnableIptables:            false,
-		HubAgentDummyIfName:       "hub-dummy0",
-		DiskCachePath:             disk.CacheBaseDir,
-		EnableResourceFilter:      true,
-		DisabledResourceFilters:   make([]string, 0),
-		WorkingMode:               string(util.WorkingModeEdge),
-		KubeletHealthGracePeriod:  time.Second * 40,
-		EnableNodePool:            true,
-		MinRequestTimeout:         time.Second * 1800,
-		CACertHashes:              make([]string, 0),
-		UnsafeSkipCAVerification:  true,
+		YurtHubHost:                "127.0.0.1",
+		YurtHubProxyHost:           "127.0.0.1",
+		YurtHubProxyPort:           util.YurtHubProxyPort,
+		YurtHubPort:                util.YurtHubPort,
+		YurtHubProxySecurePort:     util.YurtHubProxySecurePort,
+		PortForMultiplexer:         util.YurtHubMultiplexerPort,
+		LeaderHeartbeatInterval:    5 * time.Second,
+		YurtHubNamespace:           util.YurtHubNamespace,
+		GCFrequency:                120,
+		YurtHubCertOrganizations:   make([]string, 0),
+		LBMode:                     "rr",
+		HeartbeatFailedRetry:       3,
+		HeartbeatHealthyThreshold:  2,
+		HeartbeatTimeoutSeconds:    2,
+		HeartbeatIntervalSeconds:   10,
+		HealthCheckSignals:         []string{"lease"},
+		DegradedLatencyThreshold:   5 * time.Second,
+		DegradedErrorRateThreshold: 0.5,
+		MaxRequestInFlight:         250,
+		BootstrapMode:              "token",
+		RootDir:                    filepath.Join("/var/lib/", projectinfo.GetHubName()),
+		EnableProfiling:            true,
+		EnableDummyIf:              true,
+		EnableIptables:             false,
+		HubAgentDummyIfName:        "hub-dummy0",
+		DiskCachePath:              disk.CacheBaseDir,
+		StorageBackend:             util.StorageBackendDisk,
+		DiskCacheFormatVersion:     1,
+		CacheEncryptionResources:   []string{"/v1/secrets", "/v1/configmaps"},
+		CacheComponentQuotas:       make(map[string]string),
+		CacheResourceQuotas:        make(map[string]string),
+		CacheEvictionPolicy:        "lru",
+		CacheResourcePriorities:    make(map[string]string),
+		WatchHistorySize:           100,
+		OfflineWriteResources:      []string{"events", "pods/status", "nodes/status"},
+		AuditLogMaxSize:            100,
+		AuditLogMaxBackups:         3,
+		ConfigRollbackTimeout:      time.Minute,
+		EnableResourceFilter:       true,
+		DisabledResourceFilters:    make([]string, 0),
+		WorkingMode:                string(util.WorkingModeEdge),
+		KubeletHealthGracePeriod:   time.Second * 40,
+		EnableNodePool:             true,
+		MinRequestTimeout:          time.Second * 1800,
+		CACertHashes:               make([]string, 0),
+		UnsafeSkipCAVerification:   true,
 		PoolScopeResources: []schema.GroupVersionResource{
 			{Group: "", Version: "v1", Resource: "services"},
 			{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"},
@@ -106,6 +122,188 @@ func TestValidate(t *testing.T) {
 			},
 			isErr: true,
 		},
+		"invalid cloud compression": {
+			options: &YurtHubOptions{
+				NodeName:         "foo",
+				ServerAddr:       "1.2.3.4:56",
+				JoinToken:        "xxxx",
+				LBMode:           "rr",
+				CloudCompression: []string{"gzip", "br"},
+			},
+			isErr: true,
+		},
+		"audit policy without backends": {
+			options: &YurtHubOptions{
+				NodeName:        "foo",
+				ServerAddr:      "1.2.3.4:56",
+				JoinToken:       "xxxx",
+				LBMode:          "rr",
+				AuditPolicyFile: "/etc/yurthub/audit-policy.yaml",
+			},
+			isErr: true,
+		},
+		"invalid tracing sampling rate": {
+			options: &YurtHubOptions{
+				NodeName:            "foo",
+				ServerAddr:          "1.2.3.4:56",
+				JoinToken:           "xxxx",
+				LBMode:              "rr",
+				TracingSamplingRate: 2000000,
+			},
+			isErr: true,
+		},
+		"invalid dynamic config rollback timeout": {
+			options: &YurtHubOptions{
+				NodeName:              "foo",
+				ServerAddr:            "1.2.3.4:56",
+				JoinToken:             "xxxx",
+				LBMode:                "rr",
+				DynamicConfigFile:     "/etc/yurthub/config/config.yaml",
+				ConfigRollbackTimeout: 0,
+			},
+			isErr: true,
+		},
+		"invalid leader heartbeat interval": {
+			options: &YurtHubOptions{
+				NodeName:                "foo",
+				ServerAddr:              "1.2.3.4:56",
+				JoinToken:               "xxxx",
+				LBMode:                  "rr",
+				LeaderHeartbeatInterval: -time.Second,
+			},
+			isErr: true,
+		},
+		"invalid multiplexer cache memory": {
+			options: &YurtHubOptions{
+				NodeName:               "foo",
+				ServerAddr:             "1.2.3.4:56",
+				JoinToken:              "xxxx",
+				LBMode:                 "rr",
+				MultiplexerCacheMemory: "-1Mi",
+			},
+			isErr: true,
+		},
+		"invalid storage backend": {
+			options: &YurtHubOptions{
+				NodeName:       "foo",
+				ServerAddr:     "1.2.3.4:56",
+				JoinToken:      "xxxx",
+				LBMode:         "rr",
+				WorkingMode:    "edge",
+				StorageBackend: "invalid backend",
+			},
+			isErr: true,
+		},
+		"both encryption key file and kms socket are set": {
+			options: &YurtHubOptions{
+				NodeName:                 "foo",
+				ServerAddr:               "1.2.3.4:56",
+				JoinToken:                "xxxx",
+				LBMode:                   "rr",
+				WorkingMode:              "edge",
+				StorageBackend:           "disk",
+				CacheEncryptionKeyFile:   "/tmp/keys",
+				CacheEncryptionKMSSocket: "/tmp/kms.sock",
+			},
+			isErr: true,
+		},
+		"invalid encryption resources": {
+			options: &YurtHubOptions{
+				NodeName:                 "foo",
+				ServerAddr:               "1.2.3.4:56",
+				JoinToken:                "xxxx",
+				LBMode:                   "rr",
+				WorkingMode:              "edge",
+				StorageBackend:           "disk",
+				CacheEncryptionResources: []string{"secrets"},
+			},
+			isErr: true,
+		},
+		"invalid cache component quota": {
+			options: &YurtHubOptions{
+				NodeName:             "foo",
+				ServerAddr:           "1.2.3.4:56",
+				JoinToken:            "xxxx",
+				LBMode:               "rr",
+				WorkingMode:          "edge",
+				StorageBackend:       "disk",
+				CacheComponentQuotas: map[string]string{"kubelet": "100Mi:invalid"},
+			},
+			isErr: true,
+		},
+		"invalid cache eviction policy": {
+			options: &YurtHubOptions{
+				NodeName:            "foo",
+				ServerAddr:          "1.2.3.4:56",
+				JoinToken:           "xxxx",
+				LBMode:              "rr",
+				WorkingMode:         "edge",
+				StorageBackend:      "disk",
+				CacheEvictionPolicy: "fifo",
+			},
+			isErr: true,
+		},
+		"unsupported disk cache format version": {
+			options: &YurtHubOptions{
+				NodeName:               "foo",
+				ServerAddr:             "1.2.3.4:56",
+				JoinToken:              "xxxx",
+				LBMode:                 "rr",
+				WorkingMode:            "edge",
+				StorageBackend:         "disk",
+				DiskCacheFormatVersion: 3,
+			},
+			isErr: true,
+		},
+		"negative watch history size": {
+			options: &YurtHubOptions{
+				NodeName:         "foo",
+				ServerAddr:       "1.2.3.4:56",
+				JoinToken:        "xxxx",
+				LBMode:           "rr",
+				WorkingMode:      "edge",
+				StorageBackend:   "disk",
+				WatchHistorySize: -1,
+			},
+			isErr: true,
+		},
+		"invalid offline write resources": {
+			options: &YurtHubOptions{
+				NodeName:              "foo",
+				ServerAddr:            "1.2.3.4:56",
+				JoinToken:             "xxxx",
+				LBMode:                "rr",
+				WorkingMode:           "edge",
+				StorageBackend:        "disk",
+				OfflineWriteResources: []string{"pods/"},
+			},
+			isErr: true,
+		},
+		"health check signals without lease": {
+			options: &YurtHubOptions{
+				NodeName:           "foo",
+				ServerAddr:         "1.2.3.4:56",
+				JoinToken:          "xxxx",
+				LBMode:             "rr",
+				WorkingMode:        "edge",
+				StorageBackend:     "disk",
+				HealthCheckSignals: []string{"request", "tcp"},
+			},
+			isErr: true,
+		},
+		"invalid degraded error rate threshold": {
+			options: &YurtHubOptions{
+				NodeName:                   "foo",
+				ServerAddr:                 "1.2.3.4:56",
+				JoinToken:                  "xxxx",
+				LBMode:                     "rr",
+				WorkingMode:                "edge",
+				StorageBackend:             "disk",
+				HealthCheckSignals:         []string{"lease", "request"},
+				DegradedErrorRateThreshold: 1.5,
+			},
+			isErr: true,
+		},
 		"invalid working mode": {
 			options: &YurtHubOptions{
 				NodeName:    "foo",
@@ -18,8 +18,11 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -32,6 +35,7 @@ import (
 	"github.com/openyurtio/openyurt/cmd/yurthub/app/options"
 	"github.com/openyurtio/openyurt/pkg/projectinfo"
 	"github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
+	"github.com/openyurtio/openyurt/pkg/yurthub/dynamicconfig"
 	"github.com/openyurtio/openyurt/pkg/yurthub/gc"
 	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
 	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker/cloudapiserver"
@@ -41,12 +45,20 @@ import (
 	"github.com/openyurtio/openyurt/pkg/yurthub/multiplexer/storage"
 	"github.com/openyurtio/openyurt/pkg/yurthub/proxy"
 	"github.com/openyurtio/openyurt/pkg/yurthub/proxy/remote"
+	"github.com/openyurtio/openyurt/pkg/yurthub/repair"
 	"github.com/openyurtio/openyurt/pkg/yurthub/server"
+	hubstorage "github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/bolt"
 	"github.com/openyurtio/openyurt/pkg/yurthub/storage/disk"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/encryption"
 	"github.com/openyurtio/openyurt/pkg/yurthub/transport"
 	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/writequeue"
 )
 
+// tracerShutdownTimeout is the max duration for flushing spans when yurthub exits.
+const tracerShutdownTimeout = 5 * time.Second
+
 // NewCmdStartYurtHub creates a *cobra.Command object with default parameters
 func NewCmdStartYurtHub(ctx context.Context) *cobra.Command {
 	yurtHubOptions := options.NewYurtHubOptions()
@@ -119,18 +131,32 @@ func Run(ctx context.Context, cfg *config.YurtHubConfiguration) error {
 		var err error
 		if cfg.WorkingMode == util.WorkingModeEdge {
 			klog.Infof("%d. new cache manager with storage wrapper and serializer manager", trace)
-			storageManager, err := disk.NewDiskStorage(cfg.DiskCachePath)
+			storageManager, err := newStorageManager(cfg, ctx.Done())
 			if err != nil {
 				klog.Errorf("could not create storage manager, %v", err)
 				return err
 			}
-			storageWrapper = cachemanager.NewStorageWrapper(storageManager)
-			cacheManager = cachemanager.NewCacheManager(storageWrapper, cfg.SerializerManager, cfg.RESTMapperManager, cfg.ConfigManager)
+			if cfg.CacheQuota != nil {
+				storageWrapper, err = cachemanager.NewStorageWrapperWithQuota(storageManager, cfg.CacheQuota)
+				if err != nil {
+					return fmt.Errorf("could not create storage wrapper with cache quota, %w", err)
+				}
+			} else {
+				storageWrapper = cachemanager.NewStorageWrapper(storageManager)
+			}
+			cacheManager = cachemanager.NewCacheManagerWithWatchHistory(storageWrapper, cfg.SerializerManager, cfg.RESTMapperManager, cfg.ConfigManager, cfg.WatchHistorySize)
 			cfg.StorageWrapper = storageWrapper
+			cfg.CacheManager = cacheManager
 			trace++
 
 			klog.Infof("%d. create health checkers for remote servers", trace)
-			cloudHealthChecker, err = cloudapiserver.NewCloudAPIServerHealthChecker(cfg, ctx.Done())
+			// node lease is always used for checking cloud kube-apiserver, and multi-signal health checker
+			// is used when other signals are specified.
+			if slices.ContainsFunc(cfg.HealthCheckSignals, func(signal string) bool { return signal != healthchecker.SignalLease }) {
+				cloudHealthChecker, err = cloudapiserver.NewMultiSignalHealthChecker(cfg, ctx.Done())
+			} else {
+				cloudHealthChecker, err = cloudapiserver.NewCloudAPIServerHealthChecker(cfg, ctx.Done())
+			}
 			if err != nil {
 				return fmt.Errorf("could not new health checker for cloud kube-apiserver, %w", err)
 			}
@@ -143,6 +169,23 @@ func Run(ctx context.Context, cfg *config.YurtHubConfiguration) error {
 			}
 			gcMgr.Run()
 			trace++
+
+			if len(cfg.OfflineWriteResources) != 0 {
+				klog.Infof("%d. new offline write queue for replaying requests of %v after reconnecting to cloud", trace, cfg.OfflineWriteResources)
+				writeQueue, err := writequeue.NewQueue(filepath.Join(cfg.DiskCachePath, "_internal", "offline-writes"), cfg.OfflineWriteResources, writequeue.DefaultMaxRecords)
+				if err != nil {
+					return fmt.Errorf("could not new offline write queue, %w", err)
+				}
+				cfg.OfflineWriteQueue = writeQueue
+				writequeue.NewReplayManager(writeQueue, cloudHealthChecker, cfg.TransportAndDirectClientManager, ctx.Done()).Run()
+				trace++
+			}
+
+			if verifier, ok := storageManager.(hubstorage.Verifier); ok {
+				klog.Infof("%d. new repair manager for re-fetching quarantined objects of local cache", trace)
+				repair.NewRepairManager(verifier, cloudHealthChecker, cfg.YurtHubProxyServerServing.Listener.Addr().String(), ctx.Done()).Run()
+				trace++
+			}
 		}
 
 		// no leader hub servers for transport manager at startup time.
@@ -151,11 +194,42 @@ func Run(ctx context.Context, cfg *config.YurtHubConfiguration) error {
 		if err != nil {
 			return fmt.Errorf("could not new transport manager for leader hub, %w", err)
 		}
-		healthCheckerForLeaderHub := leaderhub.NewLeaderHubHealthChecker(20*time.Second, nil, ctx.Done())
-		loadBalancerForLeaderHub := remote.NewLoadBalancer("round-robin", []*url.URL{}, cacheManager, transportManagerForLeaderHub, healthCheckerForLeaderHub, nil, ctx.Done())
+		// leader hubs are probed at heartbeat interval, and follower sticks to a leader hub until it's unhealthy.
+		leaderHubCheckInterval := 20 * time.Second
+		if cfg.LeaderHeartbeatInterval > 0 {
+			leaderHubCheckInterval = cfg.LeaderHeartbeatInterval
+		}
+		healthCheckerForLeaderHub := leaderhub.NewLeaderHubHealthChecker(leaderHubCheckInterval, nil, ctx.Done())
+		loadBalancerForLeaderHub := remote.NewLoadBalancer("sticky", []*url.URL{}, cacheManager, transportManagerForLeaderHub, healthCheckerForLeaderHub, nil, ctx.Done())
 
 		cfg.LoadBalancerForLeaderHub = loadBalancerForLeaderHub
+		loadBalancer := remote.NewLoadBalancerWithNegotiator(
+			cfg.LBMode,
+			cfg.RemoteServers,
+			cacheManager,
+			cfg.TransportAndDirectClientManager,
+			cloudHealthChecker,
+			cfg.FilterFinder,
+			remote.NewNegotiator(cfg.CloudCompression, cfg.PreferProtobuf, cfg.SerializerManager),
+			ctx.Done())
+		cfg.LoadBalancer = loadBalancer
+		if cfg.MultiplexerCacheMemory > 0 {
+			klog.Infof("%d. new store for spilling objects of multiplexer cache beyond %d bytes in memory", trace, cfg.MultiplexerCacheMemory)
+			cfg.MultiplexerCacheStore, err = newMultiplexerCacheStore(cfg, ctx.Done())
+			if err != nil {
+				return fmt.Errorf("could not new store for multiplexer cache, %w", err)
+			}
+			trace++
+		}
 		requestMultiplexerManager := newRequestMultiplexerManager(cfg, healthCheckerForLeaderHub)
+		requestMultiplexerManager.Run(cloudHealthChecker, ctx.Done())
+
+		if len(cfg.DynamicConfigFile) != 0 || len(cfg.DynamicConfigConfigMap) != 0 {
+			klog.Infof("%d. new dynamic config manager for applying configuration at runtime", trace)
+			cfg.DynamicConfigManager = newDynamicConfigManager(cfg, cloudHealthChecker, loadBalancer, requestMultiplexerManager)
+			cfg.DynamicConfigManager.Run(ctx.Done())
+			trace++
+		}
 
 		if cfg.NetworkMgr != nil {
 			klog.Infof("%d. start network manager for ensuing dummy interface", trace)
@@ -188,10 +262,122 @@ func Run(ctx context.Context, cfg *config.YurtHubConfiguration) error {
 
 	}
 	<-ctx.Done()
+	if cfg.TracerProvider != nil {
+		// flush spans in batch before exit.
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), tracerShutdownTimeout)
+		defer cancel()
+		if err := cfg.TracerProvider.Shutdown(shutdownCtx); err != nil {
+			klog.Errorf("could not shutdown tracer provider, %v", err)
+		}
+	}
 	klog.Info("hub agent exited")
 	return nil
 }
 
+// newStorageManager creates the storage.Store for caching data on local disk according to the storage backend,
+// and wraps it with an encryption layer when cache encryption is enabled.
+func newStorageManager(cfg *config.YurtHubConfiguration, stopCh <-chan struct{}) (hubstorage.Store, error) {
+	var store hubstorage.Store
+	var err error
+	switch cfg.StorageBackend {
+	case util.StorageBackendBolt:
+		store, err = bolt.NewBoltStorage(cfg.DiskCachePath)
+	default:
+		store, err = disk.NewDiskStorageWithFormat(cfg.DiskCachePath, disk.FormatVersion(cfg.DiskCacheFormatVersion))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// scrub the local cache at startup, so corrupted files caused by power loss or disk failure will not be served.
+	if verifier, ok := store.(hubstorage.Verifier); ok {
+		result, err := verifier.Verify()
+		if errors.Is(err, hubstorage.ErrVerifyNotSupported) {
+			klog.V(2).Infof("skip to verify local cache, %v", err)
+		} else if err != nil {
+			klog.Errorf("could not verify local cache, %v", err)
+		} else if len(result.Quarantined) != 0 {
+			klog.Warningf("%d corrupted objects are quarantined when starting local cache, %v", len(result.Quarantined), result.Quarantined)
+		}
+	}
+
+	provider, err := newCacheKeyProvider(cfg)
+	if err != nil || provider == nil {
+		return store, err
+	}
+
+	klog.Infof("cache of resources %v will be encrypted by %s key provider", cfg.CacheEncryptionResources, provider.Name())
+	keyCheckPath := filepath.Join(cfg.DiskCachePath, "_internal", "encryption", "key-check")
+	return encryption.NewEncryptedStorage(store, provider, cfg.CacheEncryptionResources, keyCheckPath, stopCh)
+}
+
+// newMultiplexerCacheStore creates the storage.Store for objects spilled by multiplexer cache, and objects
+// are encrypted in the same way as the local cache, because resources like secrets may be spilled.
+func newMultiplexerCacheStore(cfg *config.YurtHubConfiguration, stopCh <-chan struct{}) (hubstorage.Store, error) {
+	store, err := disk.NewDiskStorage(filepath.Join(cfg.DiskCachePath, "_internal", "multiplexer"))
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := newCacheKeyProvider(cfg)
+	if err != nil || provider == nil {
+		return store, err
+	}
+	keyCheckPath := filepath.Join(cfg.DiskCachePath, "_internal", "encryption", "multiplexer-key-check")
+	return encryption.NewEncryptedStorage(store, provider, cfg.CacheEncryptionResources, keyCheckPath, stopCh)
+}
+
+// newCacheKeyProvider creates the key provider for cache encryption, and nil is returned when cache
+// encryption is not enabled.
+func newCacheKeyProvider(cfg *config.YurtHubConfiguration) (encryption.KeyProvider, error) {
+	switch {
+	case len(cfg.CacheEncryptionKeyFile) != 0:
+		return encryption.NewFileKeyProvider(cfg.CacheEncryptionKeyFile)
+	case len(cfg.CacheEncryptionKMSSocket) != 0:
+		return encryption.NewKMSKeyProvider(cfg.CacheEncryptionKMSSocket, 3*time.Second)
+	default:
+		return nil, nil
+	}
+}
+
+// newDynamicConfigManager creates the manager for applying configuration file or ConfigMap at runtime, startup flags are
+// used as initial settings. filters disabled by startup flags are not initialized, so no filters are disabled
+// at runtime initially.
+func newDynamicConfigManager(cfg *config.YurtHubConfiguration,
+	cloudHealthChecker healthchecker.Interface,
+	loadBalancer *remote.LoadBalancer,
+	requestMultiplexerManager *multiplexer.MultiplexerManager) *dynamicconfig.Manager {
+	initial := dynamicconfig.Settings{
+		RemoteServers: cfg.RemoteServers,
+		LBMode:        cfg.LBMode,
+		Heartbeat: healthchecker.HeartbeatConfig{
+			FailedRetry:      cfg.HeartbeatFailedRetry,
+			HealthyThreshold: cfg.HeartbeatHealthyThreshold,
+			IntervalSeconds:  cfg.HeartbeatIntervalSeconds,
+		},
+		HeartbeatTimeoutSeconds: cfg.HeartbeatTimeoutSeconds,
+		PoolScopeResources:      cfg.PoolScopeResources,
+	}
+	targets := dynamicconfig.Targets{
+		Transport:     cfg.TransportAndDirectClientManager,
+		HealthChecker: cloudHealthChecker,
+		LoadBalancer:  loadBalancer,
+		PoolScope:     requestMultiplexerManager,
+	}
+	if filters, ok := cfg.FilterFinder.(dynamicconfig.FilterUpdater); ok {
+		targets.Filters = filters
+	}
+	if certManager, ok := cfg.CertManager.(dynamicconfig.RemoteServersUpdater); ok {
+		targets.ServerUsers = append(targets.ServerUsers, certManager)
+	}
+
+	source := dynamicconfig.NewFileSource(cfg.DynamicConfigFile)
+	if len(cfg.DynamicConfigConfigMap) != 0 {
+		source = dynamicconfig.NewConfigMapSource(cfg.SharedFactory.Core().V1().ConfigMaps().Lister(), cfg.YurtHubNamespace, cfg.DynamicConfigConfigMap)
+	}
+	return dynamicconfig.NewManager(source, initial, targets, cfg.ConfigRollbackTimeout)
+}
+
 func newRequestMultiplexerManager(cfg *config.YurtHubConfiguration, healthCheckerForLeaderHub healthchecker.Interface) *multiplexer.MultiplexerManager {
 	insecureHubProxyAddress := cfg.YurtHubProxyServerServing.Listener.Addr().String()
 	klog.Infof("hub insecure proxy address: %s", insecureHubProxyAddress)
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CacheAction represents whether responses of requests matched by a cache rule are cached by yurthub.
+type CacheAction string
+
+const (
+	// CacheActionCache means responses of matched requests are cached on local disk.
+	CacheActionCache CacheAction = "Cache"
+	// CacheActionSkip means responses of matched requests are not cached on local disk.
+	CacheActionSkip CacheAction = "Skip"
+)
+
+// CacheResource represents the resources matched by a cache rule.
+type CacheResource struct {
+	// Group is the API group of resource, empty string means the core group, and "*" matches all groups.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Version is the API version of resource, empty string matches all versions.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Resource is the plural name of resource, like pods, and "*" matches all resources.
+	Resource string `json:"resource"`
+}
+
+// CacheRule declares whether responses of requests which are matched by all fields of rule are cached.
+type CacheRule struct {
+	// UserAgents are the components which send requests, like kubelet, and "*" matches all components.
+	UserAgents []string `json:"userAgents"`
+
+	// Resources are the resources of requests, empty list matches all resources.
+	// +optional
+	Resources []CacheResource `json:"resources,omitempty"`
+
+	// Namespaces are the namespaces of requests, empty list matches all namespaces.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// LabelSelector matches requests whose label selector includes all requirements of it, like app=foo.
+	// empty string matches all requests.
+	// +optional
+	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// FieldSelector matches requests whose field selector includes all requirements of it, like spec.nodeName=foo.
+	// empty string matches all requests.
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty"`
+
+	// Action represents responses of matched requests are cached or not, default is Cache.
+	// +optional
+	// +kubebuilder:validation:Enum=Cache;Skip
+	// +kubebuilder:default=Cache
+	Action CacheAction `json:"action,omitempty"`
+}
+
+// YurtHubCachePolicySpec defines the desired state of YurtHubCachePolicy
+type YurtHubCachePolicySpec struct {
+	// NodePools are the names of nodepools which the policy is applied to.
+	// +optional
+	NodePools []string `json:"nodePools,omitempty"`
+
+	// NodePoolSelector selects the nodepools which the policy is applied to by labels of nodepools.
+	// The policy is applied to all nodes when both NodePools and NodePoolSelector are not specified.
+	// +optional
+	NodePoolSelector *metav1.LabelSelector `json:"nodePoolSelector,omitempty"`
+
+	// Priority of the policy. The rules of policy with higher priority are evaluated at first,
+	// and policies with the same priority are evaluated in the order of their names.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Rules are evaluated in order, and the first rule which matches the request decides
+	// whether the response is cached. Requests matched by none of rules of all policies are
+	// cached according to cache_agents of yurt-hub-cfg configmap.
+	Rules []CacheRule `json:"rules"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,path=yurthubcachepolicies,shortName=ycp,categories=yurt
+// +kubebuilder:printcolumn:name="PRIORITY",type="integer",JSONPath=".spec.priority",description="The priority of cache policy."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. It is not guaranteed to be set in happens-before order across separate operations. Clients may not set this value. It is represented in RFC3339 form and is in UTC."
+
+// YurtHubCachePolicy declares which responses are cached on local disk by yurthub for nodes in specified nodepools.
+type YurtHubCachePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec YurtHubCachePolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// YurtHubCachePolicyList contains a list of YurtHubCachePolicy
+type YurtHubCachePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []YurtHubCachePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&YurtHubCachePolicy{}, &YurtHubCachePolicyList{})
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FilterAction represents how objects matched by a filter rule are handled by yurthub.
+type FilterAction string
+
+const (
+	// FilterActionDrop means matched objects are removed from responses, and watch events of
+	// matched objects are converted into DELETED events.
+	FilterActionDrop FilterAction = "Drop"
+	// FilterActionPatch means fields of matched objects are rewritten by patches of rule.
+	FilterActionPatch FilterAction = "Patch"
+)
+
+// FilterResource represents the resources matched by a filter rule.
+type FilterResource struct {
+	// Group is the API group of resource, empty string means the core group, and "*" matches all groups.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Version is the API version of resource, empty string matches all versions.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Resource is the plural name of resource, like pods, and "*" matches all resources.
+	Resource string `json:"resource"`
+}
+
+// FieldPatch rewrites a field of object.
+type FieldPatch struct {
+	// Path is the JSON pointer(RFC 6901) of field, like /spec/externalIPs or /metadata/annotations/foo~1bar.
+	// intermediate objects are created when they don't exist.
+	Path string `json:"path"`
+
+	// Value is a CEL expression whose result is set to the field, and the field is removed
+	// when value is empty. the variables in expression are the same as condition of rule.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// FilterRule declares how objects in responses of requests which are matched by all fields of rule are filtered.
+type FilterRule struct {
+	// Name of rule, it's unique in the policy and used in metrics.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// UserAgents are the components which send requests, like kube-proxy, and "*" matches all components.
+	UserAgents []string `json:"userAgents"`
+
+	// Resources are the resources of requests, empty list matches all resources.
+	// +optional
+	Resources []FilterResource `json:"resources,omitempty"`
+
+	// Namespaces are the namespaces of objects, empty list matches all namespaces.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Condition is a CEL expression which returns a bool, and action is taken only for objects
+	// whose condition is true. the object is referenced by variable `object`, and the node name
+	// and nodepool name of current node are referenced by variables `nodeName` and `nodePoolName`.
+	// empty condition matches all objects.
+	// +optional
+	Condition string `json:"condition,omitempty"`
+
+	// Action represents matched objects are dropped or patched, default is Patch.
+	// +optional
+	// +kubebuilder:validation:Enum=Drop;Patch
+	// +kubebuilder:default=Patch
+	Action FilterAction `json:"action,omitempty"`
+
+	// Patches are applied in order when action is Patch.
+	// +optional
+	Patches []FieldPatch `json:"patches,omitempty"`
+
+	// CostLimit is the limit of runtime cost for evaluating each expression of rule on an object,
+	// and the rule is skipped for the object when the limit is exceeded. default is 100000.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	CostLimit *int64 `json:"costLimit,omitempty"`
+}
+
+// YurtHubFilterPolicySpec defines the desired state of YurtHubFilterPolicy
+type YurtHubFilterPolicySpec struct {
+	// NodePools are the names of nodepools which the policy is applied to.
+	// +optional
+	NodePools []string `json:"nodePools,omitempty"`
+
+	// NodePoolSelector selects the nodepools which the policy is applied to by labels of nodepools.
+	// The policy is applied to all nodes when both NodePools and NodePoolSelector are not specified.
+	// +optional
+	NodePoolSelector *metav1.LabelSelector `json:"nodePoolSelector,omitempty"`
+
+	// Priority of the policy. The rules of policy with higher priority are evaluated at first,
+	// and policies with the same priority are evaluated in the order of their names.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Rules are evaluated in order, and all of rules which match the object are applied
+	// until the object is dropped.
+	Rules []FilterRule `json:"rules"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,path=yurthubfilterpolicies,shortName=yfp,categories=yurt
+// +kubebuilder:printcolumn:name="PRIORITY",type="integer",JSONPath=".spec.priority",description="The priority of filter policy."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. It is not guaranteed to be set in happens-before order across separate operations. Clients may not set this value. It is represented in RFC3339 form and is in UTC."
+
+// YurtHubFilterPolicy declares how objects in responses are rewritten or dropped by yurthub with CEL
+// expressions for nodes in specified nodepools.
+type YurtHubFilterPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec YurtHubFilterPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// YurtHubFilterPolicyList contains a list of YurtHubFilterPolicy
+type YurtHubFilterPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []YurtHubFilterPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&YurtHubFilterPolicy{}, &YurtHubFilterPolicyList{})
+}
@@ -30,8 +30,9 @@ const (
 	Edge  NodePoolType = "Edge"
 	Cloud NodePoolType = "Cloud"
 
-	ElectionStrategyMark   LeaderElectionStrategy = "mark"
-	ElectionStrategyRandom LeaderElectionStrategy = "random"
+	ElectionStrategyMark     LeaderElectionStrategy = "mark"
+	ElectionStrategyRandom   LeaderElectionStrategy = "random"
+	ElectionStrategyCapacity LeaderElectionStrategy = "capacity"
 
 	// LeaderStatus means the status of leader yurthub election.
 	// If it's ready the leader elected, otherwise no leader is elected.
@@ -74,6 +75,8 @@ type NodePoolSpec struct {
 	// LeaderElectionStrategy represents the policy how to elect a leader Yurthub in a nodepool.
 	// random: select one ready node as leader at random.
 	// mark: select one ready node as leader from nodes that are specified by labelselector.
+	// capacity: select ready nodes with the highest scores as leaders, nodes are scored by allocatable cpu/memory,
+	// network tier label, uptime and recent leader churn, and leaders are spread across topology zones.
 	// More strategies will be supported according to user's new requirements.
 	LeaderElectionStrategy string `json:"leaderElectionStrategy,omitempty"`
 
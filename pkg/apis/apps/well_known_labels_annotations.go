@@ -44,6 +44,14 @@ const (
 	NodePoolHostNetworkLabel = "nodepool.openyurt.io/hostnetwork"
 	NodePoolChangedEvent     = "NodePoolChanged"
 	NodePoolTypeLabel        = "nodepool.openyurt.io/type"
+
+	// LeaderNetworkTierLabel is used to specify the network quality(high, medium or low) of node, and it's
+	// used for scoring candidates when nodepool elects leader yurthubs by capacity strategy.
+	LeaderNetworkTierLabel = "nodepool.openyurt.io/leader-network-tier"
+
+	// LeaderChurnAnnotation records the times when nodes lost their leadership recently in json format, and it's
+	// used for penalizing candidates when nodepool elects leader yurthubs by capacity strategy.
+	LeaderChurnAnnotation = "nodepool.openyurt.io/leader-churn"
 )
 
 // Pod related labels and annotations
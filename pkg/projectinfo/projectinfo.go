@@ -163,3 +163,14 @@ func Get() Info {
 		NodePoolLabelKey: nodePoolLabelKey,
 	}
 }
+
+// GetHubLeaderLeaseName returns the name of the lease renewed by leader yurthub on the node
+func GetHubLeaderLeaseName(nodeName string) string {
+	return fmt.Sprintf("hub-leader-%s", nodeName)
+}
+
+// GetHubLeaderLeaseLabel returns the label of leases renewed by leader yurthubs,
+// and the value of label is the name of nodepool.
+func GetHubLeaderLeaseLabel() string {
+	return fmt.Sprintf("%s/hub-leader-lease", labelPrefix)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// package lease implements utilities for working with leases
+package lease
+
+import (
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+)
+
+// Duration returns the duration of lease, zero is returned when it's not set.
+func Duration(lease *coordinationv1.Lease) time.Duration {
+	if lease.Spec.LeaseDurationSeconds == nil {
+		return 0
+	}
+	return time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+}
+
+// ExpireTime returns the time when lease expires, acquire time or creation time of lease is used when the
+// lease has never been renewed. renew time is written with the clock of lease holder, so the expire time
+// should only be compared with the clock of holder, and others should track renewals of lease by themselves.
+func ExpireTime(lease *coordinationv1.Lease) time.Time {
+	start := lease.CreationTimestamp.Time
+	if lease.Spec.RenewTime != nil {
+		start = lease.Spec.RenewTime.Time
+	} else if lease.Spec.AcquireTime != nil {
+		start = lease.Spec.AcquireTime.Time
+	}
+	return start.Add(Duration(lease))
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lease
+
+import (
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestExpireTime(t *testing.T) {
+	now := time.Now()
+	testcases := map[string]struct {
+		lease  *coordinationv1.Lease
+		expect time.Time
+	}{
+		"renewed lease": {
+			lease: &coordinationv1.Lease{
+				Spec: coordinationv1.LeaseSpec{
+					LeaseDurationSeconds: ptr.To[int32](15),
+					AcquireTime:          &metav1.MicroTime{Time: now.Add(-time.Minute)},
+					RenewTime:            &metav1.MicroTime{Time: now},
+				},
+			},
+			expect: now.Add(15 * time.Second),
+		},
+		"lease is never renewed": {
+			lease: &coordinationv1.Lease{
+				Spec: coordinationv1.LeaseSpec{
+					LeaseDurationSeconds: ptr.To[int32](15),
+					AcquireTime:          &metav1.MicroTime{Time: now},
+				},
+			},
+			expect: now.Add(15 * time.Second),
+		},
+		"lease without duration": {
+			lease: &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: now}},
+			},
+			expect: now,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			if expireTime := ExpireTime(tc.lease); !expireTime.Equal(tc.expect) {
+				t.Errorf("expect expire time %v, but got %v", tc.expect, expireTime)
+			}
+		})
+	}
+}
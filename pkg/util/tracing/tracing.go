@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/component-base/tracing"
+	tracingapi "k8s.io/component-base/tracing/api/v1"
+)
+
+const (
+	instrumentationScope = "github.com/openyurtio/openyurt"
+
+	// MaxSamplingRatePerMillion is the max value of sampling rate, which means all of spans are sampled.
+	MaxSamplingRatePerMillion = 1000000
+
+	// AttributeServedBy is the handler(like remote, cache, multiplexer) which serves the request.
+	AttributeServedBy = "openyurt.served_by"
+	// AttributeBackend is the address of server to which the request is forwarded.
+	AttributeBackend = "openyurt.backend"
+	// AttributeComponent is the client component of request.
+	AttributeComponent = "openyurt.component"
+	// AttributeResource is the resource of request.
+	AttributeResource = "openyurt.resource"
+	// AttributeVerb is the verb of request.
+	AttributeVerb = "openyurt.verb"
+	// AttributeFilters is the name of filters in the filter chain.
+	AttributeFilters = "openyurt.filters"
+	// AttributeObjects is the count of objects handled in the span.
+	AttributeObjects = "openyurt.objects"
+	// AttributeTunnelHost is the host to which the tunnel is setup.
+	AttributeTunnelHost = "openyurt.tunnel.host"
+)
+
+// NewTracerProvider creates a TracerProvider which exports spans to the OTLP collector at endpoint.
+// a noop TracerProvider is returned if endpoint is empty, and trace context is still propagated
+// with the noop TracerProvider, so yurthub and tunnel server will not break traces of clients.
+// spans are sampled at samplingRatePerMillion, or respect the sampling decision of parent span.
+func NewTracerProvider(ctx context.Context, endpoint string, samplingRatePerMillion int32, serviceName, instance string) (tracing.TracerProvider, error) {
+	if len(endpoint) == 0 {
+		return tracing.NewNoopTracerProvider(), nil
+	}
+
+	resourceOpts := []resource.Option{
+		resource.WithAttributes(
+			semconv.ServiceName(serviceName),
+			semconv.ServiceInstanceID(instance),
+		),
+	}
+	return tracing.NewProvider(ctx, &tracingapi.TracingConfiguration{
+		Endpoint:               &endpoint,
+		SamplingRatePerMillion: &samplingRatePerMillion,
+	}, nil, resourceOpts)
+}
+
+// WithTracing extracts W3C trace context from the incoming request and starts a server span for it.
+func WithTracing(handler http.Handler, tp trace.TracerProvider, spanName string) http.Handler {
+	return tracing.WithTracing(handler, tp, spanName)
+}
+
+// Start creates a child span of the span in ctx, the span is recorded by the TracerProvider of
+// parent span, so nothing is recorded if there is no span in ctx.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer(instrumentationScope).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// SetAttributes sets attributes on the span in ctx.
+func SetAttributes(ctx context.Context, attributes ...attribute.KeyValue) {
+	trace.SpanFromContext(ctx).SetAttributes(attributes...)
+}
+
+// RecordError records err on span and marks the span as failed.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// InjectHeader writes W3C trace context of the span in ctx into header, so the trace can be
+// continued by the server which receives the request.
+func InjectHeader(ctx context.Context, header http.Header) {
+	tracing.Propagators().Inject(ctx, propagation.HeaderCarrier(header))
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	tp, err := NewTracerProvider(context.Background(), "", 0, "yurthub", "foo")
+	if err != nil {
+		t.Fatalf("could not create tracer provider, %v", err)
+	}
+	_, span := tp.Tracer("test").Start(context.Background(), "test")
+	if span.IsRecording() {
+		t.Errorf("expect spans are not recorded when endpoint is empty")
+	}
+
+	tp, err = NewTracerProvider(context.Background(), "localhost:4317", MaxSamplingRatePerMillion, "yurthub", "foo")
+	if err != nil {
+		t.Fatalf("could not create tracer provider, %v", err)
+	}
+	defer tp.Shutdown(context.Background())
+	_, span = tp.Tracer("test").Start(context.Background(), "test")
+	if !span.IsRecording() {
+		t.Errorf("expect spans are recorded when all of spans are sampled")
+	}
+}
+
+func TestStart(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	// nothing is recorded without parent span
+	_, span := Start(context.Background(), "orphan")
+	span.End()
+	if len(exporter.GetSpans()) != 0 {
+		t.Errorf("expect no span is recorded without parent span, but got %d", len(exporter.GetSpans()))
+	}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, child := Start(ctx, "child", attribute.String(AttributeVerb, "list"))
+	RecordError(child, errors.New("boom"))
+	child.End()
+	SetAttributes(ctx, attribute.String(AttributeServedBy, "cache"))
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expect 2 spans, but got %d", len(spans))
+	}
+	if spans[0].Name != "child" || spans[0].Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("expect child span of parent, but got %s with parent %s", spans[0].Name, spans[0].Parent.SpanID())
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("expect status of child span is error, but got %v", spans[0].Status.Code)
+	}
+	if len(spans[1].Attributes) != 1 || spans[1].Attributes[0].Value.AsString() != "cache" {
+		t.Errorf("expect served by attribute of parent span, but got %v", spans[1].Attributes)
+	}
+}
+
+func TestPropagation(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "client")
+	defer span.End()
+
+	header := http.Header{}
+	InjectHeader(ctx, header)
+	if len(header.Get("traceparent")) == 0 {
+		t.Fatalf("expect traceparent header is injected")
+	}
+
+	var received trace.SpanContext
+	handler := WithTracing(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		received = trace.SpanContextFromContext(req.Context())
+	}), tp, "server")
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+	req.Header = header
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if received.TraceID() != span.SpanContext().TraceID() {
+		t.Errorf("expect trace id %s, but got %s", span.SpanContext().TraceID(), received.TraceID())
+	}
+}
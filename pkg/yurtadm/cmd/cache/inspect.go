@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/openyurtio/openyurt/pkg/yurtadm/constants"
+	"github.com/openyurtio/openyurt/pkg/yurthub/server"
+)
+
+const (
+	serverAddrFlag = "server-addr"
+	outputFlag     = "output"
+
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+type inspectOptions struct {
+	serverAddr string
+	output     string
+	component  string
+	group      string
+	version    string
+	resource   string
+	namespace  string
+	name       string
+}
+
+func newInspectOptions() *inspectOptions {
+	return &inspectOptions{
+		serverAddr: fmt.Sprintf("%s:10267", constants.DefaultYurtHubServerAddr),
+		output:     outputTable,
+		version:    "v1",
+	}
+}
+
+// newCmdInspect returns "yurtadm cache inspect" command.
+func newCmdInspect(out io.Writer) *cobra.Command {
+	o := newInspectOptions()
+
+	cmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Inspect the local cache of a running yurthub",
+		Long: "Inspect the local cache of a running yurthub through its server port. Components and cached resources are listed " +
+			"if --component is not set, objects of a resource are listed if --name is not set, otherwise the cached object is printed. " +
+			"Data of secrets and resources encrypted at rest is redacted by yurthub.",
+		Example: "  yurtadm cache inspect\n" +
+			"  yurtadm cache inspect --component kubelet --resource pods\n" +
+			"  yurtadm cache inspect --component kube-proxy --group discovery.k8s.io --resource endpointslices -o json\n" +
+			"  yurtadm cache inspect --component kubelet --resource pods --namespace kube-system --name kube-proxy-xxx",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				return err
+			}
+			return o.run(out)
+		},
+	}
+
+	flagSet := cmd.Flags()
+	flagSet.StringVar(&o.serverAddr, serverAddrFlag, o.serverAddr, "The address of yurthub server for serving metrics and healthz.")
+	flagSet.StringVarP(&o.output, outputFlag, "o", o.output, "Output format, table or json.")
+	flagSet.StringVar(&o.component, "component", o.component, "The component whose cache is inspected, like kubelet.")
+	flagSet.StringVar(&o.group, "group", o.group, "The API group of resource, empty for the core group.")
+	flagSet.StringVar(&o.version, "version", o.version, "The API version of resource.")
+	flagSet.StringVar(&o.resource, "resource", o.resource, "The plural name of resource, like pods.")
+	flagSet.StringVar(&o.namespace, "namespace", o.namespace, "The namespace of objects.")
+	flagSet.StringVar(&o.name, "name", o.name, "The name of object.")
+	return cmd
+}
+
+func (o *inspectOptions) validate() error {
+	if o.output != outputTable && o.output != outputJSON {
+		return fmt.Errorf("output format %s is not supported", o.output)
+	}
+	if len(o.component) != 0 && len(o.resource) == 0 {
+		return fmt.Errorf("--resource is empty")
+	}
+	if len(o.name) != 0 && len(o.component) == 0 {
+		return fmt.Errorf("--component is empty")
+	}
+	return nil
+}
+
+func (o *inspectOptions) run(out io.Writer) error {
+	switch {
+	case len(o.component) == 0:
+		var components []server.ComponentCache
+		data, err := o.get("/cache/components", nil, &components)
+		if err != nil {
+			return err
+		}
+		if o.output == outputJSON {
+			return printJSON(out, data)
+		}
+		return printComponents(out, components)
+	case len(o.name) == 0:
+		var objects []server.ObjectCache
+		data, err := o.get("/cache/objects", o.query(), &objects)
+		if err != nil {
+			return err
+		}
+		if o.output == outputJSON {
+			return printJSON(out, data)
+		}
+		return printObjects(out, objects)
+	default:
+		query := o.query()
+		query.Set("name", o.name)
+		data, err := o.get("/cache/object", query, nil)
+		if err != nil {
+			return err
+		}
+		// a single object is always printed in json format.
+		return printJSON(out, data)
+	}
+}
+
+func (o *inspectOptions) query() url.Values {
+	query := url.Values{}
+	query.Set("component", o.component)
+	query.Set("group", o.group)
+	query.Set("version", o.version)
+	query.Set("resource", o.resource)
+	if len(o.namespace) != 0 {
+		query.Set("namespace", o.namespace)
+	}
+	return query
+}
+
+// get sends request to yurthub server, and the response is decoded into v if v is not nil.
+func (o *inspectOptions) get(path string, query url.Values, v interface{}) ([]byte, error) {
+	u := url.URL{Scheme: "http", Host: o.serverAddr, Path: path, RawQuery: query.Encode()}
+	client := http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("could not request yurthub at %s, %w", o.serverAddr, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response of yurthub, %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("yurthub responds %d, %s", resp.StatusCode, string(data))
+	}
+
+	if v != nil {
+		if err := json.Unmarshal(data, v); err != nil {
+			return nil, fmt.Errorf("could not decode response of yurthub, %w", err)
+		}
+	}
+	return data, nil
+}
+
+func printJSON(out io.Writer, data []byte) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return err
+	}
+	buf.WriteString("\n")
+	_, err := buf.WriteTo(out)
+	return err
+}
+
+func printComponents(out io.Writer, components []server.ComponentCache) error {
+	w := tabwriter.NewWriter(out, 0, 8, 3, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tRESOURCE\tCOUNT\tLAST-UPDATE")
+	for _, comp := range components {
+		for _, res := range comp.Resources {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", comp.Component, resourceString(res), res.Count, age(res.LastUpdateTime))
+		}
+	}
+	return w.Flush()
+}
+
+func printObjects(out io.Writer, objects []server.ObjectCache) error {
+	w := tabwriter.NewWriter(out, 0, 8, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tLAST-UPDATE")
+	for _, obj := range objects {
+		namespace := obj.Namespace
+		if len(namespace) == 0 {
+			namespace = "<none>"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", namespace, obj.Name, age(obj.LastUpdateTime))
+	}
+	return w.Flush()
+}
+
+func resourceString(res server.ResourceCache) string {
+	if len(res.Group) == 0 {
+		return fmt.Sprintf("%s.%s", res.Resource, res.Version)
+	}
+	return fmt.Sprintf("%s.%s.%s", res.Resource, res.Version, res.Group)
+}
+
+func age(t *metav1.Time) string {
+	if t == nil {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(t.Time)) + " ago"
+}
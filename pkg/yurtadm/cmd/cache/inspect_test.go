@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		switch r.URL.Path {
+		case "/cache/components":
+			w.Write([]byte(`[{"component":"kubelet","resources":[{"group":"","version":"v1","resource":"pods","count":2},{"group":"coordination.k8s.io","version":"v1","resource":"leases","count":1}]}]`))
+		case "/cache/objects":
+			w.Write([]byte(`[{"namespace":"default","name":"nginx"},{"name":"node1"}]`))
+		case "/cache/object":
+			w.Write([]byte(`{"kind":"Pod","metadata":{"name":"nginx"}}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	serverAddr := strings.TrimPrefix(server.URL, "http://")
+
+	testcases := map[string]struct {
+		args        []string
+		expectErr   bool
+		expectQuery string
+		expectOut   []string
+	}{
+		"list components": {
+			args:      []string{},
+			expectOut: []string{"COMPONENT", "kubelet", "pods.v1", "leases.v1.coordination.k8s.io", "<unknown>"},
+		},
+		"list components in json": {
+			args:      []string{"-o", "json"},
+			expectOut: []string{`"component": "kubelet"`},
+		},
+		"list objects": {
+			args:        []string{"--component", "kubelet", "--resource", "pods"},
+			expectQuery: "component=kubelet&group=&resource=pods&version=v1",
+			expectOut:   []string{"NAMESPACE", "default", "nginx", "<none>", "node1"},
+		},
+		"get object": {
+			args:        []string{"--component", "kubelet", "--resource", "pods", "--namespace", "default", "--name", "nginx"},
+			expectQuery: "component=kubelet&group=&name=nginx&namespace=default&resource=pods&version=v1",
+			expectOut:   []string{`"kind": "Pod"`},
+		},
+		"resource is not set": {
+			args:      []string{"--component", "kubelet"},
+			expectErr: true,
+		},
+		"unsupported output": {
+			args:      []string{"-o", "yaml"},
+			expectErr: true,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			gotQuery = ""
+			out, err := runCommand(t, append([]string{"inspect", "--server-addr", serverAddr}, tc.args...)...)
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("expect error %v, but got %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				return
+			}
+			if gotQuery != tc.expectQuery {
+				t.Errorf("expect query %s, but got %s", tc.expectQuery, gotQuery)
+			}
+			for _, s := range tc.expectOut {
+				if !strings.Contains(out, s) {
+					t.Errorf("expect %q in output, but got %s", s, out)
+				}
+			}
+		})
+	}
+}
+
+func TestInspectServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "storage is not ready", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := runCommand(t, "inspect", "--server-addr", strings.TrimPrefix(server.URL, "http://"))
+	if err == nil || !strings.Contains(err.Error(), "storage is not ready") {
+		t.Errorf("expect error from yurthub, but got %v", err)
+	}
+}
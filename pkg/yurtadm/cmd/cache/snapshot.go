@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openyurtio/openyurt/pkg/yurtadm/constants"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/bolt"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/disk"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/snapshot"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/utils"
+	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+const (
+	cacheDirFlag       = "cache-dir"
+	storageBackendFlag = "storage-backend"
+	fileFlag           = "file"
+	skipEncryptedFlag  = "skip-encrypted"
+)
+
+type snapshotOptions struct {
+	cacheDir       string
+	storageBackend string
+	nodeName       string
+	file           string
+	skipEncrypted  bool
+}
+
+func newSnapshotOptions() *snapshotOptions {
+	return &snapshotOptions{
+		cacheDir:       disk.CacheBaseDir,
+		storageBackend: hubutil.StorageBackendDisk,
+	}
+}
+
+// newCmdExport returns "yurtadm cache export" command.
+func newCmdExport(out io.Writer) *cobra.Command {
+	o := newSnapshotOptions()
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the local cache of yurthub into a snapshot file",
+		Long: "Export the local cache of yurthub into a snapshot file, which can be imported on other nodes for running workloads in autonomy " +
+			"without cloud connectivity. yurthub should be stopped before exporting, so a consistent snapshot can be got. The local cache is " +
+			"opened read-only, and objects encrypted at rest are exported as they are and marked in the manifest of snapshot.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				return err
+			}
+
+			store, err := o.openStore(true)
+			if err != nil {
+				return err
+			}
+			defer closeStore(store)
+			if len(o.nodeName) == 0 {
+				if o.nodeName, err = cachedNodeName(store); err != nil {
+					return fmt.Errorf("could not detect node name, please specify it by --%s, %w", constants.NodeName, err)
+				}
+			}
+
+			f, err := os.OpenFile(o.file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			if err != nil {
+				return fmt.Errorf("could not create snapshot file %s, %w", o.file, err)
+			}
+			defer f.Close()
+
+			manifest, err := snapshot.Export(store, o.nodeName, f)
+			if err != nil {
+				return fmt.Errorf("could not export snapshot, %w", err)
+			}
+			fmt.Fprintf(out, "exported %d resources and %d cluster info of node %s into %s\n", len(manifest.Resources), len(manifest.ClusterInfo), o.nodeName, o.file)
+			if len(manifest.Encrypted) != 0 {
+				fmt.Fprintf(out, "%d objects are encrypted at rest, and they can only be imported for yurthub with the same encryption keys\n", len(manifest.Encrypted))
+			}
+			return nil
+		},
+	}
+
+	o.addFlags(cmd.Flags())
+	return cmd
+}
+
+// newCmdImport returns "yurtadm cache import" command.
+func newCmdImport(out io.Writer) *cobra.Command {
+	o := newSnapshotOptions()
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import a snapshot file into the local cache of yurthub",
+		Long: "Import a snapshot file into the local cache of yurthub, and the node name in objects bound to the node will be replaced " +
+			"with the specified node name. yurthub should be stopped before importing. Objects encrypted at rest are imported as they are, " +
+			"so yurthub on this node should use the same encryption keys as the node which exported the snapshot, otherwise they should be " +
+			"skipped by --" + skipEncryptedFlag + ".",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.validate(); err != nil {
+				return err
+			}
+
+			f, err := os.Open(o.file)
+			if err != nil {
+				return fmt.Errorf("could not open snapshot file %s, %w", o.file, err)
+			}
+			defer f.Close()
+
+			store, err := o.openStore(false)
+			if err != nil {
+				return err
+			}
+			defer closeStore(store)
+			manifest, err := snapshot.Import(store, f, o.nodeName, o.skipEncrypted)
+			if err != nil {
+				return fmt.Errorf("could not import snapshot, %w", err)
+			}
+			fmt.Fprintf(out, "imported %d resources and %d cluster info of node %s from %s\n", len(manifest.Resources), len(manifest.ClusterInfo), manifest.NodeName, o.file)
+			if len(manifest.Encrypted) != 0 {
+				if o.skipEncrypted {
+					fmt.Fprintf(out, "skipped %d objects encrypted at rest\n", len(manifest.Encrypted))
+				} else {
+					fmt.Fprintf(out, "%d objects are encrypted at rest, and yurthub should use the same encryption keys as node %s\n", len(manifest.Encrypted), manifest.NodeName)
+				}
+			}
+			return nil
+		},
+	}
+
+	o.addFlags(cmd.Flags())
+	cmd.Flags().BoolVar(&o.skipEncrypted, skipEncryptedFlag, o.skipEncrypted, "Skip objects encrypted at rest in the snapshot.")
+	return cmd
+}
+
+func (o *snapshotOptions) validate() error {
+	if len(o.file) == 0 {
+		return fmt.Errorf("--%s is empty", fileFlag)
+	}
+	if !hubutil.IsSupportedStorageBackend(o.storageBackend) {
+		return fmt.Errorf("storage backend %s is not supported", o.storageBackend)
+	}
+	return nil
+}
+
+// openStore opens the local cache. the read-only cache is opened without recovery and verification,
+// so the local cache is not changed.
+func (o *snapshotOptions) openStore(readOnly bool) (storage.Store, error) {
+	var store storage.Store
+	var err error
+	switch {
+	case o.storageBackend == hubutil.StorageBackendBolt && readOnly:
+		store, err = bolt.NewReadOnlyBoltStorage(o.cacheDir)
+	case o.storageBackend == hubutil.StorageBackendBolt:
+		store, err = bolt.NewBoltStorage(o.cacheDir)
+	case readOnly:
+		store, err = disk.NewReadOnlyDiskStorage(o.cacheDir)
+	default:
+		store, err = disk.NewDiskStorage(o.cacheDir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open local cache at %s, %w", o.cacheDir, err)
+	}
+	return store, nil
+}
+
+// closeStore releases the resources of store, like the file lock of bolt database.
+func closeStore(store storage.Store) {
+	if closer, ok := store.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+func (o *snapshotOptions) addFlags(flagSet *flag.FlagSet) {
+	flagSet.StringVar(&o.cacheDir, cacheDirFlag, o.cacheDir, "The dir of yurthub local cache.")
+	flagSet.StringVar(&o.storageBackend, storageBackendFlag, o.storageBackend, "The storage backend of yurthub local cache, disk or bolt.")
+	flagSet.StringVar(&o.nodeName, constants.NodeName, o.nodeName,
+		"The name of node. For export, it's detected from the cached node if not set. For import, node name of the snapshot is kept if not set.")
+	flagSet.StringVarP(&o.file, fileFlag, "f", o.file, "The path of snapshot file.")
+}
+
+// cachedNodeName gets the name of node cached for kubelet.
+func cachedNodeName(store storage.Store) (string, error) {
+	keys, err := store.ListResourceKeysOfComponent("kubelet", schema.GroupVersionResource{Version: "v1", Resource: "nodes"})
+	if err != nil {
+		return "", err
+	}
+	if len(keys) != 1 {
+		return "", fmt.Errorf("expect 1 node cached for kubelet, but got %d", len(keys))
+	}
+	_, _, _, name, ok := utils.ParseObjectKey(keys[0].Key())
+	if !ok {
+		return "", storage.ErrUnrecognizedKey
+	}
+	return name, nil
+}
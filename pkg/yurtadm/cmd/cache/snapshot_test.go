@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/bolt"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/disk"
+)
+
+const encryptedSecret = `{"apiVersion":"v1","kind":"Secret","metadata":{"resourceVersion":"1"},"yurthubEncryptedContent":{"provider":"file","keyID":"key1","dek":"ZGVr","data":"ZGF0YQ=="}}`
+
+type cachedObject struct {
+	resource  string
+	namespace string
+	name      string
+	content   string
+}
+
+var cachedObjects = []cachedObject{
+	{"nodes", "", "node1", `{"kind":"Node","apiVersion":"v1","metadata":{"name":"node1"}}`},
+	{"pods", "default", "nginx", `{"kind":"Pod","apiVersion":"v1","metadata":{"name":"nginx","namespace":"default"},"spec":{"nodeName":"node1"}}`},
+	{"secrets", "default", "secret1", encryptedSecret},
+}
+
+func cacheKey(t *testing.T, store storage.Store, obj cachedObject) storage.Key {
+	key, err := store.KeyFunc(storage.KeyBuildInfo{
+		Component: "kubelet",
+		Resources: obj.resource,
+		Version:   "v1",
+		Namespace: obj.namespace,
+		Name:      obj.name,
+	})
+	if err != nil {
+		t.Fatalf("could not get key, %v", err)
+	}
+	return key
+}
+
+func prepareCache(t *testing.T, backend string) string {
+	dir := t.TempDir()
+	var store storage.Store
+	var err error
+	if backend == "bolt" {
+		store, err = bolt.NewBoltStorage(dir)
+	} else {
+		store, err = disk.NewDiskStorage(dir)
+	}
+	if err != nil {
+		t.Fatalf("could not create storage, %v", err)
+	}
+	for _, obj := range cachedObjects {
+		if err := store.Create(cacheKey(t, store, obj), []byte(obj.content)); err != nil {
+			t.Fatalf("could not create %s, %v", obj.name, err)
+		}
+	}
+	closeStore(store)
+	return dir
+}
+
+func runCommand(t *testing.T, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	cmd := NewCmdCache(nil, out, out)
+	cmd.SetArgs(args)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SilenceUsage = true
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestExportAndImport(t *testing.T) {
+	testcases := map[string]struct {
+		backend       string
+		skipEncrypted bool
+		expectObjects []string
+	}{
+		"disk storage": {
+			backend:       "disk",
+			expectObjects: []string{"node2", "nginx", "secret1"},
+		},
+		"disk storage and skip encrypted objects": {
+			backend:       "disk",
+			skipEncrypted: true,
+			expectObjects: []string{"node2", "nginx"},
+		},
+		"bolt storage": {
+			backend:       "bolt",
+			expectObjects: []string{"node2", "nginx", "secret1"},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			srcDir := prepareCache(t, tc.backend)
+			file := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+			out, err := runCommand(t, "export", "--cache-dir", srcDir, "--storage-backend", tc.backend, "-f", file)
+			if err != nil {
+				t.Fatalf("could not export cache, %v", err)
+			}
+			if !strings.Contains(out, "of node node1") || !strings.Contains(out, "1 objects are encrypted at rest") {
+				t.Errorf("expect node1 and 1 encrypted object in output, but got %s", out)
+			}
+
+			dstDir := t.TempDir()
+			args := []string{"import", "--cache-dir", dstDir, "--storage-backend", tc.backend, "-f", file, "--node-name", "node2"}
+			if tc.skipEncrypted {
+				args = append(args, "--skip-encrypted")
+			}
+			if _, err := runCommand(t, args...); err != nil {
+				t.Fatalf("could not import snapshot, %v", err)
+			}
+
+			var store storage.Store
+			if tc.backend == "bolt" {
+				store, err = bolt.NewReadOnlyBoltStorage(dstDir)
+			} else {
+				store, err = disk.NewReadOnlyDiskStorage(dstDir)
+			}
+			if err != nil {
+				t.Fatalf("could not open imported cache, %v", err)
+			}
+			defer closeStore(store)
+			var names []string
+			for _, resource := range []string{"nodes", "pods", "secrets"} {
+				keys, err := store.ListResourceKeysOfComponent("kubelet", schema.GroupVersionResource{Version: "v1", Resource: resource})
+				if err != nil && !errors.Is(err, storage.ErrStorageNotFound) {
+					t.Fatalf("could not list %s, %v", resource, err)
+				}
+				for _, key := range keys {
+					names = append(names, filepath.Base(key.Key()))
+				}
+			}
+			if strings.Join(names, ",") != strings.Join(tc.expectObjects, ",") {
+				t.Errorf("expect objects %v, but got %v", tc.expectObjects, names)
+			}
+		})
+	}
+}
+
+func TestExportReadOnly(t *testing.T) {
+	srcDir := prepareCache(t, "disk")
+	var podPath string
+	filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Name() == "nginx" {
+			podPath = path
+		}
+		return nil
+	})
+	// a backup file left by an interrupted write, which is only recovered by yurthub.
+	tmpPath := filepath.Join(filepath.Dir(podPath), "tmp_nginx")
+	if err := os.WriteFile(tmpPath, []byte("{}"), 0600); err != nil {
+		t.Fatalf("could not write tmp file, %v", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	if _, err := runCommand(t, "export", "--cache-dir", srcDir, "-f", file); err == nil {
+		t.Errorf("expect error for cache with interrupted writes, but got nil")
+	}
+	if _, err := os.Stat(tmpPath); err != nil {
+		t.Errorf("expect tmp file to be kept, but got %v", err)
+	}
+	if _, err := os.Stat(podPath); err != nil {
+		t.Errorf("expect object file to be kept, but got %v", err)
+	}
+}
+
+func TestSnapshotOptionsValidate(t *testing.T) {
+	testcases := map[string]struct {
+		options   snapshotOptions
+		expectErr bool
+	}{
+		"valid options": {
+			options: snapshotOptions{file: "snapshot.tar.gz", storageBackend: "disk"},
+		},
+		"empty file": {
+			options:   snapshotOptions{storageBackend: "disk"},
+			expectErr: true,
+		},
+		"unsupported storage backend": {
+			options:   snapshotOptions{file: "snapshot.tar.gz", storageBackend: "foo"},
+			expectErr: true,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			if err := tc.options.validate(); (err != nil) != tc.expectErr {
+				t.Errorf("expect error %v, but got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
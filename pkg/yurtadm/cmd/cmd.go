@@ -26,6 +26,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/openyurtio/openyurt/pkg/projectinfo"
+	"github.com/openyurtio/openyurt/pkg/yurtadm/cmd/cache"
 	"github.com/openyurtio/openyurt/pkg/yurtadm/cmd/config"
 	"github.com/openyurtio/openyurt/pkg/yurtadm/cmd/docs"
 	"github.com/openyurtio/openyurt/pkg/yurtadm/cmd/join"
@@ -54,6 +55,7 @@ func NewYurtadmCommand() *cobra.Command {
 	cmds.AddCommand(renew.NewCmdRenew(os.Stdin, os.Stdout, os.Stderr))
 	cmds.AddCommand(staticpods.NewCmdStaticPods(os.Stdin, os.Stdout, os.Stderr))
 	cmds.AddCommand(config.NewCmdConfig(os.Stdin, os.Stdout, os.Stderr))
+	cmds.AddCommand(cache.NewCmdCache(os.Stdin, os.Stdout, os.Stderr))
 	klog.InitFlags(nil)
 	// goflag.Parse()
 	flag.CommandLine.AddGoFlagSet(goflag.CommandLine)
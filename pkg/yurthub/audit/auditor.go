@@ -0,0 +1,434 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+
+	yurtutil "github.com/openyurtio/openyurt/pkg/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/filter"
+	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+const (
+	// HeaderAuditID is the header which carries the audit id of request, and a new
+	// audit id is generated if the request doesn't have one.
+	HeaderAuditID = "Audit-ID"
+	// watchDataBufferSize is the max number of chunks of watch response buffered for decoding watch events,
+	// chunks are dropped when buffer is full, and watch events of the request are not recorded any more.
+	watchDataBufferSize = 256
+)
+
+type recorderKeyType int
+
+const recorderKey recorderKeyType = iota
+
+// Auditor records audit events of requests into backends according to audit policy.
+type Auditor struct {
+	policy   *Policy
+	backends []Backend
+}
+
+// NewAuditor creates an auditor with policy and backends.
+func NewAuditor(policy *Policy, backends ...Backend) *Auditor {
+	return &Auditor{
+		policy:   policy,
+		backends: backends,
+	}
+}
+
+// Run starts all backends of auditor.
+func (a *Auditor) Run(stopCh <-chan struct{}) {
+	for i := range a.backends {
+		a.backends[i].Run(stopCh)
+	}
+}
+
+// NewRecorder creates a recorder for the request, nil is returned if the request is not audited.
+func (a *Auditor) NewRecorder(req *http.Request) *Recorder {
+	ctx := req.Context()
+	info, ok := apirequest.RequestInfoFrom(ctx)
+	if !ok || info == nil {
+		return nil
+	}
+	component, _ := hubutil.TruncatedClientComponentFrom(ctx)
+	level, omitStages := a.policy.evaluate(component, info)
+	if level == LevelNone {
+		return nil
+	}
+
+	auditID := req.Header.Get(HeaderAuditID)
+	if len(auditID) == 0 {
+		auditID = uuid.NewString()
+	}
+	sourceIP, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		sourceIP = req.RemoteAddr
+	}
+	event := Event{
+		Level:                    level,
+		AuditID:                  auditID,
+		RequestURI:               req.URL.RequestURI(),
+		Verb:                     info.Verb,
+		Component:                component,
+		UserAgent:                req.UserAgent(),
+		SourceIP:                 sourceIP,
+		RequestReceivedTimestamp: metav1.NewMicroTime(time.Now()),
+	}
+	if info.IsResourceRequest {
+		event.ObjectRef = &ObjectReference{
+			APIGroup:    info.APIGroup,
+			APIVersion:  info.APIVersion,
+			Resource:    info.Resource,
+			Subresource: info.Subresource,
+			Namespace:   info.Namespace,
+			Name:        info.Name,
+		}
+	}
+
+	return &Recorder{
+		auditor:    a,
+		omitStages: omitStages,
+		isWatch:    info.IsResourceRequest && info.Verb == "watch",
+		event:      event,
+	}
+}
+
+// WithRecorder returns a copy of parent in which the recorder is set.
+func WithRecorder(parent context.Context, r *Recorder) context.Context {
+	return context.WithValue(parent, recorderKey, r)
+}
+
+// RecorderFrom returns the recorder of request, nil is returned if the request is not audited.
+// all methods of Recorder can be called on nil recorder.
+func RecorderFrom(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey).(*Recorder)
+	return r
+}
+
+// Recorder records audit events of a request at each stage.
+type Recorder struct {
+	auditor    *Auditor
+	omitStages sets.Set[Stage]
+	isWatch    bool
+
+	sync.Mutex
+	event     Event
+	watchData chan []byte
+	watchDone chan struct{}
+}
+
+// SetSource records the handler which serves the request, and the address of remote server
+// when the request is forwarded.
+func (r *Recorder) SetSource(source Source, backend string) {
+	if r == nil {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+	r.event.ServedBy = source
+	r.event.Backend = backend
+}
+
+// ObserveFilter records an object in the response is kept or dropped by filters.
+func (r *Recorder) ObserveFilter(filters string, kept bool) {
+	if r == nil {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+	if r.event.Filter == nil {
+		r.event.Filter = &FilterDecision{Filters: filters}
+	}
+	if kept {
+		r.event.Filter.Kept++
+	} else {
+		r.event.Filter.Dropped++
+	}
+}
+
+// RequestReceived records the event of RequestReceived stage.
+func (r *Recorder) RequestReceived() {
+	r.emit(StageRequestReceived, nil)
+}
+
+// Complete records the event of ResponseComplete stage, it should be called after the request is served.
+func (r *Recorder) Complete() {
+	if r == nil {
+		return
+	}
+	r.Lock()
+	watchDone := r.watchDone
+	r.stopWatchDataLocked()
+	r.Unlock()
+	// wait until all of watch events are recorded.
+	if watchDone != nil {
+		<-watchDone
+	}
+	r.emit(StageResponseComplete, nil)
+}
+
+func (r *Recorder) emit(stage Stage, mutate func(*Event)) {
+	if r == nil || r.omitStages.Has(stage) {
+		return
+	}
+	r.Lock()
+	event := r.event
+	if event.Filter != nil {
+		filter := *event.Filter
+		event.Filter = &filter
+	}
+	r.Unlock()
+
+	event.Stage = stage
+	event.StageTimestamp = metav1.NewMicroTime(time.Now())
+	if mutate != nil {
+		mutate(&event)
+	}
+	for i := range r.auditor.backends {
+		r.auditor.backends[i].ProcessEvents(&event)
+	}
+}
+
+// responseStarted records the response code, and watch events are decoded from response
+// body if they should be recorded.
+func (r *Recorder) responseStarted(code int, header http.Header) {
+	r.Lock()
+	r.event.ResponseCode = code
+	r.Unlock()
+	if !r.isWatch {
+		return
+	}
+
+	r.emit(StageResponseStarted, nil)
+	if r.event.Level != LevelWatchEvents || code != http.StatusOK || r.omitStages.Has(StageWatchEvent) {
+		return
+	}
+
+	encoding := header.Get("Content-Encoding")
+	if len(encoding) != 0 && encoding != hubutil.CompressionGzip {
+		klog.V(4).Infof("skip recording watch events of %s in content encoding %s", r.event.RequestURI, encoding)
+		return
+	}
+	ref := r.event.ObjectRef
+	s := serializer.YurtHubSerializer.CreateSerializer(header.Get(yurtutil.HTTPHeaderContentType), ref.APIGroup, ref.APIVersion, ref.Resource)
+	if s == nil {
+		return
+	}
+
+	stream := &watchDataReader{data: make(chan []byte, watchDataBufferSize)}
+	done := make(chan struct{})
+	r.Lock()
+	r.watchData, r.watchDone = stream.data, done
+	r.Unlock()
+	go func() {
+		defer close(done)
+		// stop receiving watch data when decoding fails, so the response is not affected.
+		defer func() {
+			r.Lock()
+			r.stopWatchDataLocked()
+			r.Unlock()
+			for range stream.data {
+			}
+		}()
+
+		var body io.ReadCloser = stream
+		if encoding == hubutil.CompressionGzip {
+			gr, err := gzip.NewReader(stream)
+			if err != nil {
+				if err != io.EOF {
+					klog.Errorf("could not decompress watch response for auditing %s, %v", r.event.RequestURI, err)
+				}
+				return
+			}
+			body = gr
+		}
+		decoder, err := s.WatchDecoder(body)
+		if err != nil {
+			klog.Errorf("could not create watch decoder for auditing %s, %v", r.event.RequestURI, err)
+			return
+		}
+		defer decoder.Close()
+		for {
+			eventType, obj, err := decoder.Decode()
+			if err != nil {
+				return
+			}
+			r.emit(StageWatchEvent, func(e *Event) {
+				e.WatchEvent = newWatchEvent(string(eventType), obj)
+			})
+		}
+	}()
+}
+
+// observeWatchData feeds the response body of watch request into watch decoder without blocking the
+// response. when the buffer is full, watch data is dropped and watch events are not recorded any more,
+// because the rest of watch data can not be decoded.
+func (r *Recorder) observeWatchData(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+	if r.watchData == nil {
+		return
+	}
+	select {
+	case r.watchData <- bytes.Clone(data):
+	default:
+		metrics.Metrics.AddDroppedAuditData("watch", 1)
+		klog.Warningf("stop recording watch events of %s because buffer of watch data is full", r.event.RequestURI)
+		r.stopWatchDataLocked()
+	}
+}
+
+// stopWatchDataLocked stops feeding watch data into watch decoder.
+func (r *Recorder) stopWatchDataLocked() {
+	if r.watchData != nil {
+		close(r.watchData)
+		r.watchData = nil
+	}
+}
+
+// watchDataReader reads chunks of watch response from channel, and io.EOF is returned after channel is closed.
+type watchDataReader struct {
+	data    chan []byte
+	current []byte
+}
+
+func (w *watchDataReader) Read(p []byte) (int, error) {
+	for len(w.current) == 0 {
+		chunk, ok := <-w.data
+		if !ok {
+			return 0, io.EOF
+		}
+		w.current = chunk
+	}
+	n := copy(p, w.current)
+	w.current = w.current[n:]
+	return n, nil
+}
+
+func (w *watchDataReader) Close() error {
+	return nil
+}
+
+func newWatchEvent(eventType string, obj runtime.Object) *WatchEvent {
+	we := &WatchEvent{Type: eventType}
+	if accessor, err := meta.Accessor(obj); err == nil {
+		we.Namespace = accessor.GetNamespace()
+		we.Name = accessor.GetName()
+		we.ResourceVersion = accessor.GetResourceVersion()
+	}
+	return we
+}
+
+// WrapResponseWriter returns a response writer which records the response of request.
+func (r *Recorder) WrapResponseWriter(w http.ResponseWriter) http.ResponseWriter {
+	flusher, _ := w.(http.Flusher)
+	hijacker, _ := w.(http.Hijacker)
+	return &responseWriter{
+		ResponseWriter: w,
+		flusher:        flusher,
+		hijacker:       hijacker,
+		recorder:       r,
+	}
+}
+
+type responseWriter struct {
+	http.ResponseWriter
+	flusher  http.Flusher
+	hijacker http.Hijacker
+	recorder *Recorder
+	once     sync.Once
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	w.once.Do(func() {
+		w.recorder.responseStarted(code, w.Header())
+	})
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+	w.once.Do(func() {
+		w.recorder.responseStarted(http.StatusOK, w.Header())
+	})
+	n, err := w.ResponseWriter.Write(data)
+	w.recorder.observeWatchData(data[:n])
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	if w.flusher != nil {
+		w.flusher.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if w.hijacker == nil {
+		return nil, nil, fmt.Errorf("response writer doesn't support hijacking")
+	}
+	w.once.Do(func() {
+		w.recorder.responseStarted(http.StatusSwitchingProtocols, w.Header())
+	})
+	return w.hijacker.Hijack()
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// WithObjectFilter returns an object filter which records decisions of objectFilter
+// into the recorder of request, objectFilter is returned if the request is not audited.
+func WithObjectFilter(ctx context.Context, objectFilter filter.ObjectFilter) filter.ObjectFilter {
+	r := RecorderFrom(ctx)
+	if r == nil || yurtutil.IsNil(objectFilter) {
+		return objectFilter
+	}
+	return &recordedObjectFilter{ObjectFilter: objectFilter, recorder: r}
+}
+
+type recordedObjectFilter struct {
+	filter.ObjectFilter
+	recorder *Recorder
+}
+
+func (f *recordedObjectFilter) Filter(obj runtime.Object, stopCh <-chan struct{}) runtime.Object {
+	newObj := f.ObjectFilter.Filter(obj, stopCh)
+	f.recorder.ObserveFilter(f.ObjectFilter.Name(), !yurtutil.IsNil(newObj))
+	return newObj
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
+	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+type fakeBackend struct {
+	sync.Mutex
+	events []Event
+}
+
+func (b *fakeBackend) ProcessEvents(events ...*Event) {
+	b.Lock()
+	defer b.Unlock()
+	for _, event := range events {
+		b.events = append(b.events, *event)
+	}
+}
+
+func (b *fakeBackend) Run(<-chan struct{}) {}
+
+type dropPodFilter struct{}
+
+func (f dropPodFilter) Name() string { return "droppod" }
+
+func (f dropPodFilter) Filter(obj runtime.Object, _ <-chan struct{}) runtime.Object {
+	if pod, ok := obj.(*v1.Pod); ok && pod.Name == "drop" {
+		return nil
+	}
+	return obj
+}
+
+func newAuditedRequest(verb string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/pods?watch=true", nil)
+	ctx := apirequest.WithRequestInfo(req.Context(), &apirequest.RequestInfo{
+		IsResourceRequest: true,
+		Verb:              verb,
+		APIVersion:        "v1",
+		Resource:          "pods",
+		Namespace:         "default",
+	})
+	ctx = hubutil.WithClientComponent(ctx, "kubelet/v1.34.0")
+	return req.WithContext(ctx)
+}
+
+func serve(auditor *Auditor, req *http.Request, handler http.HandlerFunc) {
+	recorder := auditor.NewRecorder(req)
+	recorder.RequestReceived()
+	defer recorder.Complete()
+	handler(recorder.WrapResponseWriter(httptest.NewRecorder()), req.WithContext(WithRecorder(req.Context(), recorder)))
+}
+
+func TestRecordWatchEvents(t *testing.T) {
+	backend := &fakeBackend{}
+	auditor := NewAuditor(&Policy{Rules: []PolicyRule{{Level: LevelWatchEvents}}}, backend)
+
+	serve(auditor, newAuditedRequest("watch"), func(w http.ResponseWriter, req *http.Request) {
+		RecorderFrom(req.Context()).SetSource(SourceCache, "")
+		objectFilter := WithObjectFilter(req.Context(), dropPodFilter{})
+		s := serializer.YurtHubSerializer.CreateSerializer(runtime.ContentTypeJSON, "", "v1", "pods")
+
+		w.Header().Set("Content-Type", runtime.ContentTypeJSON)
+		w.WriteHeader(http.StatusOK)
+		for _, name := range []string{"foo", "drop", "bar"} {
+			pod := &v1.Pod{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", ResourceVersion: "1" + name},
+			}
+			if objectFilter.Filter(pod, nil) == nil {
+				continue
+			}
+			s.WatchEncode(w, &watch.Event{Type: watch.Added, Object: pod})
+		}
+	})
+
+	stages := make([]Stage, 0, len(backend.events))
+	watchEvents := make([]WatchEvent, 0)
+	for _, event := range backend.events {
+		stages = append(stages, event.Stage)
+		if event.WatchEvent != nil {
+			watchEvents = append(watchEvents, *event.WatchEvent)
+		}
+	}
+	expectStages := []Stage{StageRequestReceived, StageResponseStarted, StageWatchEvent, StageWatchEvent, StageResponseComplete}
+	if !reflect.DeepEqual(stages, expectStages) {
+		t.Fatalf("expect stages %v, but got %v", expectStages, stages)
+	}
+	expectWatchEvents := []WatchEvent{
+		{Type: "ADDED", Namespace: "default", Name: "foo", ResourceVersion: "1foo"},
+		{Type: "ADDED", Namespace: "default", Name: "bar", ResourceVersion: "1bar"},
+	}
+	if !reflect.DeepEqual(watchEvents, expectWatchEvents) {
+		t.Errorf("expect watch events %v, but got %v", expectWatchEvents, watchEvents)
+	}
+
+	completed := backend.events[len(backend.events)-1]
+	if completed.Component != "kubelet" || completed.ServedBy != SourceCache || completed.ResponseCode != http.StatusOK {
+		t.Errorf("expect request of kubelet is served by cache with 200, but got %#v", completed)
+	}
+	expectFilter := &FilterDecision{Filters: "droppod", Kept: 2, Dropped: 1}
+	if !reflect.DeepEqual(completed.Filter, expectFilter) {
+		t.Errorf("expect filter decision %#v, but got %#v", expectFilter, completed.Filter)
+	}
+}
+
+func TestRecordMetadata(t *testing.T) {
+	backend := &fakeBackend{}
+	auditor := NewAuditor(&Policy{
+		Rules:      []PolicyRule{{Level: LevelNone, Verbs: []string{"watch"}}, {Level: LevelMetadata}},
+		OmitStages: []Stage{StageRequestReceived},
+	}, backend)
+
+	if recorder := auditor.NewRecorder(newAuditedRequest("watch")); recorder != nil {
+		t.Errorf("expect watch request is not audited")
+	}
+
+	serve(auditor, newAuditedRequest("list"), func(w http.ResponseWriter, req *http.Request) {
+		RecorderFrom(req.Context()).SetSource(SourceRemote, "https://127.0.0.1:6443")
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+
+	if len(backend.events) != 1 {
+		t.Fatalf("expect only ResponseComplete event is recorded, but got %#v", backend.events)
+	}
+	event := backend.events[0]
+	if event.Stage != StageResponseComplete || event.Level != LevelMetadata || event.ResponseCode != http.StatusNotFound ||
+		event.ServedBy != SourceRemote || event.Backend != "https://127.0.0.1:6443" || len(event.AuditID) == 0 {
+		t.Errorf("unexpected audit event %#v", event)
+	}
+	expectRef := &ObjectReference{APIVersion: "v1", Resource: "pods", Namespace: "default"}
+	if !reflect.DeepEqual(event.ObjectRef, expectRef) {
+		t.Errorf("expect object reference %#v, but got %#v", expectRef, event.ObjectRef)
+	}
+}
+
+func TestLogBackend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	backend := NewLogBackend(path, 1, 1)
+	stopCh := make(chan struct{})
+	backend.Run(stopCh)
+	backend.ProcessEvents(&Event{AuditID: "1", Stage: StageResponseComplete}, &Event{AuditID: "2", Stage: StageResponseComplete})
+	close(stopCh)
+	<-backend.(*logBackend).done
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("could not open audit log, %v", err)
+	}
+	defer f.Close()
+	ids := make([]string, 0, 2)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		event := &Event{}
+		if err := json.Unmarshal(scanner.Bytes(), event); err != nil {
+			t.Fatalf("could not decode audit event, %v", err)
+		}
+		ids = append(ids, event.AuditID)
+	}
+	if !reflect.DeepEqual(ids, []string{"1", "2"}) {
+		t.Errorf("expect audit events 1 and 2, but got %v", ids)
+	}
+}
+
+type bufferWriter struct {
+	bytes.Buffer
+}
+
+func (w *bufferWriter) Close() error {
+	return nil
+}
+
+func TestLogBackendDropsEventsWhenBufferIsFull(t *testing.T) {
+	writer := &bufferWriter{}
+	backend := newLogBackend(writer, 2)
+	// events out of buffer are dropped instead of blocking the requests.
+	backend.ProcessEvents(&Event{AuditID: "1"}, &Event{AuditID: "2"}, &Event{AuditID: "3"})
+	if backend.dropped != 1 {
+		t.Errorf("expect 1 event is dropped, but got %d", backend.dropped)
+	}
+
+	stopCh := make(chan struct{})
+	close(stopCh)
+	backend.Run(stopCh)
+	<-backend.done
+	if lines := strings.Count(writer.String(), "\n"); lines != 2 {
+		t.Errorf("expect 2 buffered events are written, but got %d", lines)
+	}
+	if backend.dropped != 0 {
+		t.Errorf("expect dropped events are reported, but got %d", backend.dropped)
+	}
+}
+
+func TestWebhookBackend(t *testing.T) {
+	received := make(chan EventList, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		list := EventList{}
+		if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+			t.Errorf("could not decode event list, %v", err)
+		}
+		received <- list
+	}))
+	defer server.Close()
+
+	backend, err := NewWebhookBackend(WebhookConfig{URL: server.URL})
+	if err != nil {
+		t.Fatalf("could not create webhook backend, %v", err)
+	}
+	backend.ProcessEvents(&Event{AuditID: "1"}, &Event{AuditID: "2"})
+	stopCh := make(chan struct{})
+	backend.Run(stopCh)
+	// buffered events are sent when backend is stopped.
+	close(stopCh)
+
+	list := <-received
+	if len(list.Items) != 2 || list.Items[0].AuditID != "1" || list.Items[1].AuditID != "2" {
+		t.Errorf("expect audit events 1 and 2, but got %#v", list.Items)
+	}
+}
+
+func TestWebhookBackendWithTLS(t *testing.T) {
+	received := make(chan string, 1)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile, tokenFile := filepath.Join(dir, "ca.crt"), filepath.Join(dir, "token")
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caData, 0600); err != nil {
+		t.Fatalf("could not write ca file, %v", err)
+	}
+	if err := os.WriteFile(tokenFile, []byte("token1\n"), 0600); err != nil {
+		t.Fatalf("could not write token file, %v", err)
+	}
+
+	backend, err := NewWebhookBackend(WebhookConfig{URL: server.URL, CAFile: caFile, TokenFile: tokenFile})
+	if err != nil {
+		t.Fatalf("could not create webhook backend, %v", err)
+	}
+	if err := backend.(*webhookBackend).post([]Event{{AuditID: "1"}}); err != nil {
+		t.Fatalf("could not post audit events, %v", err)
+	}
+	if auth := <-received; auth != "Bearer token1" {
+		t.Errorf("expect bearer token is sent, but got %q", auth)
+	}
+
+	if _, err := NewWebhookBackend(WebhookConfig{URL: server.URL, CertFile: caFile}); err == nil {
+		t.Errorf("expect error when client key file is not set")
+	}
+}
+
+func TestRecordGzipWatchEvents(t *testing.T) {
+	backend := &fakeBackend{}
+	auditor := NewAuditor(&Policy{Rules: []PolicyRule{{Level: LevelWatchEvents}}}, backend)
+
+	serve(auditor, newAuditedRequest("watch"), func(w http.ResponseWriter, req *http.Request) {
+		s := serializer.YurtHubSerializer.CreateSerializer(runtime.ContentTypeJSON, "", "v1", "pods")
+		w.Header().Set("Content-Type", runtime.ContentTypeJSON)
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		gw := gzip.NewWriter(w)
+		for _, name := range []string{"foo", "bar"} {
+			pod := &v1.Pod{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", ResourceVersion: "1" + name},
+			}
+			s.WatchEncode(gw, &watch.Event{Type: watch.Added, Object: pod})
+			gw.Flush()
+		}
+		gw.Close()
+	})
+
+	names := make([]string, 0, 2)
+	for _, event := range backend.events {
+		if event.WatchEvent != nil {
+			names = append(names, event.WatchEvent.Name)
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"foo", "bar"}) {
+		t.Errorf("expect watch events of foo and bar, but got %v", names)
+	}
+}
+
+func TestWatchDataDropped(t *testing.T) {
+	recorder := &Recorder{watchData: make(chan []byte, 1)}
+	recorder.observeWatchData([]byte("foo"))
+	// buffer is full, and watch data is not fed any more.
+	recorder.observeWatchData([]byte("bar"))
+	if recorder.watchData != nil {
+		t.Fatalf("expect watch data is stopped after buffer is full")
+	}
+	recorder.observeWatchData([]byte("baz"))
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+)
+
+const (
+	// logBufferSize is the max number of events buffered for log file, events are dropped when buffer is full.
+	logBufferSize = 10000
+	// logBatchMaxSize is the max number of events written into log file at once.
+	logBatchMaxSize = 400
+	// webhookBufferSize is the max number of events buffered for webhook, events are dropped when buffer is full.
+	webhookBufferSize = 10000
+	// webhookBatchMaxSize is the max number of events sent to webhook in one request.
+	webhookBatchMaxSize = 400
+	// webhookBatchMaxWait is the max duration which events are buffered before sent to webhook.
+	webhookBatchMaxWait = 5 * time.Second
+	webhookTimeout      = 10 * time.Second
+)
+
+// Backend stores audit events.
+type Backend interface {
+	// ProcessEvents stores events, it should not block the handling of requests.
+	ProcessEvents(events ...*Event)
+	// Run starts the backend, and the backend is shut down when stopCh is closed.
+	Run(stopCh <-chan struct{})
+}
+
+// logBackend writes audit events into a local file in json lines, and the file is rotated by size.
+// events are buffered and written by a worker, so requests are not blocked by the slow disk.
+type logBackend struct {
+	writer  io.WriteCloser
+	buffer  chan *Event
+	dropped int
+	lock    sync.Mutex
+	// done is closed after the buffered events are written and the file is closed.
+	done chan struct{}
+}
+
+// NewLogBackend creates a backend which writes events into the file at path, the file is rotated when
+// its size exceeds maxSize megabytes, and at most maxBackups rotated files are retained.
+func NewLogBackend(path string, maxSize, maxBackups int) Backend {
+	return newLogBackend(&lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    maxSize,
+		MaxBackups: maxBackups,
+		Compress:   false,
+	}, logBufferSize)
+}
+
+func newLogBackend(writer io.WriteCloser, bufferSize int) *logBackend {
+	return &logBackend{
+		writer: writer,
+		buffer: make(chan *Event, bufferSize),
+		done:   make(chan struct{}),
+	}
+}
+
+func (b *logBackend) ProcessEvents(events ...*Event) {
+	for _, event := range events {
+		select {
+		case b.buffer <- event:
+		default:
+			b.lock.Lock()
+			b.dropped++
+			b.lock.Unlock()
+		}
+	}
+}
+
+func (b *logBackend) Run(stopCh <-chan struct{}) {
+	go func() {
+		defer close(b.done)
+		batch := make([]*Event, 0, logBatchMaxSize)
+		for {
+			select {
+			case event := <-b.buffer:
+				batch = append(batch, event)
+				// write the events which have been buffered together.
+				for len(batch) < logBatchMaxSize && len(b.buffer) != 0 {
+					batch = append(batch, <-b.buffer)
+				}
+				b.write(batch)
+				batch = batch[:0]
+			case <-stopCh:
+				// write buffered events before exiting.
+				for len(b.buffer) != 0 {
+					batch = append(batch, <-b.buffer)
+				}
+				b.write(batch)
+				b.writer.Close()
+				return
+			}
+		}
+	}()
+}
+
+func (b *logBackend) write(events []*Event) {
+	b.lock.Lock()
+	dropped := b.dropped
+	b.dropped = 0
+	b.lock.Unlock()
+	if dropped != 0 {
+		metrics.Metrics.AddDroppedAuditData("log", dropped)
+		klog.Warningf("%d audit events are dropped because buffer of log is full", dropped)
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	var buf bytes.Buffer
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			klog.Errorf("could not encode audit event %s, %v", event.AuditID, err)
+			continue
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	if _, err := b.writer.Write(buf.Bytes()); err != nil {
+		klog.Errorf("could not write audit events, %v", err)
+	}
+}
+
+// WebhookConfig is the configuration for connecting to audit webhook.
+type WebhookConfig struct {
+	// URL is the url to which audit events are posted.
+	URL string
+	// CAFile is the file of certificate authorities for verifying the serving certificate of webhook,
+	// system certificate pool is used if it's not set.
+	CAFile string
+	// CertFile and KeyFile are the files of client certificate for authenticating to webhook.
+	CertFile string
+	KeyFile  string
+	// TokenFile is the file of bearer token for authenticating to webhook, and it's read
+	// before each request so the rotated token can be used.
+	TokenFile string
+}
+
+// webhookBackend sends audit events to webhook in batches.
+type webhookBackend struct {
+	url       string
+	tokenFile string
+	client    *http.Client
+	buffer    chan *Event
+	dropped   int
+	lock      sync.Mutex
+}
+
+// NewWebhookBackend creates a backend which posts events to webhook in batches of EventList.
+func NewWebhookBackend(cfg WebhookConfig) (Backend, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(cfg.CAFile) != 0 {
+		pool, err := certutil.NewPool(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load ca file %s for audit webhook, %w", cfg.CAFile, err)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(cfg.CertFile) != 0 || len(cfg.KeyFile) != 0 {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate for audit webhook, %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &webhookBackend{
+		url:       cfg.URL,
+		tokenFile: cfg.TokenFile,
+		client:    &http.Client{Timeout: webhookTimeout, Transport: transport},
+		buffer:    make(chan *Event, webhookBufferSize),
+	}, nil
+}
+
+func (b *webhookBackend) ProcessEvents(events ...*Event) {
+	for _, event := range events {
+		select {
+		case b.buffer <- event:
+		default:
+			b.lock.Lock()
+			b.dropped++
+			b.lock.Unlock()
+		}
+	}
+}
+
+func (b *webhookBackend) Run(stopCh <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(webhookBatchMaxWait)
+		defer ticker.Stop()
+		batch := make([]Event, 0, webhookBatchMaxSize)
+		for {
+			select {
+			case event := <-b.buffer:
+				batch = append(batch, *event)
+				if len(batch) < webhookBatchMaxSize {
+					continue
+				}
+			case <-ticker.C:
+			case <-stopCh:
+				// send buffered events before exiting.
+				for len(b.buffer) != 0 && len(batch) < webhookBatchMaxSize {
+					batch = append(batch, *<-b.buffer)
+				}
+				b.send(batch)
+				return
+			}
+
+			b.send(batch)
+			batch = batch[:0]
+		}
+	}()
+}
+
+func (b *webhookBackend) send(events []Event) {
+	b.lock.Lock()
+	dropped := b.dropped
+	b.dropped = 0
+	b.lock.Unlock()
+	if dropped != 0 {
+		metrics.Metrics.AddDroppedAuditData("webhook", dropped)
+		klog.Warningf("%d audit events are dropped because buffer of webhook %s is full", dropped, b.url)
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	if err := b.post(events); err != nil {
+		klog.Errorf("could not send %d audit events to webhook %s, %v", len(events), b.url, err)
+	}
+}
+
+func (b *webhookBackend) post(events []Event) error {
+	data, err := json.Marshal(&EventList{Items: events})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, b.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(b.tokenFile) != 0 {
+		token, err := os.ReadFile(b.tokenFile)
+		if err != nil {
+			return fmt.Errorf("could not read token file %s, %w", b.tokenFile, err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/yaml"
+)
+
+// Policy decides the audit level of requests. it's modelled on the audit policy of kube-apiserver,
+// requests are matched against rules in order, and the first matched rule decides the level.
+// requests which don't match any rule are not audited.
+type Policy struct {
+	Rules []PolicyRule `json:"rules"`
+	// OmitStages are the stages for which no events are recorded for all requests.
+	OmitStages []Stage `json:"omitStages,omitempty"`
+}
+
+// PolicyRule matches requests by component, verb, resource and namespace, empty field matches all.
+type PolicyRule struct {
+	Level Level `json:"level"`
+	// Components are the names of clients(the user agent without version, like kubelet, kube-proxy).
+	Components []string         `json:"components,omitempty"`
+	Verbs      []string         `json:"verbs,omitempty"`
+	Resources  []GroupResources `json:"resources,omitempty"`
+	// Namespaces matches namespaced resources, and empty string matches cluster scoped resources.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NonResourceURLs matches non resource requests, and a trailing * matches paths with the prefix.
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+	OmitStages      []Stage  `json:"omitStages,omitempty"`
+}
+
+// GroupResources matches resources of an api group, resource name is in the format of resource[/subresource].
+type GroupResources struct {
+	Group         string   `json:"group,omitempty"`
+	Resources     []string `json:"resources,omitempty"`
+	ResourceNames []string `json:"resourceNames,omitempty"`
+}
+
+// LoadPolicy loads and verifies the audit policy file in yaml or json format.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read audit policy file %s, %w", path, err)
+	}
+
+	policy := &Policy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("could not decode audit policy file %s, %w", path, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("audit policy file %s is invalid, %w", path, err)
+	}
+	return policy, nil
+}
+
+func (p *Policy) validate() error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("no rules are specified")
+	}
+	if err := validateStages(p.OmitStages); err != nil {
+		return err
+	}
+	for i, rule := range p.Rules {
+		switch rule.Level {
+		case LevelNone, LevelMetadata, LevelWatchEvents:
+		default:
+			return fmt.Errorf("level %q of rule %d is not supported", rule.Level, i)
+		}
+		if len(rule.Resources) != 0 && len(rule.NonResourceURLs) != 0 {
+			return fmt.Errorf("resources and nonResourceURLs of rule %d can not be set at the same time", i)
+		}
+		if err := validateStages(rule.OmitStages); err != nil {
+			return fmt.Errorf("rule %d is invalid, %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateStages(stages []Stage) error {
+	for _, stage := range stages {
+		switch stage {
+		case StageRequestReceived, StageResponseStarted, StageResponseComplete, StageWatchEvent:
+		default:
+			return fmt.Errorf("stage %q is not supported", stage)
+		}
+	}
+	return nil
+}
+
+// evaluate returns the audit level and the omitted stages of request.
+func (p *Policy) evaluate(component string, info *apirequest.RequestInfo) (Level, sets.Set[Stage]) {
+	for i := range p.Rules {
+		if p.Rules[i].matches(component, info) {
+			return p.Rules[i].Level, sets.New(p.OmitStages...).Insert(p.Rules[i].OmitStages...)
+		}
+	}
+	return LevelNone, nil
+}
+
+func (r *PolicyRule) matches(component string, info *apirequest.RequestInfo) bool {
+	if len(r.Components) != 0 && !slices.Contains(r.Components, component) {
+		return false
+	}
+	if len(r.Verbs) != 0 && !slices.Contains(r.Verbs, info.Verb) {
+		return false
+	}
+
+	if !info.IsResourceRequest {
+		if len(r.Resources) != 0 || len(r.Namespaces) != 0 {
+			return false
+		}
+		if len(r.NonResourceURLs) == 0 {
+			return true
+		}
+		for _, url := range r.NonResourceURLs {
+			if url == "*" || url == info.Path || (strings.HasSuffix(url, "*") && strings.HasPrefix(info.Path, strings.TrimSuffix(url, "*"))) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(r.NonResourceURLs) != 0 {
+		return false
+	}
+	if len(r.Namespaces) != 0 && !slices.Contains(r.Namespaces, info.Namespace) {
+		return false
+	}
+	if len(r.Resources) == 0 {
+		return true
+	}
+
+	resource := info.Resource
+	if len(info.Subresource) != 0 {
+		resource = info.Resource + "/" + info.Subresource
+	}
+	for _, gr := range r.Resources {
+		if gr.Group != info.APIGroup {
+			continue
+		}
+		if len(gr.Resources) != 0 && !slices.Contains(gr.Resources, resource) && !slices.Contains(gr.Resources, "*") {
+			continue
+		}
+		if len(gr.ResourceNames) != 0 && !slices.Contains(gr.ResourceNames, info.Name) {
+			continue
+		}
+		return true
+	}
+	return false
+}
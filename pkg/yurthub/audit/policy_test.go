@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+const testPolicy = `
+omitStages:
+- RequestReceived
+rules:
+- level: None
+  components: ["kubelet"]
+  resources:
+  - group: coordination.k8s.io
+    resources: ["leases"]
+- level: WatchEvents
+  verbs: ["watch"]
+  resources:
+  - group: discovery.k8s.io
+    resources: ["endpointslices"]
+- level: Metadata
+  namespaces: ["kube-system", ""]
+- level: Metadata
+  nonResourceURLs: ["/version", "/apis*"]
+`
+
+func TestLoadPolicy(t *testing.T) {
+	testcases := map[string]struct {
+		policy    string
+		expectErr bool
+	}{
+		"valid policy": {
+			policy: testPolicy,
+		},
+		"no rules": {
+			policy:    "omitStages: [RequestReceived]",
+			expectErr: true,
+		},
+		"unknown level": {
+			policy:    "rules: [{level: Request}]",
+			expectErr: true,
+		},
+		"unknown stage": {
+			policy:    "rules: [{level: Metadata, omitStages: [Panic]}]",
+			expectErr: true,
+		},
+		"unknown field": {
+			policy:    "rules: [{level: Metadata, users: [foo]}]",
+			expectErr: true,
+		},
+		"resources and non resource urls": {
+			policy:    "rules: [{level: Metadata, resources: [{resources: [pods]}], nonResourceURLs: [/version]}]",
+			expectErr: true,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "policy.yaml")
+			if err := os.WriteFile(path, []byte(tc.policy), 0600); err != nil {
+				t.Fatalf("could not write policy file, %v", err)
+			}
+			_, err := LoadPolicy(path)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("expect error %v, but got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte(testPolicy), 0600); err != nil {
+		t.Fatalf("could not write policy file, %v", err)
+	}
+	policy, err := LoadPolicy(path)
+	if err != nil {
+		t.Fatalf("could not load policy, %v", err)
+	}
+
+	testcases := map[string]struct {
+		component string
+		info      *apirequest.RequestInfo
+		expect    Level
+	}{
+		"node lease of kubelet": {
+			component: "kubelet",
+			info:      &apirequest.RequestInfo{IsResourceRequest: true, Verb: "update", APIGroup: "coordination.k8s.io", Resource: "leases", Namespace: "kube-node-lease"},
+			expect:    LevelNone,
+		},
+		"watch endpointslices": {
+			component: "kube-proxy",
+			info:      &apirequest.RequestInfo{IsResourceRequest: true, Verb: "watch", APIGroup: "discovery.k8s.io", Resource: "endpointslices"},
+			expect:    LevelWatchEvents,
+		},
+		"list endpointslices of all namespaces": {
+			component: "kube-proxy",
+			info:      &apirequest.RequestInfo{IsResourceRequest: true, Verb: "list", APIGroup: "discovery.k8s.io", Resource: "endpointslices"},
+			expect:    LevelMetadata,
+		},
+		"get pod in kube-system": {
+			component: "coredns",
+			info:      &apirequest.RequestInfo{IsResourceRequest: true, Verb: "get", Resource: "pods", Namespace: "kube-system", Name: "foo"},
+			expect:    LevelMetadata,
+		},
+		"get pod in default namespace": {
+			component: "coredns",
+			info:      &apirequest.RequestInfo{IsResourceRequest: true, Verb: "get", Resource: "pods", Namespace: "default", Name: "foo"},
+			expect:    LevelNone,
+		},
+		"non resource request with prefix": {
+			component: "kubectl",
+			info:      &apirequest.RequestInfo{Verb: "get", Path: "/apis/apps/v1"},
+			expect:    LevelMetadata,
+		},
+		"non resource request not matched": {
+			component: "kubectl",
+			info:      &apirequest.RequestInfo{Verb: "get", Path: "/healthz"},
+			expect:    LevelNone,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			level, omitStages := policy.evaluate(tc.component, tc.info)
+			if level != tc.expect {
+				t.Errorf("expect level %s, but got %s", tc.expect, level)
+			}
+			if level != LevelNone && !omitStages.Has(StageRequestReceived) {
+				t.Errorf("expect stage %s is omitted", StageRequestReceived)
+			}
+		})
+	}
+}
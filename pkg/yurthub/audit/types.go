@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Level defines the amount of information recorded for a request.
+type Level string
+
+const (
+	// LevelNone disables auditing of the request.
+	LevelNone Level = "None"
+	// LevelMetadata records metadata of the request, how it's served, filter decisions and response code.
+	LevelMetadata Level = "Metadata"
+	// LevelWatchEvents records everything of LevelMetadata, and every event served for watch requests.
+	LevelWatchEvents Level = "WatchEvents"
+)
+
+// Stage defines the stage of request handling at which an audit event is generated.
+type Stage string
+
+const (
+	// StageRequestReceived is the stage when yurthub receives the request.
+	StageRequestReceived Stage = "RequestReceived"
+	// StageResponseStarted is the stage when response headers are sent, only for long-running requests like watch.
+	StageResponseStarted Stage = "ResponseStarted"
+	// StageResponseComplete is the stage when response body is completed.
+	StageResponseComplete Stage = "ResponseComplete"
+	// StageWatchEvent is the stage when a watch event is sent to client, only for LevelWatchEvents.
+	StageWatchEvent Stage = "WatchEvent"
+)
+
+// Source defines the handler of yurthub which serves the request.
+type Source string
+
+const (
+	// SourceRemote means the request is forwarded to cloud kube-apiserver or leader hub.
+	SourceRemote Source = "remote"
+	// SourceCache means the request is served by local cache.
+	SourceCache Source = "cache"
+	// SourceMultiplexer means the request is served by multiplexer of yurthub.
+	SourceMultiplexer Source = "multiplexer"
+	// SourceAutonomy means the request is served by autonomy proxy.
+	SourceAutonomy Source = "autonomy"
+)
+
+// Event is the audit record of a request at a stage.
+type Event struct {
+	Level      Level            `json:"level"`
+	AuditID    string           `json:"auditID"`
+	Stage      Stage            `json:"stage"`
+	RequestURI string           `json:"requestURI"`
+	Verb       string           `json:"verb"`
+	Component  string           `json:"component,omitempty"`
+	UserAgent  string           `json:"userAgent,omitempty"`
+	SourceIP   string           `json:"sourceIP,omitempty"`
+	ObjectRef  *ObjectReference `json:"objectRef,omitempty"`
+	// ServedBy is the handler of yurthub which serves the request, and Backend is
+	// the address of remote server when the request is forwarded.
+	ServedBy     Source          `json:"servedBy,omitempty"`
+	Backend      string          `json:"backend,omitempty"`
+	Filter       *FilterDecision `json:"filter,omitempty"`
+	ResponseCode int             `json:"responseCode,omitempty"`
+	WatchEvent   *WatchEvent     `json:"watchEvent,omitempty"`
+
+	RequestReceivedTimestamp metav1.MicroTime `json:"requestReceivedTimestamp"`
+	StageTimestamp           metav1.MicroTime `json:"stageTimestamp"`
+}
+
+// ObjectReference is the object which the request is for.
+type ObjectReference struct {
+	APIGroup    string `json:"apiGroup,omitempty"`
+	APIVersion  string `json:"apiVersion,omitempty"`
+	Resource    string `json:"resource,omitempty"`
+	Subresource string `json:"subresource,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+}
+
+// FilterDecision is the count of objects in the response which are kept or dropped by filters.
+type FilterDecision struct {
+	Filters string `json:"filters"`
+	Kept    int    `json:"kept"`
+	Dropped int    `json:"dropped"`
+}
+
+// WatchEvent is the watch event served to client.
+type WatchEvent struct {
+	Type            string `json:"type"`
+	Namespace       string `json:"namespace,omitempty"`
+	Name            string `json:"name,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// EventList is the batch of audit events sent to webhook.
+type EventList struct {
+	Items []Event `json:"items"`
+}
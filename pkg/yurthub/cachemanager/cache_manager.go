@@ -28,6 +28,7 @@ import (
 	"strings"
 	"sync"
 
+	"go.opentelemetry.io/otel/attribute"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
@@ -41,10 +42,12 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
 
+	"github.com/openyurtio/openyurt/pkg/util/tracing"
 	"github.com/openyurtio/openyurt/pkg/yurthub/configuration"
 	hubmeta "github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/meta"
 	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
 	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/utils"
 	"github.com/openyurtio/openyurt/pkg/yurthub/util"
 )
 
@@ -63,8 +66,12 @@ type CacheManager interface {
 	CacheResponse(req *http.Request, prc io.ReadCloser, stopCh <-chan struct{}) error
 	QueryCache(req *http.Request) (runtime.Object, error)
 	CanCacheFor(req *http.Request) bool
+	IsCacheableFor(req *http.Request) bool
 	DeleteKindFor(gvr schema.GroupVersionResource) error
 	QueryCacheResult() CacheResult
+	WatchEventsSince(req *http.Request) ([]watch.Event, string, bool)
+	WatchLocal(req *http.Request) (watch.Interface, error)
+	AcceptLocalWrite(req *http.Request, obj runtime.Object) error
 }
 
 type CacheResult struct {
@@ -80,6 +87,11 @@ type cacheManager struct {
 	configManager         *configuration.Manager
 	listSelectorCollector map[storage.Key]string
 	inMemoryCache         map[string]runtime.Object
+	watchHistory          *watchHistory
+	localWatchers         *localWatchers
+	// quota bounds the in-memory cache together with the backend storage, objects are kept
+	// in memory only when they are tracked by quota, and released when they are evicted.
+	quota *cacheQuota
 }
 
 // NewCacheManager creates a new CacheManager
@@ -88,6 +100,19 @@ func NewCacheManager(
 	serializerMgr *serializer.SerializerManager,
 	restMapperMgr *hubmeta.RESTMapperManager,
 	configManager *configuration.Manager,
+) CacheManager {
+	return NewCacheManagerWithWatchHistory(storagewrapper, serializerMgr, restMapperMgr, configManager, 0)
+}
+
+// NewCacheManagerWithWatchHistory creates a new CacheManager which keeps at most watchHistorySize
+// events for each watch stream, so watch requests can be resumed from local watch history.
+// watch history is disabled when watchHistorySize is 0.
+func NewCacheManagerWithWatchHistory(
+	storagewrapper StorageWrapper,
+	serializerMgr *serializer.SerializerManager,
+	restMapperMgr *hubmeta.RESTMapperManager,
+	configManager *configuration.Manager,
+	watchHistorySize int,
 ) CacheManager {
 	cm := &cacheManager{
 		storage:               storagewrapper,
@@ -96,6 +121,14 @@ func NewCacheManager(
 		configManager:         configManager,
 		listSelectorCollector: make(map[storage.Key]string),
 		inMemoryCache:         make(map[string]runtime.Object),
+		localWatchers:         newLocalWatchers(),
+	}
+	if watchHistorySize > 0 {
+		cm.watchHistory = newWatchHistory(watchHistorySize)
+	}
+	if sw, ok := storagewrapper.(*storageWrapper); ok && sw.quota != nil {
+		cm.quota = sw.quota
+		cm.quota.addForgetHandler(cm.forgetInMemoryCache)
 	}
 	return cm
 }
@@ -109,11 +142,23 @@ func (cm *cacheManager) QueryCacheResult() CacheResult {
 }
 
 // CacheResponse cache response of request into backend storage
-func (cm *cacheManager) CacheResponse(req *http.Request, prc io.ReadCloser, stopCh <-chan struct{}) error {
+func (cm *cacheManager) CacheResponse(req *http.Request, prc io.ReadCloser, stopCh <-chan struct{}) (err error) {
 	ctx := req.Context()
 	info, _ := apirequest.RequestInfoFrom(ctx)
+	ctx, span := tracing.Start(ctx, "CacheWrite", traceAttributesFor(info)...)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
 	if isWatch(ctx) {
-		return cm.saveWatchObject(ctx, info, prc, stopCh)
+		var window *eventWindow
+		if cm.watchHistory != nil {
+			if stream, rv, ok := watchStreamOf(req); ok {
+				window = cm.watchHistory.begin(stream, rv)
+				defer window.end()
+			}
+		}
+		return cm.saveWatchObject(ctx, info, prc, window, stopCh)
 	}
 
 	var buf bytes.Buffer
@@ -137,7 +182,7 @@ func (cm *cacheManager) CacheResponse(req *http.Request, prc io.ReadCloser, stop
 }
 
 // QueryCache get runtime object from backend storage for request
-func (cm *cacheManager) QueryCache(req *http.Request) (runtime.Object, error) {
+func (cm *cacheManager) QueryCache(req *http.Request) (obj runtime.Object, err error) {
 	ctx := req.Context()
 	info, ok := apirequest.RequestInfoFrom(ctx)
 	if !ok || info == nil || info.Resource == "" {
@@ -147,6 +192,13 @@ func (cm *cacheManager) QueryCache(req *http.Request) (runtime.Object, error) {
 		return nil, fmt.Errorf("could not QueryCache for getting non-resource request %s", util.ReqString(req))
 	}
 
+	ctx, span := tracing.Start(ctx, "CacheRead", traceAttributesFor(info)...)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+	req = req.WithContext(ctx)
+
 	switch info.Verb {
 	case "list":
 		return cm.queryListObject(req)
@@ -157,6 +209,17 @@ func (cm *cacheManager) QueryCache(req *http.Request) (runtime.Object, error) {
 	}
 }
 
+// traceAttributesFor returns attributes of span for reading or writing cache of request.
+func traceAttributesFor(info *apirequest.RequestInfo) []attribute.KeyValue {
+	if info == nil {
+		return nil
+	}
+	return []attribute.KeyValue{
+		attribute.String(tracing.AttributeVerb, info.Verb),
+		attribute.String(tracing.AttributeResource, info.Resource),
+	}
+}
+
 // TODO: Consider if we need accelerate the list query with in-memory cache. Currently, we only
 // use in-memory cache in queryOneObject.
 func (cm *cacheManager) queryListObject(req *http.Request) (runtime.Object, error) {
@@ -360,7 +423,7 @@ func generateEmptyListObjOfGVK(listGvk schema.GroupVersionKind) (runtime.Object,
 	return listObj, nil
 }
 
-func (cm *cacheManager) saveWatchObject(ctx context.Context, info *apirequest.RequestInfo, r io.ReadCloser, _ <-chan struct{}) error {
+func (cm *cacheManager) saveWatchObject(ctx context.Context, info *apirequest.RequestInfo, r io.ReadCloser, window *eventWindow, _ <-chan struct{}) error {
 	delObjCnt := 0
 	updateObjCnt := 0
 	addObjCnt := 0
@@ -457,9 +520,11 @@ func (cm *cacheManager) saveWatchObject(ctx context.Context, info *apirequest.Re
 			if err != nil {
 				klog.Errorf("could not process watch object %s, %v", key.Key(), err)
 			}
+			addToWatchHistory(window, watchType, obj)
 		case watch.Bookmark:
 			rv, _ := accessor.ResourceVersion(obj)
 			klog.V(4).Infof("get bookmark with rv %s for %s watch %s", rv, comp, info.Resource)
+			addToWatchHistory(window, watchType, obj)
 		case watch.Error:
 			klog.Infof("unable to understand watch event %#v", obj)
 		}
@@ -647,6 +712,23 @@ func (cm *cacheManager) updateInMemoryCache(ctx context.Context, info *apireques
 	if inMemoryCacheKey, err := inMemoryCacheKeyFunc(info); err != nil {
 		klog.Errorf("cannot get in-memorycache key of requestInfo %s, %v", util.ReqInfoString(info), err)
 		return err
+	} else if cm.quota != nil {
+		comp, _ := util.TruncatedClientComponentFrom(ctx)
+		key, err := cm.storage.KeyFunc(storage.KeyBuildInfo{
+			Component: comp,
+			Namespace: info.Namespace,
+			Name:      info.Name,
+			Resources: info.Resource,
+			Group:     info.APIGroup,
+			Version:   info.APIVersion,
+		})
+		if err != nil {
+			return err
+		}
+		cm.quota.ifTracked(key, func() {
+			klog.V(4).Infof("update in-memory cache for %s", inMemoryCacheKey)
+			cm.inMemoryCacheFor(inMemoryCacheKey, obj)
+		})
 	} else {
 		klog.V(4).Infof("update in-memory cache for %s", inMemoryCacheKey)
 		cm.inMemoryCacheFor(inMemoryCacheKey, obj)
@@ -654,6 +736,18 @@ func (cm *cacheManager) updateInMemoryCache(ctx context.Context, info *apireques
 	return nil
 }
 
+// forgetInMemoryCache releases the in-memory copy of object which is evicted or deleted from backend storage.
+func (cm *cacheManager) forgetInMemoryCache(key storage.Key) {
+	component, gvr, namespace, name, ok := utils.ParseObjectKey(key.Key())
+	if !ok || component != "kubelet" {
+		return
+	}
+
+	cm.Lock()
+	defer cm.Unlock()
+	delete(cm.inMemoryCache, filepath.Join(gvr.Resource, namespace, name))
+}
+
 func (cm *cacheManager) storeObjectWithKey(key storage.Key, obj runtime.Object) error {
 	accessor := meta.NewAccessor()
 	if isNotAssignedPod(obj) {
@@ -751,7 +845,19 @@ func isCreate(ctx context.Context) bool {
 // 2. delete/deletecollection/proxy request
 // 3. sub-resource request but is not status
 // 4. csr and sar resource request
+// the selector of list request is recorded, so list requests with the same path but different
+// selectors are not cached later.
 func (cm *cacheManager) CanCacheFor(req *http.Request) bool {
+	return cm.canCacheFor(req, true)
+}
+
+// IsCacheableFor checks response of request can be cached or not in the same way as CanCacheFor,
+// but the selector of list request is not recorded, so it has no side effects.
+func (cm *cacheManager) IsCacheableFor(req *http.Request) bool {
+	return cm.canCacheFor(req, false)
+}
+
+func (cm *cacheManager) canCacheFor(req *http.Request, recordSelector bool) bool {
 	ctx := req.Context()
 
 	comp, ok := util.TruncatedClientComponentFrom(ctx)
@@ -759,7 +865,12 @@ func (cm *cacheManager) CanCacheFor(req *http.Request) bool {
 		return false
 	}
 
-	if !cm.configManager.IsCacheable(comp) {
+	// rules of cache policies take precedence over cache agents of yurt-hub-cfg configmap.
+	if cacheable, matched := cm.configManager.CachePolicyFor(req); matched {
+		if !cacheable {
+			return false
+		}
+	} else if !cm.configManager.IsCacheable(comp) {
 		return false
 	}
 
@@ -827,7 +938,9 @@ func (cm *cacheManager) CanCacheFor(req *http.Request) bool {
 					return false
 				}
 			}
-			cm.listSelectorCollector[key] = selector
+			if recordSelector {
+				cm.listSelectorCollector[key] = selector
+			}
 		}
 	}
 
@@ -3349,6 +3349,41 @@ func TestCanCacheFor(t *testing.T) {
 	}
 }
 
+// cacheableChecker checks requests with IsCacheableFor instead of CanCacheFor.
+type cacheableChecker struct {
+	CacheManager
+}
+
+func (c cacheableChecker) CanCacheFor(req *http.Request) bool {
+	return c.IsCacheableFor(req)
+}
+
+func TestIsCacheableForHasNoSideEffects(t *testing.T) {
+	dStorage, err := disk.NewDiskStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create disk storage, %v", err)
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	configManager := configuration.NewConfigurationManager("node1", informerFactory)
+	m := NewCacheManager(NewStorageWrapper(dStorage), nil, nil, configManager)
+	informerFactory.Start(nil)
+	cache.WaitForCacheSync(stop, informerFactory.Core().V1().ConfigMaps().Informer().HasSynced)
+
+	if !checkReqCanCache(cacheableChecker{m}, "kubelet", "GET", "/api/v1/namespaces/test2/secrets?labelSelector=foo=bar1", nil, "", client) {
+		t.Errorf("expect list request is cacheable")
+	}
+	// the selector of list request checked by IsCacheableFor is not recorded.
+	if !checkReqCanCache(m, "kubelet", "GET", "/api/v1/namespaces/test2/secrets?labelSelector=foo=bar2", nil, "", client) {
+		t.Errorf("expect list request with different selector can be cached")
+	}
+	if checkReqCanCache(cacheableChecker{m}, "kubelet", "GET", "/api/v1/namespaces/test2/secrets?labelSelector=foo=bar1", nil, "", client) {
+		t.Errorf("expect list request with different selector from the recorded one is not cacheable")
+	}
+}
+
 func checkReqCanCache(m CacheManager, userAgent, verb, path string, header map[string]string, cacheAgents string, testClient *fake.Clientset) bool {
 	req, _ := http.NewRequest(verb, path, nil)
 	if len(userAgent) != 0 {
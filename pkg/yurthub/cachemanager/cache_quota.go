@@ -0,0 +1,600 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cachemanager
+
+import (
+	"container/list"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/utils"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+const (
+	quotaScopeComponent = "component"
+	quotaScopeResource  = "resource"
+)
+
+type quotaUsage struct {
+	bytes   int64
+	objects int64
+}
+
+func quotaExceeded(limit util.QuotaLimit, u *quotaUsage) bool {
+	return (limit.Bytes > 0 && u.bytes > limit.Bytes) || (limit.Objects > 0 && u.objects > limit.Objects)
+}
+
+// componentResource identifies the objects of a resource which are cached for a component.
+type componentResource struct {
+	component string
+	gvr       schema.GroupVersionResource
+}
+
+type quotaEntry struct {
+	key       storage.Key
+	component string
+	gvr       schema.GroupVersionResource
+	namespace string
+	name      string
+	size      int64
+	// resourceDir is the key prefix of objects of the same component and resource, and it's used
+	// for finding the objects under the root key of list requests.
+	resourceDir string
+	priority    int
+	// element is the position of entry in the lru list of its priority.
+	element *list.Element
+	// ownPod indicates the object is a pod assigned to the node, and refs
+	// are the secrets and configmaps referenced by the pod.
+	ownPod bool
+	refs   []string
+}
+
+// cacheQuota tracks the usage of local cache by component and resource, and evicts cached
+// objects when the usage exceeds quota. Objects which are needed for node autonomy are never
+// evicted, and the objects which are just written are also kept, so the usage may still exceed
+// the quota when all of the other objects are protected.
+type cacheQuota struct {
+	sync.Mutex
+	store           storage.Store
+	nodeName        string
+	policy          util.EvictionPolicy
+	priorities      map[schema.GroupVersionResource]int
+	componentQuotas map[string]util.QuotaLimit
+	resourceQuotas  map[schema.GroupVersionResource]util.QuotaLimit
+	entries         map[string]*quotaEntry
+	componentUsage  map[string]*quotaUsage
+	resourceUsage   map[schema.GroupVersionResource]*quotaUsage
+	protectedRefs   map[string]int
+	// lru keeps entries of each priority in the order of last access, the least recently
+	// used entry is at the front of list.
+	lru map[int]*list.List
+	// resourceDirs indexes entries by resourceDir and key.
+	resourceDirs map[string]map[string]*quotaEntry
+	// resourceEntries indexes entries by component, resource, namespace and key.
+	resourceEntries map[componentResource]map[string]map[string]*quotaEntry
+	// forgetHandlers are called when an object is evicted or deleted from cache.
+	forgetHandlers []func(key storage.Key)
+}
+
+func newCacheQuota(store storage.Store, cfg *util.CacheQuotaConfig) (*cacheQuota, error) {
+	policy := cfg.EvictionPolicy
+	if len(policy) == 0 {
+		policy = util.EvictionPolicyLRU
+	}
+	if policy != util.EvictionPolicyLRU && policy != util.EvictionPolicyPriority {
+		return nil, fmt.Errorf("eviction policy %s is not supported", policy)
+	}
+
+	cq := &cacheQuota{
+		store:           store,
+		nodeName:        cfg.NodeName,
+		policy:          policy,
+		priorities:      cfg.ResourcePriorities,
+		componentQuotas: cfg.ComponentQuotas,
+		resourceQuotas:  cfg.ResourceQuotas,
+		entries:         make(map[string]*quotaEntry),
+		componentUsage:  make(map[string]*quotaUsage),
+		resourceUsage:   make(map[schema.GroupVersionResource]*quotaUsage),
+		protectedRefs:   make(map[string]int),
+		lru:             make(map[int]*list.List),
+		resourceDirs:    make(map[string]map[string]*quotaEntry),
+		resourceEntries: make(map[componentResource]map[string]map[string]*quotaEntry),
+	}
+	for comp, limit := range cq.componentQuotas {
+		metrics.Metrics.SetCacheQuota(quotaScopeComponent, comp, limit.Bytes, limit.Objects)
+	}
+	for gvr, limit := range cq.resourceQuotas {
+		metrics.Metrics.SetCacheQuota(quotaScopeResource, resourceName(gvr), limit.Bytes, limit.Objects)
+	}
+
+	if err := cq.load(); err != nil {
+		return nil, err
+	}
+	return cq, nil
+}
+
+// load tracks the objects which have been cached before yurthub starts, and evicts objects
+// if the quotas are shrunk.
+func (cq *cacheQuota) load() error {
+	components, err := cq.store.ListComponents()
+	if err != nil {
+		return fmt.Errorf("could not list components for cache quota, %w", err)
+	}
+	for _, comp := range components {
+		if err := cq.loadComponent(comp); err != nil {
+			return err
+		}
+	}
+
+	cq.Lock()
+	var victims []*quotaEntry
+	for comp := range cq.componentQuotas {
+		victims = append(victims, cq.enforceComponent(comp, nil)...)
+	}
+	for gvr := range cq.resourceQuotas {
+		victims = append(victims, cq.enforceResource(gvr, nil)...)
+	}
+	cq.Unlock()
+	cq.evict(victims)
+
+	cq.Lock()
+	defer cq.Unlock()
+	klog.Infof("cache quota tracks %d cached objects", len(cq.entries))
+	return nil
+}
+
+func (cq *cacheQuota) loadComponent(component string) error {
+	gvrs, err := cq.store.ListResourcesOfComponent(component)
+	if errors.Is(err, storage.ErrStorageNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("could not list resources of component %s for cache quota, %w", component, err)
+	}
+
+	for _, gvr := range gvrs {
+		if gvr.Resource == "partialobjectmetadatas" {
+			// objects for requests with convert gvk are cached under the sub dir of component
+			if err := cq.loadComponent(strings.Join([]string{component, gvr.Resource + "." + gvr.Version + "." + gvr.Group}, "/")); err != nil {
+				return err
+			}
+			continue
+		}
+
+		keys, err := cq.store.ListResourceKeysOfComponent(component, gvr)
+		if errors.Is(err, storage.ErrStorageNotFound) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("could not list keys of %s for component %s, %w", gvr.String(), component, err)
+		}
+		for _, key := range keys {
+			content, err := cq.store.Get(key)
+			if err != nil {
+				klog.Warningf("could not get %s for cache quota, %v", key.Key(), err)
+				continue
+			}
+			cq.Lock()
+			cq.track(key, content)
+			cq.Unlock()
+		}
+	}
+	return nil
+}
+
+// add tracks the object which has been written into storage, and evicts other objects if quota is exceeded.
+func (cq *cacheQuota) add(key storage.Key, content []byte) {
+	cq.Lock()
+	entry := cq.track(key, content)
+	if entry == nil {
+		cq.Unlock()
+		return
+	}
+
+	exclude := map[string]struct{}{key.Key(): {}}
+	victims := cq.enforceComponent(entry.component, exclude)
+	victims = append(victims, cq.enforceResource(entry.gvr, exclude)...)
+	cq.Unlock()
+	cq.evict(victims)
+}
+
+// replace tracks the objects which are replaced by ReplaceComponentList.
+func (cq *cacheQuota) replace(component string, gvr schema.GroupVersionResource, namespace string, contents map[storage.Key][]byte) {
+	cq.Lock()
+	replaced := make(map[string]struct{}, len(contents))
+	for key := range contents {
+		replaced[key.Key()] = struct{}{}
+	}
+	cr := componentResource{component: component, gvr: gvr}
+	paths := make([]string, 0)
+	for ns, entries := range cq.resourceEntries[cr] {
+		if len(namespace) != 0 && ns != namespace {
+			continue
+		}
+		for path := range entries {
+			paths = append(paths, path)
+		}
+	}
+	for _, path := range paths {
+		if _, ok := replaced[path]; ok {
+			cq.untrack(path)
+		} else {
+			cq.forget(path)
+		}
+	}
+
+	exclude := make(map[string]struct{}, len(contents))
+	for key, content := range contents {
+		if cq.track(key, content) != nil {
+			exclude[key.Key()] = struct{}{}
+		}
+	}
+	victims := cq.enforceComponent(component, exclude)
+	victims = append(victims, cq.enforceResource(gvr, exclude)...)
+	cq.Unlock()
+	cq.evict(victims)
+}
+
+func (cq *cacheQuota) remove(key storage.Key) {
+	cq.Lock()
+	defer cq.Unlock()
+	cq.forget(key.Key())
+}
+
+func (cq *cacheQuota) removeComponent(component string) {
+	cq.Lock()
+	defer cq.Unlock()
+	paths := make([]string, 0)
+	for cr, namespaces := range cq.resourceEntries {
+		if cr.component != component && !strings.HasPrefix(cr.component, component+"/") {
+			continue
+		}
+		for _, entries := range namespaces {
+			for path := range entries {
+				paths = append(paths, path)
+			}
+		}
+	}
+	for _, path := range paths {
+		cq.forget(path)
+	}
+}
+
+// addForgetHandler registers a handler which is called when an object is evicted or deleted from cache,
+// so the copies of the object out of storage, like in-memory cache, can be released together.
+// the handler is called with the lock of cacheQuota held.
+func (cq *cacheQuota) addForgetHandler(handler func(key storage.Key)) {
+	cq.Lock()
+	defer cq.Unlock()
+	cq.forgetHandlers = append(cq.forgetHandlers, handler)
+}
+
+// ifTracked calls fn only when the object specified by key is tracked by cache quota, and the object
+// will not be evicted until fn returns.
+func (cq *cacheQuota) ifTracked(key storage.Key, fn func()) {
+	cq.Lock()
+	defer cq.Unlock()
+	if _, ok := cq.entries[key.Key()]; ok {
+		fn()
+	}
+}
+
+// touch marks the object specified by key is used recently.
+func (cq *cacheQuota) touch(key storage.Key) {
+	cq.Lock()
+	defer cq.Unlock()
+	if entry, ok := cq.entries[key.Key()]; ok {
+		cq.lru[entry.priority].MoveToBack(entry.element)
+	}
+}
+
+// touchList marks all objects under the root key are used recently. the root key is
+// either the resource dir of objects or the namespace dir under the resource dir.
+func (cq *cacheQuota) touchList(rootKey storage.Key) {
+	cq.Lock()
+	defer cq.Unlock()
+	root := rootKey.Key()
+	if entries, ok := cq.resourceDirs[root]; ok {
+		for _, entry := range entries {
+			cq.lru[entry.priority].MoveToBack(entry.element)
+		}
+		return
+	}
+
+	idx := strings.LastIndex(root, "/")
+	if idx < 0 {
+		return
+	}
+	namespace := root[idx+1:]
+	for _, entry := range cq.resourceDirs[root[:idx]] {
+		if entry.namespace == namespace {
+			cq.lru[entry.priority].MoveToBack(entry.element)
+		}
+	}
+}
+
+func (cq *cacheQuota) track(key storage.Key, content []byte) *quotaEntry {
+	if len(content) == 0 {
+		return nil
+	}
+	component, gvr, namespace, name, ok := utils.ParseObjectKey(key.Key())
+	if !ok {
+		klog.V(4).Infof("skip tracking key %s for cache quota, unknown key format", key.Key())
+		return nil
+	}
+
+	path := key.Key()
+	cq.untrack(path)
+	entry := &quotaEntry{
+		key:         key,
+		component:   component,
+		gvr:         gvr,
+		namespace:   namespace,
+		name:        name,
+		size:        int64(len(content)),
+		resourceDir: resourceDirOf(path, namespace, name),
+	}
+	if cq.policy == util.EvictionPolicyPriority {
+		entry.priority = cq.priorities[gvr]
+	}
+	if gvr.Group == "" && gvr.Resource == "pods" {
+		entry.ownPod, entry.refs = cq.podReferences(content)
+	}
+	cq.insert(entry)
+	return entry
+}
+
+// insert adds the entry into indexes and usages, the entry is placed at the back of lru list.
+func (cq *cacheQuota) insert(entry *quotaEntry) {
+	path := entry.key.Key()
+	for _, ref := range entry.refs {
+		cq.protectedRefs[ref]++
+	}
+	cq.entries[path] = entry
+	if _, ok := cq.resourceDirs[entry.resourceDir]; !ok {
+		cq.resourceDirs[entry.resourceDir] = make(map[string]*quotaEntry)
+	}
+	cq.resourceDirs[entry.resourceDir][path] = entry
+	cr := componentResource{component: entry.component, gvr: entry.gvr}
+	if _, ok := cq.resourceEntries[cr]; !ok {
+		cq.resourceEntries[cr] = make(map[string]map[string]*quotaEntry)
+	}
+	if _, ok := cq.resourceEntries[cr][entry.namespace]; !ok {
+		cq.resourceEntries[cr][entry.namespace] = make(map[string]*quotaEntry)
+	}
+	cq.resourceEntries[cr][entry.namespace][path] = entry
+	if _, ok := cq.lru[entry.priority]; !ok {
+		cq.lru[entry.priority] = list.New()
+	}
+	entry.element = cq.lru[entry.priority].PushBack(entry)
+	cq.updateUsage(entry, 1)
+}
+
+// forget untracks the object which is evicted or deleted from cache, and notifies the forget handlers.
+func (cq *cacheQuota) forget(path string) {
+	entry, ok := cq.entries[path]
+	if !ok {
+		return
+	}
+	cq.untrack(path)
+	for _, handler := range cq.forgetHandlers {
+		handler(entry.key)
+	}
+}
+
+func (cq *cacheQuota) untrack(path string) {
+	entry, ok := cq.entries[path]
+	if !ok {
+		return
+	}
+	delete(cq.entries, path)
+	if entries, ok := cq.resourceDirs[entry.resourceDir]; ok {
+		delete(entries, path)
+		if len(entries) == 0 {
+			delete(cq.resourceDirs, entry.resourceDir)
+		}
+	}
+	cr := componentResource{component: entry.component, gvr: entry.gvr}
+	if namespaces, ok := cq.resourceEntries[cr]; ok {
+		if entries, ok := namespaces[entry.namespace]; ok {
+			delete(entries, path)
+			if len(entries) == 0 {
+				delete(namespaces, entry.namespace)
+			}
+		}
+		if len(namespaces) == 0 {
+			delete(cq.resourceEntries, cr)
+		}
+	}
+	cq.lru[entry.priority].Remove(entry.element)
+	for _, ref := range entry.refs {
+		if cq.protectedRefs[ref] <= 1 {
+			delete(cq.protectedRefs, ref)
+		} else {
+			cq.protectedRefs[ref]--
+		}
+	}
+	cq.updateUsage(entry, -1)
+}
+
+func (cq *cacheQuota) updateUsage(entry *quotaEntry, delta int64) {
+	compUsage, ok := cq.componentUsage[entry.component]
+	if !ok {
+		compUsage = &quotaUsage{}
+		cq.componentUsage[entry.component] = compUsage
+	}
+	compUsage.bytes += delta * entry.size
+	compUsage.objects += delta
+	metrics.Metrics.SetCacheUsage(quotaScopeComponent, entry.component, compUsage.bytes, compUsage.objects)
+
+	resUsage, ok := cq.resourceUsage[entry.gvr]
+	if !ok {
+		resUsage = &quotaUsage{}
+		cq.resourceUsage[entry.gvr] = resUsage
+	}
+	resUsage.bytes += delta * entry.size
+	resUsage.objects += delta
+	metrics.Metrics.SetCacheUsage(quotaScopeResource, resourceName(entry.gvr), resUsage.bytes, resUsage.objects)
+}
+
+func (cq *cacheQuota) enforceComponent(component string, exclude map[string]struct{}) []*quotaEntry {
+	return cq.enforce(quotaScopeComponent, component, cq.componentQuotas[component], cq.componentUsage[component], exclude,
+		func(entry *quotaEntry) bool { return entry.component == component })
+}
+
+func (cq *cacheQuota) enforceResource(gvr schema.GroupVersionResource, exclude map[string]struct{}) []*quotaEntry {
+	return cq.enforce(quotaScopeResource, resourceName(gvr), cq.resourceQuotas[gvr], cq.resourceUsage[gvr], exclude,
+		func(entry *quotaEntry) bool { return entry.gvr == gvr })
+}
+
+// enforce untracks objects in the scope until the usage is under the quota of scope, and returns them
+// as victims which should be evicted by evict after the lock is released. objects of lower priority are
+// evicted first, and objects with the same priority are evicted in LRU order.
+func (cq *cacheQuota) enforce(scope, name string, limit util.QuotaLimit, usage *quotaUsage, exclude map[string]struct{}, inScope func(entry *quotaEntry) bool) []*quotaEntry {
+	if usage == nil || !quotaExceeded(limit, usage) {
+		return nil
+	}
+
+	priorities := make([]int, 0, len(cq.lru))
+	for priority := range cq.lru {
+		priorities = append(priorities, priority)
+	}
+	sort.Ints(priorities)
+
+	victims := make([]*quotaEntry, 0)
+	for _, priority := range priorities {
+		for e := cq.lru[priority].Front(); e != nil && quotaExceeded(limit, usage); {
+			entry := e.Value.(*quotaEntry)
+			e = e.Next()
+			if _, ok := exclude[entry.key.Key()]; ok || !inScope(entry) || cq.isProtected(entry) {
+				continue
+			}
+			klog.V(2).Infof("evict %s from cache because quota of %s %s is exceeded", entry.key.Key(), scope, name)
+			cq.untrack(entry.key.Key())
+			victims = append(victims, entry)
+		}
+	}
+
+	if quotaExceeded(limit, usage) {
+		klog.Warningf("cache usage(bytes: %d, objects: %d) of %s %s still exceeds quota(bytes: %d, objects: %d) after eviction",
+			usage.bytes, usage.objects, scope, name, limit.Bytes, limit.Objects)
+	}
+	return victims
+}
+
+// evict deletes the victims returned by enforce from storage without holding the lock of cacheQuota, so
+// requests of other objects are not blocked by the storage. the forget handlers are called for victims
+// which are deleted, and victims which can not be deleted are tracked again if they are not written
+// during the eviction.
+func (cq *cacheQuota) evict(victims []*quotaEntry) {
+	if len(victims) == 0 {
+		return
+	}
+
+	failed := make(map[*quotaEntry]struct{})
+	for _, entry := range victims {
+		if err := cq.store.Delete(entry.key); err != nil && !errors.Is(err, storage.ErrStorageNotFound) {
+			klog.Errorf("could not evict %s from cache, %v", entry.key.Key(), err)
+			failed[entry] = struct{}{}
+			continue
+		}
+		metrics.Metrics.IncCacheEvictedObjects(entry.component, resourceName(entry.gvr))
+	}
+
+	cq.Lock()
+	defer cq.Unlock()
+	for _, entry := range victims {
+		if _, ok := failed[entry]; ok {
+			if _, tracked := cq.entries[entry.key.Key()]; !tracked {
+				cq.insert(entry)
+			}
+			continue
+		}
+		for _, handler := range cq.forgetHandlers {
+			handler(entry.key)
+		}
+	}
+}
+
+// isProtected checks whether the object is needed by node autonomy, includes node and lease of the node,
+// pods on the node, and secrets/configmaps referenced by these pods.
+func (cq *cacheQuota) isProtected(entry *quotaEntry) bool {
+	if entry.ownPod {
+		return true
+	}
+
+	switch {
+	case entry.gvr.Group == "" && entry.gvr.Resource == "nodes":
+		return entry.name == cq.nodeName
+	case entry.gvr.Group == "coordination.k8s.io" && entry.gvr.Resource == "leases":
+		return entry.namespace == v1.NamespaceNodeLease && entry.name == cq.nodeName
+	case entry.gvr.Group == "" && (entry.gvr.Resource == "secrets" || entry.gvr.Resource == "configmaps"):
+		return cq.protectedRefs[refKey(entry.gvr.Resource, entry.namespace, entry.name)] > 0
+	}
+	return false
+}
+
+// podReferences checks whether the pod is assigned to the node, and returns secrets and configmaps
+// referenced by the pod on the node.
+func (cq *cacheQuota) podReferences(content []byte) (bool, []string) {
+	var pod v1.Pod
+	if err := json.Unmarshal(content, &pod); err != nil {
+		return false, nil
+	}
+	if len(cq.nodeName) == 0 || pod.Spec.NodeName != cq.nodeName {
+		return false, nil
+	}
+
+	refs := make([]string, 0)
+	podutil.VisitPodSecretNames(&pod, func(name string) bool {
+		refs = append(refs, refKey("secrets", pod.Namespace, name))
+		return true
+	})
+	podutil.VisitPodConfigmapNames(&pod, func(name string) bool {
+		refs = append(refs, refKey("configmaps", pod.Namespace, name))
+		return true
+	})
+	return true, refs
+}
+
+// resourceName returns gvr in the format of group/version/resource, which is the same as the format of flags.
+func resourceName(gvr schema.GroupVersionResource) string {
+	return strings.Join([]string{gvr.Group, gvr.Version, gvr.Resource}, "/")
+}
+
+// resourceDirOf returns the key prefix of object which is shared by the objects of the same component and resource.
+func resourceDirOf(path, namespace, name string) string {
+	dir := strings.TrimSuffix(path, "/"+name)
+	if len(namespace) != 0 {
+		dir = strings.TrimSuffix(dir, "/"+namespace)
+	}
+	return dir
+}
+
+func refKey(resource, namespace, name string) string {
+	return strings.Join([]string{resource, namespace, name}, "/")
+}
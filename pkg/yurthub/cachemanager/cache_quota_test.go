@@ -0,0 +1,380 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cachemanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/disk"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+var (
+	podsGVR       = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	secretsGVR    = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	configmapsGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+)
+
+func newQuotaTestObject(gvr schema.GroupVersionResource, name string) runtime.Object {
+	meta := metav1.ObjectMeta{Name: name, Namespace: "default", ResourceVersion: "1"}
+	switch gvr.Resource {
+	case "pods":
+		return &v1.Pod{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: meta,
+			Spec: v1.PodSpec{
+				NodeName: "node1",
+				Volumes: []v1.Volume{{
+					Name:         "secret",
+					VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "protected"}},
+				}},
+			},
+		}
+	case "secrets":
+		return &v1.Secret{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}, ObjectMeta: meta}
+	default:
+		return &v1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}, ObjectMeta: meta}
+	}
+}
+
+func quotaTestKey(t *testing.T, sw StorageWrapper, gvr schema.GroupVersionResource, name string) storage.Key {
+	key, err := sw.KeyFunc(storage.KeyBuildInfo{
+		Component: "kubelet",
+		Resources: gvr.Resource,
+		Version:   gvr.Version,
+		Group:     gvr.Group,
+		Namespace: "default",
+		Name:      name,
+	})
+	if err != nil {
+		t.Fatalf("could not get key, %v", err)
+	}
+	return key
+}
+
+func TestCacheQuotaEviction(t *testing.T) {
+	type object struct {
+		gvr  schema.GroupVersionResource
+		name string
+	}
+	testcases := map[string]struct {
+		cfg     *util.CacheQuotaConfig
+		objects []object
+		// accessed objects are read after all objects are created
+		accessed []object
+		evicted  []object
+		kept     []object
+	}{
+		"evict least recently used objects": {
+			cfg: &util.CacheQuotaConfig{
+				NodeName:        "node1",
+				ComponentQuotas: map[string]util.QuotaLimit{"kubelet": {Objects: 2}},
+			},
+			objects:  []object{{configmapsGVR, "cm1"}, {configmapsGVR, "cm2"}, {configmapsGVR, "cm3"}},
+			accessed: []object{{configmapsGVR, "cm1"}},
+			evicted:  []object{{configmapsGVR, "cm2"}},
+			kept:     []object{{configmapsGVR, "cm1"}, {configmapsGVR, "cm3"}},
+		},
+		"never evict pods on the node and referenced secrets": {
+			cfg: &util.CacheQuotaConfig{
+				NodeName:        "node1",
+				ComponentQuotas: map[string]util.QuotaLimit{"kubelet": {Objects: 2}},
+			},
+			objects: []object{{secretsGVR, "protected"}, {podsGVR, "pod1"}, {secretsGVR, "other"}, {configmapsGVR, "cm1"}},
+			evicted: []object{{secretsGVR, "other"}},
+			kept:    []object{{secretsGVR, "protected"}, {podsGVR, "pod1"}, {configmapsGVR, "cm1"}},
+		},
+		"evict objects of lower priority first": {
+			cfg: &util.CacheQuotaConfig{
+				NodeName:           "node1",
+				ResourceQuotas:     map[schema.GroupVersionResource]util.QuotaLimit{configmapsGVR: {Objects: 1}, secretsGVR: {Objects: 1}},
+				ComponentQuotas:    map[string]util.QuotaLimit{"kubelet": {Objects: 2}},
+				EvictionPolicy:     util.EvictionPolicyPriority,
+				ResourcePriorities: map[schema.GroupVersionResource]int{configmapsGVR: -1},
+			},
+			objects: []object{{secretsGVR, "secret1"}, {configmapsGVR, "cm1"}, {secretsGVR, "secret2"}},
+			evicted: []object{{configmapsGVR, "cm1"}, {secretsGVR, "secret1"}},
+			kept:    []object{{secretsGVR, "secret2"}},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			store, err := disk.NewDiskStorage(t.TempDir())
+			if err != nil {
+				t.Fatalf("could not create disk storage, %v", err)
+			}
+			sw, err := NewStorageWrapperWithQuota(store, tc.cfg)
+			if err != nil {
+				t.Fatalf("could not create storage wrapper, %v", err)
+			}
+
+			for _, obj := range tc.objects {
+				if err := sw.Create(quotaTestKey(t, sw, obj.gvr, obj.name), newQuotaTestObject(obj.gvr, obj.name)); err != nil {
+					t.Fatalf("could not create %s, %v", obj.name, err)
+				}
+				for _, accessed := range tc.accessed {
+					if _, err := sw.Get(quotaTestKey(t, sw, accessed.gvr, accessed.name)); err != nil && !errors.Is(err, storage.ErrStorageNotFound) {
+						t.Fatalf("could not get %s, %v", accessed.name, err)
+					}
+				}
+			}
+
+			for _, obj := range tc.evicted {
+				if _, err := store.Get(quotaTestKey(t, sw, obj.gvr, obj.name)); !errors.Is(err, storage.ErrStorageNotFound) {
+					t.Errorf("expect %s/%s to be evicted, but got %v", obj.gvr.Resource, obj.name, err)
+				}
+			}
+			for _, obj := range tc.kept {
+				if _, err := store.Get(quotaTestKey(t, sw, obj.gvr, obj.name)); err != nil {
+					t.Errorf("expect %s/%s to be kept, but got %v", obj.gvr.Resource, obj.name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCacheQuotaLoadAndReplace(t *testing.T) {
+	dir := t.TempDir()
+	store, err := disk.NewDiskStorage(dir)
+	if err != nil {
+		t.Fatalf("could not create disk storage, %v", err)
+	}
+	sw := NewStorageWrapper(store)
+	for _, name := range []string{"cm1", "cm2", "cm3"} {
+		if err := sw.Create(quotaTestKey(t, sw, configmapsGVR, name), newQuotaTestObject(configmapsGVR, name)); err != nil {
+			t.Fatalf("could not create %s, %v", name, err)
+		}
+	}
+
+	sw, err = NewStorageWrapperWithQuota(store, &util.CacheQuotaConfig{
+		NodeName:       "node1",
+		ResourceQuotas: map[schema.GroupVersionResource]util.QuotaLimit{configmapsGVR: {Objects: 2}},
+	})
+	if err != nil {
+		t.Fatalf("could not create storage wrapper, %v", err)
+	}
+	quota := sw.(*storageWrapper).quota
+	if usage := quota.resourceUsage[configmapsGVR]; usage == nil || usage.objects != 2 {
+		t.Errorf("expect 2 configmaps after loading existing cache, but got %v", usage)
+	}
+
+	objs := map[storage.Key]runtime.Object{}
+	for _, name := range []string{"cm4", "cm5", "cm6"} {
+		objs[quotaTestKey(t, sw, configmapsGVR, name)] = newQuotaTestObject(configmapsGVR, name)
+	}
+	if err := sw.ReplaceComponentList("kubelet", configmapsGVR, "", objs); err != nil {
+		t.Fatalf("could not replace list, %v", err)
+	}
+	// objects in the latest list are always kept.
+	if usage := quota.resourceUsage[configmapsGVR]; usage == nil || usage.objects != 3 {
+		t.Errorf("expect 3 configmaps after replacing list, but got %v", usage)
+	}
+
+	if err := sw.DeleteComponentResources("kubelet"); err != nil {
+		t.Fatalf("could not delete component resources, %v", err)
+	}
+	if usage := quota.componentUsage["kubelet"]; usage == nil || usage.objects != 0 || usage.bytes != 0 {
+		t.Errorf("expect no usage after deleting component resources, but got %v", usage)
+	}
+}
+
+func TestCacheQuotaTouchList(t *testing.T) {
+	testcases := map[string]struct {
+		namespace string
+	}{
+		"list objects of namespace": {
+			namespace: "default",
+		},
+		"list objects of all namespaces": {},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			store, err := disk.NewDiskStorage(t.TempDir())
+			if err != nil {
+				t.Fatalf("could not create disk storage, %v", err)
+			}
+			sw, err := NewStorageWrapperWithQuota(store, &util.CacheQuotaConfig{
+				NodeName:       "node1",
+				ResourceQuotas: map[schema.GroupVersionResource]util.QuotaLimit{configmapsGVR: {Objects: 2}},
+			})
+			if err != nil {
+				t.Fatalf("could not create storage wrapper, %v", err)
+			}
+			for _, obj := range []struct {
+				gvr  schema.GroupVersionResource
+				name string
+			}{{configmapsGVR, "cm1"}, {configmapsGVR, "cm2"}, {secretsGVR, "secret1"}} {
+				if err := sw.Create(quotaTestKey(t, sw, obj.gvr, obj.name), newQuotaTestObject(obj.gvr, obj.name)); err != nil {
+					t.Fatalf("could not create %s, %v", obj.name, err)
+				}
+			}
+
+			rootKey, err := sw.KeyFunc(storage.KeyBuildInfo{Component: "kubelet", Resources: "configmaps", Version: "v1", Namespace: tc.namespace})
+			if err != nil {
+				t.Fatalf("could not get root key, %v", err)
+			}
+			if _, err := sw.List(rootKey); err != nil {
+				t.Fatalf("could not list configmaps, %v", err)
+			}
+
+			// listed configmaps are used more recently than secret1.
+			lru := sw.(*storageWrapper).quota.lru[0]
+			if front := lru.Front().Value.(*quotaEntry); front.name != "secret1" {
+				t.Errorf("expect secret1 to be the least recently used object, but got %s", front.key.Key())
+			}
+			if back := lru.Back().Value.(*quotaEntry); back.gvr != configmapsGVR {
+				t.Errorf("expect configmap to be the most recently used object, but got %s", back.key.Key())
+			}
+		})
+	}
+}
+
+// blockingDeleteStore blocks Delete until release is closed.
+type blockingDeleteStore struct {
+	storage.Store
+	deleting chan struct{}
+	release  chan struct{}
+}
+
+func (s *blockingDeleteStore) Delete(key storage.Key) error {
+	close(s.deleting)
+	<-s.release
+	return s.Store.Delete(key)
+}
+
+func TestCacheQuotaEvictWithoutLock(t *testing.T) {
+	diskStore, err := disk.NewDiskStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("could not create disk storage, %v", err)
+	}
+	store := &blockingDeleteStore{Store: diskStore, deleting: make(chan struct{}), release: make(chan struct{})}
+	sw, err := NewStorageWrapperWithQuota(store, &util.CacheQuotaConfig{
+		NodeName:       "node1",
+		ResourceQuotas: map[schema.GroupVersionResource]util.QuotaLimit{configmapsGVR: {Objects: 1}},
+	})
+	if err != nil {
+		t.Fatalf("could not create storage wrapper, %v", err)
+	}
+	quota := sw.(*storageWrapper).quota
+	if err := sw.Create(quotaTestKey(t, sw, configmapsGVR, "cm1"), newQuotaTestObject(configmapsGVR, "cm1")); err != nil {
+		t.Fatalf("could not create cm1, %v", err)
+	}
+
+	created := make(chan error)
+	go func() {
+		created <- sw.Create(quotaTestKey(t, sw, configmapsGVR, "cm2"), newQuotaTestObject(configmapsGVR, "cm2"))
+	}()
+	<-store.deleting
+
+	// the lock of cache quota is not held while the evicted object is deleted from storage.
+	touched := make(chan struct{})
+	go func() {
+		quota.touch(quotaTestKey(t, sw, configmapsGVR, "cm2"))
+		close(touched)
+	}()
+	select {
+	case <-touched:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expect cache quota is not locked during eviction")
+	}
+
+	close(store.release)
+	if err := <-created; err != nil {
+		t.Fatalf("could not create cm2, %v", err)
+	}
+	if _, err := diskStore.Get(quotaTestKey(t, sw, configmapsGVR, "cm1")); !errors.Is(err, storage.ErrStorageNotFound) {
+		t.Errorf("expect cm1 to be evicted, but got %v", err)
+	}
+	if usage := quota.resourceUsage[configmapsGVR]; usage == nil || usage.objects != 1 {
+		t.Errorf("expect 1 configmap after eviction, but got %v", usage)
+	}
+}
+
+func TestInMemoryCacheBoundedByQuota(t *testing.T) {
+	store, err := disk.NewDiskStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("could not create disk storage, %v", err)
+	}
+	sw, err := NewStorageWrapperWithQuota(store, &util.CacheQuotaConfig{
+		NodeName:        "node1",
+		ComponentQuotas: map[string]util.QuotaLimit{"kubelet": {Objects: 1}},
+	})
+	if err != nil {
+		t.Fatalf("could not create storage wrapper, %v", err)
+	}
+	cm := NewCacheManager(sw, nil, nil, nil).(*cacheManager)
+
+	leasesGVR := schema.GroupVersionResource{Group: "coordination.k8s.io", Version: "v1", Resource: "leases"}
+	lease := &coordinationv1.Lease{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "coordination.k8s.io/v1", Kind: "Lease"},
+		ObjectMeta: metav1.ObjectMeta{Name: "node2", Namespace: v1.NamespaceNodeLease, ResourceVersion: "1"},
+	}
+	info := &apirequest.RequestInfo{
+		IsResourceRequest: true,
+		Verb:              "get",
+		APIGroup:          leasesGVR.Group,
+		APIVersion:        leasesGVR.Version,
+		Resource:          leasesGVR.Resource,
+		Namespace:         lease.Namespace,
+		Name:              lease.Name,
+	}
+	ctx := apirequest.WithRequestInfo(util.WithClientComponent(context.Background(), "kubelet"), info)
+	inMemoryKey := "leases/kube-node-lease/node2"
+
+	// objects which are not tracked by quota are not kept in memory.
+	if err := cm.updateInMemoryCache(ctx, info, lease); err != nil {
+		t.Fatalf("could not update in-memory cache, %v", err)
+	}
+	if _, ok := cm.inMemoryCache[inMemoryKey]; ok {
+		t.Errorf("expect lease not to be cached in memory before it's stored")
+	}
+
+	leaseKey, _ := sw.KeyFunc(storage.KeyBuildInfo{Component: "kubelet", Resources: "leases", Group: leasesGVR.Group, Version: "v1", Namespace: lease.Namespace, Name: lease.Name})
+	if err := sw.Create(leaseKey, lease); err != nil {
+		t.Fatalf("could not create lease, %v", err)
+	}
+	if err := cm.updateInMemoryCache(ctx, info, lease); err != nil {
+		t.Fatalf("could not update in-memory cache, %v", err)
+	}
+	if _, ok := cm.inMemoryCache[inMemoryKey]; !ok {
+		t.Errorf("expect lease to be cached in memory")
+	}
+
+	// in-memory copy is released together when the object is evicted.
+	if err := sw.Create(quotaTestKey(t, sw, configmapsGVR, "cm1"), newQuotaTestObject(configmapsGVR, "cm1")); err != nil {
+		t.Fatalf("could not create cm1, %v", err)
+	}
+	if _, err := store.Get(leaseKey); !errors.Is(err, storage.ErrStorageNotFound) {
+		t.Errorf("expect lease to be evicted, but got %v", err)
+	}
+	if _, ok := cm.inMemoryCache[inMemoryKey]; ok {
+		t.Errorf("expect in-memory copy of lease to be released")
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cachemanager
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metainternalversionscheme "k8s.io/apimachinery/pkg/apis/meta/internalversion/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+// localWatchChanSize is the size of result channel for each local watcher, a watcher which can
+// not keep up with local writes is stopped, so the client can watch again and resync.
+const localWatchChanSize = 100
+
+var (
+	ErrLocalWatchNotSupported = errors.New("local watch is not supported")
+	ErrLocalWriteNotSupported = errors.New("local write is not supported")
+)
+
+// localWatchers broadcasts synthetic events of objects which are written into local cache while
+// the cloud is unreachable to the watch requests served by local proxy.
+type localWatchers struct {
+	sync.Mutex
+	nextID   int
+	watchers map[int]*localWatcher
+}
+
+type localWatcher struct {
+	sync.Mutex
+	id            int
+	gvr           schema.GroupVersionResource
+	namespace     string
+	labelSelector labels.Selector
+	fieldSelector fields.Selector
+	result        chan watch.Event
+	stopped       bool
+	parent        *localWatchers
+}
+
+func newLocalWatchers() *localWatchers {
+	return &localWatchers{
+		watchers: make(map[int]*localWatcher),
+	}
+}
+
+func (lw *localWatchers) add(w *localWatcher) {
+	lw.Lock()
+	defer lw.Unlock()
+	w.id = lw.nextID
+	w.parent = lw
+	lw.nextID++
+	lw.watchers[w.id] = w
+}
+
+func (lw *localWatchers) remove(id int) {
+	lw.Lock()
+	defer lw.Unlock()
+	delete(lw.watchers, id)
+}
+
+func (lw *localWatchers) broadcast(gvr schema.GroupVersionResource, event watch.Event) {
+	lw.Lock()
+	watchers := make([]*localWatcher, 0, len(lw.watchers))
+	for _, w := range lw.watchers {
+		if w.matches(gvr, event.Object) {
+			watchers = append(watchers, w)
+		}
+	}
+	lw.Unlock()
+
+	for _, w := range watchers {
+		w.send(event)
+	}
+}
+
+func (w *localWatcher) send(event watch.Event) {
+	w.Lock()
+	defer w.Unlock()
+	if w.stopped {
+		return
+	}
+	select {
+	case w.result <- event:
+	default:
+		klog.Warningf("local watcher for %s is too slow, stop it for resync", w.gvr.String())
+		w.stopLocked()
+	}
+}
+
+func (w *localWatcher) matches(gvr schema.GroupVersionResource, obj runtime.Object) bool {
+	if w.gvr != gvr {
+		return false
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	if len(w.namespace) != 0 && w.namespace != accessor.GetNamespace() {
+		return false
+	}
+	if w.labelSelector != nil && !w.labelSelector.Matches(labels.Set(accessor.GetLabels())) {
+		return false
+	}
+	if w.fieldSelector != nil && !w.fieldSelector.Matches(fields.Set{
+		"metadata.name":      accessor.GetName(),
+		"metadata.namespace": accessor.GetNamespace(),
+	}) {
+		return false
+	}
+	return true
+}
+
+// ResultChan implements watch.Interface
+func (w *localWatcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+// Stop implements watch.Interface
+func (w *localWatcher) Stop() {
+	w.Lock()
+	defer w.Unlock()
+	w.stopLocked()
+}
+
+func (w *localWatcher) stopLocked() {
+	if w.stopped {
+		return
+	}
+	w.stopped = true
+	close(w.result)
+	w.parent.remove(w.id)
+}
+
+// WatchLocal returns a watch.Interface which delivers synthetic events of objects written locally
+// for the watch request. only field selectors on metadata.name and metadata.namespace are supported.
+func (cm *cacheManager) WatchLocal(req *http.Request) (watch.Interface, error) {
+	ctx := req.Context()
+	info, ok := apirequest.RequestInfoFrom(ctx)
+	if !ok || info == nil || info.Verb != "watch" {
+		return nil, ErrLocalWatchNotSupported
+	}
+	// partial object metadata can not be converted from synthetic events.
+	if convertGVK, ok := util.ConvertGVKFrom(ctx); ok && convertGVK != nil {
+		return nil, ErrLocalWatchNotSupported
+	}
+
+	opts := metainternalversion.ListOptions{}
+	if err := metainternalversionscheme.ParameterCodec.DecodeParameters(req.URL.Query(), metav1.SchemeGroupVersion, &opts); err != nil {
+		return nil, err
+	}
+	if opts.FieldSelector != nil {
+		for _, r := range opts.FieldSelector.Requirements() {
+			if r.Field != "metadata.name" && r.Field != "metadata.namespace" {
+				return nil, fmt.Errorf("%w for field selector %s", ErrLocalWatchNotSupported, opts.FieldSelector.String())
+			}
+		}
+	}
+
+	w := &localWatcher{
+		gvr: schema.GroupVersionResource{
+			Group:    info.APIGroup,
+			Version:  info.APIVersion,
+			Resource: info.Resource,
+		},
+		namespace:     info.Namespace,
+		labelSelector: opts.LabelSelector,
+		fieldSelector: opts.FieldSelector,
+		result:        make(chan watch.Event, localWatchChanSize),
+	}
+	cm.localWatchers.add(w)
+	return w, nil
+}
+
+// AcceptLocalWrite writes the object of request into local cache while the cloud is unreachable, and
+// delivers a synthetic modified event to the local watchers. currently only node and lease of kubelet
+// which are kept in the in-memory cache can be written locally.
+func (cm *cacheManager) AcceptLocalWrite(req *http.Request, obj runtime.Object) error {
+	ctx := req.Context()
+	info, ok := apirequest.RequestInfoFrom(ctx)
+	if !ok || info == nil || !isInMemoryCache(ctx) {
+		return ErrLocalWriteNotSupported
+	}
+
+	if err := cm.updateInMemoryCache(ctx, info, obj); err != nil {
+		return err
+	}
+
+	cm.localWatchers.broadcast(schema.GroupVersionResource{
+		Group:    info.APIGroup,
+		Version:  info.APIVersion,
+		Resource: info.Resource,
+	}, watch.Event{Type: watch.Modified, Object: obj.DeepCopyObject()})
+	return nil
+}
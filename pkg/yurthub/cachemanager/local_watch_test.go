@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cachemanager
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/endpoints/filters"
+
+	proxyutil "github.com/openyurtio/openyurt/pkg/yurthub/proxy/util"
+)
+
+func TestWatchLocal(t *testing.T) {
+	cm := &cacheManager{
+		inMemoryCache: make(map[string]runtime.Object),
+		localWatchers: newLocalWatchers(),
+	}
+	resolver := newTestRequestInfoResolver()
+	withRequest := func(method, path, userAgent string, fn func(req *http.Request)) {
+		req, _ := http.NewRequest(method, path, nil)
+		req.Header.Set("User-Agent", userAgent)
+		req.Header.Set("Accept", "application/json")
+		var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			fn(req)
+		})
+		handler = proxyutil.WithRequestClientComponent(handler)
+		handler = proxyutil.WithPartialObjectMetadataRequest(handler)
+		handler = filters.WithRequestInfo(handler, resolver)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	testcases := map[string]struct {
+		path        string
+		expectErr   bool
+		expectEvent bool
+	}{
+		"watch node by name": {
+			path:        "/api/v1/nodes?watch=true&fieldSelector=metadata.name%3Dnode1",
+			expectEvent: true,
+		},
+		"watch node with label selector": {
+			path:        "/api/v1/nodes?watch=true&labelSelector=foo%3Dbar",
+			expectEvent: true,
+		},
+		"watch other node": {
+			path: "/api/v1/nodes?watch=true&fieldSelector=metadata.name%3Dnode2",
+		},
+		"watch node with unmatched label selector": {
+			path: "/api/v1/nodes?watch=true&labelSelector=foo%3Dbaz",
+		},
+		"watch pods": {
+			path: "/api/v1/pods?watch=true",
+		},
+		"watch with unsupported field selector": {
+			path:      "/api/v1/nodes?watch=true&fieldSelector=spec.unschedulable%3Dfalse",
+			expectErr: true,
+		},
+	}
+
+	watchers := map[string]watch.Interface{}
+	for k, tc := range testcases {
+		withRequest("GET", tc.path, "kube-proxy", func(req *http.Request) {
+			w, err := cm.WatchLocal(req)
+			if tc.expectErr {
+				if !errors.Is(err, ErrLocalWatchNotSupported) {
+					t.Errorf("%s: expect ErrLocalWatchNotSupported, but got %v", k, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s: could not watch local, %v", k, err)
+			}
+			watchers[k] = w
+		})
+	}
+
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"foo": "bar"}}}
+	withRequest("GET", "/api/v1/nodes/node1", "kubelet", func(req *http.Request) {
+		if err := cm.AcceptLocalWrite(req, node); err != nil {
+			t.Fatalf("could not accept local write, %v", err)
+		}
+	})
+	withRequest("GET", "/api/v1/pods/foo", "kubelet", func(req *http.Request) {
+		if err := cm.AcceptLocalWrite(req, node); !errors.Is(err, ErrLocalWriteNotSupported) {
+			t.Errorf("expect ErrLocalWriteNotSupported for pods, but got %v", err)
+		}
+	})
+
+	for k, w := range watchers {
+		select {
+		case event := <-w.ResultChan():
+			if !testcases[k].expectEvent {
+				t.Errorf("%s: expect no event, but got %v", k, event)
+			} else if event.Type != watch.Modified || event.Object.(*v1.Node).Name != "node1" {
+				t.Errorf("%s: got unexpected event %v", k, event)
+			}
+		default:
+			if testcases[k].expectEvent {
+				t.Errorf("%s: expect event, but got nothing", k)
+			}
+		}
+		w.Stop()
+	}
+	if len(cm.localWatchers.watchers) != 0 {
+		t.Errorf("expect all of local watchers are removed, but got %d", len(cm.localWatchers.watchers))
+	}
+}
+
+func TestSlowLocalWatcherIsStopped(t *testing.T) {
+	nodesGVR := schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
+	lw := newLocalWatchers()
+	w := &localWatcher{gvr: nodesGVR, result: make(chan watch.Event, 1)}
+	lw.add(w)
+
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+	lw.broadcast(nodesGVR, watch.Event{Type: watch.Modified, Object: node})
+	lw.broadcast(nodesGVR, watch.Event{Type: watch.Modified, Object: node})
+
+	if _, ok := <-w.ResultChan(); !ok {
+		t.Errorf("expect the first event is delivered")
+	}
+	if _, ok := <-w.ResultChan(); ok {
+		t.Errorf("expect result channel is closed for slow watcher")
+	}
+	if len(lw.watchers) != 0 {
+		t.Errorf("expect slow watcher is removed")
+	}
+}
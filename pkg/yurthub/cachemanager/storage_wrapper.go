@@ -31,6 +31,7 @@ import (
 
 	"github.com/openyurtio/openyurt/pkg/projectinfo"
 	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
 )
 
 // StorageWrapper is wrapper for storage.Store interface
@@ -46,6 +47,8 @@ type StorageWrapper interface {
 	ListResourceKeysOfComponent(component string, gvr schema.GroupVersionResource) ([]storage.Key, error)
 	ReplaceComponentList(component string, gvr schema.GroupVersionResource, namespace string, contents map[storage.Key]runtime.Object) error
 	DeleteComponentResources(component string) error
+	ListComponents() ([]string, error)
+	ListResourcesOfComponent(component string) ([]schema.GroupVersionResource, error)
 	SaveClusterInfo(key storage.Key, content []byte) error
 	GetClusterInfo(key storage.Key) ([]byte, error)
 	GetStorage() storage.Store
@@ -57,10 +60,27 @@ type storageWrapper struct {
 	store             storage.Store
 	errorKeys         *errorKeys
 	backendSerializer runtime.Serializer
+	quota             *cacheQuota
 }
 
 // NewStorageWrapper create a StorageWrapper object
 func NewStorageWrapper(storage storage.Store) StorageWrapper {
+	return newStorageWrapper(storage)
+}
+
+// NewStorageWrapperWithQuota create a StorageWrapper object which tracks the usage of cache,
+// and evicts cached objects when the usage exceeds the quotas in quotaCfg.
+func NewStorageWrapperWithQuota(storage storage.Store, quotaCfg *util.CacheQuotaConfig) (StorageWrapper, error) {
+	sw := newStorageWrapper(storage)
+	quota, err := newCacheQuota(storage, quotaCfg)
+	if err != nil {
+		return nil, err
+	}
+	sw.quota = quota
+	return sw, nil
+}
+
+func newStorageWrapper(storage storage.Store) *storageWrapper {
 	sw := &storageWrapper{
 		store:             storage,
 		errorKeys:         NewErrorKeys("/var/lib/" + projectinfo.GetHubName() + "/autonomy"),
@@ -106,6 +126,9 @@ func (sw *storageWrapper) Create(key storage.Key, obj runtime.Object) error {
 	}
 
 	sw.errorKeys.del(key.Key())
+	if sw.quota != nil {
+		sw.quota.add(key, buf.Bytes())
+	}
 	return nil
 }
 
@@ -117,6 +140,9 @@ func (sw *storageWrapper) Delete(key storage.Key) error {
 		return err
 	}
 	sw.errorKeys.del(key.Key())
+	if sw.quota != nil {
+		sw.quota.remove(key)
+	}
 	return nil
 }
 
@@ -128,6 +154,9 @@ func (sw *storageWrapper) Get(key storage.Key) (runtime.Object, error) {
 	} else if len(b) == 0 {
 		return nil, nil
 	}
+	if sw.quota != nil {
+		sw.quota.touch(key)
+	}
 
 	//get the gvk from json data
 	gvk, err := json.DefaultMetaFactory.Interpret(b)
@@ -166,6 +195,9 @@ func (sw *storageWrapper) List(key storage.Key) ([]runtime.Object, error) {
 	if len(bb) == 0 {
 		return objects, nil
 	}
+	if sw.quota != nil {
+		sw.quota.touchList(key)
+	}
 	//get the gvk from json data
 	gvk, err := json.DefaultMetaFactory.Interpret(bb[0])
 	if err != nil {
@@ -229,6 +261,9 @@ func (sw *storageWrapper) Update(key storage.Key, obj runtime.Object, rv uint64)
 		return nil, err
 	}
 	sw.errorKeys.del(key.Key())
+	if sw.quota != nil {
+		sw.quota.add(key, buf.Bytes())
+	}
 	return obj, nil
 }
 
@@ -258,6 +293,9 @@ func (sw *storageWrapper) ReplaceComponentList(component string, gvr schema.Grou
 	for key := range objs {
 		sw.errorKeys.del(key.Key())
 	}
+	if sw.quota != nil {
+		sw.quota.replace(component, gvr, namespace, contents)
+	}
 	return nil
 }
 
@@ -272,9 +310,20 @@ func (sw *storageWrapper) DeleteComponentResources(component string) error {
 			sw.errorKeys.del(key)
 		}
 	}
+	if sw.quota != nil {
+		sw.quota.removeComponent(component)
+	}
 	return nil
 }
 
+func (sw *storageWrapper) ListComponents() ([]string, error) {
+	return sw.store.ListComponents()
+}
+
+func (sw *storageWrapper) ListResourcesOfComponent(component string) ([]schema.GroupVersionResource, error) {
+	return sw.store.ListResourcesOfComponent(component)
+}
+
 func (sw *storageWrapper) SaveClusterInfo(key storage.Key, content []byte) error {
 	err := sw.store.SaveClusterInfo(key, content)
 	if err != nil {
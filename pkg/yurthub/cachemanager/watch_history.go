@@ -0,0 +1,243 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cachemanager
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metainternalversionscheme "k8s.io/apimachinery/pkg/apis/meta/internalversion/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+// watchHistory keeps a bounded window of recent events for each watch stream, a watch stream
+// is identified by component, resource, namespace and selectors of watch request. so watch requests
+// with a resourceVersion in the window can be resumed from local history after yurthub reconnects
+// to cloud, and only events after the latest resourceVersion need to be fetched from kube-apiserver.
+// only watches which start from a concrete resourceVersion are recorded, because watches from rv=0
+// or without rv begin with synthetic ADDED events of all objects, which are not real history.
+type watchHistory struct {
+	sync.Mutex
+	size    int
+	windows map[string]*eventWindow
+}
+
+type historyEvent struct {
+	event watch.Event
+	rv    uint64
+}
+
+// eventWindow holds all of events in (startRV, latestRV] of a watch stream. the window is only
+// extended by watches which started within (startRV, latestRV], so events in the window are
+// contiguous, and the window is never reset while any watch is recording into it.
+type eventWindow struct {
+	sync.Mutex
+	size     int
+	startRV  uint64
+	latestRV uint64
+	events   []historyEvent
+	// recorders is the number of watches which are recording events into the window.
+	recorders int
+}
+
+func newWatchHistory(size int) *watchHistory {
+	return &watchHistory{
+		size:    size,
+		windows: make(map[string]*eventWindow),
+	}
+}
+
+// begin returns the event window for recording a new watch of stream which starts from resourceVersion rv,
+// and end of window should be called when watch exits. nil is returned if the watch can not be recorded:
+// 1. rv is 0, which means that watch starts from the most recent resourceVersion with synthetic events.
+// 2. rv is out of the window which is being extended by other watches, events between the window and rv are
+// unknown, and the window can not be reset until all of other watches exit.
+func (h *watchHistory) begin(stream string, rv uint64) *eventWindow {
+	if rv == 0 {
+		return nil
+	}
+
+	h.Lock()
+	defer h.Unlock()
+	w, ok := h.windows[stream]
+	if ok {
+		w.Lock()
+		defer w.Unlock()
+		if rv >= w.startRV && rv <= w.latestRV {
+			w.recorders++
+			return w
+		} else if w.recorders > 0 {
+			return nil
+		}
+	}
+
+	w = &eventWindow{size: h.size, startRV: rv, latestRV: rv, recorders: 1}
+	h.windows[stream] = w
+	return w
+}
+
+func (h *watchHistory) get(stream string) *eventWindow {
+	h.Lock()
+	defer h.Unlock()
+	return h.windows[stream]
+}
+
+// end is called when a watch which records events into window exits.
+func (w *eventWindow) end() {
+	if w == nil {
+		return
+	}
+	w.Lock()
+	defer w.Unlock()
+	w.recorders--
+}
+
+// add appends the event with resourceVersion rv into window, events which have been
+// kept in window by other watches of the same stream are skipped.
+func (w *eventWindow) add(event watch.Event, rv uint64) {
+	w.Lock()
+	defer w.Unlock()
+	if rv <= w.latestRV {
+		return
+	}
+
+	w.latestRV = rv
+	// bookmark only means that there is no event before rv
+	if event.Type == watch.Bookmark {
+		return
+	}
+	if len(w.events) >= w.size {
+		w.startRV = w.events[0].rv
+		w.events = append(w.events[:0], w.events[1:]...)
+	}
+	w.events = append(w.events, historyEvent{event: event, rv: rv})
+}
+
+// addToWatchHistory adds watch event into window if watch history is enabled.
+func addToWatchHistory(w *eventWindow, eventType watch.EventType, obj runtime.Object) {
+	if w == nil {
+		return
+	}
+	rvStr, err := meta.NewAccessor().ResourceVersion(obj)
+	if err != nil {
+		return
+	}
+	rv, err := strconv.ParseUint(rvStr, 10, 64)
+	if err != nil || rv == 0 {
+		return
+	}
+	w.add(watch.Event{Type: eventType, Object: obj}, rv)
+}
+
+// since returns events after resourceVersion rv and the latest resourceVersion of window,
+// ok is false if not all of events after rv are kept in window.
+func (w *eventWindow) since(rv uint64) ([]watch.Event, uint64, bool) {
+	w.Lock()
+	defer w.Unlock()
+	if rv < w.startRV || rv > w.latestRV {
+		return nil, 0, false
+	}
+
+	events := make([]watch.Event, 0, len(w.events))
+	for i := range w.events {
+		if w.events[i].rv > rv {
+			events = append(events, w.events[i].event)
+		}
+	}
+	return events, w.latestRV, true
+}
+
+// WatchEventsSince returns the events in local watch history of watch request which happened after the
+// resourceVersion of request, and the latest resourceVersion of watch stream. ok is false if the request
+// can not be resumed from local watch history.
+func (cm *cacheManager) WatchEventsSince(req *http.Request) ([]watch.Event, string, bool) {
+	if cm.watchHistory == nil {
+		return nil, "", false
+	}
+
+	stream, rv, ok := watchStreamOf(req)
+	if !ok || rv == 0 {
+		return nil, "", false
+	}
+
+	w := cm.watchHistory.get(stream)
+	if w == nil {
+		return nil, "", false
+	}
+	events, latestRV, ok := w.since(rv)
+	if !ok {
+		return nil, "", false
+	}
+	return events, strconv.FormatUint(latestRV, 10), true
+}
+
+// watchStreamOf returns the stream key and the resourceVersion of watch request.
+func watchStreamOf(req *http.Request) (string, uint64, bool) {
+	ctx := req.Context()
+	info, ok := apirequest.RequestInfoFrom(ctx)
+	if !ok || info == nil || info.Verb != "watch" {
+		return "", 0, false
+	}
+
+	opts := metainternalversion.ListOptions{}
+	if err := metainternalversionscheme.ParameterCodec.DecodeParameters(req.URL.Query(), metav1.SchemeGroupVersion, &opts); err != nil {
+		return "", 0, false
+	}
+	// watch list(sendInitialEvents=true) sends synthetic events of all objects at first,
+	// which can not be served from watch history.
+	if opts.SendInitialEvents != nil && *opts.SendInitialEvents {
+		return "", 0, false
+	}
+
+	var rv uint64
+	if len(opts.ResourceVersion) != 0 {
+		var err error
+		if rv, err = strconv.ParseUint(opts.ResourceVersion, 10, 64); err != nil {
+			return "", 0, false
+		}
+	}
+
+	comp, _ := util.TruncatedClientComponentFrom(ctx)
+	gvr := schema.GroupVersionResource{
+		Group:    info.APIGroup,
+		Version:  info.APIVersion,
+		Resource: info.Resource,
+	}
+	if convertGVK, ok := util.ConvertGVKFrom(ctx); ok && convertGVK != nil {
+		gvr, _ = meta.UnsafeGuessKindToResource(*convertGVK)
+		comp = util.AttachConvertGVK(comp, convertGVK)
+	}
+
+	var labelSelector, fieldSelector string
+	if opts.LabelSelector != nil {
+		labelSelector = opts.LabelSelector.String()
+	}
+	if opts.FieldSelector != nil {
+		fieldSelector = opts.FieldSelector.String()
+	}
+	return strings.Join([]string{comp, gvr.String(), info.Namespace, labelSelector, fieldSelector}, "|"), rv, true
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cachemanager
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/endpoints/filters"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/configuration"
+	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
+	proxyutil "github.com/openyurtio/openyurt/pkg/yurthub/proxy/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/disk"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+func newHistoryPod(name, rv string) *v1.Pod {
+	return &v1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			ResourceVersion: rv,
+		},
+		Spec: v1.PodSpec{
+			NodeName: "node1",
+		},
+	}
+}
+
+func eventRVs(t *testing.T, events []watch.Event) []string {
+	rvs := make([]string, 0, len(events))
+	for i := range events {
+		rv, err := meta.NewAccessor().ResourceVersion(events[i].Object)
+		if err != nil {
+			t.Fatalf("could not get resource version, %v", err)
+		}
+		rvs = append(rvs, rv)
+	}
+	return rvs
+}
+
+func TestEventWindow(t *testing.T) {
+	testcases := map[string]struct {
+		startRV   uint64
+		events    []watch.Event
+		sinceRV   uint64
+		expectOK  bool
+		expectRVs []string
+		latestRV  uint64
+	}{
+		"events after rv are kept": {
+			startRV:   1,
+			events:    []watch.Event{{Type: watch.Added, Object: newHistoryPod("mypod1", "2")}, {Type: watch.Modified, Object: newHistoryPod("mypod1", "3")}, {Type: watch.Deleted, Object: newHistoryPod("mypod1", "5")}},
+			sinceRV:   2,
+			expectOK:  true,
+			expectRVs: []string{"3", "5"},
+			latestRV:  5,
+		},
+		"start rv of watch is covered": {
+			startRV:   1,
+			events:    []watch.Event{{Type: watch.Added, Object: newHistoryPod("mypod1", "2")}},
+			sinceRV:   1,
+			expectOK:  true,
+			expectRVs: []string{"2"},
+			latestRV:  2,
+		},
+		"bookmark moves latest rv": {
+			startRV:   1,
+			events:    []watch.Event{{Type: watch.Added, Object: newHistoryPod("mypod1", "2")}, {Type: watch.Bookmark, Object: newHistoryPod("", "8")}},
+			sinceRV:   1,
+			expectOK:  true,
+			expectRVs: []string{"2"},
+			latestRV:  8,
+		},
+		"duplicated events are skipped": {
+			startRV:   1,
+			events:    []watch.Event{{Type: watch.Added, Object: newHistoryPod("mypod1", "3")}, {Type: watch.Added, Object: newHistoryPod("mypod1", "2")}},
+			sinceRV:   1,
+			expectOK:  true,
+			expectRVs: []string{"3"},
+			latestRV:  3,
+		},
+		"evicted events are not covered": {
+			startRV:  1,
+			events:   []watch.Event{{Type: watch.Added, Object: newHistoryPod("mypod1", "2")}, {Type: watch.Added, Object: newHistoryPod("mypod2", "3")}, {Type: watch.Added, Object: newHistoryPod("mypod3", "4")}, {Type: watch.Added, Object: newHistoryPod("mypod4", "5")}},
+			sinceRV:  1,
+			expectOK: false,
+		},
+		"events within size after eviction are covered": {
+			startRV:   1,
+			events:    []watch.Event{{Type: watch.Added, Object: newHistoryPod("mypod1", "2")}, {Type: watch.Added, Object: newHistoryPod("mypod2", "3")}, {Type: watch.Added, Object: newHistoryPod("mypod3", "4")}, {Type: watch.Added, Object: newHistoryPod("mypod4", "5")}},
+			sinceRV:   2,
+			expectOK:  true,
+			expectRVs: []string{"3", "4", "5"},
+			latestRV:  5,
+		},
+		"rv newer than window is not covered": {
+			startRV:  1,
+			events:   []watch.Event{{Type: watch.Added, Object: newHistoryPod("mypod1", "2")}},
+			sinceRV:  3,
+			expectOK: false,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			h := newWatchHistory(3)
+			w := h.begin("kubelet|/v1, Resource=pods|||", tc.startRV)
+			for _, event := range tc.events {
+				addToWatchHistory(w, event.Type, event.Object)
+			}
+
+			events, latestRV, ok := w.since(tc.sinceRV)
+			if ok != tc.expectOK {
+				t.Fatalf("expect ok %v, but got %v", tc.expectOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if rvs := eventRVs(t, events); !reflect.DeepEqual(rvs, tc.expectRVs) {
+				t.Errorf("expect events %v, but got %v", tc.expectRVs, rvs)
+			}
+			if latestRV != tc.latestRV {
+				t.Errorf("expect latest rv %d, but got %d", tc.latestRV, latestRV)
+			}
+		})
+	}
+}
+
+func TestWatchHistoryBegin(t *testing.T) {
+	h := newWatchHistory(10)
+	stream := "kubelet|/v1, Resource=pods|||"
+
+	// watch from the most recent rv is not recorded
+	if w := h.begin(stream, 0); w != nil {
+		t.Errorf("expect watch from rv 0 not to be recorded")
+	}
+
+	w1 := h.begin(stream, 1)
+	addToWatchHistory(w1, watch.Added, newHistoryPod("mypod1", "2"))
+	addToWatchHistory(w1, watch.Added, newHistoryPod("mypod2", "3"))
+
+	// watch resumed from rv in window keeps events
+	w2 := h.begin(stream, 3)
+	if w2 != w1 {
+		t.Errorf("expect watch from rv in window to record into the same window")
+	}
+	if events, _, ok := w2.since(1); !ok || len(events) != 2 {
+		t.Errorf("expect 2 events kept in window, but got %d, %v", len(events), ok)
+	}
+
+	// watch from rv out of window is not recorded while other watches are recording
+	if w := h.begin(stream, 10); w != nil {
+		t.Errorf("expect watch from rv out of window not to be recorded")
+	}
+	addToWatchHistory(w1, watch.Added, newHistoryPod("mypod3", "4"))
+	if events, latestRV, ok := h.get(stream).since(1); !ok || len(events) != 3 || latestRV != 4 {
+		t.Errorf("expect 3 events in window, but got %d events, latest rv %d, %v", len(events), latestRV, ok)
+	}
+
+	// window is reset after all of watches exit
+	w1.end()
+	w2.end()
+	w3 := h.begin(stream, 10)
+	if _, _, ok := w3.since(3); ok {
+		t.Errorf("expect window is reset")
+	}
+	if events, latestRV, ok := w3.since(10); !ok || len(events) != 0 || latestRV != 10 {
+		t.Errorf("expect empty window from rv 10, but got %d events, latest rv %d, %v", len(events), latestRV, ok)
+	}
+}
+
+func TestWatchEventsSince(t *testing.T) {
+	dStorage, err := disk.NewDiskStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create disk storage, %v", err)
+	}
+	sWrapper := NewStorageWrapper(dStorage)
+	serializerM := serializer.NewSerializerManager()
+	fakeSharedInformerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	configManager := configuration.NewConfigurationManager("node1", fakeSharedInformerFactory)
+	yurtCM := NewCacheManagerWithWatchHistory(sWrapper, serializerM, nil, configManager, 10)
+	resolver := newTestRequestInfoResolver()
+
+	serve := func(path string, fn func(req *http.Request)) {
+		req, _ := http.NewRequest("GET", path, nil)
+		req.Header.Set("User-Agent", "kubelet")
+		req.Header.Set("Accept", "application/json")
+		var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ctx := req.Context()
+			reqContentType, _ := util.ReqContentTypeFrom(ctx)
+			fn(req.WithContext(util.WithRespContentType(ctx, reqContentType)))
+		})
+		handler = proxyutil.WithRequestContentType(handler)
+		handler = proxyutil.WithRequestClientComponent(handler)
+		handler = filters.WithRequestInfo(handler, resolver)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	events := []watch.Event{
+		{Type: watch.Added, Object: newHistoryPod("mypod1", "2")},
+		{Type: watch.Modified, Object: newHistoryPod("mypod1", "4")},
+		{Type: watch.Added, Object: newHistoryPod("mypod2", "6")},
+	}
+	serve("/api/v1/namespaces/default/pods?watch=true&resourceVersion=1", func(req *http.Request) {
+		s := serializerM.CreateSerializer("application/json", "", "v1", "pods")
+		pr, pw := io.Pipe()
+		go func() {
+			for i := range events {
+				if _, err := s.WatchEncode(pw, &events[i]); err != nil {
+					t.Errorf("could not encode watch event, %v", err)
+				}
+			}
+			pw.Close()
+		}()
+		if err := yurtCM.CacheResponse(req, io.NopCloser(pr), nil); err != nil && err != io.EOF {
+			t.Errorf("failed to cache response, %v", err)
+		}
+	})
+
+	// synthetic events of watch from the most recent rv are not recorded
+	serve("/api/v1/namespaces/default/pods?watch=true&labelSelector=foo%3Dbar", func(req *http.Request) {
+		s := serializerM.CreateSerializer("application/json", "", "v1", "pods")
+		pr, pw := io.Pipe()
+		go func() {
+			for i := range events {
+				if _, err := s.WatchEncode(pw, &events[i]); err != nil {
+					t.Errorf("could not encode watch event, %v", err)
+				}
+			}
+			pw.Close()
+		}()
+		if err := yurtCM.CacheResponse(req, io.NopCloser(pr), nil); err != nil && err != io.EOF {
+			t.Errorf("failed to cache response, %v", err)
+		}
+	})
+
+	testcases := map[string]struct {
+		path      string
+		expectOK  bool
+		expectRVs []string
+	}{
+		"watch with rv in history": {
+			path:      "/api/v1/namespaces/default/pods?watch=true&resourceVersion=2",
+			expectOK:  true,
+			expectRVs: []string{"4", "6"},
+		},
+		"watch with the latest rv": {
+			path:      "/api/v1/namespaces/default/pods?watch=true&resourceVersion=6",
+			expectOK:  true,
+			expectRVs: []string{},
+		},
+		"watch without rv": {
+			path:     "/api/v1/namespaces/default/pods?watch=true",
+			expectOK: false,
+		},
+		"watch with different selector": {
+			path:     "/api/v1/namespaces/default/pods?watch=true&resourceVersion=2&labelSelector=foo%3Dbar",
+			expectOK: false,
+		},
+	}
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			serve(tc.path, func(req *http.Request) {
+				got, latestRV, ok := yurtCM.WatchEventsSince(req)
+				if ok != tc.expectOK {
+					t.Fatalf("expect ok %v, but got %v", tc.expectOK, ok)
+				}
+				if !ok {
+					return
+				}
+				if rvs := eventRVs(t, got); !reflect.DeepEqual(rvs, tc.expectRVs) {
+					t.Errorf("expect events %v, but got %v", tc.expectRVs, rvs)
+				}
+				if latestRV != "6" {
+					t.Errorf("expect latest rv 6, but got %s", latestRV)
+				}
+			})
+		})
+	}
+}
@@ -117,6 +117,14 @@ func (hcm *yurtHubCertManager) Stop() {
 	hcm.YurtServerCertificateManager.Stop()
 }
 
+// UpdateRemoteServers updates remote servers used by client certificate manager, and client
+// certificate manager which doesn't connect to remote servers is skipped.
+func (hcm *yurtHubCertManager) UpdateRemoteServers(servers []*url.URL) {
+	if updater, ok := hcm.YurtClientCertificateManager.(interface{ UpdateRemoteServers([]*url.URL) }); ok {
+		updater.UpdateRemoteServers(servers)
+	}
+}
+
 func (hcm *yurtHubCertManager) Ready() bool {
 	var errs []error
 	if hcm.GetAPIServerClientCert() == nil {
@@ -24,6 +24,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -71,6 +72,7 @@ type ClientCertificateManagerConfiguration struct {
 
 type yurtHubClientCertManager struct {
 	client                     clientset.Interface
+	serversLock                sync.RWMutex
 	remoteServers              []*url.URL
 	caCertHashes               []string
 	apiServerClientCertManager certificate.Manager
@@ -113,6 +115,19 @@ func NewYurtHubClientCertManager(cfg *ClientCertificateManagerConfiguration) (hu
 	return ycm, nil
 }
 
+// UpdateRemoteServers updates the servers to which certificate signing requests are sent.
+func (ycm *yurtHubClientCertManager) UpdateRemoteServers(servers []*url.URL) {
+	ycm.serversLock.Lock()
+	defer ycm.serversLock.Unlock()
+	ycm.remoteServers = servers
+}
+
+func (ycm *yurtHubClientCertManager) getRemoteServers() []*url.URL {
+	ycm.serversLock.RLock()
+	defer ycm.serversLock.RUnlock()
+	return ycm.remoteServers
+}
+
 func removeDirContents(dir string) error {
 	files, err := os.ReadDir(dir)
 	if err != nil {
@@ -360,7 +375,7 @@ func (ycm *yurtHubClientCertManager) generateCertClientFn(current *tls.Certifica
 	if kubeconfig == nil {
 		return nil, errors.New("kubeconfig for client certificate is not ready")
 	}
-	kubeconfig.Host = findActiveRemoteServer(ycm.remoteServers).String()
+	kubeconfig.Host = findActiveRemoteServer(ycm.getRemoteServers()).String()
 	// re-fix dial for conn management
 	kubeconfig.Dial = ycm.dialer.DialContext
 
@@ -373,7 +388,7 @@ func (ycm *yurtHubClientCertManager) generateCertClientFn(current *tls.Certifica
 
 func (ycm *yurtHubClientCertManager) retrieveHubBootstrapConfig(joinToken string) (*clientcmdapi.Config, error) {
 	// retrieve bootstrap config info from cluster-info configmap by bootstrap token
-	serverAddr := findActiveRemoteServer(ycm.remoteServers).Host
+	serverAddr := findActiveRemoteServer(ycm.getRemoteServers()).Host
 	if cfg, err := token.RetrieveValidatedConfigInfo(ycm.client, &token.BootstrapData{
 		ServerAddr:   serverAddr,
 		JoinToken:    joinToken,
@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/informers"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/apis/apps/v1alpha1"
+	"github.com/openyurtio/openyurt/pkg/yurthub/policy"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+var cachePolicyGVR = v1alpha1.GroupVersion.WithResource("yurthubcachepolicies")
+
+// cacheRule is a rule of YurtHubCachePolicy which is applied to the current node, and the
+// selectors of rule are parsed into requirements for matching requests.
+type cacheRule struct {
+	policy.Rule
+	rule      v1alpha1.CacheRule
+	labelReqs sets.Set[string]
+	fieldReqs sets.Set[string]
+}
+
+// NewConfigurationManagerWithCachePolicy creates a *Manager which also decides whether responses are cached
+// according to YurtHubCachePolicy resources that are applied to the nodepool of current node. YurtHubCachePolicy
+// resources are list/watched by policyInformers, which should be started after the manager is created.
+func NewConfigurationManagerWithCachePolicy(nodeName string, sharedFactory informers.SharedInformerFactory, policyInformers *policy.Informers) *Manager {
+	m := NewConfigurationManager(nodeName, sharedFactory)
+	m.policyInformers = policyInformers
+	m.cachePolicyLister, m.cachePolicySynced = policyInformers.Watch(cachePolicyGVR, m.updateCachePolicies)
+	return m
+}
+
+// CachePolicyFor returns whether the response of request should be cached according to
+// the YurtHubCachePolicy resources, and matched is false if none of rules matches the request.
+func (m *Manager) CachePolicyFor(req *http.Request) (cacheable bool, matched bool) {
+	ctx := req.Context()
+	comp, ok := util.TruncatedClientComponentFrom(ctx)
+	if !ok || len(comp) == 0 {
+		return false, false
+	}
+	info, ok := apirequest.RequestInfoFrom(ctx)
+	if !ok || info == nil || !info.IsResourceRequest {
+		return false, false
+	}
+	// cache rules are not complete before all of policies have been list/watched.
+	if m.cachePolicySynced == nil || !m.cachePolicySynced() {
+		return false, false
+	}
+
+	m.RLock()
+	rules := m.cacheRules
+	m.RUnlock()
+	if len(rules) == 0 {
+		return false, false
+	}
+
+	var labelReqs, fieldReqs sets.Set[string]
+	query := req.URL.Query()
+	for _, r := range rules {
+		if !r.matchesRequest(comp, info) {
+			continue
+		}
+		if labelReqs == nil {
+			labelReqs = labelRequirements(query.Get("labelSelector"))
+			fieldReqs = fieldRequirements(query.Get("fieldSelector"))
+		}
+		if labelReqs.IsSuperset(r.labelReqs) && fieldReqs.IsSuperset(r.fieldReqs) {
+			klog.V(5).Infof("request %s is matched by rule %d of cache policy %s", util.ReqString(req), r.Index, r.Policy)
+			return r.rule.Action != v1alpha1.CacheActionSkip, true
+		}
+	}
+	return false, false
+}
+
+// matchesRequest checks user agent, resource and namespace of request.
+func (r *cacheRule) matchesRequest(comp string, info *apirequest.RequestInfo) bool {
+	if !r.MatchesRequest(comp, info) {
+		return false
+	}
+	return len(r.rule.Namespaces) == 0 || slices.Contains(r.rule.Namespaces, info.Namespace)
+}
+
+// updateCachePolicies rebuilds the cache rules from all YurtHubCachePolicy resources which are applied
+// to the current node. rules are sorted by priority of policy(higher first), name of policy and index of rule.
+func (m *Manager) updateCachePolicies(action string) {
+	objs, err := m.cachePolicyLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("could not list cache policies, %v", err)
+		return
+	}
+
+	poolLabels := m.policyInformers.NodePoolLabels()
+	rules := make([]*cacheRule, 0)
+	for _, obj := range objs {
+		cachePolicy, err := toCachePolicy(obj)
+		if err != nil {
+			klog.Warningf("could not convert cache policy, %v", err)
+			continue
+		}
+		if applied, err := m.policyInformers.AppliedToNodePool(cachePolicy.Spec.NodePools, cachePolicy.Spec.NodePoolSelector, poolLabels); err != nil {
+			klog.Warningf("invalid nodepool selector of cache policy %s, %v", cachePolicy.Name, err)
+			continue
+		} else if !applied {
+			continue
+		}
+
+		for i, rule := range cachePolicy.Spec.Rules {
+			labelReqs, err := parseLabelRequirements(rule.LabelSelector)
+			if err != nil {
+				klog.Warningf("skip rule %d of cache policy %s, invalid label selector, %v", i, cachePolicy.Name, err)
+				continue
+			}
+			fieldReqs, err := parseFieldRequirements(rule.FieldSelector)
+			if err != nil {
+				klog.Warningf("skip rule %d of cache policy %s, invalid field selector, %v", i, cachePolicy.Name, err)
+				continue
+			}
+			resources := make([]policy.Resource, 0, len(rule.Resources))
+			for _, res := range rule.Resources {
+				resources = append(resources, policy.Resource(res))
+			}
+			rules = append(rules, &cacheRule{
+				Rule: policy.Rule{
+					Policy:     cachePolicy.Name,
+					Priority:   cachePolicy.Spec.Priority,
+					Index:      i,
+					UserAgents: rule.UserAgents,
+					Resources:  resources,
+				},
+				rule:      rule,
+				labelReqs: labelReqs,
+				fieldReqs: fieldReqs,
+			})
+		}
+	}
+
+	policy.SortRules(rules)
+
+	klog.Infof("After action %s, %d cache rules from cache policies are applied", action, len(rules))
+	m.Lock()
+	defer m.Unlock()
+	m.cacheRules = rules
+}
+
+func toCachePolicy(obj runtime.Object) (*v1alpha1.YurtHubCachePolicy, error) {
+	switch o := obj.(type) {
+	case *v1alpha1.YurtHubCachePolicy:
+		return o, nil
+	case *unstructured.Unstructured:
+		policy := new(v1alpha1.YurtHubCachePolicy)
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.UnstructuredContent(), policy); err != nil {
+			return nil, err
+		}
+		return policy, nil
+	default:
+		return nil, fmt.Errorf("object(%s) is an unknown type", obj.GetObjectKind().GroupVersionKind().String())
+	}
+}
+
+func parseLabelRequirements(selector string) (sets.Set[string], error) {
+	s, err := labels.Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+	reqs, _ := s.Requirements()
+	set := sets.New[string]()
+	for _, r := range reqs {
+		// a==b is the same as a=b
+		if r.Operator() == selection.DoubleEquals {
+			if normalized, err := labels.NewRequirement(r.Key(), selection.Equals, r.Values().UnsortedList()); err == nil {
+				r = *normalized
+			}
+		}
+		set.Insert(r.String())
+	}
+	return set, nil
+}
+
+func parseFieldRequirements(selector string) (sets.Set[string], error) {
+	s, err := fields.ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	set := sets.New[string]()
+	for _, r := range s.Requirements() {
+		op := r.Operator
+		if op == selection.DoubleEquals {
+			op = selection.Equals
+		}
+		set.Insert(r.Field + string(op) + r.Value)
+	}
+	return set, nil
+}
+
+// labelRequirements returns requirements of label selector in request, invalid selector has no requirements.
+func labelRequirements(selector string) sets.Set[string] {
+	set, err := parseLabelRequirements(selector)
+	if err != nil {
+		return sets.New[string]()
+	}
+	return set
+}
+
+// fieldRequirements returns requirements of field selector in request, invalid selector has no requirements.
+func fieldRequirements(selector string) sets.Set[string] {
+	set, err := parseFieldRequirements(selector)
+	if err != nil {
+		return sets.New[string]()
+	}
+	return set
+}
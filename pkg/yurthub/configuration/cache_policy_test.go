@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/server"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openyurtio/openyurt/pkg/apis/apps/v1alpha1"
+	"github.com/openyurtio/openyurt/pkg/apis/apps/v1beta2"
+	"github.com/openyurtio/openyurt/pkg/yurthub/policy"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+func TestCachePolicyFor(t *testing.T) {
+	policies := []runtime.Object{
+		&v1alpha1.YurtHubCachePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "all-nodes"},
+			Spec: v1alpha1.YurtHubCachePolicySpec{
+				Rules: []v1alpha1.CacheRule{
+					{UserAgents: []string{"kubelet"}, Resources: []v1alpha1.CacheResource{{Resource: "pods"}}, Action: v1alpha1.CacheActionCache},
+				},
+			},
+		},
+		&v1alpha1.YurtHubCachePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "hangzhou"},
+			Spec: v1alpha1.YurtHubCachePolicySpec{
+				NodePools: []string{"hangzhou"},
+				Priority:  10,
+				Rules: []v1alpha1.CacheRule{
+					{UserAgents: []string{"kubelet"}, Resources: []v1alpha1.CacheResource{{Resource: "pods"}}, Namespaces: []string{"kube-system"}, Action: v1alpha1.CacheActionSkip},
+				},
+			},
+		},
+		&v1alpha1.YurtHubCachePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "east"},
+			Spec: v1alpha1.YurtHubCachePolicySpec{
+				NodePoolSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "east"}},
+				Priority:         5,
+				Rules: []v1alpha1.CacheRule{
+					{UserAgents: []string{"*"}, Resources: []v1alpha1.CacheResource{{Resource: "configmaps"}}, LabelSelector: "app==foo", Action: v1alpha1.CacheActionSkip},
+					{UserAgents: []string{"*"}, Resources: []v1alpha1.CacheResource{{Group: "*", Resource: "*"}}, FieldSelector: "spec.nodeName=foo"},
+				},
+			},
+		},
+		&v1alpha1.YurtHubCachePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "beijing"},
+			Spec: v1alpha1.YurtHubCachePolicySpec{
+				NodePools: []string{"beijing"},
+				Priority:  100,
+				Rules: []v1alpha1.CacheRule{
+					{UserAgents: []string{"*"}, Action: v1alpha1.CacheActionSkip},
+				},
+			},
+		},
+	}
+	pool := &v1beta2.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "hangzhou", Labels: map[string]string{"region": "east"}},
+	}
+
+	testcases := map[string]struct {
+		nodePoolName    string
+		comp            string
+		path            string
+		expectCacheable bool
+		expectMatched   bool
+	}{
+		"rule of policy with higher priority is matched": {
+			nodePoolName:  "hangzhou",
+			comp:          "kubelet",
+			path:          "/api/v1/namespaces/kube-system/pods",
+			expectMatched: true,
+		},
+		"rule of policy for all nodes is matched": {
+			nodePoolName:    "hangzhou",
+			comp:            "kubelet",
+			path:            "/api/v1/namespaces/default/pods",
+			expectCacheable: true,
+			expectMatched:   true,
+		},
+		"label selector of request includes requirements of rule": {
+			nodePoolName:  "hangzhou",
+			comp:          "coredns",
+			path:          "/api/v1/namespaces/default/configmaps?labelSelector=tier%3Dweb,app%3Dfoo",
+			expectMatched: true,
+		},
+		"label selector of request doesn't include requirements of rule": {
+			nodePoolName: "hangzhou",
+			comp:         "coredns",
+			path:         "/api/v1/namespaces/default/configmaps?labelSelector=tier%3Dweb",
+		},
+		"field selector of request includes requirements of rule": {
+			nodePoolName:    "hangzhou",
+			comp:            "foo",
+			path:            "/apis/apps/v1/deployments?fieldSelector=spec.nodeName%3D%3Dfoo",
+			expectCacheable: true,
+			expectMatched:   true,
+		},
+		"policies selected by nodepool labels are not applied to other nodepools": {
+			nodePoolName: "shanghai",
+			comp:         "coredns",
+			path:         "/api/v1/namespaces/default/configmaps?labelSelector=app%3Dfoo",
+		},
+		"only policies for all nodes are applied to node without nodepool": {
+			comp:            "kubelet",
+			path:            "/api/v1/namespaces/kube-system/pods",
+			expectCacheable: true,
+			expectMatched:   true,
+		},
+		"policies are not applied to non-resource requests": {
+			nodePoolName: "hangzhou",
+			comp:         "kubelet",
+			path:         "/healthz",
+		},
+	}
+
+	resolver := server.NewRequestInfoResolver(&server.Config{LegacyAPIGroupPrefixes: sets.NewString(server.DefaultLegacyAPIPrefix)})
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			scheme := runtime.NewScheme()
+			v1alpha1.AddToScheme(scheme)
+			v1beta2.AddToScheme(scheme)
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme, append(policies, pool)...)
+			sharedFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 24*time.Hour)
+			policyInformers := policy.NewInformers(dynamicClient, tc.nodePoolName)
+			m := NewConfigurationManagerWithCachePolicy("foo", sharedFactory, policyInformers)
+			policyInformers.Start(stopCh)
+
+			// only the policy for all nodes is applied to nodes which are not in hangzhou nodepool.
+			expectRules := 1
+			if tc.nodePoolName == "hangzhou" {
+				expectRules = 4
+			}
+			if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+				m.RLock()
+				defer m.RUnlock()
+				return m.cachePolicySynced() && len(m.cacheRules) == expectRules, nil
+			}); err != nil {
+				t.Fatalf("expect %d cache rules, but got %d", expectRules, len(m.cacheRules))
+			}
+
+			req, _ := http.NewRequest("GET", tc.path, nil)
+			ctx := util.WithClientComponent(req.Context(), tc.comp)
+			info, _ := resolver.NewRequestInfo(req)
+			req = req.WithContext(apirequest.WithRequestInfo(ctx, info))
+
+			cacheable, matched := m.CachePolicyFor(req)
+			if cacheable != tc.expectCacheable || matched != tc.expectMatched {
+				t.Errorf("expect cacheable %v and matched %v, but got cacheable %v and matched %v", tc.expectCacheable, tc.expectMatched, cacheable, matched)
+			}
+		})
+	}
+}
+
+func TestCachePolicyForBeforeSynced(t *testing.T) {
+	scheme := runtime.NewScheme()
+	v1alpha1.AddToScheme(scheme)
+	policies := []runtime.Object{
+		&v1alpha1.YurtHubCachePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "all-nodes"},
+			Spec: v1alpha1.YurtHubCachePolicySpec{
+				Rules: []v1alpha1.CacheRule{{UserAgents: []string{"*"}, Action: v1alpha1.CacheActionCache}},
+			},
+		},
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme, policies...)
+	sharedFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 24*time.Hour)
+	m := NewConfigurationManagerWithCachePolicy("foo", sharedFactory, policy.NewInformers(dynamicClient, ""))
+
+	req, _ := http.NewRequest("GET", "/api/v1/namespaces/default/pods", nil)
+	resolver := server.NewRequestInfoResolver(&server.Config{LegacyAPIGroupPrefixes: sets.NewString(server.DefaultLegacyAPIPrefix)})
+	info, _ := resolver.NewRequestInfo(req)
+	req = req.WithContext(apirequest.WithRequestInfo(util.WithClientComponent(req.Context(), "kubelet"), info))
+	if _, matched := m.CachePolicyFor(req); matched {
+		t.Errorf("expect no rules matched before cache policies are synced, but got matched")
+	}
+}
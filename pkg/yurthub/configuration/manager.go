@@ -32,6 +32,8 @@ import (
 
 	"github.com/openyurtio/openyurt/cmd/yurthub/app/options"
 	"github.com/openyurtio/openyurt/pkg/projectinfo"
+	"github.com/openyurtio/openyurt/pkg/yurthub/flowcontrol"
+	"github.com/openyurtio/openyurt/pkg/yurthub/policy"
 	"github.com/openyurtio/openyurt/pkg/yurthub/util"
 )
 
@@ -45,7 +47,7 @@ var (
 )
 
 // Manager is used for managing all configurations of Yurthub in yurt-hub-cfg configmap.
-// This configuration configmap includes configurations of cache agents and filters. I'm sure that new
+// This configuration configmap includes configurations of cache agents, filters and flow control. I'm sure that new
 // configurations will be added according to user's new requirements.
 type Manager struct {
 	sync.RWMutex
@@ -54,6 +56,13 @@ type Manager struct {
 	baseKeyToFilters map[string][]string
 	reqKeyToFilters  map[string][]string
 	configMapSynced  cache.InformerSynced
+	flowController   *flowcontrol.Controller
+
+	// following fields are used for YurtHubCachePolicy resources
+	policyInformers   *policy.Informers
+	cachePolicyLister cache.GenericLister
+	cachePolicySynced cache.InformerSynced
+	cacheRules        []*cacheRule
 }
 
 func NewConfigurationManager(nodeName string, sharedFactory informers.SharedInformerFactory) *Manager {
@@ -64,6 +73,7 @@ func NewConfigurationManager(nodeName string, sharedFactory informers.SharedInfo
 		baseKeyToFilters: make(map[string][]string),
 		reqKeyToFilters:  make(map[string][]string),
 		configMapSynced:  configmapInformer.HasSynced,
+		flowController:   flowcontrol.NewController(nodeName),
 	}
 
 	// init cache agents
@@ -131,6 +141,11 @@ func (m *Manager) IsCacheable(comp string) bool {
 	return m.allCacheAgents.HasAny("*", comp)
 }
 
+// FlowController returns the flow controller whose settings are loaded from yurt-hub-cfg configmap.
+func (m *Manager) FlowController() *flowcontrol.Controller {
+	return m.flowController
+}
+
 // FindFiltersFor is used for finding all filters for the specified request.
 // the return value represents all the filter names for the request.
 func (m *Manager) FindFiltersFor(req *http.Request) []string {
@@ -152,6 +167,7 @@ func (m *Manager) addConfigmap(obj interface{}) {
 
 	m.updateCacheAgents(cfg.Data[cacheUserAgentsKey], "add")
 	m.updateFilterSettings(cfg.Data, "add")
+	m.updateFlowControlSettings(cfg.Data, "add")
 }
 
 func (m *Manager) updateConfigmap(oldObj, newObj interface{}) {
@@ -165,11 +181,16 @@ func (m *Manager) updateConfigmap(oldObj, newObj interface{}) {
 	if filterSettingsChanged(oldCfg.Data, newCfg.Data) {
 		m.updateFilterSettings(newCfg.Data, "update")
 	}
+
+	if flowcontrol.SettingsChanged(oldCfg.Data, newCfg.Data) {
+		m.updateFlowControlSettings(newCfg.Data, "update")
+	}
 }
 
 func (m *Manager) deleteConfigmap(obj interface{}) {
 	m.updateCacheAgents("", "delete")
 	m.updateFilterSettings(map[string]string{}, "delete")
+	m.updateFlowControlSettings(map[string]string{}, "delete")
 }
 
 // updateCacheAgents update cache agents
@@ -249,6 +270,19 @@ func (m *Manager) updateFilterSettings(cmData map[string]string, action string)
 	m.reqKeyToFilters = reqKeyToFilters
 }
 
+// updateFlowControlSettings updates settings of flow controller, and invalid settings are ignored.
+func (m *Manager) updateFlowControlSettings(cmData map[string]string, action string) {
+	settings, err := flowcontrol.ParseSettings(cmData)
+	if err != nil {
+		klog.Errorf("After action %s, flow control settings are not updated because of %v", action, err)
+		return
+	}
+
+	klog.Infof("After action %s, the flow control settings are as follows: rate limits %v, priority levels %v, system agents %v",
+		action, settings.RateLimits, settings.Levels, sets.List(settings.SystemAgents))
+	m.flowController.UpdateSettings(settings)
+}
+
 // getKeyByRequest returns reqKey for specified request.
 func getKeyByRequest(req *http.Request) string {
 	var key string
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicconfig
+
+import (
+	"fmt"
+	"net/url"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openyurtio/openyurt/cmd/yurthub/app/options"
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+const (
+	// APIVersion is the version of yurthub configuration file.
+	APIVersion = "hub.openyurt.io/v1alpha1"
+	// Kind is the kind of yurthub configuration file.
+	Kind = "YurtHubConfiguration"
+)
+
+// Configuration is the versioned configuration of yurthub which can be changed at runtime, it's usually
+// mounted from a ConfigMap. fields which are not specified keep the values of startup flags.
+type Configuration struct {
+	metav1.TypeMeta `json:",inline"`
+	// Generation is the version of configuration, and it should be increased for every change.
+	Generation int64 `json:"generation"`
+	// ServerAddr is the addresses of cloud kube-apiservers, the format is: "server1,server2,...".
+	ServerAddr string `json:"serverAddr,omitempty"`
+	// LBMode is the mode of load balancer to connect cloud kube-apiservers.
+	LBMode    string     `json:"lbMode,omitempty"`
+	Heartbeat *Heartbeat `json:"heartbeat,omitempty"`
+	// DisabledResourceFilters are disabled in addition to the filters disabled by startup flags,
+	// filters disabled by startup flags can not be enabled at runtime.
+	DisabledResourceFilters []string `json:"disabledResourceFilters,omitempty"`
+	// PoolScopeResources are in the format of Group/Version/Resource.
+	PoolScopeResources []string `json:"poolScopeResources,omitempty"`
+}
+
+// Heartbeat is the configuration of heartbeats sent to cloud kube-apiservers.
+type Heartbeat struct {
+	FailedRetry      *int `json:"failedRetry,omitempty"`
+	HealthyThreshold *int `json:"healthyThreshold,omitempty"`
+	TimeoutSeconds   *int `json:"timeoutSeconds,omitempty"`
+	IntervalSeconds  *int `json:"intervalSeconds,omitempty"`
+}
+
+// Settings is the effective configuration of yurthub which is applied to components.
+type Settings struct {
+	RemoteServers           []*url.URL
+	LBMode                  string
+	Heartbeat               healthchecker.HeartbeatConfig
+	HeartbeatTimeoutSeconds int
+	DisabledResourceFilters []string
+	PoolScopeResources      []schema.GroupVersionResource
+}
+
+// Load decodes and verifies the configuration in yaml or json format.
+func Load(data []byte) (*Configuration, error) {
+	cfg := &Configuration{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("could not decode configuration, %w", err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("configuration is invalid, %w", err)
+	}
+	return cfg, nil
+}
+
+func (c *Configuration) validate() error {
+	if c.APIVersion != APIVersion || c.Kind != Kind {
+		return fmt.Errorf("apiVersion %q and kind %q are not supported, expect %s and %s", c.APIVersion, c.Kind, APIVersion, Kind)
+	}
+	if c.Generation <= 0 {
+		return fmt.Errorf("generation(%d) should be positive", c.Generation)
+	}
+	if len(c.ServerAddr) != 0 {
+		if _, err := util.ParseRemoteServers(c.ServerAddr); err != nil {
+			return fmt.Errorf("serverAddr is invalid, %w", err)
+		}
+	}
+	if len(c.LBMode) != 0 && !util.IsSupportedLBMode(c.LBMode) {
+		return fmt.Errorf("lb mode(%s) is not supported", c.LBMode)
+	}
+	if c.Heartbeat != nil {
+		for name, value := range map[string]*int{
+			"failedRetry":      c.Heartbeat.FailedRetry,
+			"healthyThreshold": c.Heartbeat.HealthyThreshold,
+			"timeoutSeconds":   c.Heartbeat.TimeoutSeconds,
+			"intervalSeconds":  c.Heartbeat.IntervalSeconds,
+		} {
+			if value != nil && *value <= 0 {
+				return fmt.Errorf("heartbeat %s(%d) should be positive", name, *value)
+			}
+		}
+	}
+	for _, name := range c.DisabledResourceFilters {
+		if _, ok := options.FilterToComponentsResourcesAndVerbs[name]; !ok {
+			return fmt.Errorf("resource filter(%s) is not supported", name)
+		}
+	}
+	if _, err := util.ParseGroupVersionResources(c.PoolScopeResources); err != nil {
+		return fmt.Errorf("poolScopeResources is invalid, %w", err)
+	}
+	return nil
+}
+
+// Merge returns the settings which are overridden by fields specified in configuration.
+func (c *Configuration) Merge(base Settings) (Settings, error) {
+	settings := base
+	if len(c.ServerAddr) != 0 {
+		servers, err := util.ParseRemoteServers(c.ServerAddr)
+		if err != nil {
+			return settings, err
+		}
+		settings.RemoteServers = servers
+	}
+	if len(c.LBMode) != 0 {
+		settings.LBMode = c.LBMode
+	}
+	if c.Heartbeat != nil {
+		if c.Heartbeat.FailedRetry != nil {
+			settings.Heartbeat.FailedRetry = *c.Heartbeat.FailedRetry
+		}
+		if c.Heartbeat.HealthyThreshold != nil {
+			settings.Heartbeat.HealthyThreshold = *c.Heartbeat.HealthyThreshold
+		}
+		if c.Heartbeat.IntervalSeconds != nil {
+			settings.Heartbeat.IntervalSeconds = *c.Heartbeat.IntervalSeconds
+		}
+		if c.Heartbeat.TimeoutSeconds != nil {
+			settings.HeartbeatTimeoutSeconds = *c.Heartbeat.TimeoutSeconds
+		}
+	}
+	if c.DisabledResourceFilters != nil {
+		settings.DisabledResourceFilters = c.DisabledResourceFilters
+	}
+	if c.PoolScopeResources != nil {
+		gvrs, err := util.ParseGroupVersionResources(c.PoolScopeResources)
+		if err != nil {
+			return settings, err
+		}
+		settings.PoolScopeResources = gvrs
+	}
+	return settings, nil
+}
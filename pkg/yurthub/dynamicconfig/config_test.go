@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicconfig
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
+)
+
+const header = `apiVersion: hub.openyurt.io/v1alpha1
+kind: YurtHubConfiguration
+`
+
+func TestLoad(t *testing.T) {
+	testcases := map[string]struct {
+		data    string
+		isErr   bool
+		servers int
+	}{
+		"valid configuration": {
+			data: header + `generation: 1
+serverAddr: https://10.0.0.1:6443,https://10.0.0.2:6443
+lbMode: priority
+heartbeat:
+  intervalSeconds: 5
+disabledResourceFilters: ["servicetopology"]
+poolScopeResources: ["/v1/services"]
+`,
+			servers: 2,
+		},
+		"unknown field": {
+			data:  header + "generation: 1\nfoo: bar\n",
+			isErr: true,
+		},
+		"unsupported kind": {
+			data:  "apiVersion: v1\nkind: ConfigMap\ngeneration: 1\n",
+			isErr: true,
+		},
+		"generation is not set": {
+			data:  header,
+			isErr: true,
+		},
+		"unsupported lb mode": {
+			data:  header + "generation: 1\nlbMode: random\n",
+			isErr: true,
+		},
+		"invalid heartbeat": {
+			data:  header + "generation: 1\nheartbeat:\n  failedRetry: 0\n",
+			isErr: true,
+		},
+		"unknown filter": {
+			data:  header + "generation: 1\ndisabledResourceFilters: [\"foo\"]\n",
+			isErr: true,
+		},
+		"invalid pool scope resource": {
+			data:  header + "generation: 1\npoolScopeResources: [\"services\"]\n",
+			isErr: true,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			cfg, err := Load([]byte(tc.data))
+			if tc.isErr != (err != nil) {
+				t.Fatalf("expect error %v, but got %v", tc.isErr, err)
+			}
+			if err != nil {
+				return
+			}
+			settings, err := cfg.Merge(Settings{})
+			if err != nil {
+				t.Fatalf("could not merge settings, %v", err)
+			}
+			if len(settings.RemoteServers) != tc.servers {
+				t.Errorf("expect %d servers, but got %v", tc.servers, settings.RemoteServers)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := Settings{
+		RemoteServers:           []*url.URL{{Scheme: "https", Host: "10.0.0.1:6443"}},
+		LBMode:                  "rr",
+		Heartbeat:               healthchecker.HeartbeatConfig{FailedRetry: 3, HealthyThreshold: 2, IntervalSeconds: 10},
+		HeartbeatTimeoutSeconds: 2,
+		PoolScopeResources:      []schema.GroupVersionResource{{Version: "v1", Resource: "services"}},
+	}
+	failedRetry, timeout := 5, 4
+	cfg := &Configuration{
+		Generation:         1,
+		LBMode:             "priority",
+		Heartbeat:          &Heartbeat{FailedRetry: &failedRetry, TimeoutSeconds: &timeout},
+		PoolScopeResources: []string{},
+	}
+
+	settings, err := cfg.Merge(base)
+	if err != nil {
+		t.Fatalf("could not merge settings, %v", err)
+	}
+	expect := Settings{
+		RemoteServers:           base.RemoteServers,
+		LBMode:                  "priority",
+		Heartbeat:               healthchecker.HeartbeatConfig{FailedRetry: 5, HealthyThreshold: 2, IntervalSeconds: 10},
+		HeartbeatTimeoutSeconds: 4,
+		PoolScopeResources:      []schema.GroupVersionResource{},
+	}
+	if !reflect.DeepEqual(settings, expect) {
+		t.Errorf("expect settings %+v, but got %+v", expect, settings)
+	}
+}
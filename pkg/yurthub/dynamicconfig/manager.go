@@ -0,0 +1,307 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicconfig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	yurtutil "github.com/openyurtio/openyurt/pkg/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
+	"github.com/openyurtio/openyurt/pkg/yurthub/transport"
+)
+
+const (
+	defaultSyncPeriod   = 5 * time.Second
+	defaultVerifyPeriod = time.Second
+)
+
+// Phase is the phase of configuration file.
+type Phase string
+
+const (
+	// PhaseApplied means the configuration has been applied.
+	PhaseApplied Phase = "Applied"
+	// PhaseVerifying means the configuration has been applied, and yurthub is waiting for cloud
+	// kube-apiserver to be healthy with the new configuration.
+	PhaseVerifying Phase = "Verifying"
+	// PhaseInvalid means the configuration is not applied because it's invalid.
+	PhaseInvalid Phase = "Invalid"
+	// PhaseRolledBack means the configuration has been rolled back because it could not be applied,
+	// or cloud kube-apiserver is unreachable with it.
+	PhaseRolledBack Phase = "RolledBack"
+)
+
+// Status is the status of configuration file.
+type Status struct {
+	// ObservedGeneration is the generation of the latest decoded configuration file.
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// AppliedGeneration is the generation of configuration in use, 0 means only startup flags are used.
+	AppliedGeneration  int64       `json:"appliedGeneration"`
+	Phase              Phase       `json:"phase"`
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// LoadBalancer is the load balancer for cloud kube-apiservers.
+type LoadBalancer interface {
+	UpdateBackends(remoteServers []*url.URL)
+	UpdateMode(mode string)
+}
+
+// FilterUpdater disables resource filters at runtime.
+type FilterUpdater interface {
+	SetDisabledFilters(names []string)
+}
+
+// PoolScopeUpdater updates pool scope resources at runtime.
+type PoolScopeUpdater interface {
+	SetPoolScopeResources(gvrs []schema.GroupVersionResource)
+}
+
+// RemoteServersUpdater updates remote servers at runtime.
+type RemoteServersUpdater interface {
+	UpdateRemoteServers(remoteServers []*url.URL)
+}
+
+// Targets are the components to which settings are applied, and nil targets are skipped.
+type Targets struct {
+	Transport     transport.Interface
+	HealthChecker healthchecker.Interface
+	LoadBalancer  LoadBalancer
+	Filters       FilterUpdater
+	PoolScope     PoolScopeUpdater
+	// ServerUsers are the other components which use remote servers, like certificate manager.
+	ServerUsers []RemoteServersUpdater
+}
+
+// Manager watches the configuration and applies it to yurthub at runtime. when cloud kube-apiserver
+// becomes unreachable after new servers or heartbeat are applied, the previous settings are restored.
+type Manager struct {
+	source          Source
+	rollbackTimeout time.Duration
+	syncPeriod      time.Duration
+	verifyPeriod    time.Duration
+	targets         Targets
+	initial         Settings
+
+	// fields below are only accessed by the sync loop.
+	lastData             []byte
+	applied              Settings
+	rolledBackGeneration int64
+
+	statusLock sync.RWMutex
+	status     Status
+}
+
+// NewManager creates a manager for configuration from source, initial settings come from startup flags,
+// and fields which are not specified in configuration keep the initial values.
+func NewManager(source Source, initial Settings, targets Targets, rollbackTimeout time.Duration) *Manager {
+	return &Manager{
+		source:          source,
+		rollbackTimeout: rollbackTimeout,
+		syncPeriod:      defaultSyncPeriod,
+		verifyPeriod:    defaultVerifyPeriod,
+		targets:         targets,
+		initial:         initial,
+		applied:         initial,
+		status: Status{
+			Phase:              PhaseApplied,
+			Message:            "startup flags are used",
+			LastTransitionTime: metav1.Now(),
+		},
+	}
+}
+
+// Run starts to watch the configuration file.
+func (m *Manager) Run(stopCh <-chan struct{}) {
+	klog.Infof("start to watch yurthub configuration in %s", m.source)
+	go wait.Until(func() {
+		m.sync(stopCh)
+	}, m.syncPeriod, stopCh)
+}
+
+// Status returns the status of configuration file.
+func (m *Manager) Status() Status {
+	m.statusLock.RLock()
+	defer m.statusLock.RUnlock()
+	return m.status
+}
+
+func (m *Manager) sync(stopCh <-chan struct{}) {
+	data, err := m.source.Read()
+	if err != nil {
+		klog.Errorf("could not read yurthub configuration in %s, %v", m.source, err)
+		return
+	}
+	// settings in use are kept when configuration is removed.
+	if data == nil || bytes.Equal(data, m.lastData) {
+		return
+	}
+	m.lastData = data
+
+	status := m.Status()
+	cfg, err := Load(data)
+	if err != nil {
+		klog.Errorf("could not load yurthub configuration in %s, %v", m.source, err)
+		m.setStatus(status.ObservedGeneration, status.AppliedGeneration, PhaseInvalid, err.Error())
+		return
+	}
+
+	switch {
+	case cfg.Generation == m.rolledBackGeneration:
+		err = fmt.Errorf("generation(%d) has been rolled back, generation should be increased for new configuration", cfg.Generation)
+	case cfg.Generation <= status.AppliedGeneration:
+		err = fmt.Errorf("generation(%d) should be greater than applied generation(%d)", cfg.Generation, status.AppliedGeneration)
+	}
+	if err != nil {
+		klog.Errorf("skip yurthub configuration in %s, %v", m.source, err)
+		m.setStatus(cfg.Generation, status.AppliedGeneration, PhaseInvalid, err.Error())
+		return
+	}
+
+	settings, err := cfg.Merge(m.initial)
+	if err != nil {
+		m.setStatus(cfg.Generation, status.AppliedGeneration, PhaseInvalid, err.Error())
+		return
+	}
+	m.apply(cfg.Generation, settings, stopCh)
+}
+
+// apply applies settings of generation, and previous settings are restored if settings could not be applied
+// or cloud kube-apiserver becomes unreachable with them. settings are verified even if cloud kube-apiserver
+// is unreachable before they are applied, so settings which can not fix the connection are rolled back.
+func (m *Manager) apply(generation int64, settings Settings, stopCh <-chan struct{}) {
+	previous := m.applied
+	appliedGeneration := m.Status().AppliedGeneration
+
+	klog.Infof("apply yurthub configuration of generation %d", generation)
+	if err := m.applySettings(previous, settings); err != nil {
+		m.rollback(generation, appliedGeneration, settings, previous, fmt.Sprintf("could not apply configuration, %v", err))
+		return
+	}
+
+	if !yurtutil.IsNil(m.targets.HealthChecker) && (serversChanged(previous, settings) || heartbeatChanged(previous, settings)) {
+		m.setStatus(generation, appliedGeneration, PhaseVerifying, "waiting for cloud kube-apiserver to be healthy")
+		if !m.waitForHealthy(stopCh) {
+			m.rollback(generation, appliedGeneration, settings, previous, fmt.Sprintf("cloud kube-apiserver is unreachable in %v", m.rollbackTimeout))
+			return
+		}
+	}
+
+	m.applied = settings
+	m.setStatus(generation, generation, PhaseApplied, "")
+	klog.Infof("yurthub configuration of generation %d is applied", generation)
+}
+
+func (m *Manager) rollback(generation, appliedGeneration int64, failed, previous Settings, reason string) {
+	klog.Errorf("roll back yurthub configuration of generation %d to generation %d, %s", generation, appliedGeneration, reason)
+	if err := m.applySettings(failed, previous); err != nil {
+		klog.Errorf("could not roll back yurthub configuration to generation %d, %v", appliedGeneration, err)
+	}
+	m.rolledBackGeneration = generation
+	m.setStatus(generation, appliedGeneration, PhaseRolledBack, reason)
+}
+
+// applySettings applies the changed fields from old to new. transport is updated at first,
+// because health checker and load balancer depend on the clients of new servers.
+func (m *Manager) applySettings(old, new Settings) error {
+	transportChanged := serversChanged(old, new) || old.HeartbeatTimeoutSeconds != new.HeartbeatTimeoutSeconds
+	if transportChanged && !yurtutil.IsNil(m.targets.Transport) {
+		if err := m.targets.Transport.UpdateServers(new.RemoteServers, new.HeartbeatTimeoutSeconds); err != nil {
+			return err
+		}
+	}
+
+	if hc := m.targets.HealthChecker; !yurtutil.IsNil(hc) {
+		if serversChanged(old, new) {
+			hc.UpdateBackends(new.RemoteServers)
+		}
+		// probers are recreated when heartbeat timeout is changed, so they use the new clients.
+		if heartbeatChanged(old, new) {
+			if updater, ok := hc.(healthchecker.HeartbeatUpdater); ok {
+				updater.UpdateHeartbeat(new.Heartbeat)
+			}
+		}
+	}
+
+	if serversChanged(old, new) {
+		for _, user := range m.targets.ServerUsers {
+			user.UpdateRemoteServers(new.RemoteServers)
+		}
+	}
+
+	if lb := m.targets.LoadBalancer; !yurtutil.IsNil(lb) {
+		if serversChanged(old, new) {
+			lb.UpdateBackends(new.RemoteServers)
+		}
+		if old.LBMode != new.LBMode {
+			lb.UpdateMode(new.LBMode)
+		}
+	}
+
+	if !slices.Equal(old.DisabledResourceFilters, new.DisabledResourceFilters) && !yurtutil.IsNil(m.targets.Filters) {
+		m.targets.Filters.SetDisabledFilters(new.DisabledResourceFilters)
+	}
+
+	if !slices.Equal(old.PoolScopeResources, new.PoolScopeResources) && !yurtutil.IsNil(m.targets.PoolScope) {
+		m.targets.PoolScope.SetPoolScopeResources(new.PoolScopeResources)
+	}
+	return nil
+}
+
+func (m *Manager) waitForHealthy(stopCh <-chan struct{}) bool {
+	ctx, cancel := context.WithTimeout(wait.ContextForChannel(stopCh), m.rollbackTimeout)
+	defer cancel()
+	err := wait.PollUntilContextCancel(ctx, m.verifyPeriod, true, func(context.Context) (bool, error) {
+		return m.targets.HealthChecker.IsHealthy(), nil
+	})
+	return err == nil
+}
+
+func (m *Manager) setStatus(observedGeneration, appliedGeneration int64, phase Phase, message string) {
+	m.statusLock.Lock()
+	defer m.statusLock.Unlock()
+	if m.status.Phase != phase {
+		m.status.LastTransitionTime = metav1.Now()
+	}
+	m.status.ObservedGeneration = observedGeneration
+	m.status.AppliedGeneration = appliedGeneration
+	m.status.Phase = phase
+	m.status.Message = message
+}
+
+func heartbeatChanged(old, new Settings) bool {
+	return old.Heartbeat != new.Heartbeat || old.HeartbeatTimeoutSeconds != new.HeartbeatTimeoutSeconds
+}
+
+// serversChanged returns true if remote servers of settings are different.
+func serversChanged(old, new Settings) bool {
+	return !slices.EqualFunc(old.RemoteServers, new.RemoteServers, func(a, b *url.URL) bool {
+		return a.String() == b.String()
+	})
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicconfig
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	fakeHealthChecker "github.com/openyurtio/openyurt/pkg/yurthub/healthchecker/fake"
+	"github.com/openyurtio/openyurt/pkg/yurthub/transport"
+)
+
+type fakeLoadBalancer struct {
+	backends []*url.URL
+	mode     string
+}
+
+func (lb *fakeLoadBalancer) UpdateBackends(remoteServers []*url.URL) {
+	lb.backends = remoteServers
+}
+
+func (lb *fakeLoadBalancer) UpdateMode(mode string) {
+	lb.mode = mode
+}
+
+type fakeUpdater struct {
+	filters   []string
+	resources []schema.GroupVersionResource
+	servers   []*url.URL
+}
+
+func (f *fakeUpdater) UpdateRemoteServers(remoteServers []*url.URL) {
+	f.servers = remoteServers
+}
+
+func (f *fakeUpdater) SetDisabledFilters(names []string) {
+	f.filters = names
+}
+
+func (f *fakeUpdater) SetPoolScopeResources(gvrs []schema.GroupVersionResource) {
+	f.resources = gvrs
+}
+
+func TestSync(t *testing.T) {
+	server := &url.URL{Scheme: "https", Host: "10.0.0.1:6443"}
+	type step struct {
+		data          string
+		phase         Phase
+		observed      int64
+		applied       int64
+		lbMode        string
+		backend       string
+		filters       []string
+		poolResources int
+	}
+	testcases := map[string]struct {
+		unhealthy bool
+		steps     []step
+	}{
+		"apply lb mode, filters and pool scope resources": {
+			steps: []step{
+				{
+					data:          header + "generation: 1\nlbMode: priority\ndisabledResourceFilters: [\"servicetopology\"]\npoolScopeResources: [\"/v1/services\", \"discovery.k8s.io/v1/endpointslices\"]\n",
+					phase:         PhaseApplied,
+					observed:      1,
+					applied:       1,
+					lbMode:        "priority",
+					filters:       []string{"servicetopology"},
+					poolResources: 2,
+				},
+				{
+					// fields which are removed from configuration are restored to startup flags.
+					data:     header + "generation: 2\n",
+					phase:    PhaseApplied,
+					observed: 2,
+					applied:  2,
+					lbMode:   "rr",
+					filters:  nil,
+				},
+			},
+		},
+		"invalid configuration is not applied": {
+			steps: []step{
+				{
+					data:  header + "generation: 1\nlbMode: random\n",
+					phase: PhaseInvalid,
+				},
+			},
+		},
+		"generation should be increased": {
+			steps: []step{
+				{
+					data:     header + "generation: 2\nlbMode: priority\n",
+					phase:    PhaseApplied,
+					observed: 2,
+					applied:  2,
+					lbMode:   "priority",
+				},
+				{
+					data:     header + "generation: 2\nlbMode: latency-aware\n",
+					phase:    PhaseInvalid,
+					observed: 2,
+					applied:  2,
+					lbMode:   "priority",
+				},
+			},
+		},
+		"roll back when cloud kube-apiserver is unreachable with new servers": {
+			steps: []step{
+				{
+					// new servers are unhealthy in fake health checker.
+					data:     header + "generation: 1\nserverAddr: https://10.0.0.2:6443\n",
+					phase:    PhaseRolledBack,
+					observed: 1,
+					applied:  0,
+					lbMode:   "rr",
+					backend:  server.Host,
+				},
+				{
+					data:     header + "generation: 1\nserverAddr: https://10.0.0.3:6443\n",
+					phase:    PhaseInvalid,
+					observed: 1,
+					applied:  0,
+					lbMode:   "rr",
+					backend:  server.Host,
+				},
+			},
+		},
+		"roll back new servers even if cloud kube-apiserver is unreachable before": {
+			unhealthy: true,
+			steps: []step{
+				{
+					data:     header + "generation: 1\nserverAddr: https://10.0.0.2:6443\n",
+					phase:    PhaseRolledBack,
+					observed: 1,
+					applied:  0,
+					lbMode:   "rr",
+					backend:  server.Host,
+				},
+			},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			lb := &fakeLoadBalancer{backends: []*url.URL{server}, mode: "rr"}
+			updater := &fakeUpdater{}
+			targets := Targets{
+				Transport:     transport.NewFakeTransportManager(http.StatusOK, map[string]kubernetes.Interface{}),
+				HealthChecker: fakeHealthChecker.NewFakeChecker(map[*url.URL]bool{server: !tc.unhealthy}),
+				LoadBalancer:  lb,
+				Filters:       updater,
+				PoolScope:     updater,
+				ServerUsers:   []RemoteServersUpdater{updater},
+			}
+			initial := Settings{
+				RemoteServers: []*url.URL{server},
+				LBMode:        "rr",
+			}
+			m := NewManager(NewFileSource(path), initial, targets, 100*time.Millisecond)
+			m.verifyPeriod = 10 * time.Millisecond
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			for i, s := range tc.steps {
+				if err := os.WriteFile(path, []byte(s.data), 0600); err != nil {
+					t.Fatalf("could not write configuration file, %v", err)
+				}
+				m.sync(stopCh)
+
+				status := m.Status()
+				if status.Phase != s.phase || status.ObservedGeneration != s.observed || status.AppliedGeneration != s.applied {
+					t.Errorf("step %d: expect phase %s, observed generation %d and applied generation %d, but got %+v", i, s.phase, s.observed, s.applied, status)
+				}
+				if len(s.lbMode) != 0 && lb.mode != s.lbMode {
+					t.Errorf("step %d: expect lb mode %s, but got %s", i, s.lbMode, lb.mode)
+				}
+				if len(s.backend) != 0 && (len(lb.backends) != 1 || lb.backends[0].Host != s.backend) {
+					t.Errorf("step %d: expect backend %s, but got %v", i, s.backend, lb.backends)
+				}
+				if len(s.backend) != 0 && (len(updater.servers) != 1 || updater.servers[0].Host != s.backend) {
+					t.Errorf("step %d: expect server %s is used by server users, but got %v", i, s.backend, updater.servers)
+				}
+				if !slices.Equal(updater.filters, s.filters) {
+					t.Errorf("step %d: expect disabled filters %v, but got %v", i, s.filters, updater.filters)
+				}
+				if len(updater.resources) != s.poolResources {
+					t.Errorf("step %d: expect %d pool scope resources, but got %v", i, s.poolResources, updater.resources)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigMapSource(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	source := NewConfigMapSource(corelisters.NewConfigMapLister(indexer), "kube-system", "yurt-hub-config")
+
+	if data, err := source.Read(); data != nil || err != nil {
+		t.Errorf("expect no configuration when configmap doesn't exist, but got %q, %v", string(data), err)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "yurt-hub-config"},
+		Data:       map[string]string{"foo": "bar"},
+	}
+	indexer.Add(cm)
+	if data, err := source.Read(); data != nil || err != nil {
+		t.Errorf("expect no configuration when %s is not in configmap, but got %q, %v", ConfigMapDataKey, string(data), err)
+	}
+
+	cm.Data[ConfigMapDataKey] = header + "generation: 1\n"
+	indexer.Update(cm)
+	if data, err := source.Read(); string(data) != cm.Data[ConfigMapDataKey] || err != nil {
+		t.Errorf("expect configuration %q, but got %q, %v", cm.Data[ConfigMapDataKey], string(data), err)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// ConfigMapDataKey is the key of configuration in the data of ConfigMap.
+const ConfigMapDataKey = "config.yaml"
+
+// Source provides the content of configuration.
+type Source interface {
+	// Read returns the content of configuration, and nil content is returned if configuration doesn't exist.
+	Read() ([]byte, error)
+	String() string
+}
+
+type fileSource struct {
+	path string
+}
+
+// NewFileSource creates a source which reads configuration from the file at path.
+func NewFileSource(path string) Source {
+	return &fileSource{path: path}
+}
+
+func (s *fileSource) Read() ([]byte, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+func (s *fileSource) String() string {
+	return fmt.Sprintf("file %s", s.path)
+}
+
+type configMapSource struct {
+	lister    corelisters.ConfigMapLister
+	namespace string
+	name      string
+}
+
+// NewConfigMapSource creates a source which reads configuration from the config.yaml key of ConfigMap,
+// so configuration can be changed without mounting ConfigMap into yurthub.
+func NewConfigMapSource(lister corelisters.ConfigMapLister, namespace, name string) Source {
+	return &configMapSource{lister: lister, namespace: namespace, name: name}
+}
+
+func (s *configMapSource) Read() ([]byte, error) {
+	cm, err := s.lister.ConfigMaps(s.namespace).Get(s.name)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	data, ok := cm.Data[ConfigMapDataKey]
+	if !ok {
+		return nil, nil
+	}
+	return []byte(data), nil
+}
+
+func (s *configMapSource) String() string {
+	return fmt.Sprintf("configmap %s/%s", s.namespace, s.name)
+}
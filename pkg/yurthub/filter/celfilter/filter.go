@@ -0,0 +1,265 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celfilter
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/apis/apps/v1alpha1"
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+)
+
+const (
+	resultDropped      = "dropped"
+	resultPatched      = "patched"
+	resultUnmatched    = "unmatched"
+	resultError        = "error"
+	resultCostExceeded = "cost-exceeded"
+)
+
+var jsonValueType = reflect.TypeOf(&structpb.Value{})
+
+// celFilter executes rules on objects in order. the rule is skipped for the object when
+// it could not be evaluated, so objects are never broken by invalid rules.
+type celFilter struct {
+	rules []*filterRule
+	vars  map[string]interface{}
+}
+
+func (f *celFilter) Name() string {
+	return FilterName
+}
+
+func (f *celFilter) Filter(obj runtime.Object, _ <-chan struct{}) runtime.Object {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return obj
+	}
+
+	var content map[string]interface{}
+	patched := false
+	for _, r := range f.rules {
+		if len(r.rule.Namespaces) != 0 && !slices.Contains(r.rule.Namespaces, accessor.GetNamespace()) {
+			continue
+		}
+		if content == nil {
+			if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+				klog.Errorf("could not convert %s/%s to unstructured for filter policies, %v", accessor.GetNamespace(), accessor.GetName(), err)
+				return obj
+			}
+		}
+
+		newContent, result, err := r.evaluate(content, f.vars)
+		metrics.Metrics.IncFilterRuleEvaluations(r.Policy, r.rule.Name, result)
+		if err != nil {
+			klog.Warningf("skip rule %s of filter policy %s for %s/%s, %v", r.rule.Name, r.Policy, accessor.GetNamespace(), accessor.GetName(), err)
+			continue
+		}
+
+		switch result {
+		case resultDropped:
+			klog.V(4).Infof("%s/%s is dropped by rule %s of filter policy %s", accessor.GetNamespace(), accessor.GetName(), r.rule.Name, r.Policy)
+			return nil
+		case resultPatched:
+			content = newContent
+			patched = true
+		}
+	}
+
+	if !patched {
+		return obj
+	}
+	newObj, err := fromUnstructured(obj, content)
+	if err != nil {
+		klog.Errorf("could not convert patched %s/%s from unstructured, %v", accessor.GetNamespace(), accessor.GetName(), err)
+		return obj
+	}
+	return newObj
+}
+
+// evaluate evaluates rule on content of object, and returns patched content and the result of evaluation.
+// content is not modified, because patches are applied on a copy of it.
+func (r *filterRule) evaluate(content map[string]interface{}, vars map[string]interface{}) (map[string]interface{}, string, error) {
+	activation := map[string]interface{}{"object": content}
+	for k, v := range vars {
+		activation[k] = v
+	}
+
+	if r.condition != nil {
+		val, _, err := r.condition.Eval(activation)
+		if err != nil {
+			return nil, errorResult(err), fmt.Errorf("could not evaluate condition, %w", err)
+		}
+		matched, ok := val.(types.Bool)
+		if !ok {
+			return nil, resultError, fmt.Errorf("condition returns %s instead of bool", val.Type().TypeName())
+		}
+		if !matched {
+			return nil, resultUnmatched, nil
+		}
+	}
+
+	if r.rule.Action == v1alpha1.FilterActionDrop {
+		return nil, resultDropped, nil
+	}
+
+	// values of all patches are evaluated before any patch of rule is applied.
+	values := make([]interface{}, len(r.patches))
+	for i, p := range r.patches {
+		if p.value == nil {
+			continue
+		}
+		val, _, err := p.value.Eval(activation)
+		if err != nil {
+			return nil, errorResult(err), fmt.Errorf("could not evaluate value of patch %d, %w", i, err)
+		}
+		if values[i], err = toNative(val); err != nil {
+			return nil, resultError, fmt.Errorf("could not convert value of patch %d, %w", i, err)
+		}
+	}
+
+	newContent := runtime.DeepCopyJSON(content)
+	for i, p := range r.patches {
+		if _, err := patchField(newContent, p.path, values[i], p.value == nil); err != nil {
+			return nil, resultError, fmt.Errorf("could not apply patch %d, %w", i, err)
+		}
+	}
+	return newContent, resultPatched, nil
+}
+
+func errorResult(err error) string {
+	var cancelled interpreter.EvalCancelledError
+	if errors.As(err, &cancelled) && cancelled.Cause == interpreter.CostLimitExceeded {
+		return resultCostExceeded
+	}
+	return resultError
+}
+
+// toNative converts the result of expression into json value which can be set into unstructured object.
+func toNative(val ref.Val) (interface{}, error) {
+	jsonValue, err := val.ConvertToNative(jsonValueType)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeNumbers(jsonValue.(*structpb.Value).AsInterface()), nil
+}
+
+// normalizeNumbers converts integral float64 into int64, because all numbers are float64 in json
+// value and integers of unstructured objects are int64.
+func normalizeNumbers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case float64:
+		if t == math.Trunc(t) && math.Abs(t) < math.MaxInt64 {
+			return int64(t)
+		}
+	case map[string]interface{}:
+		for k := range t {
+			t[k] = normalizeNumbers(t[k])
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = normalizeNumbers(t[i])
+		}
+	}
+	return v
+}
+
+// patchField sets or removes the field referenced by path in obj, and returns the patched obj.
+// intermediate objects are created for setting field, and "-" appends value to an array.
+func patchField(obj interface{}, path []string, value interface{}, remove bool) (interface{}, error) {
+	token, last := path[0], len(path) == 1
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		if last {
+			if remove {
+				delete(o, token)
+			} else {
+				o[token] = value
+			}
+			return o, nil
+		}
+		child, ok := o[token]
+		if !ok || child == nil {
+			if remove {
+				return o, nil
+			}
+			child = map[string]interface{}{}
+		}
+		child, err := patchField(child, path[1:], value, remove)
+		if err != nil {
+			return nil, err
+		}
+		o[token] = child
+		return o, nil
+	case []interface{}:
+		if token == "-" && last && !remove {
+			return append(o, value), nil
+		}
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 || index >= len(o) {
+			return nil, fmt.Errorf("index %s is out of range of array with %d items", token, len(o))
+		}
+		if last {
+			if remove {
+				return append(o[:index], o[index+1:]...), nil
+			}
+			o[index] = value
+			return o, nil
+		}
+		child, err := patchField(o[index], path[1:], value, remove)
+		if err != nil {
+			return nil, err
+		}
+		o[index] = child
+		return o, nil
+	default:
+		return nil, fmt.Errorf("field %s is not in an object or array", token)
+	}
+}
+
+// fromUnstructured converts content into an object with the same type of obj.
+func fromUnstructured(obj runtime.Object, content map[string]interface{}) (runtime.Object, error) {
+	if _, ok := obj.(*unstructured.Unstructured); ok {
+		return &unstructured.Unstructured{Object: content}, nil
+	}
+	t := reflect.TypeOf(obj)
+	if t.Kind() != reflect.Pointer {
+		return nil, fmt.Errorf("object(%s) is not a pointer", t)
+	}
+	newObj, ok := reflect.New(t.Elem()).Interface().(runtime.Object)
+	if !ok {
+		return nil, fmt.Errorf("object(%s) is not a runtime.Object", t)
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, newObj); err != nil {
+		return nil, err
+	}
+	return newObj, nil
+}
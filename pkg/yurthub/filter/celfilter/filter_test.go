@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celfilter
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/openyurtio/openyurt/pkg/apis/apps/v1alpha1"
+)
+
+func newTestFilter(t *testing.T, rules ...v1alpha1.FilterRule) *celFilter {
+	env, err := newEnv()
+	if err != nil {
+		t.Fatalf("could not create cel environment, %v", err)
+	}
+	m := &Manager{env: env}
+	f := &celFilter{
+		vars: map[string]interface{}{"nodeName": "node1", "nodePoolName": "hangzhou"},
+	}
+	for i := range rules {
+		r, err := m.compile(rules[i])
+		if err != nil {
+			t.Fatalf("could not compile rule %s, %v", rules[i].Name, err)
+		}
+		r.Policy = "test"
+		f.rules = append(f.rules, r)
+	}
+	return f
+}
+
+func newService(namespace, name string, svcType corev1.ServiceType) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:        svcType,
+			ExternalIPs: []string{"1.1.1.1"},
+			Ports:       []corev1.ServicePort{{Port: 80}},
+		},
+	}
+}
+
+func TestFilter(t *testing.T) {
+	costLimit := int64(1)
+	testcases := map[string]struct {
+		rules  []v1alpha1.FilterRule
+		obj    runtime.Object
+		expect runtime.Object
+	}{
+		"drop object matched by condition": {
+			rules: []v1alpha1.FilterRule{
+				{Name: "drop-nodeport", Condition: "object.spec.type == 'NodePort'", Action: v1alpha1.FilterActionDrop},
+			},
+			obj:    newService("default", "foo", corev1.ServiceTypeNodePort),
+			expect: nil,
+		},
+		"object unmatched by condition is not changed": {
+			rules: []v1alpha1.FilterRule{
+				{Name: "drop-nodeport", Condition: "object.spec.type == 'NodePort'", Action: v1alpha1.FilterActionDrop},
+			},
+			obj:    newService("default", "foo", corev1.ServiceTypeClusterIP),
+			expect: newService("default", "foo", corev1.ServiceTypeClusterIP),
+		},
+		"object in other namespaces is not changed": {
+			rules: []v1alpha1.FilterRule{
+				{Name: "drop-all", Namespaces: []string{"kube-system"}, Action: v1alpha1.FilterActionDrop},
+			},
+			obj:    newService("default", "foo", corev1.ServiceTypeClusterIP),
+			expect: newService("default", "foo", corev1.ServiceTypeClusterIP),
+		},
+		"patch fields of typed object": {
+			rules: []v1alpha1.FilterRule{
+				{
+					Name: "rewrite",
+					Patches: []v1alpha1.FieldPatch{
+						{Path: "/spec/externalIPs"},
+						{Path: "/metadata/annotations/foo"},
+						{Path: "/metadata/labels/node", Value: "nodeName + '.' + nodePoolName"},
+						{Path: "/spec/ports/0/port", Value: "object.spec.ports[0].port + 8000"},
+					},
+				},
+			},
+			obj: newService("default", "foo", corev1.ServiceTypeClusterIP),
+			expect: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "foo",
+					Annotations: map[string]string{},
+					Labels:      map[string]string{"node": "node1.hangzhou"},
+				},
+				Spec: corev1.ServiceSpec{
+					Type:  corev1.ServiceTypeClusterIP,
+					Ports: []corev1.ServicePort{{Port: 8080}},
+				},
+			},
+		},
+		"rules are applied in order": {
+			rules: []v1alpha1.FilterRule{
+				{Name: "to-nodeport", Patches: []v1alpha1.FieldPatch{{Path: "/spec/type", Value: "'NodePort'"}}},
+				{Name: "drop-nodeport", Condition: "object.spec.type == 'NodePort'", Action: v1alpha1.FilterActionDrop},
+			},
+			obj:    newService("default", "foo", corev1.ServiceTypeClusterIP),
+			expect: nil,
+		},
+		"patch fields of unstructured object": {
+			rules: []v1alpha1.FilterRule{
+				{
+					Name:      "rewrite",
+					Condition: "has(object.spec.replicas)",
+					Patches:   []v1alpha1.FieldPatch{{Path: "/spec/tags/-", Value: "{'edge': true}"}},
+				},
+			},
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"kind":       "Foo",
+				"metadata":   map[string]interface{}{"name": "foo"},
+				"spec":       map[string]interface{}{"replicas": int64(1), "tags": []interface{}{}},
+			}},
+			expect: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"kind":       "Foo",
+				"metadata":   map[string]interface{}{"name": "foo"},
+				"spec":       map[string]interface{}{"replicas": int64(1), "tags": []interface{}{map[string]interface{}{"edge": true}}},
+			}},
+		},
+		"rule is skipped when cost limit is exceeded": {
+			rules: []v1alpha1.FilterRule{
+				{Name: "drop-nodeport", Condition: "object.spec.type == 'NodePort'", Action: v1alpha1.FilterActionDrop, CostLimit: &costLimit},
+			},
+			obj:    newService("default", "foo", corev1.ServiceTypeNodePort),
+			expect: newService("default", "foo", corev1.ServiceTypeNodePort),
+		},
+		"rule is skipped when condition could not be evaluated": {
+			rules: []v1alpha1.FilterRule{
+				{Name: "drop", Condition: "object.spec.foo == 'bar'", Action: v1alpha1.FilterActionDrop},
+			},
+			obj:    newService("default", "foo", corev1.ServiceTypeNodePort),
+			expect: newService("default", "foo", corev1.ServiceTypeNodePort),
+		},
+		"rule is skipped when patch could not be applied": {
+			rules: []v1alpha1.FilterRule{
+				{Name: "rewrite", Patches: []v1alpha1.FieldPatch{{Path: "/spec/type", Value: "'NodePort'"}, {Path: "/spec/ports/3/port", Value: "80"}}},
+			},
+			obj:    newService("default", "foo", corev1.ServiceTypeClusterIP),
+			expect: newService("default", "foo", corev1.ServiceTypeClusterIP),
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			f := newTestFilter(t, tc.rules...)
+			result := f.Filter(tc.obj, nil)
+			if tc.expect == nil {
+				if result != nil {
+					t.Errorf("expect object is dropped, but got %v", result)
+				}
+				return
+			}
+			if !reflect.DeepEqual(result, tc.expect) {
+				t.Errorf("expect object %#v, but got %#v", tc.expect, result)
+			}
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	testcases := map[string]struct {
+		rule  v1alpha1.FilterRule
+		isErr bool
+	}{
+		"valid drop rule": {
+			rule: v1alpha1.FilterRule{Name: "drop", Condition: "object.metadata.name.startsWith('foo')", Action: v1alpha1.FilterActionDrop},
+		},
+		"invalid condition": {
+			rule:  v1alpha1.FilterRule{Name: "drop", Condition: "object.metadata.name ==", Action: v1alpha1.FilterActionDrop},
+			isErr: true,
+		},
+		"condition doesn't return bool": {
+			rule:  v1alpha1.FilterRule{Name: "drop", Condition: "nodeName", Action: v1alpha1.FilterActionDrop},
+			isErr: true,
+		},
+		"patch rule without patches": {
+			rule:  v1alpha1.FilterRule{Name: "patch", Action: v1alpha1.FilterActionPatch},
+			isErr: true,
+		},
+		"invalid path of patch": {
+			rule:  v1alpha1.FilterRule{Name: "patch", Patches: []v1alpha1.FieldPatch{{Path: "spec"}}},
+			isErr: true,
+		},
+		"invalid value of patch": {
+			rule:  v1alpha1.FilterRule{Name: "patch", Patches: []v1alpha1.FieldPatch{{Path: "/spec", Value: "foo("}}},
+			isErr: true,
+		},
+	}
+
+	env, err := newEnv()
+	if err != nil {
+		t.Fatalf("could not create cel environment, %v", err)
+	}
+	m := &Manager{env: env}
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			_, err := m.compile(tc.rule)
+			if tc.isErr != (err != nil) {
+				t.Errorf("expect error %v, but got %v", tc.isErr, err)
+			}
+		})
+	}
+}
+
+func TestParsePointer(t *testing.T) {
+	tokens, err := parsePointer("/metadata/annotations/foo~1bar~0baz")
+	if err != nil {
+		t.Fatalf("could not parse pointer, %v", err)
+	}
+	expect := []string{"metadata", "annotations", "foo/bar~baz"}
+	if !reflect.DeepEqual(tokens, expect) {
+		t.Errorf("expect tokens %v, but got %v", expect, tokens)
+	}
+
+	for _, pointer := range []string{"", "/", "metadata"} {
+		if _, err := parsePointer(pointer); err == nil {
+			t.Errorf("expect error for pointer %q, but got nil", pointer)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celfilter
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/apis/apps/v1alpha1"
+	"github.com/openyurtio/openyurt/pkg/projectinfo"
+	"github.com/openyurtio/openyurt/pkg/yurthub/filter"
+	"github.com/openyurtio/openyurt/pkg/yurthub/policy"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+const (
+	// FilterName is the name of filter which executes rules of YurtHubFilterPolicy resources.
+	FilterName = "celfilter"
+
+	defaultCostLimit = 100000
+)
+
+var filterPolicyGVR = v1alpha1.GroupVersion.WithResource("yurthubfilterpolicies")
+
+// filterRule is a rule of YurtHubFilterPolicy which is applied to the current node, and the
+// expressions of rule are compiled into CEL programs.
+type filterRule struct {
+	policy.Rule
+	rule      v1alpha1.FilterRule
+	condition cel.Program
+	patches   []fieldPatch
+}
+
+// fieldPatch is a compiled patch of rule, and the field is removed when value is nil.
+type fieldPatch struct {
+	path  []string
+	value cel.Program
+}
+
+// Manager manages the rules of YurtHubFilterPolicy resources which are applied to the nodepool
+// of current node, and creates filter with the rules matched by request.
+type Manager struct {
+	sync.RWMutex
+	nodeName        string
+	skipUserAgents  sets.Set[string]
+	env             *cel.Env
+	policyInformers *policy.Informers
+	policyLister    cache.GenericLister
+	policySynced    cache.InformerSynced
+	rules           []*filterRule
+}
+
+// NewManager creates a *Manager for the node nodeName. YurtHubFilterPolicy resources are list/watched
+// by policyInformers, which should be started after the manager is created.
+func NewManager(nodeName string, policyInformers *policy.Informers) (*Manager, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, fmt.Errorf("could not create cel environment, %w", err)
+	}
+	m := &Manager{
+		nodeName:        nodeName,
+		skipUserAgents:  sets.New[string](projectinfo.GetHubName(), util.MultiplexerProxyClientUserAgentPrefix+nodeName),
+		env:             env,
+		policyInformers: policyInformers,
+	}
+	m.policyLister, m.policySynced = policyInformers.Watch(filterPolicyGVR, m.updateFilterPolicies)
+	return m, nil
+}
+
+// HasSynced checks whether all of YurtHubFilterPolicy resources and the nodepool of current node have been list/watched.
+func (m *Manager) HasSynced() bool {
+	return m.policySynced()
+}
+
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		cel.Variable("nodeName", cel.StringType),
+		cel.Variable("nodePoolName", cel.StringType),
+		ext.Strings(),
+	)
+}
+
+// FilterFor returns a filter which executes the rules matched by user agent and resource of request,
+// and false is returned if none of rules matches the request.
+func (m *Manager) FilterFor(req *http.Request) (filter.ObjectFilter, bool) {
+	ctx := req.Context()
+	comp, ok := util.TruncatedClientComponentFrom(ctx)
+	if !ok || len(comp) == 0 || m.skipUserAgents.Has(comp) {
+		return nil, false
+	}
+	info, ok := apirequest.RequestInfoFrom(ctx)
+	if !ok || info == nil || !info.IsResourceRequest {
+		return nil, false
+	}
+
+	m.RLock()
+	rules := m.rules
+	m.RUnlock()
+
+	matched := make([]*filterRule, 0)
+	for _, r := range rules {
+		if r.MatchesRequest(comp, info) {
+			matched = append(matched, r)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, false
+	}
+	klog.V(5).Infof("request %s is matched by %d rules of filter policies", util.ReqString(req), len(matched))
+
+	return &celFilter{
+		rules: matched,
+		vars: map[string]interface{}{
+			"nodeName":     m.nodeName,
+			"nodePoolName": m.policyInformers.NodePoolName(),
+		},
+	}, true
+}
+
+// updateFilterPolicies rebuilds the filter rules from all YurtHubFilterPolicy resources which are applied
+// to the current node. rules are sorted by priority of policy(higher first), name of policy and index of rule.
+func (m *Manager) updateFilterPolicies(action string) {
+	objs, err := m.policyLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("could not list filter policies, %v", err)
+		return
+	}
+
+	poolLabels := m.policyInformers.NodePoolLabels()
+	rules := make([]*filterRule, 0)
+	for _, obj := range objs {
+		filterPolicy, err := toFilterPolicy(obj)
+		if err != nil {
+			klog.Warningf("could not convert filter policy, %v", err)
+			continue
+		}
+		if applied, err := m.policyInformers.AppliedToNodePool(filterPolicy.Spec.NodePools, filterPolicy.Spec.NodePoolSelector, poolLabels); err != nil {
+			klog.Warningf("invalid nodepool selector of filter policy %s, %v", filterPolicy.Name, err)
+			continue
+		} else if !applied {
+			continue
+		}
+
+		for i := range filterPolicy.Spec.Rules {
+			rule, err := m.compile(filterPolicy.Spec.Rules[i])
+			if err != nil {
+				klog.Warningf("skip rule %s of filter policy %s, %v", filterPolicy.Spec.Rules[i].Name, filterPolicy.Name, err)
+				continue
+			}
+			resources := make([]policy.Resource, 0, len(rule.rule.Resources))
+			for _, res := range rule.rule.Resources {
+				resources = append(resources, policy.Resource(res))
+			}
+			rule.Rule = policy.Rule{
+				Policy:     filterPolicy.Name,
+				Priority:   filterPolicy.Spec.Priority,
+				Index:      i,
+				UserAgents: rule.rule.UserAgents,
+				Resources:  resources,
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	policy.SortRules(rules)
+
+	klog.Infof("After action %s, %d filter rules from filter policies are applied", action, len(rules))
+	m.Lock()
+	defer m.Unlock()
+	m.rules = rules
+}
+
+// compile compiles the condition and patches of rule into CEL programs with cost limit.
+func (m *Manager) compile(rule v1alpha1.FilterRule) (*filterRule, error) {
+	if rule.Action != v1alpha1.FilterActionDrop && len(rule.Patches) == 0 {
+		return nil, fmt.Errorf("patches should be specified for action %s", v1alpha1.FilterActionPatch)
+	}
+	costLimit := uint64(defaultCostLimit)
+	if rule.CostLimit != nil {
+		if *rule.CostLimit <= 0 {
+			return nil, fmt.Errorf("cost limit(%d) should be positive", *rule.CostLimit)
+		}
+		costLimit = uint64(*rule.CostLimit)
+	}
+
+	r := &filterRule{rule: rule}
+	if len(rule.Condition) != 0 {
+		ast, iss := m.env.Compile(rule.Condition)
+		if iss.Err() != nil {
+			return nil, fmt.Errorf("could not compile condition, %w", iss.Err())
+		}
+		if !ast.OutputType().IsExactType(cel.BoolType) && !ast.OutputType().IsExactType(cel.DynType) {
+			return nil, fmt.Errorf("condition should return bool, but got %s", ast.OutputType())
+		}
+		prg, err := m.env.Program(ast, cel.CostLimit(costLimit))
+		if err != nil {
+			return nil, fmt.Errorf("could not create program of condition, %w", err)
+		}
+		r.condition = prg
+	}
+
+	if rule.Action == v1alpha1.FilterActionDrop {
+		return r, nil
+	}
+	for _, patch := range rule.Patches {
+		path, err := parsePointer(patch.Path)
+		if err != nil {
+			return nil, err
+		}
+		p := fieldPatch{path: path}
+		if len(patch.Value) != 0 {
+			ast, iss := m.env.Compile(patch.Value)
+			if iss.Err() != nil {
+				return nil, fmt.Errorf("could not compile value of patch %s, %w", patch.Path, iss.Err())
+			}
+			if p.value, err = m.env.Program(ast, cel.CostLimit(costLimit)); err != nil {
+				return nil, fmt.Errorf("could not create program of patch %s, %w", patch.Path, err)
+			}
+		}
+		r.patches = append(r.patches, p)
+	}
+	return r, nil
+}
+
+func toFilterPolicy(obj runtime.Object) (*v1alpha1.YurtHubFilterPolicy, error) {
+	switch o := obj.(type) {
+	case *v1alpha1.YurtHubFilterPolicy:
+		return o, nil
+	case *unstructured.Unstructured:
+		policy := new(v1alpha1.YurtHubFilterPolicy)
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.UnstructuredContent(), policy); err != nil {
+			return nil, err
+		}
+		return policy, nil
+	default:
+		return nil, fmt.Errorf("object(%s) is an unknown type", obj.GetObjectKind().GroupVersionKind().String())
+	}
+}
+
+// parsePointer parses JSON pointer(RFC 6901) into reference tokens, the whole object can not be patched.
+func parsePointer(pointer string) ([]string, error) {
+	if !strings.HasPrefix(pointer, "/") || len(pointer) == 1 {
+		return nil, fmt.Errorf("path(%s) should be a JSON pointer of field", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(tokens[i], "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celfilter
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/server"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/openyurtio/openyurt/pkg/apis/apps/v1alpha1"
+	"github.com/openyurtio/openyurt/pkg/apis/apps/v1beta2"
+	"github.com/openyurtio/openyurt/pkg/projectinfo"
+	"github.com/openyurtio/openyurt/pkg/yurthub/policy"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+func TestFilterFor(t *testing.T) {
+	dropRule := func(name string, userAgents []string, resources ...v1alpha1.FilterResource) v1alpha1.FilterRule {
+		return v1alpha1.FilterRule{Name: name, UserAgents: userAgents, Resources: resources, Action: v1alpha1.FilterActionDrop}
+	}
+	policies := []runtime.Object{
+		&v1alpha1.YurtHubFilterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "all-nodes"},
+			Spec: v1alpha1.YurtHubFilterPolicySpec{
+				Rules: []v1alpha1.FilterRule{
+					dropRule("services", []string{"kube-proxy"}, v1alpha1.FilterResource{Resource: "services"}),
+					// invalid rule is skipped
+					{Name: "invalid", UserAgents: []string{"*"}, Condition: "object.", Action: v1alpha1.FilterActionDrop},
+				},
+			},
+		},
+		&v1alpha1.YurtHubFilterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "hangzhou"},
+			Spec: v1alpha1.YurtHubFilterPolicySpec{
+				NodePools: []string{"hangzhou"},
+				Priority:  10,
+				Rules: []v1alpha1.FilterRule{
+					dropRule("all", []string{"*"}, v1alpha1.FilterResource{Group: "*", Resource: "*"}),
+				},
+			},
+		},
+		&v1alpha1.YurtHubFilterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "east"},
+			Spec: v1alpha1.YurtHubFilterPolicySpec{
+				NodePoolSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "east"}},
+				Rules: []v1alpha1.FilterRule{
+					dropRule("endpointslices", []string{"coredns"}, v1alpha1.FilterResource{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"}),
+				},
+			},
+		},
+	}
+	pool := &v1beta2.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "hangzhou", Labels: map[string]string{"region": "east"}},
+	}
+
+	testcases := map[string]struct {
+		nodePoolName string
+		comp         string
+		path         string
+		expectRules  []string
+	}{
+		"rules are sorted by priority of policies": {
+			nodePoolName: "hangzhou",
+			comp:         "kube-proxy",
+			path:         "/api/v1/services",
+			expectRules:  []string{"hangzhou/all", "all-nodes/services"},
+		},
+		"policy selected by nodepool labels is applied": {
+			nodePoolName: "hangzhou",
+			comp:         "coredns",
+			path:         "/apis/discovery.k8s.io/v1/namespaces/kube-system/endpointslices",
+			expectRules:  []string{"hangzhou/all", "east/endpointslices"},
+		},
+		"only policies for all nodes are applied to node without nodepool": {
+			comp:        "kube-proxy",
+			path:        "/api/v1/services",
+			expectRules: []string{"all-nodes/services"},
+		},
+		"no rules are matched": {
+			comp: "coredns",
+			path: "/api/v1/services",
+		},
+		"requests from yurthub are skipped": {
+			nodePoolName: "hangzhou",
+			comp:         projectinfo.GetHubName(),
+			path:         "/api/v1/services",
+		},
+		"non-resource requests are skipped": {
+			nodePoolName: "hangzhou",
+			comp:         "kube-proxy",
+			path:         "/healthz",
+		},
+	}
+
+	resolver := server.NewRequestInfoResolver(&server.Config{LegacyAPIGroupPrefixes: sets.NewString(server.DefaultLegacyAPIPrefix)})
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			scheme := runtime.NewScheme()
+			v1alpha1.AddToScheme(scheme)
+			v1beta2.AddToScheme(scheme)
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme, append(policies, pool)...)
+			policyInformers := policy.NewInformers(dynamicClient, tc.nodePoolName)
+			m, err := NewManager("foo", policyInformers)
+			if err != nil {
+				t.Fatalf("could not create manager, %v", err)
+			}
+			policyInformers.Start(stopCh)
+
+			// only the valid rule of policy for all nodes is applied to nodes which are not in hangzhou nodepool.
+			expectRules := 1
+			if tc.nodePoolName == "hangzhou" {
+				expectRules = 3
+			}
+			if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+				m.RLock()
+				defer m.RUnlock()
+				return m.HasSynced() && len(m.rules) == expectRules, nil
+			}); err != nil {
+				t.Fatalf("expect %d filter rules, but got %d", expectRules, len(m.rules))
+			}
+
+			req, _ := http.NewRequest("GET", tc.path, nil)
+			ctx := util.WithClientComponent(req.Context(), tc.comp)
+			info, _ := resolver.NewRequestInfo(req)
+			req = req.WithContext(apirequest.WithRequestInfo(ctx, info))
+
+			objectFilter, found := m.FilterFor(req)
+			if found != (len(tc.expectRules) != 0) {
+				t.Fatalf("expect found %v, but got %v", len(tc.expectRules) != 0, found)
+			}
+			if !found {
+				return
+			}
+			if objectFilter.Name() != FilterName {
+				t.Errorf("expect filter name %s, but got %s", FilterName, objectFilter.Name())
+			}
+			rules := make([]string, 0)
+			for _, r := range objectFilter.(*celFilter).rules {
+				rules = append(rules, r.Policy+"/"+r.rule.Name)
+			}
+			if !slices.Equal(rules, tc.expectRules) {
+				t.Errorf("expect rules %v, but got %v", tc.expectRules, rules)
+			}
+		})
+	}
+}
+
+func TestHasSyncedWithoutCRD(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	scheme := runtime.NewScheme()
+	v1alpha1.AddToScheme(scheme)
+	v1beta2.AddToScheme(scheme)
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme)
+	// YurtHubFilterPolicy and NodePool CRDs are not installed.
+	dynamicClient.PrependReactor("list", "*", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), "")
+	})
+	policyInformers := policy.NewInformers(dynamicClient, "hangzhou")
+	m, err := NewManager("foo", policyInformers)
+	if err != nil {
+		t.Fatalf("could not create manager, %v", err)
+	}
+	policyInformers.Start(stopCh)
+
+	if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+		return m.HasSynced(), nil
+	}); err != nil {
+		t.Errorf("expect manager synced when CRDs are not installed, but got %v", err)
+	}
+}
@@ -19,7 +19,9 @@ package manager
 import (
 	"net/http"
 	"strconv"
+	"sync"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -30,10 +32,12 @@ import (
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter/approver"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter/base"
+	"github.com/openyurtio/openyurt/pkg/yurthub/filter/celfilter"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter/initializer"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter/objectfilter"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter/responsefilter"
 	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
+	"github.com/openyurtio/openyurt/pkg/yurthub/policy"
 	"github.com/openyurtio/openyurt/pkg/yurthub/util"
 )
 
@@ -42,6 +46,9 @@ type Manager struct {
 	nameToObjectFilter map[string]filter.ObjectFilter
 	serializerManager  *serializer.SerializerManager
 	resourceSyncers    []filter.ResourceSyncer
+	disabledLock       sync.RWMutex
+	disabledFilters    sets.Set[string]
+	policyManager      *celfilter.Manager
 }
 
 func NewFilterManager(options *yurtoptions.YurtHubOptions,
@@ -95,62 +102,101 @@ func NewFilterManager(options *yurtoptions.YurtHubOptions,
 		nameToObjectFilter: nameToFilters,
 		serializerManager:  serializerManager,
 		resourceSyncers:    resourceSyncers,
+		disabledFilters:    sets.New[string](),
 	}, nil
 }
 
+// NewFilterManagerWithFilterPolicy creates a filter manager which also filters objects with the rules
+// of YurtHubFilterPolicy resources that are applied to the nodepool of current node. YurtHubFilterPolicy
+// resources are list/watched by policyInformers, which should be started after the manager is created.
+func NewFilterManagerWithFilterPolicy(options *yurtoptions.YurtHubOptions,
+	sharedFactory informers.SharedInformerFactory,
+	dynamicSharedFactory dynamicinformer.DynamicSharedInformerFactory,
+	proxiedClient kubernetes.Interface,
+	serializerManager *serializer.SerializerManager,
+	configManager *configuration.Manager,
+	policyInformers *policy.Informers) (filter.FilterFinder, error) {
+	finder, err := NewFilterManager(options, sharedFactory, dynamicSharedFactory, proxiedClient, serializerManager, configManager)
+	if err != nil || !options.EnableResourceFilter {
+		return finder, err
+	}
+
+	m := finder.(*Manager)
+	if m.policyManager, err = celfilter.NewManager(options.NodeName, policyInformers); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (m *Manager) HasSynced() bool {
 	for i := range m.resourceSyncers {
 		if !m.resourceSyncers[i].HasSynced() {
 			return false
 		}
 	}
+	// filters should not work before rules of filter policies are complete.
+	if m.policyManager != nil && !m.policyManager.HasSynced() {
+		return false
+	}
 	return true
 }
 
+// SetDisabledFilters disables the specified filters at runtime, and filters which are not in the list
+// are enabled again. filters disabled by startup options can not be enabled at runtime, because they
+// have not been initialized.
+func (m *Manager) SetDisabledFilters(names []string) {
+	m.disabledLock.Lock()
+	defer m.disabledLock.Unlock()
+	m.disabledFilters = sets.New[string](names...)
+}
+
 func (m *Manager) FindResponseFilter(req *http.Request) (filter.ResponseFilter, bool) {
-	if len(m.nameToObjectFilter) == 0 {
+	objectFilters := m.findObjectFilters(req)
+	if len(objectFilters) == 0 {
 		return nil, false
 	}
 
-	approved, filterNames := m.Approve(req)
-	if approved {
-		objectFilters := make([]filter.ObjectFilter, 0)
-		for i := range filterNames {
-			if objectFilter, ok := m.nameToObjectFilter[filterNames[i]]; ok {
-				objectFilters = append(objectFilters, objectFilter)
-			}
-		}
-
-		if len(objectFilters) == 0 {
-			return nil, false
-		}
+	return responsefilter.CreateResponseFilter(objectFilters, m.serializerManager), true
+}
 
-		return responsefilter.CreateResponseFilter(objectFilters, m.serializerManager), true
+func (m *Manager) FindObjectFilter(req *http.Request) (filter.ObjectFilter, bool) {
+	objectFilters := m.findObjectFilters(req)
+	if len(objectFilters) == 0 {
+		return nil, false
 	}
 
-	return nil, false
+	return objectfilter.CreateFilterChain(objectFilters), true
 }
 
-func (m *Manager) FindObjectFilter(req *http.Request) (filter.ObjectFilter, bool) {
-	if len(m.nameToObjectFilter) == 0 {
-		return nil, false
+// findObjectFilters returns the approved filters for request, and the filter of filter policies
+// is appended at last, so it works on the objects which have been handled by other filters.
+func (m *Manager) findObjectFilters(req *http.Request) []filter.ObjectFilter {
+	objectFilters := make([]filter.ObjectFilter, 0)
+	if len(m.nameToObjectFilter) != 0 {
+		if approved, filterNames := m.Approve(req); approved {
+			objectFilters = m.enabledFilters(filterNames)
+		}
 	}
 
-	approved, filterNames := m.Approve(req)
-	if !approved {
-		return nil, false
+	if m.policyManager != nil {
+		if policyFilter, ok := m.policyManager.FilterFor(req); ok {
+			objectFilters = append(objectFilters, policyFilter)
+		}
 	}
+	return objectFilters
+}
 
+func (m *Manager) enabledFilters(filterNames []string) []filter.ObjectFilter {
+	m.disabledLock.RLock()
+	defer m.disabledLock.RUnlock()
 	objectFilters := make([]filter.ObjectFilter, 0)
 	for i := range filterNames {
+		if m.disabledFilters.Has(filterNames[i]) {
+			continue
+		}
 		if objectFilter, ok := m.nameToObjectFilter[filterNames[i]]; ok {
 			objectFilters = append(objectFilters, objectFilter)
 		}
 	}
-
-	if len(objectFilters) == 0 {
-		return nil, false
-	}
-
-	return objectfilter.CreateFilterChain(objectFilters), true
+	return objectFilters
 }
@@ -17,14 +17,17 @@ limitations under the License.
 package manager
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/endpoints/filters"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/client-go/dynamic/dynamicinformer"
@@ -34,9 +37,11 @@ import (
 
 	"github.com/openyurtio/openyurt/cmd/yurthub/app/options"
 	"github.com/openyurtio/openyurt/pkg/apis"
+	"github.com/openyurtio/openyurt/pkg/apis/apps/v1alpha1"
 	"github.com/openyurtio/openyurt/pkg/yurthub/configuration"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter"
 	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
+	"github.com/openyurtio/openyurt/pkg/yurthub/policy"
 	"github.com/openyurtio/openyurt/pkg/yurthub/proxy/util"
 )
 
@@ -196,6 +201,7 @@ func TestFindObjectFilter(t *testing.T) {
 		enableResourceFilter    bool
 		workingMode             string
 		disabledResourceFilters []string
+		runtimeDisabledFilters  []string
 		enableDummyIf           bool
 		userAgent               string
 		verb                    string
@@ -248,6 +254,16 @@ func TestFindObjectFilter(t *testing.T) {
 			path:                    "/api/v1/endpoints",
 			isFound:                 false,
 		},
+		"disable node port isolation filter at runtime": {
+			enableResourceFilter:   true,
+			runtimeDisabledFilters: []string{"nodeportisolation"},
+			enableDummyIf:          true,
+			userAgent:              "kube-proxy",
+			verb:                   "GET",
+			path:                   "/api/v1/services",
+			isFound:                true,
+			names:                  sets.New("discardcloudservice"),
+		},
 		"can't get discard cloud service filter in cloud mode": {
 			enableResourceFilter: true,
 			workingMode:          "cloud",
@@ -291,6 +307,7 @@ func TestFindObjectFilter(t *testing.T) {
 			defer close(stopper)
 
 			finder, _ := NewFilterManager(options, sharedFactory, nodePoolFactory, fakeClient, serializerManager, configManager)
+			finder.(*Manager).SetDisabledFilters(tt.runtimeDisabledFilters)
 
 			sharedFactory.Start(stopper)
 			nodePoolFactory.Start(stopper)
@@ -407,3 +424,114 @@ func TestHasSynced(t *testing.T) {
 	}
 
 }
+
+func TestFindObjectFilterWithFilterPolicy(t *testing.T) {
+	fakeClient := &fake.Clientset{}
+	scheme := runtime.NewScheme()
+	apis.AddToScheme(scheme)
+	filterPolicy := &v1alpha1.YurtHubFilterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "hide-external-ips"},
+		Spec: v1alpha1.YurtHubFilterPolicySpec{
+			Rules: []v1alpha1.FilterRule{
+				{
+					Name:       "services",
+					UserAgents: []string{"kube-proxy", "unknown-agent"},
+					Resources:  []v1alpha1.FilterResource{{Resource: "services"}},
+					Patches:    []v1alpha1.FieldPatch{{Path: "/spec/externalIPs"}},
+				},
+			},
+		},
+	}
+	fakeDynamicClient := dynamicfake.NewSimpleDynamicClient(scheme, filterPolicy)
+	serializerManager := serializer.NewSerializerManager()
+
+	testcases := map[string]struct {
+		userAgent string
+		path      string
+		isFound   bool
+		names     sets.Set[string]
+	}{
+		"filter of policy is appended to approved filters": {
+			userAgent: "kube-proxy",
+			path:      "/api/v1/services",
+			isFound:   true,
+			names:     sets.New("discardcloudservice", "nodeportisolation", "celfilter"),
+		},
+		"filter of policy is found for component rejected by approver": {
+			userAgent: "unknown-agent",
+			path:      "/api/v1/services",
+			isFound:   true,
+			names:     sets.New("celfilter"),
+		},
+		"filter of policy is not found for unmatched resource": {
+			userAgent: "unknown-agent",
+			path:      "/api/v1/pods",
+			isFound:   false,
+		},
+	}
+
+	options := &options.YurtHubOptions{
+		EnableResourceFilter:    true,
+		DisabledResourceFilters: make([]string, 0),
+		EnableDummyIf:           true,
+		NodeName:                "test",
+		YurtHubProxySecurePort:  10268,
+		HubAgentDummyIfIP:       "127.0.0.1",
+		YurtHubProxyHost:        "127.0.0.1",
+	}
+	sharedFactory, nodePoolFactory := informers.NewSharedInformerFactory(fakeClient, 24*time.Hour),
+		dynamicinformer.NewDynamicSharedInformerFactory(fakeDynamicClient, 24*time.Hour)
+	configManager := configuration.NewConfigurationManager(options.NodeName, sharedFactory)
+	stopper := make(chan struct{})
+	defer close(stopper)
+
+	policyInformers := policy.NewInformers(fakeDynamicClient, options.NodePoolName)
+	finder, err := NewFilterManagerWithFilterPolicy(options, sharedFactory, nodePoolFactory, fakeClient, serializerManager, configManager, policyInformers)
+	if err != nil {
+		t.Fatalf("could not create filter manager, %v", err)
+	}
+	sharedFactory.Start(stopper)
+	nodePoolFactory.Start(stopper)
+	policyInformers.Start(stopper)
+
+	resolver := newTestRequestInfoResolver()
+	findObjectFilter := func(userAgent, path string) (filter.ObjectFilter, bool) {
+		req, _ := http.NewRequest("GET", path, nil)
+		req.RemoteAddr = "127.0.0.1"
+		req.Header.Set("User-Agent", userAgent)
+
+		var isFound bool
+		var objectFilter filter.ObjectFilter
+		var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			objectFilter, isFound = finder.FindObjectFilter(req)
+		})
+		handler = util.WithRequestClientComponent(handler)
+		handler = filters.WithRequestInfo(handler, resolver)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		return objectFilter, isFound
+	}
+
+	// wait for rules of policy are loaded.
+	if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+		_, found := findObjectFilter("unknown-agent", "/api/v1/services")
+		return found, nil
+	}); err != nil {
+		t.Fatalf("rules of filter policy are not loaded, %v", err)
+	}
+
+	for k, tt := range testcases {
+		t.Run(k, func(t *testing.T) {
+			objectFilter, isFound := findObjectFilter(tt.userAgent, tt.path)
+			if isFound != tt.isFound {
+				t.Fatalf("expect found result %v, but got %v", tt.isFound, isFound)
+			} else if !tt.isFound {
+				return
+			}
+
+			names := strings.Split(objectFilter.Name(), ",")
+			if !tt.names.Equal(sets.New(names...)) {
+				t.Errorf("expect filter names %v, but got %v", sets.List(tt.names), names)
+			}
+		})
+	}
+}
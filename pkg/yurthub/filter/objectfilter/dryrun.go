@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectfilter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	yurtutil "github.com/openyurtio/openyurt/pkg/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/filter"
+)
+
+// Change is a change of field made by a filter. Path is the JSON pointer of field,
+// Old is nil for added field, and New is nil for removed field.
+type Change struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// Effect is the effect of a filter on an object.
+type Effect struct {
+	Filter  string   `json:"filter"`
+	Dropped bool     `json:"dropped,omitempty"`
+	Changes []Change `json:"changes,omitempty"`
+}
+
+// ObjectResult is the effects of filters on an object, and filters after the one
+// which drops the object are not executed.
+type ObjectResult struct {
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name"`
+	Dropped   bool     `json:"dropped"`
+	Effects   []Effect `json:"effects"`
+}
+
+// Filters returns the filters of chain in order, and a filter which is not a chain is returned as it is.
+func Filters(objFilter filter.ObjectFilter) []filter.ObjectFilter {
+	if chain, ok := objFilter.(filterChain); ok {
+		return chain
+	}
+	return []filter.ObjectFilter{objFilter}
+}
+
+// DryRun executes filters of objFilter in order on copies of obj, and records the effect of each filter.
+// items of list object are filtered one by one like response filter, and only the objects changed or
+// dropped by filters are returned.
+func DryRun(objFilter filter.ObjectFilter, obj runtime.Object, stopCh <-chan struct{}) ([]ObjectResult, error) {
+	items := []runtime.Object{obj}
+	if meta.IsListType(obj) {
+		var err error
+		if items, err = meta.ExtractList(obj); err != nil {
+			return nil, fmt.Errorf("could not extract items of list, %w", err)
+		}
+	}
+
+	filters := Filters(objFilter)
+	results := make([]ObjectResult, 0)
+	for _, item := range items {
+		result, err := dryRunObject(filters, item, stopCh)
+		if err != nil {
+			return nil, err
+		}
+		if result.Dropped || len(result.Effects) != 0 {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func dryRunObject(filters []filter.ObjectFilter, obj runtime.Object, stopCh <-chan struct{}) (ObjectResult, error) {
+	var result ObjectResult
+	if accessor, err := meta.Accessor(obj); err == nil {
+		result.Namespace = accessor.GetNamespace()
+		result.Name = accessor.GetName()
+	}
+
+	before, err := toContent(obj)
+	if err != nil {
+		return result, err
+	}
+	current := obj
+	for _, f := range filters {
+		// filters may modify object in place, so a copy of object is filtered.
+		filtered := f.Filter(current.DeepCopyObject(), stopCh)
+		if yurtutil.IsNil(filtered) {
+			result.Dropped = true
+			result.Effects = append(result.Effects, Effect{Filter: f.Name(), Dropped: true})
+			return result, nil
+		}
+
+		after, err := toContent(filtered)
+		if err != nil {
+			return result, err
+		}
+		if changes := Diff(before, after); len(changes) != 0 {
+			result.Effects = append(result.Effects, Effect{Filter: f.Name(), Changes: changes})
+		}
+		current, before = filtered, after
+	}
+	return result, nil
+}
+
+func toContent(obj runtime.Object) (map[string]interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj.DeepCopyObject())
+	if err != nil {
+		return nil, fmt.Errorf("could not convert object to unstructured, %w", err)
+	}
+	return content, nil
+}
+
+// Diff returns the changes from old to new content in the order of paths. arrays with different
+// lengths are compared as a whole, because it's hard to tell which items are added or removed.
+func Diff(old, new map[string]interface{}) []Change {
+	changes := make([]Change, 0)
+	diffValue("", old, new, &changes)
+	return changes
+}
+
+func diffValue(path string, old, new interface{}, changes *[]Change) {
+	switch o := old.(type) {
+	case map[string]interface{}:
+		n, ok := new.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(o)+len(n))
+		for k := range o {
+			keys = append(keys, k)
+		}
+		for k := range n {
+			if _, ok := o[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			diffValue(path+"/"+escapePointer(k), o[k], n[k], changes)
+		}
+		return
+	case []interface{}:
+		n, ok := new.([]interface{})
+		if !ok || len(o) != len(n) {
+			break
+		}
+		for i := range o {
+			diffValue(path+"/"+strconv.Itoa(i), o[i], n[i], changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(old, new) {
+		*changes = append(*changes, Change{Path: path, Old: old, New: new})
+	}
+}
+
+// escapePointer escapes reference token of JSON pointer(RFC 6901).
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
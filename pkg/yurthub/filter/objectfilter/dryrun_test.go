@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectfilter
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/filter"
+	"github.com/openyurtio/openyurt/pkg/yurthub/filter/masterservice"
+)
+
+// dropFilter drops services with the specified name, and removes annotations of other services in place.
+type dropFilter struct {
+	name string
+}
+
+func (f *dropFilter) Name() string {
+	return "drop"
+}
+
+func (f *dropFilter) Filter(obj runtime.Object, _ <-chan struct{}) runtime.Object {
+	svc, ok := obj.(*v1.Service)
+	if !ok {
+		return obj
+	}
+	if svc.Name == f.name {
+		return nil
+	}
+	svc.Annotations = nil
+	return svc
+}
+
+func newService(name string) v1.Service {
+	return v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: map[string]string{"a/b": "c"}},
+		Spec: v1.ServiceSpec{
+			ClusterIP:  "10.96.0.1",
+			ClusterIPs: []string{"10.96.0.1"},
+			Ports:      []v1.ServicePort{{Name: "https", Port: 443}},
+		},
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	msf, _ := masterservice.NewMasterServiceFilter()
+	msf.(interface{ SetMasterServiceHost(string) error }).SetMasterServiceHost("169.254.2.1")
+	msf.(interface{ SetMasterServicePort(string) error }).SetMasterServicePort("10268")
+
+	testcases := map[string]struct {
+		filters []filter.ObjectFilter
+		obj     runtime.Object
+		expect  []ObjectResult
+	}{
+		"effects of each filter are recorded": {
+			filters: []filter.ObjectFilter{msf, &dropFilter{name: "foo"}},
+			obj:     func() runtime.Object { svc := newService("kubernetes"); return &svc }(),
+			expect: []ObjectResult{
+				{
+					Namespace: "default",
+					Name:      "kubernetes",
+					Effects: []Effect{
+						{
+							Filter: "masterservice",
+							Changes: []Change{
+								{Path: "/spec/clusterIP", Old: "10.96.0.1", New: "169.254.2.1"},
+								{Path: "/spec/clusterIPs/0", Old: "10.96.0.1", New: "169.254.2.1"},
+								{Path: "/spec/ports/0/port", Old: int64(443), New: int64(10268)},
+							},
+						},
+						{
+							Filter:  "drop",
+							Changes: []Change{{Path: "/metadata/annotations", Old: map[string]interface{}{"a/b": "c"}}},
+						},
+					},
+				},
+			},
+		},
+		"items of list are filtered one by one": {
+			filters: []filter.ObjectFilter{msf, &dropFilter{name: "foo"}},
+			obj: &v1.ServiceList{
+				Items: []v1.Service{newService("foo"), newService("bar")},
+			},
+			expect: []ObjectResult{
+				{
+					Namespace: "default",
+					Name:      "foo",
+					Dropped:   true,
+					Effects:   []Effect{{Filter: "drop", Dropped: true}},
+				},
+				{
+					Namespace: "default",
+					Name:      "bar",
+					Effects: []Effect{
+						{
+							Filter:  "drop",
+							Changes: []Change{{Path: "/metadata/annotations", Old: map[string]interface{}{"a/b": "c"}}},
+						},
+					},
+				},
+			},
+		},
+		"objects which are not changed are skipped": {
+			filters: []filter.ObjectFilter{msf},
+			obj:     func() runtime.Object { svc := newService("bar"); return &svc }(),
+			expect:  []ObjectResult{},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			original := tc.obj.DeepCopyObject()
+			results, err := DryRun(CreateFilterChain(tc.filters), tc.obj, nil)
+			if err != nil {
+				t.Fatalf("could not dry run filters, %v", err)
+			}
+			if !reflect.DeepEqual(results, tc.expect) {
+				t.Errorf("expect results %+v, but got %+v", tc.expect, results)
+			}
+			if !reflect.DeepEqual(original, tc.obj) {
+				t.Errorf("expect object is not modified, but got %+v", tc.obj)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := map[string]interface{}{
+		"a":   "b",
+		"c/d": map[string]interface{}{"e": int64(1)},
+		"f":   []interface{}{"g", "h"},
+	}
+	new := map[string]interface{}{
+		"a":   "b",
+		"c/d": map[string]interface{}{"e": int64(2), "x": true},
+		"f":   []interface{}{"g"},
+	}
+	expect := []Change{
+		{Path: "/c~1d/e", Old: int64(1), New: int64(2)},
+		{Path: "/c~1d/x", New: true},
+		{Path: "/f", Old: []interface{}{"g", "h"}, New: []interface{}{"g"}},
+	}
+	if changes := Diff(old, new); !reflect.DeepEqual(changes, expect) {
+		t.Errorf("expect changes %+v, but got %+v", expect, changes)
+	}
+}
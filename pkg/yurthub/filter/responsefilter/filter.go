@@ -23,6 +23,7 @@ import (
 	"io"
 	"net/http"
 
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
@@ -30,6 +31,8 @@ import (
 	"k8s.io/klog/v2"
 
 	yurtutil "github.com/openyurtio/openyurt/pkg/util"
+	"github.com/openyurtio/openyurt/pkg/util/tracing"
+	"github.com/openyurtio/openyurt/pkg/yurthub/audit"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter/objectfilter"
 	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
@@ -37,6 +40,7 @@ import (
 )
 
 type filterReadCloser struct {
+	ctx          context.Context
 	rc           io.ReadCloser
 	filterCache  *bytes.Buffer
 	watchDataCh  chan *bytes.Buffer
@@ -66,11 +70,12 @@ func newFilterReadCloser(
 	}
 
 	frc := &filterReadCloser{
+		ctx:          ctx,
 		rc:           rc,
 		watchDataCh:  make(chan *bytes.Buffer),
 		filterCache:  new(bytes.Buffer),
 		serializer:   s,
-		objectFilter: objectFilter,
+		objectFilter: audit.WithObjectFilter(ctx, objectFilter),
 		isWatch:      info.Verb == "watch",
 		isList:       info.Verb == "list",
 		ownerName:    ownerName,
@@ -131,11 +136,14 @@ func (frc *filterReadCloser) objectResponseFilter(rc io.ReadCloser) (*bytes.Buff
 		return &buf, nil
 	}
 
+	_, span := tracing.Start(frc.ctx, "FilterChain", attribute.String(tracing.AttributeFilters, frc.ownerName))
+	defer span.End()
 	if frc.isList {
 		items, err := meta.ExtractList(obj)
 		if err != nil || len(items) == 0 {
 			obj = frc.objectFilter.Filter(obj, frc.stopCh)
 		} else {
+			span.SetAttributes(attribute.Int(tracing.AttributeObjects, len(items)))
 			list := make([]runtime.Object, 0)
 			for i := range items {
 				newObj := frc.objectFilter.Filter(items[i], frc.stopCh)
@@ -169,11 +177,20 @@ func (frc *filterReadCloser) streamResponseFilter(rc io.ReadCloser, ch chan *byt
 		return err
 	}
 
+	// the span covers the whole watch stream, so events are counted instead of creating a span for each event.
+	_, span := tracing.Start(frc.ctx, "FilterChain", attribute.String(tracing.AttributeFilters, frc.ownerName))
+	var events int
+	defer func() {
+		span.SetAttributes(attribute.Int(tracing.AttributeObjects, events))
+		span.End()
+	}()
+
 	for {
 		watchType, obj, err := d.Decode()
 		if err != nil {
 			return err
 		}
+		events++
 
 		newObj := obj
 		// BOOKMARK and ERROR response are unnecessary to filter
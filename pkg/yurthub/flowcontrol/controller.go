@@ -0,0 +1,320 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowcontrol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/sets"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/projectinfo"
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+const (
+	// queueWaitTimeout is the max duration which a request waits in the queue of priority level.
+	queueWaitTimeout = 15 * time.Second
+	// retryAfterSeconds is returned to clients whose requests are rejected.
+	retryAfterSeconds = 1
+	// limiterSweepInterval is the interval for removing token buckets of idle clients.
+	limiterSweepInterval = time.Minute
+	unknownClient        = "unknown"
+
+	reasonRateLimited = "rate-limited"
+	reasonQueueFull   = "queue-full"
+	reasonTimeout     = "timeout"
+)
+
+// Controller limits requests by token bucket of each client, and limits concurrency of requests in each
+// priority level. requests exceeding the concurrency are queued and dispatched fairly among clients,
+// so requests of system components like kubelet will not be starved by other clients.
+type Controller struct {
+	sync.Mutex
+	baseSystemAgents sets.Set[string]
+	settings         *Settings
+	limiters         map[string]*rate.Limiter
+	lastSweep        time.Time
+	levels           map[PriorityLevel]*priorityLevel
+}
+
+// NewController creates a flow controller which doesn't limit any requests until settings are updated.
+func NewController(nodeName string) *Controller {
+	return &Controller{
+		baseSystemAgents: sets.New[string]("kubelet", util.MultiplexerProxyClientUserAgentPrefix+nodeName),
+		settings:         &Settings{RateLimits: map[string]RateLimit{}, Levels: map[PriorityLevel]LevelConfig{}, SystemAgents: sets.New[string]()},
+		limiters:         make(map[string]*rate.Limiter),
+		levels: map[PriorityLevel]*priorityLevel{
+			PriorityLevelSystem:   newPriorityLevel(PriorityLevelSystem),
+			PriorityLevelWorkload: newPriorityLevel(PriorityLevelWorkload),
+		},
+	}
+}
+
+// UpdateSettings applies new settings. token buckets of clients are updated with new rate limits and
+// tokens in them are kept, so clients can not get a full bucket by changing settings.
+func (c *Controller) UpdateSettings(s *Settings) {
+	c.Lock()
+	defer c.Unlock()
+	c.settings = s
+	for client, limiter := range c.limiters {
+		_, limit, ok := c.classifyLocked(client)
+		if !ok {
+			delete(c.limiters, client)
+			continue
+		}
+		limiter.SetLimit(rate.Limit(limit.QPS))
+		limiter.SetBurst(limit.Burst)
+	}
+	for name, level := range c.levels {
+		level.update(s.Levels[name])
+	}
+}
+
+// Acquire checks the request can be served or not. the returned release func should be called
+// after the request is served, and error is returned if the request should be rejected.
+func (c *Controller) Acquire(req *http.Request) (func(), error) {
+	ctx := req.Context()
+	info, ok := apirequest.RequestInfoFrom(ctx)
+	if !ok || !info.IsResourceRequest {
+		return noop, nil
+	}
+	client, ok := util.TruncatedClientComponentFrom(ctx)
+	if !ok || len(client) == 0 {
+		client = unknownClient
+	}
+
+	levelName, limiter := c.classify(client, info)
+	if levelName == PriorityLevelExempt {
+		return noop, nil
+	}
+
+	if limiter != nil && !limiter.Allow() {
+		metrics.Metrics.IncRejectedRequests(string(levelName), client, reasonRateLimited)
+		return nil, apierrors.NewTooManyRequests(fmt.Sprintf("client %s exceeds its rate limit in yurthub", client), retryAfterSeconds)
+	}
+
+	// long-running requests only consume tokens, and they don't occupy the concurrency of priority level.
+	if info.Verb == "watch" || httpstream.IsUpgradeRequest(req) {
+		return noop, nil
+	}
+
+	level := c.levels[levelName]
+	if reason := level.acquire(ctx, client); len(reason) != 0 {
+		metrics.Metrics.IncRejectedRequests(string(levelName), client, reason)
+		klog.V(2).Infof("request %s is rejected by flow control of %s priority level, %s", util.ReqString(req), levelName, reason)
+		return nil, apierrors.NewTooManyRequests(fmt.Sprintf("too many requests in %s priority level of yurthub", levelName), retryAfterSeconds)
+	}
+	return level.release, nil
+}
+
+// classify returns the priority level and token bucket of the request.
+func (c *Controller) classify(client string, info *apirequest.RequestInfo) (PriorityLevel, *rate.Limiter) {
+	// node lease and requests from yurthub itself are never limited.
+	if (client == "kubelet" && info.Resource == "leases") || client == projectinfo.GetHubName() {
+		return PriorityLevelExempt, nil
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.sweepLimitersLocked()
+	level, limit, ok := c.classifyLocked(client)
+	if !ok {
+		return level, nil
+	}
+
+	limiter, ok := c.limiters[client]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(limit.QPS), limit.Burst)
+		c.limiters[client] = limiter
+	}
+	return level, limiter
+}
+
+// classifyLocked returns the priority level and rate limit of client, false is returned
+// if client is not rate limited.
+func (c *Controller) classifyLocked(client string) (PriorityLevel, RateLimit, bool) {
+	level := PriorityLevelWorkload
+	if c.baseSystemAgents.Has(client) || c.settings.SystemAgents.Has(client) {
+		level = PriorityLevelSystem
+	}
+
+	limit, ok := c.settings.RateLimits[client]
+	if !ok && level == PriorityLevelWorkload {
+		// default rate limit is only applied on clients in workload priority level.
+		limit, ok = c.settings.RateLimits[AnyClient]
+	}
+	return level, limit, ok
+}
+
+// sweepLimitersLocked removes full token buckets periodically, so token buckets of clients which
+// have gone don't pile up. a full token bucket is the same as a new one, so the removal doesn't
+// change the rate limit of clients.
+func (c *Controller) sweepLimitersLocked() {
+	now := time.Now()
+	if now.Sub(c.lastSweep) < limiterSweepInterval {
+		return
+	}
+	c.lastSweep = now
+	for client, limiter := range c.limiters {
+		if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+			delete(c.limiters, client)
+		}
+	}
+}
+
+func noop() {}
+
+type waiter struct {
+	client  string
+	ready   chan struct{}
+	granted bool
+}
+
+// priorityLevel limits the concurrency of requests, and queued requests are dispatched
+// in round-robin among clients, so a noisy client can not occupy the whole priority level.
+type priorityLevel struct {
+	sync.Mutex
+	name        PriorityLevel
+	maxInFlight int
+	queueLength int
+	inFlight    int
+	queued      int
+	queues      map[string][]*waiter
+	clients     []string
+	next        int
+}
+
+func newPriorityLevel(name PriorityLevel) *priorityLevel {
+	return &priorityLevel{
+		name:   name,
+		queues: make(map[string][]*waiter),
+	}
+}
+
+func (pl *priorityLevel) update(cfg LevelConfig) {
+	pl.Lock()
+	defer pl.Unlock()
+	pl.maxInFlight = cfg.MaxInFlight
+	pl.queueLength = cfg.QueueLength
+	pl.dispatchLocked()
+}
+
+// acquire occupies a seat of priority level, and the reason is returned if the request is rejected.
+func (pl *priorityLevel) acquire(ctx context.Context, client string) string {
+	pl.Lock()
+	if pl.maxInFlight == 0 || pl.inFlight < pl.maxInFlight {
+		pl.inFlight++
+		pl.Unlock()
+		return ""
+	}
+	if pl.queued >= pl.queueLength {
+		pl.Unlock()
+		return reasonQueueFull
+	}
+
+	w := &waiter{client: client, ready: make(chan struct{})}
+	if len(pl.queues[client]) == 0 {
+		pl.clients = append(pl.clients, client)
+	}
+	pl.queues[client] = append(pl.queues[client], w)
+	pl.queued++
+	pl.Unlock()
+
+	timer := time.NewTimer(queueWaitTimeout)
+	defer timer.Stop()
+	select {
+	case <-w.ready:
+		return ""
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+
+	pl.Lock()
+	defer pl.Unlock()
+	// the request may be dispatched at the same time of timeout.
+	if w.granted {
+		return ""
+	}
+	pl.removeLocked(w)
+	return reasonTimeout
+}
+
+func (pl *priorityLevel) release() {
+	pl.Lock()
+	defer pl.Unlock()
+	pl.inFlight--
+	pl.dispatchLocked()
+}
+
+// dispatchLocked grants seats to queued requests of clients in round-robin.
+func (pl *priorityLevel) dispatchLocked() {
+	for pl.queued > 0 && (pl.maxInFlight == 0 || pl.inFlight < pl.maxInFlight) {
+		if pl.next >= len(pl.clients) {
+			pl.next = 0
+		}
+		client := pl.clients[pl.next]
+		w := pl.queues[client][0]
+		pl.queues[client] = pl.queues[client][1:]
+		if len(pl.queues[client]) == 0 {
+			delete(pl.queues, client)
+			pl.clients = append(pl.clients[:pl.next], pl.clients[pl.next+1:]...)
+		} else {
+			pl.next++
+		}
+
+		pl.queued--
+		pl.inFlight++
+		w.granted = true
+		close(w.ready)
+	}
+}
+
+func (pl *priorityLevel) removeLocked(w *waiter) {
+	queue := pl.queues[w.client]
+	for i := range queue {
+		if queue[i] != w {
+			continue
+		}
+		pl.queues[w.client] = append(queue[:i], queue[i+1:]...)
+		pl.queued--
+		break
+	}
+
+	if len(pl.queues[w.client]) != 0 {
+		return
+	}
+	delete(pl.queues, w.client)
+	for i := range pl.clients {
+		if pl.clients[i] == w.client {
+			pl.clients = append(pl.clients[:i], pl.clients[i+1:]...)
+			if i < pl.next {
+				pl.next--
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowcontrol
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+func newRequest(ctx context.Context, client, verb, resource string) *http.Request {
+	req, _ := http.NewRequestWithContext(ctx, "GET", "/api/v1/"+resource, nil)
+	ctx = apirequest.WithRequestInfo(req.Context(), &apirequest.RequestInfo{
+		IsResourceRequest: true,
+		Verb:              verb,
+		APIVersion:        "v1",
+		Resource:          resource,
+	})
+	ctx = util.WithClientComponent(ctx, client)
+	return req.WithContext(ctx)
+}
+
+func TestRateLimit(t *testing.T) {
+	testcases := map[string]struct {
+		rateLimits   map[string]RateLimit
+		systemAgents sets.Set[string]
+		client       string
+		resource     string
+		verb         string
+		expectServed int
+	}{
+		"requests are not limited without settings": {
+			client:       "kubectl",
+			expectServed: 5,
+		},
+		"client is limited by its own rate limit": {
+			rateLimits:   map[string]RateLimit{"kubectl": {QPS: 0.001, Burst: 2}, AnyClient: {QPS: 0.001, Burst: 1}},
+			client:       "kubectl/v1.30.0",
+			expectServed: 2,
+		},
+		"client is limited by default rate limit": {
+			rateLimits:   map[string]RateLimit{AnyClient: {QPS: 0.001, Burst: 1}},
+			client:       "operator",
+			expectServed: 1,
+		},
+		"watch requests are also limited": {
+			rateLimits:   map[string]RateLimit{AnyClient: {QPS: 0.001, Burst: 3}},
+			client:       "operator",
+			verb:         "watch",
+			expectServed: 3,
+		},
+		"kubelet is not limited by default rate limit": {
+			rateLimits:   map[string]RateLimit{AnyClient: {QPS: 0.001, Burst: 1}},
+			client:       "kubelet",
+			expectServed: 5,
+		},
+		"configured system agent is not limited by default rate limit": {
+			rateLimits:   map[string]RateLimit{AnyClient: {QPS: 0.001, Burst: 1}},
+			systemAgents: sets.New[string]("coredns"),
+			client:       "coredns",
+			expectServed: 5,
+		},
+		"node lease is never limited": {
+			rateLimits:   map[string]RateLimit{"kubelet": {QPS: 0.001, Burst: 1}},
+			client:       "kubelet",
+			resource:     "leases",
+			expectServed: 5,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			c := NewController("node1")
+			settings, _ := ParseSettings(map[string]string{})
+			if tc.rateLimits != nil {
+				settings.RateLimits = tc.rateLimits
+			}
+			if tc.systemAgents != nil {
+				settings.SystemAgents = tc.systemAgents
+			}
+			c.UpdateSettings(settings)
+
+			verb, resource := "list", "pods"
+			if len(tc.verb) != 0 {
+				verb = tc.verb
+			}
+			if len(tc.resource) != 0 {
+				resource = tc.resource
+			}
+
+			served := 0
+			for i := 0; i < 5; i++ {
+				release, err := c.Acquire(newRequest(context.Background(), tc.client, verb, resource))
+				if err != nil {
+					if !apierrors.IsTooManyRequests(err) {
+						t.Fatalf("expect too many requests error, but got %v", err)
+					}
+					continue
+				}
+				served++
+				release()
+			}
+			if served != tc.expectServed {
+				t.Errorf("expect %d requests served, but got %d", tc.expectServed, served)
+			}
+		})
+	}
+}
+
+func TestUpdateSettingsKeepsTokens(t *testing.T) {
+	c := NewController("node1")
+	settings, _ := ParseSettings(map[string]string{})
+	settings.RateLimits = map[string]RateLimit{AnyClient: {QPS: 0.001, Burst: 2}, "kubectl": {QPS: 0.001, Burst: 1}}
+	c.UpdateSettings(settings)
+	for _, client := range []string{"operator", "operator", "kubectl"} {
+		if _, err := c.Acquire(newRequest(context.Background(), client, "list", "pods")); err != nil {
+			t.Fatalf("expect request of %s is served, but got %v", client, err)
+		}
+	}
+
+	newSettings, _ := ParseSettings(map[string]string{})
+	newSettings.RateLimits = map[string]RateLimit{AnyClient: {QPS: 0.001, Burst: 5}}
+	c.UpdateSettings(newSettings)
+	if _, err := c.Acquire(newRequest(context.Background(), "operator", "list", "pods")); !apierrors.IsTooManyRequests(err) {
+		t.Errorf("expect tokens of operator are kept after settings are updated, but got %v", err)
+	}
+	if _, err := c.Acquire(newRequest(context.Background(), "kubectl", "list", "pods")); !apierrors.IsTooManyRequests(err) {
+		t.Errorf("expect tokens of kubectl are kept after its own rate limit is removed, but got %v", err)
+	}
+	if burst := c.limiters["operator"].Burst(); burst != 5 {
+		t.Errorf("expect burst of operator is updated to 5, but got %d", burst)
+	}
+}
+
+func TestSweepLimiters(t *testing.T) {
+	c := NewController("node1")
+	settings, _ := ParseSettings(map[string]string{})
+	settings.RateLimits = map[string]RateLimit{AnyClient: {QPS: 0.001, Burst: 1}}
+	c.UpdateSettings(settings)
+	c.Acquire(newRequest(context.Background(), "operator", "list", "pods"))
+	c.limiters["idle"] = rate.NewLimiter(rate.Limit(0.001), 1)
+
+	c.lastSweep = time.Now().Add(-2 * limiterSweepInterval)
+	c.Acquire(newRequest(context.Background(), "kubectl", "list", "pods"))
+	if _, ok := c.limiters["idle"]; ok {
+		t.Errorf("expect full token bucket of idle client is removed")
+	}
+	if _, ok := c.limiters["operator"]; !ok {
+		t.Errorf("expect token bucket of operator is kept")
+	}
+}
+
+func TestPriorityLevel(t *testing.T) {
+	t.Run("queued requests are dispatched fairly among clients", func(t *testing.T) {
+		pl := newPriorityLevel(PriorityLevelWorkload)
+		pl.update(LevelConfig{MaxInFlight: 1, QueueLength: 10})
+		if reason := pl.acquire(context.Background(), "noisy"); len(reason) != 0 {
+			t.Fatalf("expect first request is served, but rejected for %s", reason)
+		}
+
+		served := make(chan string, 10)
+		enqueue := func(client string) {
+			pl.Lock()
+			queued := pl.queued
+			pl.Unlock()
+			go func() {
+				if reason := pl.acquire(context.Background(), client); len(reason) != 0 {
+					t.Errorf("expect request of %s is served, but rejected for %s", client, reason)
+					return
+				}
+				served <- client
+			}()
+			// wait until the request is queued, so the order of requests is fixed.
+			for {
+				pl.Lock()
+				n := pl.queued
+				pl.Unlock()
+				if n > queued {
+					return
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}
+		for _, client := range []string{"noisy", "noisy", "noisy", "kube-proxy", "coredns"} {
+			enqueue(client)
+		}
+
+		order := make([]string, 0, 5)
+		for i := 0; i < 5; i++ {
+			pl.release()
+			order = append(order, <-served)
+		}
+		expect := []string{"noisy", "kube-proxy", "coredns", "noisy", "noisy"}
+		if !reflect.DeepEqual(order, expect) {
+			t.Errorf("expect requests are served in order %v, but got %v", expect, order)
+		}
+	})
+
+	t.Run("request is rejected when queue is full", func(t *testing.T) {
+		pl := newPriorityLevel(PriorityLevelWorkload)
+		pl.update(LevelConfig{MaxInFlight: 1, QueueLength: 0})
+		pl.acquire(context.Background(), "foo")
+		if reason := pl.acquire(context.Background(), "bar"); reason != reasonQueueFull {
+			t.Errorf("expect request is rejected for %s, but got %q", reasonQueueFull, reason)
+		}
+	})
+
+	t.Run("queued request is removed when it's canceled", func(t *testing.T) {
+		pl := newPriorityLevel(PriorityLevelWorkload)
+		pl.update(LevelConfig{MaxInFlight: 1, QueueLength: 1})
+		pl.acquire(context.Background(), "foo")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if reason := pl.acquire(ctx, "bar"); reason != reasonTimeout {
+			t.Errorf("expect request is rejected for %s, but got %q", reasonTimeout, reason)
+		}
+		if pl.queued != 0 || len(pl.clients) != 0 || len(pl.queues) != 0 {
+			t.Errorf("expect queue is empty, but got %d queued requests of clients %v", pl.queued, pl.clients)
+		}
+	})
+
+	t.Run("queued requests are dispatched when limit is removed", func(t *testing.T) {
+		pl := newPriorityLevel(PriorityLevelWorkload)
+		pl.update(LevelConfig{MaxInFlight: 1, QueueLength: 1})
+		pl.acquire(context.Background(), "foo")
+
+		done := make(chan string)
+		go func() {
+			done <- pl.acquire(context.Background(), "bar")
+		}()
+		for {
+			pl.Lock()
+			n := pl.queued
+			pl.Unlock()
+			if n == 1 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		pl.update(LevelConfig{})
+		if reason := <-done; len(reason) != 0 {
+			t.Errorf("expect queued request is served, but rejected for %s", reason)
+		}
+	})
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowcontrol
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// RateLimitsKey is the key of rate limits of clients in yurt-hub-cfg configmap, the value is like
+	// "kubectl=5:10,*=20:40" which means qps:burst of each client, and * is for clients in workload
+	// priority level which don't have their own rate limits.
+	RateLimitsKey = "rate_limits"
+	// PriorityLevelsKey is the key of priority levels in yurt-hub-cfg configmap, the value is like
+	// "system=100:500,workload=20:200" which means max-in-flight:queue-length of each priority level.
+	// 0 max-in-flight means requests of the priority level are not limited.
+	PriorityLevelsKey = "priority_levels"
+	// SystemAgentsKey is the key of clients in system priority level besides the default ones
+	// in yurt-hub-cfg configmap, the value is like "kube-proxy,coredns".
+	SystemAgentsKey = "system_agents"
+
+	// AnyClient is used for specifying rate limit of clients which don't have their own rate limits.
+	AnyClient = "*"
+
+	sepForItem  = ","
+	sepForValue = ":"
+)
+
+// PriorityLevel is the level which requests are classified into.
+type PriorityLevel string
+
+const (
+	// PriorityLevelExempt is for requests which are never limited, like node lease requests of kubelet.
+	PriorityLevelExempt PriorityLevel = "exempt"
+	// PriorityLevelSystem is for requests from system components, like kubelet.
+	PriorityLevelSystem PriorityLevel = "system"
+	// PriorityLevelWorkload is for requests from all other clients.
+	PriorityLevelWorkload PriorityLevel = "workload"
+)
+
+// RateLimit is the token bucket setting of a client.
+type RateLimit struct {
+	QPS   float64
+	Burst int
+}
+
+// LevelConfig is the concurrency setting of a priority level.
+type LevelConfig struct {
+	MaxInFlight int
+	QueueLength int
+}
+
+// Settings is the flow control settings in yurt-hub-cfg configmap.
+type Settings struct {
+	RateLimits   map[string]RateLimit
+	Levels       map[PriorityLevel]LevelConfig
+	SystemAgents sets.Set[string]
+}
+
+// ParseSettings parses flow control settings from data of yurt-hub-cfg configmap,
+// and requests are not limited when the settings are not specified.
+func ParseSettings(data map[string]string) (*Settings, error) {
+	s := &Settings{
+		RateLimits:   make(map[string]RateLimit),
+		Levels:       make(map[PriorityLevel]LevelConfig),
+		SystemAgents: sets.New[string](),
+	}
+
+	for _, item := range splitItems(data[RateLimitsKey]) {
+		client, qps, burst, err := parseItem(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit %q, %w", item, err)
+		}
+		qpsValue, err := strconv.ParseFloat(qps, 64)
+		if err != nil || qpsValue <= 0 {
+			return nil, fmt.Errorf("invalid rate limit %q, qps should be a positive number", item)
+		}
+		burstValue, err := strconv.Atoi(burst)
+		if err != nil || burstValue <= 0 {
+			return nil, fmt.Errorf("invalid rate limit %q, burst should be a positive integer", item)
+		}
+		s.RateLimits[client] = RateLimit{QPS: qpsValue, Burst: burstValue}
+	}
+
+	for _, item := range splitItems(data[PriorityLevelsKey]) {
+		level, maxInFlight, queueLength, err := parseItem(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority level %q, %w", item, err)
+		}
+		if PriorityLevel(level) != PriorityLevelSystem && PriorityLevel(level) != PriorityLevelWorkload {
+			return nil, fmt.Errorf("invalid priority level %q, only %s and %s levels can be configured", item, PriorityLevelSystem, PriorityLevelWorkload)
+		}
+		maxInFlightValue, err := strconv.Atoi(maxInFlight)
+		if err != nil || maxInFlightValue < 0 {
+			return nil, fmt.Errorf("invalid priority level %q, max-in-flight should be a non-negative integer", item)
+		}
+		queueLengthValue, err := strconv.Atoi(queueLength)
+		if err != nil || queueLengthValue < 0 {
+			return nil, fmt.Errorf("invalid priority level %q, queue length should be a non-negative integer", item)
+		}
+		s.Levels[PriorityLevel(level)] = LevelConfig{MaxInFlight: maxInFlightValue, QueueLength: queueLengthValue}
+	}
+
+	s.SystemAgents.Insert(splitItems(data[SystemAgentsKey])...)
+	return s, nil
+}
+
+// SettingsChanged is used to verify flow control settings are changed or not.
+func SettingsChanged(old, new map[string]string) bool {
+	for _, key := range []string{RateLimitsKey, PriorityLevelsKey, SystemAgentsKey} {
+		if old[key] != new[key] {
+			return true
+		}
+	}
+	return false
+}
+
+func splitItems(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, sepForItem) {
+		item = strings.TrimSpace(item)
+		if len(item) != 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseItem parses item like name=first:second.
+func parseItem(item string) (string, string, string, error) {
+	name, value, found := strings.Cut(item, "=")
+	if !found || len(strings.TrimSpace(name)) == 0 {
+		return "", "", "", fmt.Errorf("format should be name=value")
+	}
+	first, second, found := strings.Cut(value, sepForValue)
+	if !found {
+		return "", "", "", fmt.Errorf("value should be separated by %s", sepForValue)
+	}
+	return strings.TrimSpace(name), strings.TrimSpace(first), strings.TrimSpace(second), nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowcontrol
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestParseSettings(t *testing.T) {
+	testcases := map[string]struct {
+		data      map[string]string
+		expect    *Settings
+		expectErr bool
+	}{
+		"no settings": {
+			data: map[string]string{"cache_agents": "foo"},
+			expect: &Settings{
+				RateLimits:   map[string]RateLimit{},
+				Levels:       map[PriorityLevel]LevelConfig{},
+				SystemAgents: sets.New[string](),
+			},
+		},
+		"all settings": {
+			data: map[string]string{
+				RateLimitsKey:     "kubectl=5:10, *=0.5:1",
+				PriorityLevelsKey: "system=100:500,workload=20:0",
+				SystemAgentsKey:   "kube-proxy, coredns",
+			},
+			expect: &Settings{
+				RateLimits: map[string]RateLimit{
+					"kubectl": {QPS: 5, Burst: 10},
+					AnyClient: {QPS: 0.5, Burst: 1},
+				},
+				Levels: map[PriorityLevel]LevelConfig{
+					PriorityLevelSystem:   {MaxInFlight: 100, QueueLength: 500},
+					PriorityLevelWorkload: {MaxInFlight: 20, QueueLength: 0},
+				},
+				SystemAgents: sets.New[string]("kube-proxy", "coredns"),
+			},
+		},
+		"rate limit without burst": {
+			data:      map[string]string{RateLimitsKey: "kubectl=5"},
+			expectErr: true,
+		},
+		"zero qps": {
+			data:      map[string]string{RateLimitsKey: "kubectl=0:10"},
+			expectErr: true,
+		},
+		"exempt level can not be configured": {
+			data:      map[string]string{PriorityLevelsKey: "exempt=10:10"},
+			expectErr: true,
+		},
+		"negative max in flight": {
+			data:      map[string]string{PriorityLevelsKey: "workload=-1:10"},
+			expectErr: true,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			settings, err := ParseSettings(tc.data)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expect error, but got settings %#v", settings)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("could not parse settings, %v", err)
+			}
+			if !reflect.DeepEqual(settings, tc.expect) {
+				t.Errorf("expect settings %#v, but got %#v", tc.expect, settings)
+			}
+		})
+	}
+}
+
+func TestSettingsChanged(t *testing.T) {
+	old := map[string]string{RateLimitsKey: "kubectl=5:10", "cache_agents": "foo"}
+	if SettingsChanged(old, map[string]string{RateLimitsKey: "kubectl=5:10", "cache_agents": "bar"}) {
+		t.Errorf("expect settings are not changed when only cache agents are changed")
+	}
+	if !SettingsChanged(old, map[string]string{RateLimitsKey: "kubectl=5:10", SystemAgentsKey: "coredns"}) {
+		t.Errorf("expect settings are changed when system agents are added")
+	}
+}
@@ -25,12 +25,14 @@ import (
 
 	coordinationv1 "k8s.io/api/coordination/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
 	"github.com/openyurtio/openyurt/cmd/yurthub/app/config"
 	"github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
 	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
 	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/transport"
 )
 
 const (
@@ -44,31 +46,36 @@ type cloudAPIServerHealthChecker struct {
 	sync.RWMutex
 	remoteServers     []*url.URL
 	probers           map[string]healthchecker.BackendProber
+	leaseLock         sync.Mutex
 	latestLease       *coordinationv1.Lease
 	sw                cachemanager.StorageWrapper
 	remoteServerIndex int
 	heartbeatInterval int
+	// fields below are used for creating probers when servers or heartbeat are updated at runtime.
+	tcm                    transport.Interface
+	nodeName               string
+	failedRetry            int
+	healthyThreshold       int
+	healthCheckGracePeriod time.Duration
 }
 
 // NewCloudAPIServerHealthChecker returns a health checker for verifying cloud kube-apiserver status.
 func NewCloudAPIServerHealthChecker(cfg *config.YurtHubConfiguration, stopCh <-chan struct{}) (healthchecker.Interface, error) {
 	hc := &cloudAPIServerHealthChecker{
-		probers:           make(map[string]healthchecker.BackendProber),
-		remoteServers:     cfg.RemoteServers,
-		remoteServerIndex: 0,
-		sw:                cfg.StorageWrapper,
-		heartbeatInterval: cfg.HeartbeatIntervalSeconds,
+		probers:                make(map[string]healthchecker.BackendProber),
+		remoteServers:          cfg.RemoteServers,
+		remoteServerIndex:      0,
+		sw:                     cfg.StorageWrapper,
+		heartbeatInterval:      cfg.HeartbeatIntervalSeconds,
+		tcm:                    cfg.TransportAndDirectClientManager,
+		nodeName:               cfg.NodeName,
+		failedRetry:            cfg.HeartbeatFailedRetry,
+		healthyThreshold:       cfg.HeartbeatHealthyThreshold,
+		healthCheckGracePeriod: cfg.KubeletHealthGracePeriod,
 	}
 
 	for remoteServer, client := range cfg.TransportAndDirectClientManager.ListDirectClientset() {
-		hc.probers[remoteServer] = newProber(client,
-			remoteServer,
-			cfg.NodeName,
-			cfg.HeartbeatFailedRetry,
-			cfg.HeartbeatHealthyThreshold,
-			cfg.KubeletHealthGracePeriod,
-			hc.setLastNodeLease,
-			hc.getLastNodeLease)
+		hc.probers[remoteServer] = hc.newProber(remoteServer, client)
 	}
 	if len(hc.probers) != 0 {
 		go hc.run(stopCh)
@@ -76,15 +83,26 @@ func NewCloudAPIServerHealthChecker(cfg *config.YurtHubConfiguration, stopCh <-c
 	return hc, nil
 }
 
+func (hc *cloudAPIServerHealthChecker) newProber(remoteServer string, client kubernetes.Interface) healthchecker.BackendProber {
+	return newProber(client,
+		remoteServer,
+		hc.nodeName,
+		hc.failedRetry,
+		hc.healthyThreshold,
+		hc.healthCheckGracePeriod,
+		hc.setLastNodeLease,
+		hc.getLastNodeLease)
+}
+
 func (hc *cloudAPIServerHealthChecker) RenewKubeletLeaseTime() {
 	currentTime := time.Now()
-	for _, prober := range hc.probers {
+	for _, prober := range hc.listProbers() {
 		prober.RenewKubeletLeaseTime(currentTime)
 	}
 }
 
 func (hc *cloudAPIServerHealthChecker) IsHealthy() bool {
-	for _, prober := range hc.probers {
+	for _, prober := range hc.listProbers() {
 		if prober.IsHealthy() {
 			return true
 		}
@@ -94,9 +112,9 @@ func (hc *cloudAPIServerHealthChecker) IsHealthy() bool {
 }
 
 func (hc *cloudAPIServerHealthChecker) PickOneHealthyBackend() *url.URL {
-	for i := range hc.remoteServers {
-		if hc.BackendIsHealthy(hc.remoteServers[i]) {
-			return hc.remoteServers[i]
+	for _, server := range hc.servers() {
+		if hc.BackendIsHealthy(server) {
+			return server
 		}
 	}
 
@@ -105,19 +123,61 @@ func (hc *cloudAPIServerHealthChecker) PickOneHealthyBackend() *url.URL {
 
 // BackendHealthyStatus returns the healthy stats of specified server
 func (hc *cloudAPIServerHealthChecker) BackendIsHealthy(server *url.URL) bool {
-	if prober, ok := hc.probers[server.String()]; ok {
+	hc.RLock()
+	prober, ok := hc.probers[server.String()]
+	hc.RUnlock()
+	if ok {
 		return prober.IsHealthy()
 	}
 	// If there is no checker for server, default unhealthy.
 	return false
 }
 
+// UpdateBackends updates the servers to be checked, probers of unchanged servers are kept, so their
+// healthy status will not be reset. direct clients of servers should have been prepared by transport manager.
 func (hc *cloudAPIServerHealthChecker) UpdateBackends(servers []*url.URL) {
-	// do nothing
+	hc.RLock()
+	existing := hc.probers
+	hc.RUnlock()
+	hc.updateProbers(servers, existing)
+}
+
+// UpdateHeartbeat recreates probers of all servers with the new heartbeat configuration, and the interval
+// takes effect from the next heartbeat.
+func (hc *cloudAPIServerHealthChecker) UpdateHeartbeat(cfg healthchecker.HeartbeatConfig) {
+	hc.Lock()
+	hc.failedRetry = cfg.FailedRetry
+	hc.healthyThreshold = cfg.HealthyThreshold
+	hc.heartbeatInterval = cfg.IntervalSeconds
+	servers := hc.remoteServers
+	hc.Unlock()
+	hc.updateProbers(servers, nil)
+}
+
+func (hc *cloudAPIServerHealthChecker) updateProbers(servers []*url.URL, existing map[string]healthchecker.BackendProber) {
+	clients := hc.tcm.ListDirectClientset()
+	probers := make(map[string]healthchecker.BackendProber, len(servers))
+	for _, server := range servers {
+		if prober, ok := existing[server.String()]; ok {
+			probers[server.String()] = prober
+		} else if client, ok := clients[server.String()]; ok {
+			// new prober sends the first heartbeat when it's created, so it's prepared without holding the lock.
+			probers[server.String()] = hc.newProber(server.String(), client)
+		} else {
+			klog.Errorf("could not find direct client for remote server %s, skip checking it", server.String())
+		}
+	}
+
+	hc.Lock()
+	defer hc.Unlock()
+	hc.remoteServers = servers
+	hc.probers = probers
+	hc.remoteServerIndex = 0
 }
 
 func (hc *cloudAPIServerHealthChecker) run(stopCh <-chan struct{}) {
-	intervalTicker := time.NewTicker(time.Duration(hc.heartbeatInterval) * time.Second)
+	interval := hc.interval()
+	intervalTicker := time.NewTicker(interval)
 	defer intervalTicker.Stop()
 
 	for {
@@ -128,12 +188,17 @@ func (hc *cloudAPIServerHealthChecker) run(stopCh <-chan struct{}) {
 		case <-intervalTicker.C:
 			// Ensure that the node heartbeat can be reported when there is a healthy remote server.
 			// Try to detect all remote server in a loop, if there is a remote server can update nodeLease, exit the loop.
-			for i := 0; i < len(hc.remoteServers); i++ {
+			for i := 0; i < len(hc.servers()); i++ {
 				p := hc.getProber()
-				if p.Probe(ProbePhaseNormal) {
+				if p != nil && p.Probe(ProbePhaseNormal) {
 					break
 				}
 			}
+
+			if current := hc.interval(); current != interval {
+				interval = current
+				intervalTicker.Reset(interval)
+			}
 		}
 	}
 }
@@ -142,6 +207,8 @@ func (hc *cloudAPIServerHealthChecker) setLastNodeLease(lease *coordinationv1.Le
 	if lease == nil {
 		return nil
 	}
+	hc.leaseLock.Lock()
+	defer hc.leaseLock.Unlock()
 	hc.latestLease = lease
 
 	accessor := meta.NewAccessor()
@@ -175,6 +242,8 @@ func (hc *cloudAPIServerHealthChecker) setLastNodeLease(lease *coordinationv1.Le
 }
 
 func (hc *cloudAPIServerHealthChecker) getLastNodeLease() *coordinationv1.Lease {
+	hc.leaseLock.Lock()
+	defer hc.leaseLock.Unlock()
 	if hc.latestLease != nil {
 		delete(hc.latestLease.Annotations, DelegateHeartBeat)
 	}
@@ -182,7 +251,35 @@ func (hc *cloudAPIServerHealthChecker) getLastNodeLease() *coordinationv1.Lease
 }
 
 func (hc *cloudAPIServerHealthChecker) getProber() healthchecker.BackendProber {
+	hc.Lock()
+	defer hc.Unlock()
+	if len(hc.remoteServers) == 0 {
+		return nil
+	}
+	hc.remoteServerIndex = hc.remoteServerIndex % len(hc.remoteServers)
 	prober := hc.probers[hc.remoteServers[hc.remoteServerIndex].String()]
 	hc.remoteServerIndex = (hc.remoteServerIndex + 1) % len(hc.remoteServers)
 	return prober
 }
+
+func (hc *cloudAPIServerHealthChecker) servers() []*url.URL {
+	hc.RLock()
+	defer hc.RUnlock()
+	return hc.remoteServers
+}
+
+func (hc *cloudAPIServerHealthChecker) listProbers() []healthchecker.BackendProber {
+	hc.RLock()
+	defer hc.RUnlock()
+	probers := make([]healthchecker.BackendProber, 0, len(hc.probers))
+	for _, prober := range hc.probers {
+		probers = append(probers, prober)
+	}
+	return probers
+}
+
+func (hc *cloudAPIServerHealthChecker) interval() time.Duration {
+	hc.RLock()
+	defer hc.RUnlock()
+	return time.Duration(hc.heartbeatInterval) * time.Second
+}
@@ -0,0 +1,390 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudapiserver
+
+import (
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/cmd/yurthub/app/config"
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+)
+
+const (
+	// requestWindow is the period in which results of requests are used for evaluating servers.
+	requestWindow = time.Minute
+	// maxRequestSamples is the max number of requests kept for each server.
+	maxRequestSamples = 100
+	// minRequestSamples is the min number of requests for evaluating latency and error rate,
+	// so a few failed requests will not make server degraded.
+	minRequestSamples  = 10
+	defaultDialTimeout = 5 * time.Second
+)
+
+type serverState int
+
+const (
+	stateHealthy serverState = iota
+	stateDegraded
+	stateUnhealthy
+)
+
+func (s serverState) String() string {
+	switch s {
+	case stateHealthy:
+		return "healthy"
+	case stateDegraded:
+		return "degraded"
+	default:
+		return "unhealthy"
+	}
+}
+
+type requestSample struct {
+	timestamp time.Time
+	latency   time.Duration
+	failed    bool
+}
+
+// serverStatus is the status of a server evaluated from multiple signals.
+type serverStatus struct {
+	state        serverState
+	pendingState serverState
+	pendingCnt   int
+	tcpFailures  int
+	// requestDegraded is whether requests to server are slow or failing. it's kept when there are not
+	// enough requests for evaluating, until tcp probes(or lease probes when tcp signal is not enabled)
+	// give positive evidence that server has recovered.
+	requestDegraded bool
+	samples         []requestSample
+	next            int
+}
+
+// requestStats returns the number, error rate and average latency of requests in the window.
+func (s *serverStatus) requestStats(now time.Time) (int, float64, time.Duration) {
+	var cnt, failed int
+	var total time.Duration
+	for _, sample := range s.samples {
+		if now.Sub(sample.timestamp) > requestWindow {
+			continue
+		}
+		cnt++
+		total += sample.latency
+		if sample.failed {
+			failed++
+		}
+	}
+	if cnt == 0 {
+		return 0, 0, 0
+	}
+	return cnt, float64(failed) / float64(cnt), total / time.Duration(cnt)
+}
+
+// transit moves the server into target state. the state becomes worse immediately, and becomes
+// better only after target state has been observed for healthyThreshold consecutive times,
+// so a flapping link will not cause repeated switches between remote and local proxying.
+func (s *serverStatus) transit(target serverState, healthyThreshold int) bool {
+	if target == s.state {
+		s.pendingCnt = 0
+		return false
+	}
+
+	if target < s.state {
+		if s.pendingState != target {
+			s.pendingState = target
+			s.pendingCnt = 0
+		}
+		s.pendingCnt++
+		if s.pendingCnt < healthyThreshold {
+			return false
+		}
+	}
+	s.state = target
+	s.pendingCnt = 0
+	return true
+}
+
+type multiSignalHealthChecker struct {
+	*cloudAPIServerHealthChecker
+	statusLock         sync.RWMutex
+	statuses           map[string]*serverStatus
+	signals            sets.Set[string]
+	healthyThreshold   int
+	failedThreshold    int
+	latencyThreshold   time.Duration
+	errorRateThreshold float64
+	degradedMode       bool
+	dial               func(network, address string, timeout time.Duration) (net.Conn, error)
+}
+
+// NewMultiSignalHealthChecker returns a health checker for verifying cloud kube-apiserver status by combining
+// node lease updates, latency and error rate of proxied requests and tcp-level probes. Servers whose requests are
+// slow or failing are marked as degraded, and they are considered as unhealthy if degraded mode is not enabled.
+func NewMultiSignalHealthChecker(cfg *config.YurtHubConfiguration, stopCh <-chan struct{}) (healthchecker.Interface, error) {
+	checker, err := NewCloudAPIServerHealthChecker(cfg, stopCh)
+	if err != nil {
+		return nil, err
+	}
+
+	hc := &multiSignalHealthChecker{
+		cloudAPIServerHealthChecker: checker.(*cloudAPIServerHealthChecker),
+		statuses:                    make(map[string]*serverStatus),
+		signals:                     sets.New[string](cfg.HealthCheckSignals...),
+		healthyThreshold:            cfg.HeartbeatHealthyThreshold,
+		failedThreshold:             cfg.HeartbeatFailedRetry,
+		latencyThreshold:            cfg.DegradedLatencyThreshold,
+		errorRateThreshold:          cfg.DegradedErrorRateThreshold,
+		degradedMode:                cfg.EnableDegradedMode,
+		dial:                        net.DialTimeout,
+	}
+	hc.syncStatuses(hc.remoteServers)
+
+	if len(hc.probers) != 0 {
+		go hc.evaluate(stopCh)
+	}
+	return hc, nil
+}
+
+func (hc *multiSignalHealthChecker) IsHealthy() bool {
+	for _, server := range hc.servers() {
+		if hc.BackendIsHealthy(server) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDegraded returns true when there are reachable servers and all of them are degraded.
+func (hc *multiSignalHealthChecker) IsDegraded() bool {
+	degraded := false
+	for _, server := range hc.servers() {
+		if !hc.BackendIsHealthy(server) {
+			continue
+		}
+		if hc.stateOf(server) != stateDegraded {
+			return false
+		}
+		degraded = true
+	}
+	return degraded
+}
+
+// PickOneHealthyBackend prefers healthy servers to degraded servers.
+func (hc *multiSignalHealthChecker) PickOneHealthyBackend() *url.URL {
+	var degraded *url.URL
+	for _, server := range hc.servers() {
+		if !hc.BackendIsHealthy(server) {
+			continue
+		}
+		if hc.stateOf(server) == stateHealthy {
+			return server
+		} else if degraded == nil {
+			degraded = server
+		}
+	}
+	return degraded
+}
+
+// BackendIsHealthy returns true if the server is reachable, degraded server is also reachable.
+func (hc *multiSignalHealthChecker) BackendIsHealthy(server *url.URL) bool {
+	if !hc.cloudAPIServerHealthChecker.BackendIsHealthy(server) {
+		return false
+	}
+	return hc.stateOf(server) != stateUnhealthy
+}
+
+// UpdateBackends updates the servers to be checked, and statuses of unchanged servers are kept.
+func (hc *multiSignalHealthChecker) UpdateBackends(servers []*url.URL) {
+	hc.cloudAPIServerHealthChecker.UpdateBackends(servers)
+	hc.syncStatuses(servers)
+}
+
+// UpdateHeartbeat updates the heartbeat configuration, and thresholds of the other signals
+// are also updated because they share the heartbeat configuration.
+func (hc *multiSignalHealthChecker) UpdateHeartbeat(cfg healthchecker.HeartbeatConfig) {
+	hc.cloudAPIServerHealthChecker.UpdateHeartbeat(cfg)
+	hc.statusLock.Lock()
+	hc.healthyThreshold = cfg.HealthyThreshold
+	hc.failedThreshold = cfg.FailedRetry
+	hc.statusLock.Unlock()
+}
+
+// syncStatuses prepares statuses for new servers and removes statuses of removed servers.
+func (hc *multiSignalHealthChecker) syncStatuses(servers []*url.URL) {
+	hc.statusLock.Lock()
+	defer hc.statusLock.Unlock()
+	statuses := make(map[string]*serverStatus, len(servers))
+	for _, server := range servers {
+		if status, ok := hc.statuses[server.String()]; ok {
+			statuses[server.String()] = status
+			continue
+		}
+		status := &serverStatus{state: stateUnhealthy}
+		if hc.cloudAPIServerHealthChecker.BackendIsHealthy(server) {
+			status.state = stateHealthy
+		}
+		statuses[server.String()] = status
+	}
+	hc.statuses = statuses
+}
+
+// ObserveRequest records the result of request proxied to server.
+func (hc *multiSignalHealthChecker) ObserveRequest(server *url.URL, latency time.Duration, failed bool) {
+	if !hc.signals.Has(healthchecker.SignalRequest) || server == nil {
+		return
+	}
+
+	hc.statusLock.Lock()
+	defer hc.statusLock.Unlock()
+	status, ok := hc.statuses[server.String()]
+	if !ok {
+		return
+	}
+	sample := requestSample{timestamp: time.Now(), latency: latency, failed: failed}
+	if len(status.samples) < maxRequestSamples {
+		status.samples = append(status.samples, sample)
+	} else {
+		status.samples[status.next] = sample
+		status.next = (status.next + 1) % maxRequestSamples
+	}
+}
+
+func (hc *multiSignalHealthChecker) stateOf(server *url.URL) serverState {
+	hc.statusLock.RLock()
+	defer hc.statusLock.RUnlock()
+	if status, ok := hc.statuses[server.String()]; ok {
+		return status.state
+	}
+	return stateUnhealthy
+}
+
+func (hc *multiSignalHealthChecker) evaluate(stopCh <-chan struct{}) {
+	interval := hc.interval()
+	intervalTicker := time.NewTicker(interval)
+	defer intervalTicker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			klog.Infof("exit normally in multi-signal health check loop.")
+			return
+		case <-intervalTicker.C:
+			for _, server := range hc.servers() {
+				hc.evaluateServer(server)
+			}
+
+			if current := hc.interval(); current != interval {
+				interval = current
+				intervalTicker.Reset(interval)
+			}
+		}
+	}
+}
+
+// evaluateServer combines all signals into the target state of server, and transits the server into it.
+func (hc *multiSignalHealthChecker) evaluateServer(server *url.URL) {
+	// tcp probe is sent without holding the lock, because it may take a while.
+	tcpFailed := false
+	var tcpLatency time.Duration
+	if hc.signals.Has(healthchecker.SignalTCP) {
+		start := time.Now()
+		conn, err := hc.dial("tcp", dialAddress(server), defaultDialTimeout)
+		if err != nil {
+			klog.V(2).Infof("could not probe remote server %s by tcp, %v", server.String(), err)
+			tcpFailed = true
+		} else {
+			tcpLatency = time.Since(start)
+			conn.Close()
+		}
+	}
+
+	hc.statusLock.Lock()
+	defer hc.statusLock.Unlock()
+	status, ok := hc.statuses[server.String()]
+	if !ok {
+		return
+	}
+	if tcpFailed {
+		status.tcpFailures++
+	} else {
+		status.tcpFailures = 0
+	}
+
+	// the result of requests is kept when there are not enough requests, because no requests
+	// are not the evidence of recovery. for example, requests may be proxied to other servers
+	// once the server is degraded. successful probes are needed for recovering server, and lease
+	// probes are used when tcp signal is not enabled, otherwise the server which gets no requests
+	// would never recover.
+	leaseHealthy := hc.cloudAPIServerHealthChecker.BackendIsHealthy(server)
+	cnt, errorRate, latency := status.requestStats(time.Now())
+	switch {
+	case hc.signals.Has(healthchecker.SignalRequest) && cnt >= minRequestSamples:
+		status.requestDegraded = errorRate >= hc.errorRateThreshold || (hc.latencyThreshold > 0 && latency >= hc.latencyThreshold)
+	case status.requestDegraded && hc.signals.Has(healthchecker.SignalTCP) && !tcpFailed &&
+		(hc.latencyThreshold == 0 || tcpLatency < hc.latencyThreshold):
+		status.requestDegraded = false
+	case status.requestDegraded && !hc.signals.Has(healthchecker.SignalTCP) && leaseHealthy:
+		status.requestDegraded = false
+	}
+
+	target := stateHealthy
+	switch {
+	case !leaseHealthy:
+		target = stateUnhealthy
+	case status.tcpFailures >= hc.failedThreshold:
+		target = stateUnhealthy
+	case status.requestDegraded:
+		target = stateDegraded
+		if !hc.degradedMode {
+			target = stateUnhealthy
+		}
+	}
+
+	// server does not recover while tcp probes are still failing.
+	if status.tcpFailures > 0 && target < status.state {
+		target = status.state
+	}
+
+	oldState := status.state
+	if status.transit(target, hc.healthyThreshold) {
+		klog.Infof("remote server %s becomes %s from %s, tcp failures: %d, requests: %d, error rate: %.2f, average latency: %v",
+			server.String(), status.state, oldState, status.tcpFailures, cnt, errorRate, latency)
+		if status.state == stateUnhealthy {
+			metrics.Metrics.ObserveServerHealthy(server.String(), 0)
+		} else {
+			metrics.Metrics.ObserveServerHealthy(server.String(), 1)
+		}
+	}
+}
+
+// dialAddress returns host:port of server for tcp probe.
+func dialAddress(server *url.URL) string {
+	port := server.Port()
+	if len(port) == 0 {
+		port = "443"
+		if server.Scheme == "http" {
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(server.Hostname(), port)
+}
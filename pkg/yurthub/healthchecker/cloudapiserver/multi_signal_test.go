@@ -0,0 +1,278 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudapiserver
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	clientfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
+	"github.com/openyurtio/openyurt/pkg/yurthub/transport"
+)
+
+type fakeProber struct {
+	healthy bool
+}
+
+func (p *fakeProber) RenewKubeletLeaseTime(time time.Time) {}
+
+func (p *fakeProber) Probe(phase string) bool {
+	return p.healthy
+}
+
+func (p *fakeProber) IsHealthy() bool {
+	return p.healthy
+}
+
+type step struct {
+	leaseUnhealthy bool
+	tcpFailed      bool
+	requests       int
+	latency        time.Duration
+	failed         bool
+	expireRequests bool
+	expectState    serverState
+}
+
+func TestMultiSignalHealthChecker(t *testing.T) {
+	testcases := map[string]struct {
+		degradedMode bool
+		disableTCP   bool
+		initState    serverState
+		steps        []step
+	}{
+		"slow requests make server degraded": {
+			degradedMode: true,
+			steps: []step{
+				{requests: 10, latency: 6 * time.Second, expectState: stateDegraded},
+			},
+		},
+		"failed requests make server degraded": {
+			degradedMode: true,
+			steps: []step{
+				{requests: 10, failed: true, expectState: stateDegraded},
+			},
+		},
+		"degraded server is unhealthy if degraded mode is disabled": {
+			steps: []step{
+				{requests: 10, failed: true, expectState: stateUnhealthy},
+			},
+		},
+		"a few failed requests don't make server degraded": {
+			degradedMode: true,
+			steps: []step{
+				{requests: 5, failed: true, expectState: stateHealthy},
+			},
+		},
+		"server becomes unhealthy after consecutive tcp failures": {
+			steps: []step{
+				{tcpFailed: true, expectState: stateHealthy},
+				{tcpFailed: true, expectState: stateUnhealthy},
+			},
+		},
+		"server becomes unhealthy immediately when node lease fails": {
+			steps: []step{
+				{leaseUnhealthy: true, expectState: stateUnhealthy},
+			},
+		},
+		"server recovers after healthy threshold": {
+			initState: stateUnhealthy,
+			steps: []step{
+				{leaseUnhealthy: true, expectState: stateUnhealthy},
+				{expectState: stateUnhealthy},
+				{tcpFailed: true, expectState: stateUnhealthy},
+				{expectState: stateUnhealthy},
+				{expectState: stateHealthy},
+			},
+		},
+		"degraded server recovers after healthy threshold": {
+			degradedMode: true,
+			initState:    stateDegraded,
+			steps: []step{
+				{requests: 20, latency: time.Millisecond, expectState: stateDegraded},
+				{expectState: stateHealthy},
+			},
+		},
+		"degraded server recovers by tcp probes without requests": {
+			degradedMode: true,
+			steps: []step{
+				{requests: 10, latency: 6 * time.Second, expectState: stateDegraded},
+				{expireRequests: true, tcpFailed: true, expectState: stateDegraded},
+				{expectState: stateDegraded},
+				{expectState: stateHealthy},
+			},
+		},
+		"degraded server recovers by lease probes without requests and tcp probes": {
+			degradedMode: true,
+			disableTCP:   true,
+			steps: []step{
+				{requests: 10, latency: 6 * time.Second, expectState: stateDegraded},
+				{requests: 5, latency: 6 * time.Second, expireRequests: true, expectState: stateDegraded},
+				{expectState: stateHealthy},
+			},
+		},
+		"unhealthy server recovers by lease probes without requests and tcp probes": {
+			disableTCP: true,
+			steps: []step{
+				{requests: 10, failed: true, expectState: stateUnhealthy},
+				{expireRequests: true, leaseUnhealthy: true, expectState: stateUnhealthy},
+				{expectState: stateUnhealthy},
+				{expectState: stateHealthy},
+			},
+		},
+		"degraded server is kept while requests are still slow without tcp probes": {
+			degradedMode: true,
+			disableTCP:   true,
+			steps: []step{
+				{requests: 10, latency: 6 * time.Second, expectState: stateDegraded},
+				{requests: 10, latency: 6 * time.Second, expectState: stateDegraded},
+				{expectState: stateDegraded},
+			},
+		},
+	}
+
+	server := &url.URL{Scheme: "https", Host: "127.0.0.1:6443"}
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			prober := &fakeProber{healthy: true}
+			tcpFailed := false
+			hc := &multiSignalHealthChecker{
+				cloudAPIServerHealthChecker: &cloudAPIServerHealthChecker{
+					remoteServers: []*url.URL{server},
+					probers:       map[string]healthchecker.BackendProber{server.String(): prober},
+				},
+				statuses:           map[string]*serverStatus{server.String(): {state: tc.initState}},
+				signals:            sets.New[string](healthchecker.SignalLease, healthchecker.SignalRequest, healthchecker.SignalTCP),
+				healthyThreshold:   2,
+				failedThreshold:    2,
+				latencyThreshold:   5 * time.Second,
+				errorRateThreshold: 0.5,
+				degradedMode:       tc.degradedMode,
+				dial: func(network, address string, timeout time.Duration) (net.Conn, error) {
+					if address != "127.0.0.1:6443" {
+						t.Errorf("expect probe address 127.0.0.1:6443, but got %s", address)
+					}
+					if tcpFailed {
+						return nil, errors.New("connection refused")
+					}
+					c1, c2 := net.Pipe()
+					c2.Close()
+					return c1, nil
+				},
+			}
+
+			if tc.disableTCP {
+				hc.signals.Delete(healthchecker.SignalTCP)
+			}
+
+			for i, s := range tc.steps {
+				prober.healthy = !s.leaseUnhealthy
+				tcpFailed = s.tcpFailed
+				if s.expireRequests {
+					hc.statuses[server.String()].samples = nil
+				}
+				for j := 0; j < s.requests; j++ {
+					hc.ObserveRequest(server, s.latency, s.failed)
+				}
+				hc.evaluateServer(server)
+
+				if state := hc.stateOf(server); state != s.expectState {
+					t.Fatalf("step %d: expect server %s, but got %s", i, s.expectState, state)
+				}
+				if hc.IsHealthy() != (s.expectState != stateUnhealthy && !s.leaseUnhealthy) {
+					t.Errorf("step %d: expect healthy %v, but got %v", i, s.expectState != stateUnhealthy, hc.IsHealthy())
+				}
+				if hc.IsDegraded() != (s.expectState == stateDegraded) {
+					t.Errorf("step %d: expect degraded %v, but got %v", i, s.expectState == stateDegraded, hc.IsDegraded())
+				}
+			}
+		})
+	}
+}
+
+func TestMultiSignalHealthCheckerUpdateBackends(t *testing.T) {
+	server1 := &url.URL{Scheme: "https", Host: "127.0.0.1:6443"}
+	server2 := &url.URL{Scheme: "https", Host: "127.0.0.2:6443"}
+	server3 := &url.URL{Scheme: "https", Host: "127.0.0.3:6443"}
+	prober1, prober2 := &fakeProber{healthy: true}, &fakeProber{healthy: true}
+	tcm := transport.NewFakeTransportManager(200, map[string]kubernetes.Interface{
+		server1.String(): clientfake.NewSimpleClientset(),
+		server2.String(): clientfake.NewSimpleClientset(),
+	})
+	hc := &multiSignalHealthChecker{
+		cloudAPIServerHealthChecker: &cloudAPIServerHealthChecker{
+			remoteServers: []*url.URL{server1, server2},
+			probers: map[string]healthchecker.BackendProber{
+				server1.String(): prober1,
+				server2.String(): prober2,
+			},
+			remoteServerIndex: 1,
+			tcm:               tcm,
+		},
+		statuses: map[string]*serverStatus{
+			server1.String(): {state: stateHealthy},
+			server2.String(): {state: stateDegraded},
+		},
+		degradedMode: true,
+	}
+
+	// server3 has no direct client, so it's not checked and considered as unhealthy.
+	hc.UpdateBackends([]*url.URL{server2, server3})
+
+	if len(hc.probers) != 1 || hc.probers[server2.String()] != prober2 {
+		t.Errorf("expect prober of %s is kept, but got %v", server2.String(), hc.probers)
+	}
+	if len(hc.statuses) != 2 || hc.stateOf(server2) != stateDegraded || hc.stateOf(server3) != stateUnhealthy {
+		t.Errorf("expect status of %s is kept and %s is unhealthy, but got %v", server2.String(), server3.String(), hc.statuses)
+	}
+	if hc.BackendIsHealthy(server1) {
+		t.Errorf("expect removed server %s is not healthy", server1.String())
+	}
+	if p := hc.getProber(); p != prober2 {
+		t.Errorf("expect prober of %s is picked after update, but got %v", server2.String(), p)
+	}
+	if !hc.IsDegraded() {
+		t.Errorf("expect degraded when only degraded server is reachable")
+	}
+}
+
+func TestDialAddress(t *testing.T) {
+	testcases := map[string]struct {
+		server *url.URL
+		expect string
+	}{
+		"server with port":          {server: &url.URL{Scheme: "https", Host: "1.2.3.4:6443"}, expect: "1.2.3.4:6443"},
+		"https server without port": {server: &url.URL{Scheme: "https", Host: "apiserver.local"}, expect: "apiserver.local:443"},
+		"http server without port":  {server: &url.URL{Scheme: "http", Host: "1.2.3.4"}, expect: "1.2.3.4:80"},
+		"ipv6 server without port":  {server: &url.URL{Scheme: "https", Host: "[::1]"}, expect: "[::1]:443"},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			if addr := dialAddress(tc.server); addr != tc.expect {
+				t.Errorf("expect %s, but got %s", tc.expect, addr)
+			}
+		})
+	}
+}
@@ -21,6 +21,15 @@ import (
 	"time"
 )
 
+const (
+	// SignalLease means servers are checked by updating node lease to them.
+	SignalLease = "lease"
+	// SignalRequest means servers are checked by latency and error rate of requests proxied to them.
+	SignalRequest = "request"
+	// SignalTCP means servers are checked by establishing tcp connections to them.
+	SignalTCP = "tcp"
+)
+
 // Interface is an interface for checking healthy status of servers
 type Interface interface {
 	// RenewKubeletLeaseTime is used for notifying whether kubelet stopped or not,
@@ -44,3 +53,31 @@ type BackendProber interface {
 	Probe(phase string) bool
 	IsHealthy() bool
 }
+
+// RequestObserver is an optional interface for health checkers which take the results
+// of requests proxied to servers into account.
+type RequestObserver interface {
+	// ObserveRequest records the latency of request proxied to server and whether it's failed.
+	ObserveRequest(server *url.URL, latency time.Duration, failed bool)
+}
+
+// DegradedChecker is an optional interface for health checkers which can mark servers as degraded.
+type DegradedChecker interface {
+	// IsDegraded returns true when servers are reachable but all of them are degraded. In this case,
+	// read requests should be served from local cache and write requests are still forwarded to servers.
+	IsDegraded() bool
+}
+
+// HeartbeatConfig is the configuration of heartbeats sent to servers.
+type HeartbeatConfig struct {
+	FailedRetry      int
+	HealthyThreshold int
+	IntervalSeconds  int
+}
+
+// HeartbeatUpdater is an optional interface for health checkers whose heartbeat configuration
+// can be updated at runtime.
+type HeartbeatUpdater interface {
+	// UpdateHeartbeat applies cfg to all of servers, and probers of servers are recreated.
+	UpdateHeartbeat(cfg HeartbeatConfig)
+}
@@ -36,7 +36,7 @@ type leaderHubHealthChecker struct {
 
 func NewLeaderHubHealthChecker(checkerInterval time.Duration, pingFunc func(*url.URL) bool, stopCh <-chan struct{}) healthchecker.Interface {
 	if pingFunc == nil {
-		pingFunc = pingServer
+		pingFunc = PingServer
 	}
 
 	hc := &leaderHubHealthChecker{
@@ -134,7 +134,8 @@ func (hc *leaderHubHealthChecker) checkServers() {
 	hc.statusMutex.Unlock()
 }
 
-func pingServer(server *url.URL) bool {
+// PingServer checks whether the server is reachable by dialing a tcp connection to it.
+func PingServer(server *url.URL) bool {
 	if server != nil {
 		conn, err := net.DialTimeout("tcp", server.Host, 2*time.Second)
 		if err != nil {
@@ -210,6 +210,20 @@ func (rm *RESTMapperManager) UpdateKind(gvk schema.GroupVersionKind) error {
 	return nil
 }
 
+// UpdateKindFor is used to add the mapping relationship between GVR and GVK of Custom Resource which are got
+// from discovery info, because plural name of resource can not always be guessed from kind.
+func (rm *RESTMapperManager) UpdateKindFor(gvr schema.GroupVersionResource, gvk schema.GroupVersionKind) error {
+	isScheme, t := rm.KindFor(gvr)
+	if isScheme || t == gvk {
+		return nil
+	}
+
+	rm.Lock()
+	rm.dynamicRESTMapper[gvr] = gvk
+	rm.Unlock()
+	return rm.updateCachedDynamicRESTMapper()
+}
+
 // ResetRESTMapper is used to clean up all cached GVR/GVK information in DynamicRESTMapper,
 // and delete the corresponding file in the disk (cache-crd-restmapper.conf), it should be used carefully.
 func (rm *RESTMapperManager) ResetRESTMapper() error {
@@ -213,6 +213,46 @@ func TestResetRESTMapper(t *testing.T) {
 	}
 }
 
+func TestUpdateKindFor(t *testing.T) {
+	defer func() {
+		if err := os.RemoveAll(rootDir); err != nil {
+			t.Errorf("Unable to clean up test directory %q: %v", rootDir, err)
+		}
+	}()
+	yurtHubRESTMapperManager, err := NewRESTMapperManager(rootDir)
+	if err != nil {
+		t.Fatalf("failed to initialize an empty dynamicRESTMapper, %v", err)
+	}
+
+	// plural name of resource can not be guessed from kind
+	gvr := schema.GroupVersionResource{Group: "stable.example.com", Version: "v1", Resource: "octopi"}
+	gvk := schema.GroupVersionKind{Group: "stable.example.com", Version: "v1", Kind: "Octopus"}
+	if err := yurtHubRESTMapperManager.UpdateKindFor(gvr, gvk); err != nil {
+		t.Fatalf("failed to update kind for %v, %v", gvr, err)
+	}
+	if isScheme, kind := yurtHubRESTMapperManager.KindFor(gvr); isScheme || kind != gvk {
+		t.Errorf("expect kind %v for %v, but got %v", gvk, gvr, kind)
+	}
+
+	// built-in resources are not added into dynamic RESTMapper
+	podGVR := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	if err := yurtHubRESTMapperManager.UpdateKindFor(podGVR, schema.GroupVersionKind{Version: "v1", Kind: "Pod"}); err != nil {
+		t.Fatalf("failed to update kind for %v, %v", podGVR, err)
+	}
+	if _, exists := yurtHubRESTMapperManager.dynamicRESTMapper[podGVR]; exists {
+		t.Errorf("expect built-in resource %v is not added into dynamic RESTMapper", podGVR)
+	}
+
+	// the mapping is persisted on disk
+	restored, err := NewRESTMapperManager(rootDir)
+	if err != nil {
+		t.Fatalf("failed to restore dynamicRESTMapper, %v", err)
+	}
+	if _, kind := restored.KindFor(gvr); kind != gvk {
+		t.Errorf("expect kind %v for %v after restored, but got %v", gvk, gvr, kind)
+	}
+}
+
 func compareDynamicRESTMapper(gotMapper map[string]string, expectedMapper map[string]string) bool {
 	if len(gotMapper) != len(expectedMapper) {
 		return false
@@ -18,6 +18,7 @@ package metrics
 
 import (
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -54,6 +55,24 @@ type HubMetrics struct {
 	proxyTrafficCollector                 *prometheus.CounterVec
 	errorKeysPersistencyStatusCollector   prometheus.Gauge
 	errorKeysCountCollector               prometheus.Gauge
+	cacheUsageCollector                   *prometheus.GaugeVec
+	cacheQuotaCollector                   *prometheus.GaugeVec
+	cacheEvictedObjectsCollector          *prometheus.CounterVec
+	resumedWatchEventsCollector           *prometheus.CounterVec
+	offlineWriteQueueLengthCollector      prometheus.Gauge
+	offlineWritesCollector                *prometheus.CounterVec
+	backendScoreCollector                 *prometheus.GaugeVec
+	rejectedRequestsCollector             *prometheus.CounterVec
+	savedTrafficCollector                 *prometheus.CounterVec
+	remoteTrafficCollector                *prometheus.CounterVec
+	remoteRequestsCollector               *prometheus.CounterVec
+	remoteWatchDurationCollector          *prometheus.HistogramVec
+	filterRuleEvaluationsCollector        *prometheus.CounterVec
+	leaderHubSwitchesCollector            *prometheus.CounterVec
+	multiplexerCacheAccessesCollector     *prometheus.CounterVec
+	multiplexerCacheSpillsCollector       *prometheus.CounterVec
+	droppedAuditDataCollector             *prometheus.CounterVec
+	trafficAccountant                     *trafficAccountant
 }
 
 func newHubMetrics() *HubMetrics {
@@ -133,6 +152,142 @@ func newHubMetrics() *HubMetrics {
 			Name:      "error_keys_count",
 			Help:      "error keys count",
 		})
+	cacheUsageCollector := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_usage_collector",
+			Help:      "collector of local cache usage by component or resource(unit: bytes or objects)",
+		},
+		[]string{"scope", "name", "unit"})
+	cacheQuotaCollector := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_quota_collector",
+			Help:      "collector of local cache quota by component or resource(unit: bytes or objects)",
+		},
+		[]string{"scope", "name", "unit"})
+	cacheEvictedObjectsCollector := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_evicted_objects_collector",
+			Help:      "collector of objects evicted from local cache because of quota",
+		},
+		[]string{"component", "resource"})
+	resumedWatchEventsCollector := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "resumed_watch_events_collector",
+			Help:      "collector of watch events served from local watch history when watch requests are resumed",
+		},
+		[]string{"client", "resource"})
+	offlineWriteQueueLengthCollector := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "offline_write_queue_length",
+			Help:      "length of write queue which records mutating requests while cloud is unreachable",
+		})
+	offlineWritesCollector := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "offline_writes_collector",
+			Help:      "collector of mutating requests recorded while cloud is unreachable by result(queued, superseded, rejected, replayed, conflicted, stale, failed)",
+		},
+		[]string{"resource", "result"})
+	backendScoreCollector := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "lb_backend_score",
+			Help:      "score of remote server evaluated by latency-aware load balancer, lower is better",
+		},
+		[]string{"server"})
+	rejectedRequestsCollector := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "rejected_requests_collector",
+			Help:      "collector of requests rejected by flow control by priority level, client and reason(rate-limited, queue-full, timeout)",
+		},
+		[]string{"level", "client", "reason"})
+	savedTrafficCollector := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "saved_traffic_bytes_collector",
+			Help:      "collector of bytes saved on the link to cloud by response compression and protobuf encoding by group, version and resource",
+		},
+		[]string{"group", "version", "resource"})
+	remoteTrafficCollector := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "remote_traffic_bytes_collector",
+			Help:      "collector of traffic between hub agent and remote servers by component, verb, resource, backend and direction(in, out)",
+		},
+		[]string{"component", "verb", "group", "version", "resource", "backend", "direction"})
+	remoteRequestsCollector := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "remote_requests_collector",
+			Help:      "collector of requests forwarded to remote servers by component, verb, resource and backend",
+		},
+		[]string{"component", "verb", "group", "version", "resource", "backend"})
+	remoteWatchDurationCollector := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "remote_watch_duration_seconds",
+			Help:      "duration of watch requests forwarded to remote servers by component, resource and backend",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+		},
+		[]string{"component", "group", "version", "resource", "backend"})
+	filterRuleEvaluationsCollector := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "filter_rule_evaluations_collector",
+			Help:      "collector of objects evaluated by rules of filter policies by policy, rule and result(dropped, patched, unmatched, error, cost-exceeded)",
+		},
+		[]string{"policy", "rule", "result"})
+	leaderHubSwitchesCollector := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "leader_hub_switches_collector",
+			Help:      "collector of switches of upstream for pool scope metadata between leader hubs and cloud kube-apiserver by from and to",
+		},
+		[]string{"from", "to"})
+	multiplexerCacheAccessesCollector := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "multiplexer_cache_accesses_collector",
+			Help:      "collector of objects read from multiplexer cache by result(hit means the object is in memory, miss means it's loaded from local disk)",
+		},
+		[]string{"resource", "result"})
+	multiplexerCacheSpillsCollector := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "multiplexer_cache_spills_collector",
+			Help:      "collector of objects spilled from memory to local disk because multiplexer cache exceeds its memory limit",
+		},
+		[]string{"resource"})
+	droppedAuditDataCollector := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "dropped_audit_data_collector",
+			Help:      "collector of audit data dropped because buffer is full by buffer(log and webhook: audit events, watch: chunks of watch response)",
+		},
+		[]string{"buffer"})
 	prometheus.MustRegister(serversHealthyCollector)
 	prometheus.MustRegister(inFlightRequestsCollector)
 	prometheus.MustRegister(inFlightRequestsGauge)
@@ -143,6 +298,23 @@ func newHubMetrics() *HubMetrics {
 	prometheus.MustRegister(proxyTrafficCollector)
 	prometheus.MustRegister(errorKeysPersistencyStatusCollector)
 	prometheus.MustRegister(errorKeysCountCollector)
+	prometheus.MustRegister(cacheUsageCollector)
+	prometheus.MustRegister(cacheQuotaCollector)
+	prometheus.MustRegister(cacheEvictedObjectsCollector)
+	prometheus.MustRegister(resumedWatchEventsCollector)
+	prometheus.MustRegister(offlineWriteQueueLengthCollector)
+	prometheus.MustRegister(offlineWritesCollector)
+	prometheus.MustRegister(backendScoreCollector)
+	prometheus.MustRegister(rejectedRequestsCollector)
+	prometheus.MustRegister(savedTrafficCollector)
+	prometheus.MustRegister(remoteTrafficCollector)
+	prometheus.MustRegister(remoteRequestsCollector)
+	prometheus.MustRegister(remoteWatchDurationCollector)
+	prometheus.MustRegister(filterRuleEvaluationsCollector)
+	prometheus.MustRegister(leaderHubSwitchesCollector)
+	prometheus.MustRegister(multiplexerCacheAccessesCollector)
+	prometheus.MustRegister(multiplexerCacheSpillsCollector)
+	prometheus.MustRegister(droppedAuditDataCollector)
 	return &HubMetrics{
 		serversHealthyCollector:               serversHealthyCollector,
 		inFlightRequestsCollector:             inFlightRequestsCollector,
@@ -154,6 +326,24 @@ func newHubMetrics() *HubMetrics {
 		proxyTrafficCollector:                 proxyTrafficCollector,
 		errorKeysPersistencyStatusCollector:   errorKeysPersistencyStatusCollector,
 		errorKeysCountCollector:               errorKeysCountCollector,
+		cacheUsageCollector:                   cacheUsageCollector,
+		cacheQuotaCollector:                   cacheQuotaCollector,
+		cacheEvictedObjectsCollector:          cacheEvictedObjectsCollector,
+		resumedWatchEventsCollector:           resumedWatchEventsCollector,
+		offlineWriteQueueLengthCollector:      offlineWriteQueueLengthCollector,
+		offlineWritesCollector:                offlineWritesCollector,
+		backendScoreCollector:                 backendScoreCollector,
+		rejectedRequestsCollector:             rejectedRequestsCollector,
+		savedTrafficCollector:                 savedTrafficCollector,
+		remoteTrafficCollector:                remoteTrafficCollector,
+		remoteRequestsCollector:               remoteRequestsCollector,
+		remoteWatchDurationCollector:          remoteWatchDurationCollector,
+		filterRuleEvaluationsCollector:        filterRuleEvaluationsCollector,
+		leaderHubSwitchesCollector:            leaderHubSwitchesCollector,
+		multiplexerCacheAccessesCollector:     multiplexerCacheAccessesCollector,
+		multiplexerCacheSpillsCollector:       multiplexerCacheSpillsCollector,
+		droppedAuditDataCollector:             droppedAuditDataCollector,
+		trafficAccountant:                     newTrafficAccountant(trafficBucketDuration, trafficBuckets),
 	}
 }
 
@@ -168,6 +358,24 @@ func (hm *HubMetrics) Reset() {
 	hm.proxyTrafficCollector.Reset()
 	hm.errorKeysPersistencyStatusCollector.Set(float64(0))
 	hm.errorKeysCountCollector.Set(float64(0))
+	hm.cacheUsageCollector.Reset()
+	hm.cacheQuotaCollector.Reset()
+	hm.cacheEvictedObjectsCollector.Reset()
+	hm.resumedWatchEventsCollector.Reset()
+	hm.offlineWriteQueueLengthCollector.Set(float64(0))
+	hm.offlineWritesCollector.Reset()
+	hm.backendScoreCollector.Reset()
+	hm.rejectedRequestsCollector.Reset()
+	hm.savedTrafficCollector.Reset()
+	hm.remoteTrafficCollector.Reset()
+	hm.remoteRequestsCollector.Reset()
+	hm.remoteWatchDurationCollector.Reset()
+	hm.filterRuleEvaluationsCollector.Reset()
+	hm.leaderHubSwitchesCollector.Reset()
+	hm.multiplexerCacheAccessesCollector.Reset()
+	hm.multiplexerCacheSpillsCollector.Reset()
+	hm.droppedAuditDataCollector.Reset()
+	hm.trafficAccountant.reset()
 }
 
 func (hm *HubMetrics) ObserveServerHealthy(server string, status int) {
@@ -231,3 +439,102 @@ func (hm *HubMetrics) IncErrorKeysCount() {
 func (hm *HubMetrics) DecErrorKeysCount() {
 	hm.errorKeysCountCollector.Dec()
 }
+
+func (hm *HubMetrics) SetCacheUsage(scope, name string, bytes, objects int64) {
+	hm.cacheUsageCollector.WithLabelValues(scope, name, "bytes").Set(float64(bytes))
+	hm.cacheUsageCollector.WithLabelValues(scope, name, "objects").Set(float64(objects))
+}
+
+func (hm *HubMetrics) SetCacheQuota(scope, name string, bytes, objects int64) {
+	if bytes > 0 {
+		hm.cacheQuotaCollector.WithLabelValues(scope, name, "bytes").Set(float64(bytes))
+	}
+	if objects > 0 {
+		hm.cacheQuotaCollector.WithLabelValues(scope, name, "objects").Set(float64(objects))
+	}
+}
+
+func (hm *HubMetrics) IncCacheEvictedObjects(component, resource string) {
+	hm.cacheEvictedObjectsCollector.WithLabelValues(component, resource).Inc()
+}
+
+func (hm *HubMetrics) AddResumedWatchEvents(client, resource string, cnt int) {
+	if cnt > 0 {
+		hm.resumedWatchEventsCollector.WithLabelValues(client, resource).Add(float64(cnt))
+	}
+}
+
+func (hm *HubMetrics) SetOfflineWriteQueueLength(length int) {
+	hm.offlineWriteQueueLengthCollector.Set(float64(length))
+}
+
+func (hm *HubMetrics) IncOfflineWrites(resource, result string) {
+	hm.offlineWritesCollector.WithLabelValues(resource, result).Inc()
+}
+
+func (hm *HubMetrics) ObserveBackendScore(server string, score float64) {
+	hm.backendScoreCollector.WithLabelValues(server).Set(score)
+}
+
+func (hm *HubMetrics) DeleteBackendScore(server string) {
+	hm.backendScoreCollector.DeleteLabelValues(server)
+}
+
+func (hm *HubMetrics) IncRejectedRequests(level, client, reason string) {
+	hm.rejectedRequestsCollector.WithLabelValues(level, client, reason).Inc()
+}
+
+func (hm *HubMetrics) AddSavedTrafficBytes(group, version, resource string, size int64) {
+	hm.savedTrafficCollector.WithLabelValues(group, version, resource).Add(float64(size))
+}
+
+func (hm *HubMetrics) AddRemoteTrafficBytes(key TrafficKey, in, out int64) {
+	if in > 0 {
+		hm.remoteTrafficCollector.WithLabelValues(key.Component, key.Verb, key.Group, key.Version, key.Resource, key.Backend, "in").Add(float64(in))
+	}
+	if out > 0 {
+		hm.remoteTrafficCollector.WithLabelValues(key.Component, key.Verb, key.Group, key.Version, key.Resource, key.Backend, "out").Add(float64(out))
+	}
+	if in > 0 || out > 0 {
+		hm.trafficAccountant.add(key, TrafficSummary{BytesIn: in, BytesOut: out})
+	}
+}
+
+func (hm *HubMetrics) IncRemoteRequests(key TrafficKey) {
+	hm.remoteRequestsCollector.WithLabelValues(key.Component, key.Verb, key.Group, key.Version, key.Resource, key.Backend).Inc()
+	hm.trafficAccountant.add(key, TrafficSummary{Requests: 1})
+}
+
+func (hm *HubMetrics) ObserveRemoteWatchDuration(key TrafficKey, duration time.Duration) {
+	hm.remoteWatchDurationCollector.WithLabelValues(key.Component, key.Group, key.Version, key.Resource, key.Backend).Observe(duration.Seconds())
+	hm.trafficAccountant.add(key, TrafficSummary{WatchSeconds: duration.Seconds()})
+}
+
+func (hm *HubMetrics) IncFilterRuleEvaluations(policy, rule, result string) {
+	hm.filterRuleEvaluationsCollector.WithLabelValues(policy, rule, result).Inc()
+}
+
+func (hm *HubMetrics) IncLeaderHubSwitches(from, to string) {
+	hm.leaderHubSwitchesCollector.WithLabelValues(from, to).Inc()
+}
+
+func (hm *HubMetrics) AddMultiplexerCacheAccesses(resource, result string, cnt int) {
+	if cnt > 0 {
+		hm.multiplexerCacheAccessesCollector.WithLabelValues(resource, result).Add(float64(cnt))
+	}
+}
+
+func (hm *HubMetrics) IncMultiplexerCacheSpills(resource string) {
+	hm.multiplexerCacheSpillsCollector.WithLabelValues(resource).Inc()
+}
+
+func (hm *HubMetrics) AddDroppedAuditData(buffer string, cnt int) {
+	if cnt > 0 {
+		hm.droppedAuditDataCollector.WithLabelValues(buffer).Add(float64(cnt))
+	}
+}
+
+// TopRemoteTraffic returns the top n traffic summaries in the rolling window sorted by sortBy, and the window duration.
+func (hm *HubMetrics) TopRemoteTraffic(n int, sortBy string) ([]TrafficSummary, time.Duration) {
+	return hm.trafficAccountant.top(n, sortBy), hm.trafficAccountant.window()
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// TrafficSortByBytes sorts traffic summaries by bytes received and sent.
+	TrafficSortByBytes = "bytes"
+	// TrafficSortByRequests sorts traffic summaries by request count.
+	TrafficSortByRequests = "requests"
+	// TrafficSortByWatch sorts traffic summaries by duration of watch requests.
+	TrafficSortByWatch = "watch"
+
+	// trafficBucketDuration is the granularity of rolling window of traffic summary.
+	trafficBucketDuration = time.Minute
+	// trafficBuckets is the count of buckets in rolling window of traffic summary.
+	trafficBuckets = 10
+)
+
+// TrafficKey identifies the traffic between yurthub and remote servers which is driven by a component.
+type TrafficKey struct {
+	Component string `json:"component"`
+	Verb      string `json:"verb"`
+	Group     string `json:"group"`
+	Version   string `json:"version"`
+	Resource  string `json:"resource"`
+	Backend   string `json:"backend"`
+}
+
+// TrafficSummary is the traffic of a TrafficKey in the rolling window. BytesIn is the size of
+// responses received from remote server, and BytesOut is the size of requests sent to remote server.
+type TrafficSummary struct {
+	TrafficKey
+	BytesIn      int64   `json:"bytesIn"`
+	BytesOut     int64   `json:"bytesOut"`
+	Requests     int64   `json:"requests"`
+	WatchSeconds float64 `json:"watchSeconds"`
+}
+
+func (s *TrafficSummary) add(other *TrafficSummary) {
+	s.BytesIn += other.BytesIn
+	s.BytesOut += other.BytesOut
+	s.Requests += other.Requests
+	s.WatchSeconds += other.WatchSeconds
+}
+
+type trafficBucket struct {
+	start time.Time
+	stats map[TrafficKey]*TrafficSummary
+}
+
+// trafficAccountant aggregates traffic into buckets of a rolling window,
+// so the top talkers of recent period can be figured out.
+type trafficAccountant struct {
+	sync.Mutex
+	bucketDuration time.Duration
+	buckets        []trafficBucket
+	now            func() time.Time
+}
+
+func newTrafficAccountant(bucketDuration time.Duration, buckets int) *trafficAccountant {
+	return &trafficAccountant{
+		bucketDuration: bucketDuration,
+		buckets:        make([]trafficBucket, buckets),
+		now:            time.Now,
+	}
+}
+
+// window returns the duration covered by the rolling window.
+func (ta *trafficAccountant) window() time.Duration {
+	return ta.bucketDuration * time.Duration(len(ta.buckets))
+}
+
+func (ta *trafficAccountant) add(key TrafficKey, delta TrafficSummary) {
+	ta.Lock()
+	defer ta.Unlock()
+	start := ta.now().Truncate(ta.bucketDuration)
+	bucket := &ta.buckets[(start.UnixNano()/int64(ta.bucketDuration))%int64(len(ta.buckets))]
+	if !bucket.start.Equal(start) {
+		bucket.start = start
+		bucket.stats = make(map[TrafficKey]*TrafficSummary)
+	}
+
+	summary, ok := bucket.stats[key]
+	if !ok {
+		summary = &TrafficSummary{TrafficKey: key}
+		bucket.stats[key] = summary
+	}
+	summary.add(&delta)
+}
+
+// top returns at most n summaries with the most traffic in the rolling window, all summaries are returned if n <= 0.
+func (ta *trafficAccountant) top(n int, sortBy string) []TrafficSummary {
+	ta.Lock()
+	oldest := ta.now().Truncate(ta.bucketDuration).Add(-ta.window())
+	merged := make(map[TrafficKey]*TrafficSummary)
+	for i := range ta.buckets {
+		if !ta.buckets[i].start.After(oldest) {
+			continue
+		}
+		for key, stat := range ta.buckets[i].stats {
+			summary, ok := merged[key]
+			if !ok {
+				summary = &TrafficSummary{TrafficKey: key}
+				merged[key] = summary
+			}
+			summary.add(stat)
+		}
+	}
+	ta.Unlock()
+
+	result := make([]TrafficSummary, 0, len(merged))
+	for _, summary := range merged {
+		result = append(result, *summary)
+	}
+	less := func(a, b *TrafficSummary) bool { return a.BytesIn+a.BytesOut > b.BytesIn+b.BytesOut }
+	switch sortBy {
+	case TrafficSortByRequests:
+		less = func(a, b *TrafficSummary) bool { return a.Requests > b.Requests }
+	case TrafficSortByWatch:
+		less = func(a, b *TrafficSummary) bool { return a.WatchSeconds > b.WatchSeconds }
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if less(&result[i], &result[j]) {
+			return true
+		} else if less(&result[j], &result[i]) {
+			return false
+		}
+		return trafficKeyString(result[i].TrafficKey) < trafficKeyString(result[j].TrafficKey)
+	})
+
+	if n > 0 && len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+func (ta *trafficAccountant) reset() {
+	ta.Lock()
+	defer ta.Unlock()
+	ta.buckets = make([]trafficBucket, len(ta.buckets))
+}
+
+func trafficKeyString(key TrafficKey) string {
+	return key.Component + "/" + key.Verb + "/" + key.Group + "/" + key.Version + "/" + key.Resource + "/" + key.Backend
+}
+
+// IsSupportedTrafficSortBy checks the field for sorting traffic summaries is supported or not.
+func IsSupportedTrafficSortBy(sortBy string) bool {
+	switch sortBy {
+	case TrafficSortByBytes, TrafficSortByRequests, TrafficSortByWatch:
+		return true
+	}
+	return false
+}
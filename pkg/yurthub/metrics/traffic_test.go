@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTrafficAccountant(t *testing.T) {
+	operator := TrafficKey{Component: "operator", Verb: "list", Version: "v1", Resource: "configmaps", Backend: "https://10.0.0.1:6443"}
+	kubelet := TrafficKey{Component: "kubelet", Verb: "watch", Version: "v1", Resource: "pods", Backend: "https://10.0.0.1:6443"}
+	proxy := TrafficKey{Component: "kube-proxy", Verb: "list", Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices", Backend: "https://10.0.0.1:6443"}
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ta := newTrafficAccountant(time.Minute, 10)
+	ta.now = func() time.Time { return now }
+
+	// traffic out of the rolling window should be dropped.
+	ta.add(proxy, TrafficSummary{BytesIn: 1 << 20, Requests: 100})
+	now = now.Add(10 * time.Minute)
+	ta.add(operator, TrafficSummary{BytesIn: 1000, BytesOut: 100, Requests: 1})
+	ta.add(kubelet, TrafficSummary{BytesIn: 500, Requests: 1, WatchSeconds: 300})
+	now = now.Add(5 * time.Minute)
+	ta.add(operator, TrafficSummary{BytesIn: 1000, Requests: 1})
+	ta.add(kubelet, TrafficSummary{Requests: 1})
+
+	testcases := map[string]struct {
+		n      int
+		sortBy string
+		expect []TrafficSummary
+	}{
+		"sort by bytes": {
+			sortBy: TrafficSortByBytes,
+			expect: []TrafficSummary{
+				{TrafficKey: operator, BytesIn: 2000, BytesOut: 100, Requests: 2},
+				{TrafficKey: kubelet, BytesIn: 500, Requests: 2, WatchSeconds: 300},
+			},
+		},
+		"sort by watch": {
+			sortBy: TrafficSortByWatch,
+			expect: []TrafficSummary{
+				{TrafficKey: kubelet, BytesIn: 500, Requests: 2, WatchSeconds: 300},
+				{TrafficKey: operator, BytesIn: 2000, BytesOut: 100, Requests: 2},
+			},
+		},
+		"ties are sorted by key": {
+			n:      1,
+			sortBy: TrafficSortByRequests,
+			expect: []TrafficSummary{
+				{TrafficKey: kubelet, BytesIn: 500, Requests: 2, WatchSeconds: 300},
+			},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			if got := ta.top(tc.n, tc.sortBy); !reflect.DeepEqual(got, tc.expect) {
+				t.Errorf("expect top traffic %#v, but got %#v", tc.expect, got)
+			}
+		})
+	}
+
+	now = now.Add(10 * time.Minute)
+	if got := ta.top(0, TrafficSortByBytes); len(got) != 0 {
+		t.Errorf("expect no traffic after window passed, but got %#v", got)
+	}
+}
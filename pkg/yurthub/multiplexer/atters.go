@@ -18,6 +18,7 @@ package multiplexer
 
 import (
 	"fmt"
+	"strconv"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -56,11 +57,67 @@ var AttrsFuncMap = map[string]kstorage.AttrFunc{
 	schema.GroupVersionResource{Group: "", Version: "v1", Resource: "services"}.String(): ServiceGetAttrs,
 }
 
+const (
+	// maxSelectableFieldDepth is the max depth of nested fields which can be selected by field selector.
+	maxSelectableFieldDepth = 5
+	// maxSelectableFieldValueLength is the max length of field values which can be selected by field selector,
+	// so long values(like data of configmaps) don't waste memory of cache.
+	maxSelectableFieldValueLength = 256
+)
+
 func GetAttrsFunc(gvr *schema.GroupVersionResource) kstorage.AttrFunc {
 	if _, exist := AttrsFuncMap[gvr.String()]; exist {
 		return AttrsFuncMap[gvr.String()]
 	}
-	return DefaultAttrsFunc
+	return GenericGetAttrs
+}
+
+// GenericGetAttrs extracts labels and selectable fields of any object. besides metadata.name and metadata.namespace,
+// scalar fields out of metadata of custom resources, which are handled as unstructured objects, are selectable by
+// their paths(like spec.nodeName), except for fields in lists and deeply nested fields. typed objects are not
+// converted into unstructured objects for every event, so only fields of metadata are selectable for typed objects
+// unless the attrs function of resource is registered in AttrsFuncMap.
+func GenericGetAttrs(obj runtime.Object) (labels.Set, fields.Set, error) {
+	labelSet, fieldSet, err := DefaultAttrsFunc(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	u, ok := obj.(runtime.Unstructured)
+	if !ok {
+		return labelSet, fieldSet, nil
+	}
+
+	for key, value := range u.UnstructuredContent() {
+		switch key {
+		case "apiVersion", "kind", "metadata":
+			continue
+		}
+		addSelectableFields(fieldSet, key, value, 1)
+	}
+	return labelSet, fieldSet, nil
+}
+
+func addSelectableFields(fieldSet fields.Set, path string, value interface{}, depth int) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if depth >= maxSelectableFieldDepth {
+			return
+		}
+		for key, nested := range v {
+			addSelectableFields(fieldSet, path+"."+key, nested, depth+1)
+		}
+	case string:
+		if len(v) <= maxSelectableFieldValueLength {
+			fieldSet[path] = v
+		}
+	case bool:
+		fieldSet[path] = strconv.FormatBool(v)
+	case int64:
+		fieldSet[path] = strconv.FormatInt(v, 10)
+	case float64:
+		fieldSet[path] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
 }
 
 func ServiceGetAttrs(obj runtime.Object) (labels.Set, fields.Set, error) {
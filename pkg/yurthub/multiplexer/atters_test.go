@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiplexer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGenericGetAttrs(t *testing.T) {
+	testcases := map[string]struct {
+		obj            runtime.Object
+		expectedLabels labels.Set
+		expectedFields fields.Set
+	}{
+		"custom resource": {
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "stable.example.com/v1",
+					"kind":       "CronTab",
+					"metadata": map[string]interface{}{
+						"name":      "backup",
+						"namespace": "default",
+						"labels":    map[string]interface{}{"app": "db"},
+					},
+					"spec": map[string]interface{}{
+						"image":    "busybox",
+						"replicas": int64(2),
+						"suspend":  false,
+						"ratio":    0.5,
+						"data":     strings.Repeat("x", maxSelectableFieldValueLength+1),
+						"args":     []interface{}{"a", "b"},
+						"a": map[string]interface{}{
+							"b": map[string]interface{}{
+								"c": map[string]interface{}{
+									"d": "nested",
+									"e": map[string]interface{}{"f": "too deep"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedLabels: labels.Set{"app": "db"},
+			expectedFields: fields.Set{
+				"metadata.name":      "backup",
+				"metadata.namespace": "default",
+				"spec.image":         "busybox",
+				"spec.replicas":      "2",
+				"spec.suspend":       "false",
+				"spec.ratio":         "0.5",
+				"spec.a.b.c.d":       "nested",
+			},
+		},
+		"typed object": {
+			obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "nginx",
+					Namespace: "default",
+				},
+				Spec: v1.PodSpec{
+					NodeName: "node1",
+				},
+				Status: v1.PodStatus{
+					Phase: v1.PodRunning,
+				},
+			},
+			expectedLabels: labels.Set{},
+			expectedFields: fields.Set{
+				"metadata.name":      "nginx",
+				"metadata.namespace": "default",
+			},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			labelSet, fieldSet, err := GenericGetAttrs(tc.obj)
+			if err != nil {
+				t.Fatalf("could not get attrs, %v", err)
+			}
+			if len(labelSet) != len(tc.expectedLabels) || !labels.Equals(labelSet, tc.expectedLabels) {
+				t.Errorf("expect labels %v, but got %v", tc.expectedLabels, labelSet)
+			}
+			if !reflect.DeepEqual(fieldSet, tc.expectedFields) {
+				t.Errorf("expect fields %v, but got %v", tc.expectedFields, fieldSet)
+			}
+		})
+	}
+}
@@ -0,0 +1,1007 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiplexer
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	kstorage "k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/cacher"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+	hubstorage "github.com/openyurtio/openyurt/pkg/yurthub/storage"
+)
+
+const (
+	// spillComponent is the component under which objects spilled by multiplexer cache are saved in the store.
+	spillComponent = "multiplexer"
+	// boundedCacheEventsSize is the number of recent events which are kept for resuming watch requests.
+	boundedCacheEventsSize = 1024
+	// boundedCacheEventsMemoryFraction means that a quarter of memory limit is used for keeping recent events,
+	// and the rest is used for keeping objects.
+	boundedCacheEventsMemoryFraction = 4
+	// boundedCacheLoadRetries is the max times of loading a spilled object which is changed while it's loaded.
+	boundedCacheLoadRetries = 3
+	// boundedCacheWatcherBufferSize is the number of events buffered for a watcher, the watcher which can't
+	// keep up with events is terminated, and the client will watch again from its last resource version.
+	boundedCacheWatcherBufferSize = 100
+	// freshResourceVersionTimeout is the max duration of waiting for cache to catch up with the requested resource version.
+	freshResourceVersionTimeout = 3 * time.Second
+)
+
+type boundedCacheEntry struct {
+	key       string
+	namespace string
+	name      string
+	rv        uint64
+	labels    labels.Set
+	fields    fields.Set
+	size      int64
+	// obj is nil when the object has been spilled into the store.
+	obj     runtime.Object
+	element *list.Element
+	// spilling is true while the object is written into the store without holding the lock, the object
+	// is still read from memory, but it's not in lru any more.
+	spilling bool
+}
+
+type boundedCacheEvent struct {
+	eventType watch.EventType
+	key       string
+	rv        uint64
+	obj       runtime.Object
+	labels    labels.Set
+	fields    fields.Set
+	// hasPrev is true when the object existed before the event, and prevObj is nil when the previous
+	// object was spilled and not loaded because no watcher needed it.
+	hasPrev    bool
+	prevObj    runtime.Object
+	prevLabels labels.Set
+	prevFields fields.Set
+	// size is the size of objects kept by the event.
+	size int64
+}
+
+// boundedCacheItem is an object read from cache, obj is nil until the spilled object is loaded.
+type boundedCacheItem struct {
+	entry *boundedCacheEntry
+	obj   runtime.Object
+}
+
+// boundedCache is the multiplexer cache whose memory usage is bounded. objects are list/watched from the storage
+// in the same way as cacher, and recently written or read objects are kept in memory until their size exceeds
+// the memory limit, then the least recently used objects are spilled into the store on local disk and loaded
+// when they are read. labels and fields of all objects are kept in memory, so selectors are matched without
+// loading objects, and watch requests are served from recent events just like cacher. recent events are kept
+// within a share of the memory limit, and spilled objects are written and read without holding the lock.
+type boundedCache struct {
+	// Interface is the underlying storage, requests which are not served by cache are delegated to it.
+	kstorage.Interface
+	resource     schema.GroupVersionResource
+	resourceName string
+	config       *resourceCacheConfig
+	store        hubstorage.Store
+	// maxBytes is the memory limit of objects, and maxEventBytes is the memory limit of recent events.
+	maxBytes      int64
+	maxEventBytes int64
+	versioner     kstorage.Versioner
+	serializer    runtime.Serializer
+	reflector     *cache.Reflector
+	readyCh       chan struct{}
+	readyOnce     sync.Once
+	stopCh        chan struct{}
+	stopOnce      sync.Once
+
+	sync.Mutex
+	rv uint64
+	// oldestRV is the resource version after which all events are kept in events.
+	oldestRV   uint64
+	entries    map[string]*boundedCacheEntry
+	lru        *list.List
+	usedBytes  int64
+	events     []*boundedCacheEvent
+	eventBytes int64
+	watchers   map[int]*boundedCacheWatcher
+	watcherID  int
+}
+
+func newBoundedCache(
+	s kstorage.Interface,
+	resource *schema.GroupVersionResource,
+	config *resourceCacheConfig,
+	store hubstorage.Store,
+	maxBytes int64) (kstorage.Interface, func(), error) {
+	// objects spilled by the last run of yurthub are stale, because all objects will be listed again.
+	if err := store.ReplaceComponentList(spillComponent, *resource, "", nil); err != nil {
+		return nil, func() {}, fmt.Errorf("failed to clean spilled objects of %s, error: %v", resource.String(), err)
+	}
+
+	maxEventBytes := maxBytes / boundedCacheEventsMemoryFraction
+	c := &boundedCache{
+		Interface:     s,
+		resource:      *resource,
+		resourceName:  resource.GroupResource().String(),
+		config:        config,
+		store:         store,
+		maxBytes:      maxBytes - maxEventBytes,
+		maxEventBytes: maxEventBytes,
+		versioner:     kstorage.APIObjectVersioner{},
+		serializer:    json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme.Scheme, scheme.Scheme, json.SerializerOptions{}),
+		readyCh:       make(chan struct{}),
+		stopCh:        make(chan struct{}),
+		entries:       make(map[string]*boundedCacheEntry),
+		lru:           list.New(),
+		watchers:      make(map[int]*boundedCacheWatcher),
+	}
+	metrics.Metrics.SetCacheQuota(spillComponent, c.resourceName, maxBytes, 0)
+
+	lw := cacher.NewListerWatcher(s, "", config.NewListFunc, nil)
+	c.reflector = cache.NewReflectorWithOptions(lw, config.NewFunc(), reflectorStore{cache: c}, cache.ReflectorOptions{
+		Name: fmt.Sprintf("multiplexer bounded cache for %s", resource.String()),
+	})
+	go c.reflector.Run(c.stopCh)
+
+	destroyFunc := func() {
+		c.stopOnce.Do(func() {
+			close(c.stopCh)
+			c.Lock()
+			defer c.Unlock()
+			c.stopAllWatchersLocked()
+		})
+	}
+	return c, destroyFunc, nil
+}
+
+// reflectorStore receives objects and events from reflector for boundedCache.
+type reflectorStore struct {
+	cache *boundedCache
+}
+
+// Add implements cache.ReflectorStore interface.
+func (s reflectorStore) Add(obj interface{}) error {
+	return s.cache.processEvent(watch.Added, obj)
+}
+
+// Update implements cache.ReflectorStore interface.
+func (s reflectorStore) Update(obj interface{}) error {
+	return s.cache.processEvent(watch.Modified, obj)
+}
+
+// Delete implements cache.ReflectorStore interface.
+func (s reflectorStore) Delete(obj interface{}) error {
+	return s.cache.processEvent(watch.Deleted, obj)
+}
+
+// Replace implements cache.ReflectorStore interface.
+func (s reflectorStore) Replace(objs []interface{}, resourceVersion string) error {
+	return s.cache.replace(objs, resourceVersion)
+}
+
+// Resync implements cache.ReflectorStore interface.
+func (s reflectorStore) Resync() error {
+	return nil
+}
+
+// UpdateResourceVersion implements cache.ResourceVersionUpdater interface.
+func (s reflectorStore) UpdateResourceVersion(resourceVersion string) {
+	s.cache.updateResourceVersion(resourceVersion)
+}
+
+// replace replaces all objects with the listed objects. all watchers are stopped when objects are replaced,
+// because events between the old and new list are lost, and clients should list again.
+func (c *boundedCache) replace(objs []interface{}, resourceVersion string) error {
+	rv, err := c.versioner.ParseResourceVersion(resourceVersion)
+	if err != nil {
+		return err
+	}
+
+	entries := make(map[string]*boundedCacheEntry, len(objs))
+	for _, obj := range objs {
+		object, ok := obj.(runtime.Object)
+		if !ok {
+			return fmt.Errorf("%v is not a runtime.Object", obj)
+		}
+		key, entry, err := c.newEntry(object)
+		if err != nil {
+			return err
+		}
+		entries[key] = entry
+	}
+
+	c.Lock()
+	c.stopAllWatchersLocked()
+	if err := c.store.ReplaceComponentList(spillComponent, c.resource, "", nil); err != nil {
+		klog.Errorf("could not clean spilled objects of %s, %v", c.resource.String(), err)
+	}
+	c.entries = make(map[string]*boundedCacheEntry, len(entries))
+	c.lru.Init()
+	c.usedBytes = 0
+	victims := make([]*boundedCacheEntry, 0)
+	for _, entry := range entries {
+		victims = append(victims, c.addLocked(entry)...)
+	}
+	c.rv = rv
+	c.oldestRV = rv
+	c.events = nil
+	c.eventBytes = 0
+	c.reportUsageLocked()
+	c.Unlock()
+	c.spill(victims)
+
+	c.readyOnce.Do(func() {
+		close(c.readyCh)
+	})
+	return nil
+}
+
+// updateResourceVersion is called when bookmark events are received, and bookmark events
+// are sent to watchers which allow them.
+func (c *boundedCache) updateResourceVersion(resourceVersion string) {
+	rv, err := c.versioner.ParseResourceVersion(resourceVersion)
+	if err != nil || rv == 0 {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if rv > c.rv {
+		c.rv = rv
+	}
+	event := &boundedCacheEvent{eventType: watch.Bookmark, rv: c.rv}
+	for _, w := range c.watchers {
+		if !w.pred.AllowWatchBookmarks {
+			continue
+		}
+		// bookmark events are optional, so they are dropped when watcher is busy.
+		select {
+		case w.input <- event:
+		default:
+		}
+	}
+}
+
+func (c *boundedCache) processEvent(eventType watch.EventType, obj interface{}) error {
+	object, ok := obj.(runtime.Object)
+	if !ok {
+		return fmt.Errorf("%v is not a runtime.Object", obj)
+	}
+	key, entry, err := c.newEntry(object)
+	if err != nil {
+		return err
+	}
+	event := &boundedCacheEvent{
+		eventType: eventType,
+		key:       key,
+		rv:        entry.rv,
+		obj:       object,
+		labels:    entry.labels,
+		fields:    entry.fields,
+		size:      entry.size,
+	}
+
+	// the previous object is only needed by watchers with selectors for converting events, so the spilled
+	// previous object is loaded only for them and without holding the lock. entries are only changed by
+	// reflector, so the previous entry is still there after it's loaded.
+	c.Lock()
+	prev := c.entries[key]
+	var prevObj runtime.Object
+	if prev != nil {
+		prevObj = prev.obj
+	}
+	loadPrev := prev != nil && prevObj == nil && c.hasSelectiveWatcherLocked(key)
+	c.Unlock()
+	if loadPrev {
+		if _, prevObj, err = c.loadEntry(prev); err != nil {
+			klog.Errorf("could not load previous object %s of %s, %v", key, c.resource.String(), err)
+		}
+	}
+
+	c.Lock()
+	var victims []*boundedCacheEntry
+	if prev, ok := c.entries[key]; ok {
+		if prevObj == nil {
+			prevObj = prev.obj
+		}
+		event.hasPrev, event.prevObj, event.prevLabels, event.prevFields = true, prevObj, prev.labels, prev.fields
+		if prevObj != nil {
+			event.size += prev.size
+		}
+		c.removeLocked(prev)
+	}
+	if eventType != watch.Deleted {
+		victims = c.addLocked(entry)
+	}
+	if entry.rv > c.rv {
+		c.rv = entry.rv
+	}
+
+	c.events = append(c.events, event)
+	c.eventBytes += event.size
+	for len(c.events) > boundedCacheEventsSize || (len(c.events) != 0 && c.eventBytes > c.maxEventBytes) {
+		c.oldestRV = c.events[0].rv
+		c.eventBytes -= c.events[0].size
+		c.events[0] = nil
+		c.events = c.events[1:]
+	}
+	for id, w := range c.watchers {
+		if !w.keyMatches(key) {
+			continue
+		}
+		select {
+		case w.input <- event:
+		default:
+			klog.Warningf("watcher of %s is stopped, because it can't keep up with events", c.resource.String())
+			c.stopWatcherLocked(id, w)
+		}
+	}
+	c.reportUsageLocked()
+	c.Unlock()
+	c.spill(victims)
+	return nil
+}
+
+// hasSelectiveWatcherLocked returns whether any watcher of key has label or field selectors.
+func (c *boundedCache) hasSelectiveWatcherLocked(key string) bool {
+	for _, w := range c.watchers {
+		if w.keyMatches(key) && !w.pred.Empty() {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *boundedCache) newEntry(obj runtime.Object) (string, *boundedCacheEntry, error) {
+	key, err := c.config.KeyFunc(obj)
+	if err != nil {
+		return "", nil, err
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return "", nil, err
+	}
+	rv, err := c.versioner.ObjectResourceVersion(obj)
+	if err != nil {
+		return "", nil, err
+	}
+	labelSet, fieldSet, err := c.config.GetAttrsFunc(obj)
+	if err != nil {
+		return "", nil, err
+	}
+	size, err := c.estimateSize(obj)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return key, &boundedCacheEntry{
+		key:       key,
+		namespace: accessor.GetNamespace(),
+		name:      accessor.GetName(),
+		rv:        rv,
+		labels:    labelSet,
+		fields:    fieldSet,
+		size:      size,
+		obj:       obj,
+	}, nil
+}
+
+// estimateSize estimates the memory usage of object without encoding it. the size of protobuf encoding is
+// used for typed objects, and the size of keys and values is summed up for unstructured objects.
+func (c *boundedCache) estimateSize(obj runtime.Object) (int64, error) {
+	switch o := obj.(type) {
+	case runtime.Unstructured:
+		return unstructuredSize(o.UnstructuredContent()), nil
+	case interface{ Size() int }:
+		return int64(o.Size()), nil
+	}
+	data, err := c.encode(obj)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(data)), nil
+}
+
+func unstructuredSize(value interface{}) int64 {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		var size int64
+		for key, item := range v {
+			size += int64(len(key)) + unstructuredSize(item)
+		}
+		return size
+	case []interface{}:
+		var size int64
+		for _, item := range v {
+			size += unstructuredSize(item)
+		}
+		return size
+	case string:
+		return int64(len(v))
+	default:
+		// numbers, booleans and null
+		return 8
+	}
+}
+
+// addLocked adds the entry as the most recently used one, and returns the least recently used objects
+// which should be spilled into the store by spill when memory limit is exceeded.
+func (c *boundedCache) addLocked(entry *boundedCacheEntry) []*boundedCacheEntry {
+	entry.element = c.lru.PushFront(entry.key)
+	c.entries[entry.key] = entry
+	c.usedBytes += entry.size
+
+	var victims []*boundedCacheEntry
+	for c.usedBytes > c.maxBytes && c.lru.Len() != 0 {
+		element := c.lru.Back()
+		spilled := c.entries[element.Value.(string)]
+		c.lru.Remove(element)
+		c.usedBytes -= spilled.size
+		spilled.element, spilled.spilling = nil, true
+		victims = append(victims, spilled)
+	}
+	return victims
+}
+
+// spill writes objects of victims into the store without holding the lock, and releases them from memory after
+// they are written. entries are only changed by reflector which spills objects, so victims are not removed or
+// replaced while they are spilled. objects which can't be spilled are kept in memory as least recently used ones.
+func (c *boundedCache) spill(victims []*boundedCacheEntry) {
+	for i, entry := range victims {
+		err := c.writeSpilled(entry)
+
+		c.Lock()
+		if err != nil {
+			klog.Errorf("could not spill object %s of %s, %v", entry.key, c.resource.String(), err)
+			for _, kept := range victims[i:] {
+				kept.element, kept.spilling = c.lru.PushBack(kept.key), false
+				c.usedBytes += kept.size
+			}
+			c.reportUsageLocked()
+			c.Unlock()
+			return
+		}
+		entry.obj, entry.spilling = nil, false
+		c.Unlock()
+		metrics.Metrics.IncMultiplexerCacheSpills(c.resourceName)
+	}
+}
+
+func (c *boundedCache) writeSpilled(entry *boundedCacheEntry) error {
+	data, err := c.encode(entry.obj)
+	if err != nil {
+		return err
+	}
+	key, err := c.storeKey(entry)
+	if err != nil {
+		return err
+	}
+
+	err = c.store.Create(key, data)
+	if errors.Is(err, hubstorage.ErrKeyExists) {
+		// the object may be left in the store when it could not be deleted after loaded into memory.
+		if err = c.store.Delete(key); err == nil {
+			err = c.store.Create(key, data)
+		}
+	}
+	return err
+}
+
+func (c *boundedCache) removeLocked(entry *boundedCacheEntry) {
+	delete(c.entries, entry.key)
+	if entry.obj != nil {
+		if !entry.spilling {
+			c.lru.Remove(entry.element)
+			c.usedBytes -= entry.size
+		}
+		return
+	}
+
+	storeKey, err := c.storeKey(entry)
+	if err == nil {
+		err = c.store.Delete(storeKey)
+	}
+	if err != nil && !errors.Is(err, hubstorage.ErrStorageNotFound) {
+		klog.Errorf("could not delete spilled object %s of %s, %v", entry.key, c.resource.String(), err)
+	}
+}
+
+// matchEntriesLocked returns entries of objects which match key and pred, and they are sorted by key.
+func (c *boundedCache) matchEntriesLocked(key string, recursive bool, pred kstorage.SelectionPredicate) []*boundedCacheEntry {
+	entries := make([]*boundedCacheEntry, 0)
+	for k, entry := range c.entries {
+		if keyMatches(key, recursive, k) && pred.MatchesObjectAttributes(entry.labels, entry.fields) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	return entries
+}
+
+// snapshotLocked returns items of entries, objects in memory are returned directly, and spilled objects
+// should be loaded by loadItems without holding the lock.
+func (c *boundedCache) snapshotLocked(entries []*boundedCacheEntry) []boundedCacheItem {
+	items := make([]boundedCacheItem, 0, len(entries))
+	for _, entry := range entries {
+		if entry.obj != nil && !entry.spilling {
+			c.lru.MoveToFront(entry.element)
+		}
+		items = append(items, boundedCacheItem{entry: entry, obj: entry.obj})
+	}
+	return items
+}
+
+// loadItems loads spilled objects of items from the store without holding the lock, so reading disk doesn't
+// block events and other requests. objects may be updated or deleted while they are loaded, then current
+// objects are returned if they still match pred, and deleted objects are dropped.
+func (c *boundedCache) loadItems(items []boundedCacheItem, pred kstorage.SelectionPredicate) ([]boundedCacheItem, error) {
+	result := make([]boundedCacheItem, 0, len(items))
+	loaded := make([]boundedCacheItem, 0)
+	var hits, misses int
+	for _, item := range items {
+		if item.obj != nil {
+			hits++
+			result = append(result, item)
+			continue
+		}
+
+		misses++
+		entry, obj, err := c.loadEntry(item.entry)
+		if err != nil {
+			return nil, kstorage.NewInternalError(fmt.Errorf("could not load object %s, %v", item.entry.key, err))
+		}
+		if obj == nil || (entry != item.entry && !pred.MatchesObjectAttributes(entry.labels, entry.fields)) {
+			continue
+		}
+		item = boundedCacheItem{entry: entry, obj: obj}
+		result = append(result, item)
+		loaded = append(loaded, item)
+	}
+
+	if len(loaded) != 0 {
+		c.Lock()
+		for _, item := range loaded {
+			c.keepLocked(item.entry, item.obj)
+		}
+		c.reportUsageLocked()
+		c.Unlock()
+	}
+	metrics.Metrics.AddMultiplexerCacheAccesses(c.resourceName, "hit", hits)
+	metrics.Metrics.AddMultiplexerCacheAccesses(c.resourceName, "miss", misses)
+	return result, nil
+}
+
+// loadEntry reads the spilled object of entry from the store without holding the lock. the object may be
+// loaded into memory, updated or deleted by others while it's read, so the current entry of the key is
+// checked again when the object can't be read, and nil object is returned when it has been deleted.
+func (c *boundedCache) loadEntry(entry *boundedCacheEntry) (*boundedCacheEntry, runtime.Object, error) {
+	var err error
+	for i := 0; i < boundedCacheLoadRetries; i++ {
+		var obj runtime.Object
+		if obj, err = c.readSpilled(entry); err == nil {
+			return entry, obj, nil
+		}
+
+		c.Lock()
+		current, ok := c.entries[entry.key]
+		if ok {
+			obj = current.obj
+		}
+		c.Unlock()
+		if !ok {
+			return nil, nil, nil
+		} else if obj != nil {
+			return current, obj, nil
+		}
+		entry = current
+	}
+	return nil, nil, err
+}
+
+func (c *boundedCache) readSpilled(entry *boundedCacheEntry) (runtime.Object, error) {
+	storeKey, err := c.storeKey(entry)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.store.Get(storeKey)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := c.decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// the spilled object may be replaced by a newer one of the same key.
+	rv, err := c.versioner.ObjectResourceVersion(obj)
+	if err != nil {
+		return nil, err
+	} else if rv != entry.rv {
+		return nil, fmt.Errorf("spilled object %s is changed from resource version %d to %d", entry.key, entry.rv, rv)
+	}
+	return obj, nil
+}
+
+// keepLocked keeps the loaded object of entry in memory again only when there is enough space, so reading
+// all objects(like list requests) doesn't spill other objects.
+func (c *boundedCache) keepLocked(entry *boundedCacheEntry, obj runtime.Object) {
+	if c.entries[entry.key] != entry || entry.obj != nil || c.usedBytes+entry.size > c.maxBytes {
+		return
+	}
+
+	storeKey, err := c.storeKey(entry)
+	if err == nil {
+		err = c.store.Delete(storeKey)
+	}
+	if err != nil {
+		klog.Warningf("could not delete spilled object %s of %s, %v", entry.key, c.resource.String(), err)
+	}
+	entry.obj = obj
+	entry.element = c.lru.PushFront(entry.key)
+	c.usedBytes += entry.size
+}
+
+func (c *boundedCache) storeKey(entry *boundedCacheEntry) (hubstorage.Key, error) {
+	return c.store.KeyFunc(hubstorage.KeyBuildInfo{
+		Component: spillComponent,
+		Group:     c.resource.Group,
+		Version:   c.resource.Version,
+		Resources: c.resource.Resource,
+		Namespace: entry.namespace,
+		Name:      entry.name,
+	})
+}
+
+func (c *boundedCache) encode(obj runtime.Object) ([]byte, error) {
+	if _, ok := obj.(runtime.Unstructured); ok {
+		return runtime.Encode(unstructured.UnstructuredJSONScheme, obj)
+	}
+	return runtime.Encode(c.serializer, obj)
+}
+
+func (c *boundedCache) decode(data []byte) (runtime.Object, error) {
+	into := c.config.NewFunc()
+	if _, ok := into.(runtime.Unstructured); ok {
+		obj, _, err := unstructured.UnstructuredJSONScheme.Decode(data, nil, into)
+		return obj, err
+	}
+	obj, _, err := c.serializer.Decode(data, nil, into)
+	return obj, err
+}
+
+func (c *boundedCache) reportUsageLocked() {
+	metrics.Metrics.SetCacheUsage(spillComponent, c.resourceName, c.usedBytes+c.eventBytes, int64(c.lru.Len()))
+}
+
+func (c *boundedCache) waitUntilReady(ctx context.Context) error {
+	select {
+	case <-c.readyCh:
+		return nil
+	case <-c.stopCh:
+	case <-ctx.Done():
+	}
+	return apierrors.NewServiceUnavailable(fmt.Sprintf("multiplexer cache of %s is not ready", c.resource.String()))
+}
+
+func (c *boundedCache) waitUntilFresh(ctx context.Context, rv uint64) error {
+	var current uint64
+	err := wait.PollUntilContextTimeout(ctx, 50*time.Millisecond, freshResourceVersionTimeout, true, func(context.Context) (bool, error) {
+		c.Lock()
+		defer c.Unlock()
+		current = c.rv
+		return current >= rv, nil
+	})
+	if err != nil {
+		return kstorage.NewTooLargeResourceVersionError(rv, current, 1)
+	}
+	return nil
+}
+
+// GetList serves list requests from cache with objects of the latest resource version, limit of
+// list requests is not supported just like cacher.
+func (c *boundedCache) GetList(ctx context.Context, key string, opts kstorage.ListOptions, listObj runtime.Object) error {
+	if err := c.waitUntilReady(ctx); err != nil {
+		return err
+	}
+	rv, err := c.versioner.ParseResourceVersion(opts.ResourceVersion)
+	if err != nil {
+		return err
+	}
+	if rv > 0 {
+		if err := c.waitUntilFresh(ctx, rv); err != nil {
+			return err
+		}
+	}
+
+	key, recursive := normalizeKey(key, opts.Recursive)
+	c.Lock()
+	items := c.snapshotLocked(c.matchEntriesLocked(key, recursive, opts.Predicate))
+	rv = c.rv
+	c.reportUsageLocked()
+	c.Unlock()
+
+	items, err = c.loadItems(items, opts.Predicate)
+	if err != nil {
+		return err
+	}
+	objs := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		objs = append(objs, item.obj)
+	}
+	if err := meta.SetList(listObj, objs); err != nil {
+		return err
+	}
+	return c.versioner.UpdateList(listObj, rv, "", nil)
+}
+
+// Watch serves watch requests from cache. current objects are sent as initial events when resource version
+// is not specified or initial events are requested, otherwise events after the resource version are sent,
+// and ResourceExpired error is returned when the resource version is older than recent events.
+func (c *boundedCache) Watch(ctx context.Context, key string, opts kstorage.ListOptions) (watch.Interface, error) {
+	if err := c.waitUntilReady(ctx); err != nil {
+		return nil, err
+	}
+	rv, err := c.versioner.ParseResourceVersion(opts.ResourceVersion)
+	if err != nil {
+		return nil, err
+	}
+	if rv > 0 {
+		if err := c.waitUntilFresh(ctx, rv); err != nil {
+			return nil, err
+		}
+	}
+
+	key, recursive := normalizeKey(key, opts.Recursive)
+	w := &boundedCacheWatcher{
+		key:       key,
+		recursive: recursive,
+		pred:      opts.Predicate,
+		newFunc:   c.config.NewFunc,
+		versioner: c.versioner,
+		input:     make(chan *boundedCacheEvent, boundedCacheWatcherBufferSize),
+		result:    make(chan watch.Event),
+		done:      make(chan struct{}),
+	}
+	sendInitialEvents := opts.SendInitialEvents != nil && *opts.SendInitialEvents
+	initEvents := sendInitialEvents || (opts.SendInitialEvents == nil && rv == 0)
+
+	c.Lock()
+	var items []boundedCacheItem
+	switch {
+	case initEvents:
+		items = c.snapshotLocked(c.matchEntriesLocked(key, recursive, opts.Predicate))
+		if sendInitialEvents && opts.Predicate.AllowWatchBookmarks {
+			w.initEventsEndRV = c.rv
+		}
+	case rv == 0:
+		// watch from the latest resource version without initial events.
+	case rv < c.oldestRV:
+		oldestRV := c.oldestRV
+		c.Unlock()
+		return nil, apierrors.NewResourceExpired(fmt.Sprintf("too old resource version: %d (%d)", rv, oldestRV))
+	default:
+		for _, event := range c.events {
+			if event.rv > rv && w.keyMatches(event.key) {
+				w.initEvents = append(w.initEvents, event)
+			}
+		}
+	}
+
+	c.watcherID++
+	id := c.watcherID
+	c.watchers[id] = w
+	w.forget = func() {
+		c.Lock()
+		defer c.Unlock()
+		if c.watchers[id] == w {
+			c.stopWatcherLocked(id, w)
+		}
+	}
+	c.Unlock()
+
+	// watcher is added before initial objects are loaded, so events after them are not missed, and
+	// they are buffered until initial events are sent.
+	if initEvents {
+		items, err := c.loadItems(items, opts.Predicate)
+		if err != nil {
+			w.forget()
+			return nil, err
+		}
+		for _, item := range items {
+			w.initEvents = append(w.initEvents, &boundedCacheEvent{
+				eventType: watch.Added,
+				key:       item.entry.key,
+				obj:       item.obj,
+				labels:    item.entry.labels,
+				fields:    item.entry.fields,
+			})
+		}
+	}
+	go w.process()
+	return w, nil
+}
+
+func (c *boundedCache) stopWatcherLocked(id int, w *boundedCacheWatcher) {
+	delete(c.watchers, id)
+	close(w.input)
+}
+
+func (c *boundedCache) stopAllWatchersLocked() {
+	for id, w := range c.watchers {
+		c.stopWatcherLocked(id, w)
+	}
+}
+
+// ReadinessCheck returns error until objects have been listed.
+func (c *boundedCache) ReadinessCheck() error {
+	select {
+	case <-c.readyCh:
+		return nil
+	default:
+		return fmt.Errorf("multiplexer cache of %s is not ready", c.resource.String())
+	}
+}
+
+// normalizeKey appends "/" to the key of recursive requests, so objects in namespace "foo"
+// don't match the key of namespace "fo".
+func normalizeKey(key string, recursive bool) (string, bool) {
+	if recursive && !strings.HasSuffix(key, "/") {
+		key += "/"
+	}
+	return key, recursive
+}
+
+func keyMatches(key string, recursive bool, objKey string) bool {
+	if recursive {
+		return strings.HasPrefix(objKey, key)
+	}
+	return objKey == key
+}
+
+type boundedCacheWatcher struct {
+	key             string
+	recursive       bool
+	pred            kstorage.SelectionPredicate
+	newFunc         func() runtime.Object
+	versioner       kstorage.Versioner
+	initEvents      []*boundedCacheEvent
+	initEventsEndRV uint64
+	input           chan *boundedCacheEvent
+	result          chan watch.Event
+	done            chan struct{}
+	stopOnce        sync.Once
+	forget          func()
+}
+
+func (w *boundedCacheWatcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *boundedCacheWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.done)
+		w.forget()
+	})
+}
+
+func (w *boundedCacheWatcher) keyMatches(key string) bool {
+	return keyMatches(w.key, w.recursive, key)
+}
+
+func (w *boundedCacheWatcher) process() {
+	defer close(w.result)
+	for _, event := range w.initEvents {
+		if !w.send(event) {
+			return
+		}
+	}
+	w.initEvents = nil
+
+	if w.initEventsEndRV != 0 {
+		bookmark := w.newFunc()
+		if err := w.versioner.UpdateObject(bookmark, w.initEventsEndRV); err != nil {
+			klog.Errorf("could not set resource version for bookmark event, %v", err)
+			return
+		}
+		if accessor, err := meta.Accessor(bookmark); err == nil {
+			accessor.SetAnnotations(map[string]string{metav1.InitialEventsAnnotationKey: "true"})
+		}
+		select {
+		case w.result <- watch.Event{Type: watch.Bookmark, Object: bookmark}:
+		case <-w.done:
+			return
+		}
+	}
+
+	for {
+		select {
+		case event, ok := <-w.input:
+			if !ok || !w.send(event) {
+				return
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func (w *boundedCacheWatcher) send(event *boundedCacheEvent) bool {
+	watchEvent, ok := w.convert(event)
+	if !ok {
+		return true
+	}
+	select {
+	case w.result <- watchEvent:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+// convert converts event to watch event for this watcher in the same way as cacher, for example,
+// modified event is converted to deleted event when the object doesn't match selectors any more.
+func (w *boundedCacheWatcher) convert(event *boundedCacheEvent) (watch.Event, bool) {
+	if event.eventType == watch.Bookmark {
+		bookmark := w.newFunc()
+		if err := w.versioner.UpdateObject(bookmark, event.rv); err != nil {
+			return watch.Event{}, false
+		}
+		return watch.Event{Type: watch.Bookmark, Object: bookmark}, true
+	}
+
+	curPasses := event.eventType != watch.Deleted && w.pred.MatchesObjectAttributes(event.labels, event.fields)
+	oldPasses := event.hasPrev && w.pred.MatchesObjectAttributes(event.prevLabels, event.prevFields)
+	switch {
+	case curPasses && oldPasses:
+		return watch.Event{Type: watch.Modified, Object: event.obj}, true
+	case curPasses && !oldPasses:
+		return watch.Event{Type: watch.Added, Object: event.obj}, true
+	case !curPasses && oldPasses:
+		// previous object is not loaded when the event happened without watchers which need it,
+		// then the current object is sent for the resumed watcher instead.
+		oldObj := event.obj.DeepCopyObject()
+		if event.prevObj != nil {
+			oldObj = event.prevObj.DeepCopyObject()
+		}
+		if err := w.versioner.UpdateObject(oldObj, event.rv); err != nil {
+			klog.Errorf("could not set resource version for deleted object, %v", err)
+		}
+		return watch.Event{Type: watch.Deleted, Object: oldObj}, true
+	}
+	return watch.Event{}, false
+}
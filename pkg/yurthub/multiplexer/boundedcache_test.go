@@ -0,0 +1,488 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiplexer
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/storage"
+
+	ystorage "github.com/openyurtio/openyurt/pkg/yurthub/multiplexer/storage"
+	hubstorage "github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/disk"
+)
+
+type fakeWatchStorage struct {
+	*ystorage.CommonFakeStorage
+	items   []v1.Service
+	watcher *watch.FakeWatcher
+}
+
+func (fs *fakeWatchStorage) GetList(ctx context.Context, key string, opts storage.ListOptions, listObj runtime.Object) error {
+	serviceList := listObj.(*v1.ServiceList)
+	serviceList.ResourceVersion = "100"
+	serviceList.Items = fs.items
+	return nil
+}
+
+func (fs *fakeWatchStorage) Watch(ctx context.Context, key string, opts storage.ListOptions) (watch.Interface, error) {
+	return fs.watcher, nil
+}
+
+func newLabeledService(namespace, name, rv string, labelSet map[string]string) *v1.Service {
+	svc := newService(namespace, name)
+	svc.ResourceVersion = rv
+	svc.Labels = labelSet
+	return svc
+}
+
+func newTestBoundedCache(t *testing.T, maxBytes int64, items []v1.Service) (*boundedCache, *fakeWatchStorage) {
+	store, err := disk.NewDiskStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("could not new disk storage, %v", err)
+	}
+	fs := &fakeWatchStorage{
+		CommonFakeStorage: &ystorage.CommonFakeStorage{},
+		items:             items,
+		watcher:           watch.NewFakeWithChanSize(10, false),
+	}
+
+	s, destroy, err := newBoundedCache(fs, serviceGVR, &resourceCacheConfig{
+		KeyFunc:      keyFunc,
+		NewFunc:      newServiceFunc,
+		NewListFunc:  newServiceListFunc,
+		GetAttrsFunc: GetAttrsFunc(serviceGVR),
+	}, store, maxBytes)
+	if err != nil {
+		t.Fatalf("could not new bounded cache, %v", err)
+	}
+	t.Cleanup(destroy)
+
+	if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return s.ReadinessCheck() == nil, nil
+	}); err != nil {
+		t.Fatalf("bounded cache is not ready, %v", err)
+	}
+	return s.(*boundedCache), fs
+}
+
+func TestBoundedCache_GetList(t *testing.T) {
+	items := []v1.Service{
+		*newLabeledService(metav1.NamespaceSystem, "coredns", "", map[string]string{"app": "dns"}),
+		*newLabeledService(metav1.NamespaceDefault, "nginx", "", map[string]string{"app": "web"}),
+		*newLabeledService(metav1.NamespaceDefault, "redis", "", map[string]string{"app": "db"}),
+	}
+
+	for k, tc := range map[string]struct {
+		maxBytes       int64
+		expectInMemory int
+		expectInStore  int
+		key            string
+		labelSelector  labels.Selector
+		expectServices []string
+	}{
+		"all objects are kept in memory": {
+			maxBytes:       1024 * 1024,
+			expectInMemory: 3,
+			key:            "/",
+			labelSelector:  labels.Everything(),
+			expectServices: []string{"nginx", "redis", "coredns"},
+		},
+		"all objects are spilled into store": {
+			maxBytes:       1,
+			expectInStore:  3,
+			key:            "/",
+			labelSelector:  labels.Everything(),
+			expectServices: []string{"nginx", "redis", "coredns"},
+		},
+		"list spilled objects by namespace": {
+			maxBytes:       1,
+			expectInStore:  3,
+			key:            "/default",
+			labelSelector:  labels.Everything(),
+			expectServices: []string{"nginx", "redis"},
+		},
+		"list spilled objects by label selector": {
+			maxBytes:       1,
+			expectInStore:  3,
+			key:            "/",
+			labelSelector:  labels.SelectorFromSet(labels.Set{"app": "db"}),
+			expectServices: []string{"redis"},
+		},
+	} {
+		t.Run(k, func(t *testing.T) {
+			c, _ := newTestBoundedCache(t, tc.maxBytes, items)
+
+			opts := mockListOptions()
+			opts.Predicate.Label = tc.labelSelector
+			serviceList := &v1.ServiceList{}
+			if err := c.GetList(context.Background(), tc.key, opts, serviceList); err != nil {
+				t.Fatalf("could not get list, %v", err)
+			}
+			if serviceList.ResourceVersion != "100" {
+				t.Errorf("expect resource version 100, but got %s", serviceList.ResourceVersion)
+			}
+			names := make([]string, 0, len(serviceList.Items))
+			for i := range serviceList.Items {
+				names = append(names, serviceList.Items[i].Name)
+			}
+			if !slices.Equal(tc.expectServices, names) {
+				t.Errorf("expect services %v, but got %v", tc.expectServices, names)
+			}
+
+			if c.lru.Len() != tc.expectInMemory {
+				t.Errorf("expect %d objects in memory, but got %d", tc.expectInMemory, c.lru.Len())
+			}
+			keys, err := c.store.ListResourceKeysOfComponent(spillComponent, *serviceGVR)
+			if err != nil {
+				t.Fatalf("could not list spilled keys, %v", err)
+			}
+			if len(keys) != tc.expectInStore {
+				t.Errorf("expect %d objects in store, but got %d", tc.expectInStore, len(keys))
+			}
+		})
+	}
+}
+
+func TestBoundedCache_LoadSpilledObjects(t *testing.T) {
+	items := []v1.Service{
+		*newLabeledService(metav1.NamespaceSystem, "coredns", "", nil),
+		*newLabeledService(metav1.NamespaceDefault, "nginx", "", nil),
+	}
+	c, _ := newTestBoundedCache(t, 1, items)
+
+	// spilled objects are loaded into memory again when the memory limit is raised.
+	c.Lock()
+	c.maxBytes = 1024 * 1024
+	c.Unlock()
+	serviceList := &v1.ServiceList{}
+	if err := c.GetList(context.Background(), "/", mockListOptions(), serviceList); err != nil {
+		t.Fatalf("could not get list, %v", err)
+	}
+	if len(serviceList.Items) != 2 {
+		t.Errorf("expect 2 services, but got %d", len(serviceList.Items))
+	}
+	if c.lru.Len() != 2 {
+		t.Errorf("expect 2 objects in memory, but got %d", c.lru.Len())
+	}
+	keys, _ := c.store.ListResourceKeysOfComponent(spillComponent, *serviceGVR)
+	if len(keys) != 0 {
+		t.Errorf("expect no objects in store, but got %v", keys)
+	}
+}
+
+func TestBoundedCache_LoadEntry(t *testing.T) {
+	testcases := map[string]struct {
+		change    func(c *boundedCache, entry *boundedCacheEntry)
+		expectObj bool
+	}{
+		"spilled object is loaded from store": {
+			change:    func(c *boundedCache, entry *boundedCacheEntry) {},
+			expectObj: true,
+		},
+		"object is loaded into memory by others": {
+			change: func(c *boundedCache, entry *boundedCacheEntry) {
+				obj, err := c.readSpilled(entry)
+				if err != nil {
+					t.Fatalf("could not read spilled object, %v", err)
+				}
+				c.Lock()
+				defer c.Unlock()
+				c.maxBytes = 1024 * 1024
+				c.keepLocked(entry, obj)
+			},
+			expectObj: true,
+		},
+		"object is deleted": {
+			change: func(c *boundedCache, entry *boundedCacheEntry) {
+				c.Lock()
+				defer c.Unlock()
+				c.removeLocked(entry)
+			},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			c, _ := newTestBoundedCache(t, 1, []v1.Service{*newLabeledService(metav1.NamespaceSystem, "coredns", "100", nil)})
+			c.Lock()
+			entry := c.entries["/kube-system/coredns"]
+			c.Unlock()
+			if entry == nil || entry.obj != nil {
+				t.Fatalf("expect spilled object, but got %v", entry)
+			}
+
+			tc.change(c, entry)
+			_, obj, err := c.loadEntry(entry)
+			if err != nil {
+				t.Fatalf("could not load entry, %v", err)
+			}
+			if (obj != nil) != tc.expectObj {
+				t.Errorf("expect object loaded %v, but got %v", tc.expectObj, obj)
+			}
+		})
+	}
+}
+
+func TestBoundedCache_Watch(t *testing.T) {
+	c, fs := newTestBoundedCache(t, 1, []v1.Service{
+		*newLabeledService(metav1.NamespaceSystem, "coredns", "100", map[string]string{"app": "dns"}),
+		*newLabeledService(metav1.NamespaceDefault, "nginx", "100", map[string]string{"app": "web"}),
+	})
+	// objects are spilled, and recent events are kept in memory.
+	c.Lock()
+	c.maxEventBytes = 1024 * 1024
+	c.Unlock()
+	dnsOpts := storage.ListOptions{
+		Recursive: true,
+		Predicate: storage.SelectionPredicate{
+			Label: labels.SelectorFromSet(labels.Set{"app": "dns"}),
+			Field: fields.Everything(),
+		},
+	}
+
+	// objects are sent as initial events when resource version is not specified.
+	w, err := c.Watch(context.Background(), "/", dnsOpts)
+	if err != nil {
+		t.Fatalf("could not watch, %v", err)
+	}
+	assertWatchEvent(t, w, watch.Added, "coredns", "100")
+
+	fs.watcher.Modify(newLabeledService(metav1.NamespaceSystem, "coredns", "101", map[string]string{"app": "dns"}))
+	assertWatchEvent(t, w, watch.Modified, "coredns", "101")
+
+	// modified object which doesn't match selector any more is sent as deleted event.
+	fs.watcher.Modify(newLabeledService(metav1.NamespaceSystem, "coredns", "102", map[string]string{"app": "other"}))
+	assertWatchEvent(t, w, watch.Deleted, "coredns", "102")
+	w.Stop()
+
+	// watch is resumed from recent events.
+	resumeOpts := mockListOptions()
+	resumeOpts.ResourceVersion = "101"
+	w, err = c.Watch(context.Background(), "/kube-system", resumeOpts)
+	if err != nil {
+		t.Fatalf("could not watch, %v", err)
+	}
+	assertWatchEvent(t, w, watch.Modified, "coredns", "102")
+	w.Stop()
+
+	// initial events are ended with bookmark.
+	sendInitialEvents := true
+	initOpts := mockListOptions()
+	initOpts.ResourceVersion = ""
+	initOpts.SendInitialEvents = &sendInitialEvents
+	initOpts.Predicate.AllowWatchBookmarks = true
+	w, err = c.Watch(context.Background(), "/default", initOpts)
+	if err != nil {
+		t.Fatalf("could not watch, %v", err)
+	}
+	assertWatchEvent(t, w, watch.Added, "nginx", "100")
+	event := nextWatchEvent(t, w)
+	if event.Type != watch.Bookmark || event.Object.(*v1.Service).Annotations[metav1.InitialEventsAnnotationKey] != "true" {
+		t.Errorf("expect bookmark of initial events end, but got %v", event)
+	}
+	w.Stop()
+
+	// resource version older than recent events is expired.
+	tooOldOpts := mockListOptions()
+	tooOldOpts.ResourceVersion = "50"
+	if _, err = c.Watch(context.Background(), "/", tooOldOpts); !apierrors.IsResourceExpired(err) {
+		t.Errorf("expect resource expired error, but got %v", err)
+	}
+}
+
+func TestBoundedCache_EventsMemory(t *testing.T) {
+	c, fs := newTestBoundedCache(t, 1, []v1.Service{
+		*newLabeledService(metav1.NamespaceSystem, "coredns", "100", map[string]string{"app": "dns"}),
+	})
+
+	// previous objects are not loaded from store when no watcher has selectors.
+	opts := mockListOptions()
+	opts.ResourceVersion = ""
+	w, err := c.Watch(context.Background(), "/", opts)
+	if err != nil {
+		t.Fatalf("could not watch, %v", err)
+	}
+	assertWatchEvent(t, w, watch.Added, "coredns", "100")
+	c.Lock()
+	c.maxEventBytes = 1024 * 1024
+	c.Unlock()
+	fs.watcher.Modify(newLabeledService(metav1.NamespaceSystem, "coredns", "101", map[string]string{"app": "dns"}))
+	assertWatchEvent(t, w, watch.Modified, "coredns", "101")
+	w.Stop()
+
+	c.Lock()
+	event := c.events[len(c.events)-1]
+	if !event.hasPrev || event.prevObj != nil {
+		t.Errorf("expect previous object is not loaded, but got %v", event.prevObj)
+	}
+	// recent events are dropped when they exceed the memory limit of events.
+	c.maxEventBytes = event.size + 16
+	c.Unlock()
+	fs.watcher.Modify(newLabeledService(metav1.NamespaceSystem, "coredns", "102", map[string]string{"app": "other"}))
+
+	if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		c.Lock()
+		defer c.Unlock()
+		return c.rv == 102, nil
+	}); err != nil {
+		t.Fatalf("event is not processed, %v", err)
+	}
+	c.Lock()
+	if len(c.events) != 1 || c.eventBytes > c.maxEventBytes || c.oldestRV != 101 {
+		t.Errorf("expect 1 event within %d bytes after resource version 101, but got %d events of %d bytes after %d", c.maxEventBytes, len(c.events), c.eventBytes, c.oldestRV)
+	}
+	c.Unlock()
+
+	// resumed watcher with selector gets deleted event with current object when previous object is not loaded.
+	dnsOpts := mockListOptions()
+	dnsOpts.ResourceVersion = "101"
+	dnsOpts.Predicate.Label = labels.SelectorFromSet(labels.Set{"app": "dns"})
+	w, err = c.Watch(context.Background(), "/", dnsOpts)
+	if err != nil {
+		t.Fatalf("could not watch, %v", err)
+	}
+	assertWatchEvent(t, w, watch.Deleted, "coredns", "102")
+	w.Stop()
+
+	tooOldOpts := mockListOptions()
+	tooOldOpts.ResourceVersion = "100"
+	if _, err = c.Watch(context.Background(), "/", tooOldOpts); !apierrors.IsResourceExpired(err) {
+		t.Errorf("expect resource expired error, but got %v", err)
+	}
+}
+
+func nextWatchEvent(t *testing.T, w watch.Interface) watch.Event {
+	select {
+	case event, ok := <-w.ResultChan():
+		if !ok {
+			t.Fatalf("watch is closed unexpectedly")
+		}
+		return event
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("timeout for waiting watch event")
+	}
+	return watch.Event{}
+}
+
+func assertWatchEvent(t *testing.T, w watch.Interface, eventType watch.EventType, name, rv string) {
+	event := nextWatchEvent(t, w)
+	svc, ok := event.Object.(*v1.Service)
+	if event.Type != eventType || !ok || svc.Name != name || svc.ResourceVersion != rv {
+		t.Errorf("expect %s event of service %s with resource version %s, but got %v", eventType, name, rv, event)
+	}
+}
+
+// blockingCreateStore blocks Create until release is closed.
+type blockingCreateStore struct {
+	hubstorage.Store
+	creating chan struct{}
+	release  chan struct{}
+}
+
+func (s *blockingCreateStore) Create(key hubstorage.Key, content []byte) error {
+	select {
+	case s.creating <- struct{}{}:
+	default:
+	}
+	<-s.release
+	return s.Store.Create(key, content)
+}
+
+func TestBoundedCache_SpillWithoutLock(t *testing.T) {
+	c, fs := newTestBoundedCache(t, 1024*1024, []v1.Service{*newLabeledService(metav1.NamespaceSystem, "coredns", "100", nil)})
+	store := &blockingCreateStore{Store: c.store, creating: make(chan struct{}, 1), release: make(chan struct{})}
+	c.Lock()
+	c.store = store
+	c.maxBytes = 1
+	c.Unlock()
+
+	fs.watcher.Add(newLabeledService(metav1.NamespaceDefault, "nginx", "101", nil))
+	<-store.creating
+
+	// objects are served from memory while they are spilled.
+	listed := make(chan []v1.Service)
+	go func() {
+		serviceList := &v1.ServiceList{}
+		if err := c.GetList(context.Background(), "/", mockListOptions(), serviceList); err != nil {
+			t.Errorf("could not get list, %v", err)
+		}
+		listed <- serviceList.Items
+	}()
+	select {
+	case items := <-listed:
+		if len(items) != 2 {
+			t.Errorf("expect 2 services, but got %d", len(items))
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("expect list is not blocked by spilling objects")
+	}
+
+	close(store.release)
+	if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		c.Lock()
+		defer c.Unlock()
+		for _, entry := range c.entries {
+			if entry.obj != nil || entry.spilling {
+				return false, nil
+			}
+		}
+		return len(c.entries) == 2 && c.lru.Len() == 0 && c.usedBytes == 0, nil
+	}); err != nil {
+		t.Fatalf("expect all objects are spilled, %v", err)
+	}
+}
+
+func TestBoundedCache_EstimateSize(t *testing.T) {
+	c, _ := newTestBoundedCache(t, 1024*1024, nil)
+	svc := newLabeledService(metav1.NamespaceSystem, "coredns", "100", map[string]string{"app": "dns"})
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(svc)
+	if err != nil {
+		t.Fatalf("could not convert service, %v", err)
+	}
+
+	for k, obj := range map[string]runtime.Object{
+		"typed object":        svc,
+		"unstructured object": &unstructured.Unstructured{Object: content},
+	} {
+		t.Run(k, func(t *testing.T) {
+			data, err := c.encode(obj)
+			if err != nil {
+				t.Fatalf("could not encode object, %v", err)
+			}
+			size, err := c.estimateSize(obj)
+			if err != nil {
+				t.Fatalf("could not estimate size, %v", err)
+			}
+			if size <= 0 || size > int64(len(data)) {
+				t.Errorf("expect estimated size in (0, %d], but got %d", len(data), size)
+			}
+		})
+	}
+}
@@ -41,6 +41,8 @@ type resourceCacheConfig struct {
 	NewFunc      func() runtime.Object
 	NewListFunc  func() runtime.Object
 	GetAttrsFunc kstorage.AttrFunc
+	// Codec is used for encoding objects in cache, LegacyCodec of scheme is used when it's not specified.
+	Codec runtime.Codec
 }
 
 func newResourceCache(
@@ -48,6 +50,11 @@ func newResourceCache(
 	resource *schema.GroupVersionResource,
 	config *resourceCacheConfig) (kstorage.Interface, func(), error) {
 
+	codec := config.Codec
+	if codec == nil {
+		codec = scheme.Codecs.LegacyCodec(resource.GroupVersion())
+	}
+
 	cacheConfig := cacher.Config{
 		Storage:             s,
 		Versioner:           kstorage.APIObjectVersioner{},
@@ -56,7 +63,7 @@ func newResourceCache(
 		NewFunc:             config.NewFunc,
 		NewListFunc:         config.NewListFunc,
 		GetAttrsFunc:        config.GetAttrsFunc,
-		Codec:               scheme.Codecs.LegacyCodec(resource.GroupVersion()),
+		Codec:               codec,
 		EventsHistoryWindow: 3 * time.Minute, // Required in k8s v1.34+
 	}
 
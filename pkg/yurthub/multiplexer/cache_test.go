@@ -64,6 +64,7 @@ func TestResourceCache_GetList(t *testing.T) {
 			newServiceFunc,
 			newServiceListFunc,
 			GetAttrsFunc(serviceGVR),
+			nil,
 		},
 	)
 	wait.PollUntilContextCancel(context.Background(), 100*time.Millisecond, true, func(context.Context) (done bool, err error) {
@@ -133,6 +134,7 @@ func TestResourceCache_Watch(t *testing.T) {
 			newServiceFunc,
 			newServiceListFunc,
 			GetAttrsFunc(serviceGVR),
+			nil,
 		},
 	)
 	wait.PollUntilContextCancel(context.Background(), 100*time.Millisecond, true, func(context.Context) (done bool, err error) {
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiplexer
+
+import (
+	"net/url"
+	"slices"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
+)
+
+// leaderCandidate is a node in the pool which can be elected as temporary leader hub.
+type leaderCandidate struct {
+	name    string
+	address string
+}
+
+// electTemporaryLeader elects a temporary leader hub through the pool network when the pool is disconnected
+// from cloud kube-apiserver and all leader hubs elected by yurt-manager are unreachable. candidates are sorted
+// by name in leader hub configmap, and the first reachable candidate is elected. all yurthubs in the pool elect
+// the same temporary leader hub without coordination only when every node in the pool can access every other
+// node, under partial connectivity yurthubs may elect different temporary leader hubs until the connectivity is
+// recovered. once cloud kube-apiserver or any leader hub elected by yurt-manager is reachable again, the temporary
+// leader hub is dropped and leader hubs elected by yurt-manager are restored.
+func (m *MultiplexerManager) electTemporaryLeader(cloudHealthChecker healthchecker.Interface) {
+	m.electionLock.Lock()
+	candidates := m.candidates
+	leaderAddresses := m.leaderAddresses.Clone()
+	m.electionLock.Unlock()
+
+	// leader hubs and candidates are probed in parallel without holding election lock, because probing
+	// unreachable servers takes a while and configuration of leader hubs should not be blocked.
+	var reachable sets.Set[string]
+	cloudHealthy := cloudHealthChecker.IsHealthy()
+	if len(candidates) != 0 && !cloudHealthy {
+		addresses := leaderAddresses.Clone()
+		for _, candidate := range candidates {
+			if candidate.name != m.nodeName {
+				addresses.Insert(candidate.address)
+			}
+		}
+		reachable = m.pingServers(addresses)
+	}
+
+	m.electionLock.Lock()
+	defer m.electionLock.Unlock()
+	// leader hub configuration has been updated while probing, so the result of probing is discarded
+	// and temporary leader hub will be elected again at the next interval.
+	if !slices.Equal(candidates, m.candidates) || !leaderAddresses.Equal(m.leaderAddresses) {
+		return
+	}
+
+	if len(candidates) == 0 || cloudHealthy || reachable.HasAny(leaderAddresses.UnsortedList()...) {
+		if len(m.temporaryLeader) != 0 {
+			klog.Infof("temporary leader hub %s is dropped, leader hubs %v are restored", m.temporaryLeader, m.leaderAddresses.UnsortedList())
+			m.restoreLeaders()
+		}
+		return
+	}
+
+	var elected *leaderCandidate
+	for i := range candidates {
+		candidate := &candidates[i]
+		// leader hubs elected by yurt-manager are unreachable.
+		if leaderAddresses.Has(candidate.address) {
+			continue
+		}
+		if candidate.name == m.nodeName || reachable.Has(candidate.address) {
+			elected = candidate
+			break
+		}
+	}
+	if elected == nil || elected.name == m.temporaryLeader {
+		return
+	}
+
+	klog.Infof("pool is disconnected from cloud and leader hubs %v are unreachable, %s is elected as temporary leader hub",
+		m.leaderAddresses.UnsortedList(), elected.name)
+	m.temporaryLeader = elected.name
+	if elected.name == m.nodeName {
+		// pool scope metadata are served by multiplexer of this node, and multiplexer lists/watches
+		// pool scope metadata from cloud kube-apiserver or local cache.
+		m.setSourceForPoolScopeMetadata(APIServerSourceForPoolScopeMetadata)
+		return
+	}
+
+	servers := []*url.URL{m.resolveLeaderHubServer(elected.address)}
+	m.healthCheckerForLeaders.UpdateBackends(servers)
+	m.loadBalancerForLeaders.UpdateBackends(servers)
+	m.setSourceForPoolScopeMetadata(PoolSourceForPoolScopeMetadata)
+}
+
+// TemporaryLeader returns the name of temporary leader hub which is elected while the pool is disconnected
+// from cloud, and empty string is returned when leader hubs elected by yurt-manager are used.
+func (m *MultiplexerManager) TemporaryLeader() string {
+	m.electionLock.Lock()
+	defer m.electionLock.Unlock()
+	return m.temporaryLeader
+}
+
+// pingServers probes leader hubs on addresses in parallel, and returns the reachable addresses.
+func (m *MultiplexerManager) pingServers(addresses sets.Set[string]) sets.Set[string] {
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	reachable := sets.New[string]()
+	for address := range addresses {
+		server := m.resolveLeaderHubServer(address)
+		if server == nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if m.pingFunc(server) {
+				lock.Lock()
+				defer lock.Unlock()
+				reachable.Insert(address)
+			}
+		}()
+	}
+	wg.Wait()
+	return reachable
+}
+
+func (m *MultiplexerManager) restoreLeaders() {
+	servers := m.resolveLeaderHubServers(m.leaderAddresses)
+	m.healthCheckerForLeaders.UpdateBackends(servers)
+	m.loadBalancerForLeaders.UpdateBackends(servers)
+	m.setSourceForPoolScopeMetadata(m.configuredSource)
+	m.temporaryLeader = ""
+}
+
+func (m *MultiplexerManager) setSourceForPoolScopeMetadata(source string) {
+	m.Lock()
+	defer m.Unlock()
+	m.sourceForPoolScopeMetadata = source
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiplexer
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker/fake"
+	"github.com/openyurtio/openyurt/pkg/yurthub/proxy/remote"
+)
+
+type fakeLoadBalancer struct {
+	remote.Server
+	hosts sets.Set[string]
+}
+
+func (lb *fakeLoadBalancer) UpdateBackends(servers []*url.URL) {
+	lb.hosts = sets.New[string]()
+	for _, server := range servers {
+		lb.hosts.Insert(server.Host)
+	}
+}
+
+func (lb *fakeLoadBalancer) PickOne(_ *http.Request) *remote.RemoteProxy {
+	return nil
+}
+
+func TestElectTemporaryLeader(t *testing.T) {
+	cloudServer, _ := url.Parse("https://192.168.0.1:6443")
+	cloudHealthy := map[*url.URL]bool{cloudServer: true}
+	cloudHealthChecker := fake.NewFakeChecker(cloudHealthy)
+	leaderHealthChecker := fake.NewFakeChecker(map[*url.URL]bool{})
+	loadBalancer := &fakeLoadBalancer{}
+	reachable := sets.New[string]("10.0.0.1:10269", "10.0.0.2:10269")
+
+	m := &MultiplexerManager{
+		healthCheckerForLeaders:       leaderHealthChecker,
+		loadBalancerForLeaders:        loadBalancer,
+		portForLeaderHub:              10269,
+		nodeName:                      "node3",
+		poolScopeMetadata:             sets.New[string](),
+		lazyLoadedGVRCache:            make(map[string]Interface),
+		lazyLoadedGVRCacheDestroyFunc: make(map[string]func()),
+		leaderAddresses:               sets.New[string](),
+		pingFunc: func(server *url.URL) bool {
+			return reachable.Has(server.Host)
+		},
+	}
+	cm := &corev1.ConfigMap{
+		Data: map[string]string{
+			LeaderEndpointsKey:   "node1/10.0.0.1",
+			EnableLeaderElection: "true",
+			InterConnectivityKey: "true",
+			LeaderCandidatesKey:  "node1/10.0.0.1,node2/10.0.0.2,node3/10.0.0.3,node4/10.0.0.4",
+		},
+	}
+	m.updateLeaderHubConfiguration(cm)
+
+	steps := []struct {
+		description     string
+		cloudHealthy    bool
+		reachable       []string
+		expectLeader    string
+		expectSource    string
+		expectLeaderHub string
+	}{
+		{
+			description:     "cloud is healthy",
+			cloudHealthy:    true,
+			expectSource:    PoolSourceForPoolScopeMetadata,
+			expectLeaderHub: "10.0.0.1:10269",
+		},
+		{
+			description:     "cloud is disconnected but leader hub is reachable",
+			reachable:       []string{"10.0.0.1:10269", "10.0.0.2:10269"},
+			expectSource:    PoolSourceForPoolScopeMetadata,
+			expectLeaderHub: "10.0.0.1:10269",
+		},
+		{
+			description:     "leader hub is unreachable",
+			reachable:       []string{"10.0.0.2:10269"},
+			expectLeader:    "node2",
+			expectSource:    PoolSourceForPoolScopeMetadata,
+			expectLeaderHub: "10.0.0.2:10269",
+		},
+		{
+			description:     "temporary leader hub is unreachable",
+			reachable:       []string{"10.0.0.4:10269"},
+			expectLeader:    "node3",
+			expectSource:    APIServerSourceForPoolScopeMetadata,
+			expectLeaderHub: "10.0.0.2:10269",
+		},
+		{
+			description:     "leader hub is reachable again",
+			reachable:       []string{"10.0.0.1:10269"},
+			expectSource:    PoolSourceForPoolScopeMetadata,
+			expectLeaderHub: "10.0.0.1:10269",
+		},
+	}
+
+	for _, step := range steps {
+		cloudHealthy[cloudServer] = step.cloudHealthy
+		reachable = sets.New[string](step.reachable...)
+		m.electTemporaryLeader(cloudHealthChecker)
+
+		if leader := m.TemporaryLeader(); leader != step.expectLeader {
+			t.Errorf("%s: expect temporary leader %q, but got %q", step.description, step.expectLeader, leader)
+		}
+		if source := m.SourceForPoolScopeMetadata(); source != step.expectSource {
+			t.Errorf("%s: expect source %s, but got %s", step.description, step.expectSource, source)
+		}
+		if !loadBalancer.hosts.Equal(sets.New[string](step.expectLeaderHub)) {
+			t.Errorf("%s: expect leader hub %s, but got %v", step.description, step.expectLeaderHub, loadBalancer.hosts.UnsortedList())
+		}
+		if hosts := leaderHealthChecker.(*fake.FakeChecker).ListServerHosts(); !hosts.Equal(loadBalancer.hosts) {
+			t.Errorf("%s: expect leader hubs of health checker %v, but got %v", step.description, loadBalancer.hosts.UnsortedList(), hosts.UnsortedList())
+		}
+	}
+
+	// temporary leader hub is dropped when leader hub configmap is updated after cloud returns.
+	cloudHealthy[cloudServer] = false
+	reachable = sets.New[string]("10.0.0.2:10269")
+	m.electTemporaryLeader(cloudHealthChecker)
+	if leader := m.TemporaryLeader(); leader != "node2" {
+		t.Fatalf("expect temporary leader node2, but got %q", leader)
+	}
+	cm.Data[LeaderEndpointsKey] = "node4/10.0.0.4"
+	m.updateLeaderHubConfiguration(cm)
+	if leader := m.TemporaryLeader(); len(leader) != 0 {
+		t.Errorf("expect temporary leader is dropped, but got %q", leader)
+	}
+	if !loadBalancer.hosts.Equal(sets.New[string]("10.0.0.4:10269")) {
+		t.Errorf("expect leader hub 10.0.0.4:10269, but got %v", loadBalancer.hosts.UnsortedList())
+	}
+
+	// no temporary leader hub is elected when nodes in the pool can't access with each other.
+	cm.Data[InterConnectivityKey] = "false"
+	m.updateLeaderHubConfiguration(cm)
+	reachable = sets.New[string]("10.0.0.2:10269")
+	m.electTemporaryLeader(cloudHealthChecker)
+	if leader := m.TemporaryLeader(); len(leader) != 0 {
+		t.Errorf("expect no temporary leader, but got %q", leader)
+	}
+}
+
+func TestElectTemporaryLeaderPingInParallel(t *testing.T) {
+	cloudServer, _ := url.Parse("https://192.168.0.1:6443")
+	cloudHealthChecker := fake.NewFakeChecker(map[*url.URL]bool{cloudServer: false})
+	loadBalancer := &fakeLoadBalancer{}
+
+	// leader hub 10.0.0.1 and candidates 10.0.0.2, 10.0.0.4 are probed, and every probe is blocked
+	// until all of probes are started, so the election can complete only when probes are in parallel.
+	var lock sync.Mutex
+	started := 0
+	allStarted := make(chan struct{})
+	var m *MultiplexerManager
+	m = &MultiplexerManager{
+		healthCheckerForLeaders:       fake.NewFakeChecker(map[*url.URL]bool{}),
+		loadBalancerForLeaders:        loadBalancer,
+		portForLeaderHub:              10269,
+		nodeName:                      "node3",
+		poolScopeMetadata:             sets.New[string](),
+		lazyLoadedGVRCache:            make(map[string]Interface),
+		lazyLoadedGVRCacheDestroyFunc: make(map[string]func()),
+		leaderAddresses:               sets.New[string](),
+		pingFunc: func(server *url.URL) bool {
+			// election lock is not held while probing.
+			m.TemporaryLeader()
+
+			lock.Lock()
+			started++
+			if started == 3 {
+				close(allStarted)
+			}
+			lock.Unlock()
+			select {
+			case <-allStarted:
+			case <-time.After(5 * time.Second):
+				return false
+			}
+			return server.Host == "10.0.0.2:10269"
+		},
+	}
+	m.updateLeaderHubConfiguration(&corev1.ConfigMap{
+		Data: map[string]string{
+			LeaderEndpointsKey:   "node1/10.0.0.1",
+			EnableLeaderElection: "true",
+			InterConnectivityKey: "true",
+			LeaderCandidatesKey:  "node1/10.0.0.1,node2/10.0.0.2,node3/10.0.0.3,node4/10.0.0.4",
+		},
+	})
+
+	m.electTemporaryLeader(cloudHealthChecker)
+	if leader := m.TemporaryLeader(); leader != "node2" {
+		t.Errorf("expect temporary leader node2, but got %q", leader)
+	}
+	if !loadBalancer.hosts.Equal(sets.New[string]("10.0.0.2:10269")) {
+		t.Errorf("expect leader hub 10.0.0.2:10269, but got %v", loadBalancer.hosts.UnsortedList())
+	}
+}
@@ -19,6 +19,7 @@ package multiplexer
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,6 +36,8 @@ import (
 	"k8s.io/klog/v2"
 
 	yurtutil "github.com/openyurtio/openyurt/pkg/util"
+	"github.com/openyurtio/openyurt/pkg/util/tracing"
+	"github.com/openyurtio/openyurt/pkg/yurthub/audit"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter"
 	"github.com/openyurtio/openyurt/pkg/yurthub/util"
 )
@@ -71,7 +74,7 @@ func (fs *filterStore) List(ctx context.Context, options *metainternalversion.Li
 		return result, nil
 	}
 
-	if result, err = fs.filterListObject(ctx, result, filters); err != nil {
+	if result, err = fs.filterListObject(ctx, result, audit.WithObjectFilter(ctx, filters)); err != nil {
 		return nil, storeerr.InterpretListError(err, fs.qualifiedResourceFromContext(ctx))
 	}
 
@@ -83,11 +86,14 @@ func (fs *filterStore) filterListObject(ctx context.Context, obj runtime.Object,
 		return obj, nil
 	}
 
+	_, span := tracing.Start(ctx, "FilterChain", attribute.String(tracing.AttributeFilters, filter.Name()))
+	defer span.End()
 	items, err := meta.ExtractList(obj)
 
 	if err != nil || len(items) == 0 {
 		return filter.Filter(obj, ctx.Done()), nil
 	}
+	span.SetAttributes(attribute.Int(tracing.AttributeObjects, len(items)))
 
 	list := make([]runtime.Object, 0)
 	for _, item := range items {
@@ -122,7 +128,7 @@ func (fs *filterStore) Watch(ctx context.Context, options *metainternalversion.L
 	if !ok {
 		return result, nil
 	}
-	return newFilterWatch(result, filters), nil
+	return newFilterWatch(result, audit.WithObjectFilter(ctx, filters)), nil
 }
 
 func (fs *filterStore) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
@@ -21,6 +21,7 @@ import (
 	"sync"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/registry/generic/registry"
@@ -29,8 +30,11 @@ import (
 	"k8s.io/kubectl/pkg/scheme"
 
 	"github.com/openyurtio/openyurt/cmd/yurthub/app/config"
+	yurtutil "github.com/openyurtio/openyurt/pkg/util"
 	hubmeta "github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/meta"
 	storage2 "github.com/openyurtio/openyurt/pkg/yurthub/multiplexer/storage"
+	hubstorage "github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/transport"
 )
 
 type filterStoreManager struct {
@@ -38,6 +42,11 @@ type filterStoreManager struct {
 	filterStores    map[string]*filterStore
 	restMapper      *hubmeta.RESTMapperManager
 	storageProvider storage2.StorageProvider
+	transportMgr    transport.Interface
+	// objects beyond cacheMemory in memory are spilled into cacheStore, and all objects are kept in memory
+	// when cacheMemory is not set.
+	cacheMemory int64
+	cacheStore  hubstorage.Store
 }
 
 func newFilterStoreManager(hubCfg *config.YurtHubConfiguration, sp storage2.StorageProvider) *filterStoreManager {
@@ -45,6 +54,9 @@ func newFilterStoreManager(hubCfg *config.YurtHubConfiguration, sp storage2.Stor
 		filterStores:    make(map[string]*filterStore),
 		restMapper:      hubCfg.RESTMapperManager,
 		storageProvider: sp,
+		transportMgr:    hubCfg.TransportAndDirectClientManager,
+		cacheMemory:     hubCfg.MultiplexerCacheMemory,
+		cacheStore:      hubCfg.MultiplexerCacheStore,
 	}
 }
 
@@ -116,6 +128,9 @@ func (fsm *filterStoreManager) resourceCache(gvr *schema.GroupVersionResource) (
 		return nil, nil, errors.Wrapf(err, "failed to generate resource cache config")
 	}
 
+	if fsm.cacheMemory > 0 && !yurtutil.IsNil(fsm.cacheStore) {
+		return newBoundedCache(restStore, gvr, resourceCacheConfig, fsm.cacheStore, fsm.cacheMemory)
+	}
 	return newResourceCache(restStore, gvr, resourceCacheConfig)
 }
 
@@ -126,15 +141,35 @@ func (fsm *filterStoreManager) newResourceCacheConfig(gvr *schema.GroupVersionRe
 	}
 	newFunc, newListFunc := fsm.getNewFunc(gvk, listGVK)
 
+	codec := scheme.Codecs.LegacyCodec(gvr.GroupVersion())
+	if !scheme.Scheme.Recognizes(gvk) {
+		codec = unstructured.UnstructuredJSONScheme
+	}
+
 	return &resourceCacheConfig{
 		KeyFunc:      keyFunc,
 		NewFunc:      newFunc,
 		NewListFunc:  newListFunc,
 		GetAttrsFunc: GetAttrsFunc(gvr),
+		Codec:        codec,
 	}, nil
 }
 
 func (fsm *filterStoreManager) getNewFunc(gvk, listGvk schema.GroupVersionKind) (func() runtime.Object, func() runtime.Object) {
+	// custom resources are not registered in scheme, so they are handled as unstructured objects.
+	if !scheme.Scheme.Recognizes(gvk) {
+		return func() runtime.Object {
+				obj := &unstructured.Unstructured{}
+				obj.SetGroupVersionKind(gvk)
+				return obj
+			},
+			func() runtime.Object {
+				objList := &unstructured.UnstructuredList{}
+				objList.SetGroupVersionKind(listGvk)
+				return objList
+			}
+	}
+
 	return func() runtime.Object {
 			obj, _ := scheme.Scheme.New(gvk)
 			return obj
@@ -147,6 +182,9 @@ func (fsm *filterStoreManager) getNewFunc(gvk, listGvk schema.GroupVersionKind)
 
 func (fsm *filterStoreManager) convertToGVK(gvr *schema.GroupVersionResource) (schema.GroupVersionKind, schema.GroupVersionKind, error) {
 	_, gvk := fsm.restMapper.KindFor(*gvr)
+	if gvk.Empty() {
+		gvk = fsm.discoverKind(gvr)
+	}
 	if gvk.Empty() {
 		return schema.GroupVersionKind{}, schema.GroupVersionKind{}, fmt.Errorf("failed to convert gvk from gvr %s", gvr.String())
 	}
@@ -160,9 +198,50 @@ func (fsm *filterStoreManager) convertToGVK(gvr *schema.GroupVersionResource) (s
 	return gvk, listGvk, nil
 }
 
+// discoverKind gets kind of gvr from discovery info of cloud kube-apiserver, it's used for custom resources
+// which have not been cached in RESTMapper. and the discovered kind is cached in RESTMapper.
+func (fsm *filterStoreManager) discoverKind(gvr *schema.GroupVersionResource) schema.GroupVersionKind {
+	if yurtutil.IsNil(fsm.transportMgr) {
+		return schema.GroupVersionKind{}
+	}
+	client := fsm.transportMgr.GetDirectClientsetAtRandom()
+	if yurtutil.IsNil(client) {
+		return schema.GroupVersionKind{}
+	}
+
+	resources, err := client.Discovery().ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		klog.Errorf("could not discover resources for %s, %v", gvr.GroupVersion().String(), err)
+		return schema.GroupVersionKind{}
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name != gvr.Resource {
+			continue
+		}
+		gvk := gvr.GroupVersion().WithKind(resource.Kind)
+		if err := fsm.restMapper.UpdateKindFor(*gvr, gvk); err != nil {
+			klog.Errorf("could not cache kind %s for %s, %v", gvk.String(), gvr.String(), err)
+		}
+		return gvk
+	}
+	return schema.GroupVersionKind{}
+}
+
 func (fsm *filterStoreManager) DeleteFilterStore(gvrStr string) {
 	fsm.Lock()
 	defer fsm.Unlock()
 
 	delete(fsm.filterStores, gvrStr)
 }
+
+// ReadinessCheck returns error when any of the filter stores which have been initialized is not ready.
+func (fsm *filterStoreManager) ReadinessCheck() error {
+	fsm.RLock()
+	defer fsm.RUnlock()
+	for gvr, fs := range fsm.filterStores {
+		if err := fs.ReadinessCheck(); err != nil {
+			return fmt.Errorf("filter store for %s is not ready, %w", gvr, err)
+		}
+	}
+	return nil
+}
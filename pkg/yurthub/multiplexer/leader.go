@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiplexer
+
+import (
+	"context"
+	"math"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coordclientset "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	"github.com/openyurtio/openyurt/pkg/projectinfo"
+	yurtutil "github.com/openyurtio/openyurt/pkg/util"
+	leaseutil "github.com/openyurtio/openyurt/pkg/util/lease"
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+)
+
+// leaseDurationIntervals is the number of heartbeat intervals after which lease of leader hub is expired.
+const leaseDurationIntervals = 3
+
+// Run starts the heartbeat of leader hub and the election of temporary leader hub. when this node is elected
+// as leader hub, its lease is renewed at heartbeat interval as long as multiplexer is ready, and yurt-manager
+// demotes leader hubs whose lease is expired. the lease is deleted when this node is not leader hub anymore,
+// but an expired lease is kept until multiplexer is ready, because yurt-manager doesn't elect nodes with expired
+// leases. and when the pool is disconnected from cloud, a temporary leader hub is elected at heartbeat interval.
+func (m *MultiplexerManager) Run(cloudHealthChecker healthchecker.Interface, stopCh <-chan struct{}) {
+	if m.heartbeatInterval <= 0 {
+		klog.Infof("heartbeat and temporary election of leader hub are disabled")
+		return
+	}
+	if !yurtutil.IsNil(m.transportMgr) {
+		go wait.Until(m.heartbeat, m.heartbeatInterval, stopCh)
+	}
+	if !yurtutil.IsNil(cloudHealthChecker) {
+		go wait.Until(func() {
+			m.electTemporaryLeader(cloudHealthChecker)
+		}, m.heartbeatInterval, stopCh)
+	}
+}
+
+func (m *MultiplexerManager) heartbeat() {
+	m.RLock()
+	isLeader := m.isLeader
+	m.RUnlock()
+	if !isLeader && m.lease == nil && m.leaseChecked {
+		return
+	}
+
+	client := m.transportMgr.GetDirectClientsetAtRandom()
+	if yurtutil.IsNil(client) {
+		klog.Warningf("could not get client for heartbeat of leader hub")
+		return
+	}
+	leaseClient := client.CoordinationV1().Leases(m.leaseNamespace)
+	ctx, cancel := context.WithTimeout(context.Background(), m.heartbeatInterval)
+	defer cancel()
+
+	if !isLeader {
+		m.releaseLease(ctx, leaseClient)
+		return
+	}
+
+	if m.lease == nil {
+		lease, err := m.ensureLease(ctx, leaseClient)
+		if err != nil {
+			klog.Errorf("could not ensure lease of leader hub, %v", err)
+			return
+		}
+		m.lease = lease
+		m.leaseChecked = true
+	}
+
+	if err := m.filterStoreManager.ReadinessCheck(); err != nil {
+		klog.Warningf("skip renewing lease %s of leader hub, because multiplexer is not ready, %v", m.lease.Name, err)
+		return
+	}
+
+	lease := m.lease.DeepCopy()
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: time.Now()}
+	lease.Spec.LeaseDurationSeconds = ptr.To(m.leaseDurationSeconds())
+	renewed, err := leaseClient.Update(ctx, lease, metav1.UpdateOptions{})
+	if err != nil {
+		klog.Errorf("could not renew lease %s of leader hub, %v", lease.Name, err)
+		// lease will be got again in the next heartbeat, because it may be updated or deleted by others.
+		m.lease = nil
+		m.leaseChecked = false
+		return
+	}
+	m.lease = renewed
+}
+
+// releaseLease deletes the lease of leader hub when this node is not leader hub anymore. the lease which is
+// expired means this node is demoted for being unhealthy, so it's kept until multiplexer is ready, otherwise
+// the node would be elected again by yurt-manager right after it's demoted.
+func (m *MultiplexerManager) releaseLease(ctx context.Context, leaseClient coordclientset.LeaseInterface) {
+	if m.lease == nil {
+		// the lease may be left by the previous yurthub process, so it's checked once after yurthub starts.
+		lease, err := leaseClient.Get(ctx, projectinfo.GetHubLeaderLeaseName(m.nodeName), metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			klog.Errorf("could not get lease of leader hub, %v", err)
+			return
+		}
+		m.leaseChecked = true
+		if err != nil {
+			return
+		}
+		m.lease = lease
+	}
+
+	if !leaseutil.ExpireTime(m.lease).After(time.Now()) {
+		if err := m.filterStoreManager.ReadinessCheck(); err != nil {
+			klog.Warningf("keep expired lease %s of leader hub, because multiplexer is not ready, %v", m.lease.Name, err)
+			return
+		}
+	}
+
+	err := leaseClient.Delete(ctx, m.lease.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("could not delete lease %s of leader hub, %v", m.lease.Name, err)
+		return
+	}
+	klog.Infof("lease %s of leader hub is deleted, because node is not leader hub anymore", m.lease.Name)
+	m.lease = nil
+}
+
+// ensureLease gets the lease of leader hub on this node, and creates it if not found. the renew time of
+// a new lease is left empty until multiplexer is ready, so leader hub which is never ready is demoted too.
+func (m *MultiplexerManager) ensureLease(ctx context.Context, leaseClient coordclientset.LeaseInterface) (*coordinationv1.Lease, error) {
+	name := projectinfo.GetHubLeaderLeaseName(m.nodeName)
+	lease, err := leaseClient.Get(ctx, name, metav1.GetOptions{})
+	if err == nil || !apierrors.IsNotFound(err) {
+		return lease, err
+	}
+
+	return leaseClient.Create(ctx, &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: m.leaseNamespace,
+			Labels: map[string]string{
+				projectinfo.GetHubLeaderLeaseLabel(): m.nodePoolName,
+			},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To(m.nodeName),
+			LeaseDurationSeconds: ptr.To(m.leaseDurationSeconds()),
+			AcquireTime:          &metav1.MicroTime{Time: time.Now()},
+		},
+	}, metav1.CreateOptions{})
+}
+
+func (m *MultiplexerManager) leaseDurationSeconds() int32 {
+	return int32(math.Ceil((leaseDurationIntervals * m.heartbeatInterval).Seconds()))
+}
+
+// SwitchUpstream records the upstream(a leader hub or cloud kube-apiserver) of requests for pool scope metadata
+// which are forwarded by multiplexer of follower hub, and every switch of upstream is counted in metrics.
+func (m *MultiplexerManager) SwitchUpstream(upstream string) {
+	last, _ := m.upstream.Swap(upstream).(string)
+	if len(last) != 0 && last != upstream {
+		klog.Infof("upstream for pool scope metadata is switched from %s to %s", last, upstream)
+		metrics.Metrics.IncLeaderHubSwitches(last, upstream)
+	}
+}
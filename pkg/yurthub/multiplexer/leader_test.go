@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiplexer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/openyurtio/openyurt/pkg/projectinfo"
+	"github.com/openyurtio/openyurt/pkg/yurthub/transport"
+)
+
+func TestHeartbeat(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	m := &MultiplexerManager{
+		filterStoreManager: &filterStoreManager{filterStores: make(map[string]*filterStore)},
+		nodeName:           "node1",
+		nodePoolName:       "hangzhou",
+		leaseNamespace:     "kube-system",
+		heartbeatInterval:  5 * time.Second,
+		transportMgr:       transport.NewFakeTransportManager(http.StatusOK, map[string]kubernetes.Interface{"https://10.0.0.1:6443": client}),
+	}
+	leaseName := projectinfo.GetHubLeaderLeaseName("node1")
+
+	// no lease is created when node is not leader hub.
+	m.heartbeat()
+	if _, err := client.CoordinationV1().Leases("kube-system").Get(context.Background(), leaseName, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expect lease is not found, but got %v", err)
+	}
+
+	// lease is created and renewed when node is leader hub.
+	m.isLeader = true
+	m.heartbeat()
+	lease, err := client.CoordinationV1().Leases("kube-system").Get(context.Background(), leaseName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("could not get lease, %v", err)
+	}
+	if lease.Labels[projectinfo.GetHubLeaderLeaseLabel()] != "hangzhou" {
+		t.Errorf("expect lease is labeled with nodepool hangzhou, but got %v", lease.Labels)
+	}
+	if *lease.Spec.HolderIdentity != "node1" || *lease.Spec.LeaseDurationSeconds != 15 || lease.Spec.RenewTime == nil {
+		t.Errorf("expect lease is held by node1 for 15s and renewed, but got %+v", lease.Spec)
+	}
+
+	firstRenewTime := lease.Spec.RenewTime.Time
+	time.Sleep(10 * time.Millisecond)
+	m.heartbeat()
+	lease, _ = client.CoordinationV1().Leases("kube-system").Get(context.Background(), leaseName, metav1.GetOptions{})
+	if !lease.Spec.RenewTime.After(firstRenewTime) {
+		t.Errorf("expect lease is renewed after %v, but got %v", firstRenewTime, lease.Spec.RenewTime)
+	}
+
+	// lease is deleted when node is not leader hub anymore.
+	m.isLeader = false
+	m.heartbeat()
+	if _, err := client.CoordinationV1().Leases("kube-system").Get(context.Background(), leaseName, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expect lease is deleted, but got %v", err)
+	}
+	if m.lease != nil {
+		t.Errorf("expect lease is reset, but got %v", m.lease)
+	}
+}
+
+func TestReleaseExpiredLease(t *testing.T) {
+	leaseName := projectinfo.GetHubLeaderLeaseName("node1")
+	// the expired lease is left by the previous yurthub process.
+	expiredLease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: leaseName, Namespace: "kube-system"},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To("node1"),
+			LeaseDurationSeconds: ptr.To[int32](15),
+			RenewTime:            &metav1.MicroTime{Time: time.Now().Add(-time.Minute)},
+		},
+	}
+
+	testcases := map[string]struct {
+		readinessErr error
+		expectLease  bool
+	}{
+		"expired lease is kept when multiplexer is not ready": {
+			readinessErr: errors.New("not ready"),
+			expectLease:  true,
+		},
+		"expired lease is deleted when multiplexer is ready": {
+			expectLease: false,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			client := fake.NewSimpleClientset(expiredLease)
+			m := &MultiplexerManager{
+				filterStoreManager: &filterStoreManager{filterStores: make(map[string]*filterStore)},
+				nodeName:           "node1",
+				leaseNamespace:     "kube-system",
+				heartbeatInterval:  5 * time.Second,
+				transportMgr:       transport.NewFakeTransportManager(http.StatusOK, map[string]kubernetes.Interface{"https://10.0.0.1:6443": client}),
+			}
+			if tc.readinessErr != nil {
+				m.filterStoreManager.filterStores["v1/services"] = &filterStore{store: &registry.Store{
+					ReadinessCheckFunc: func() error { return tc.readinessErr },
+				}}
+			}
+
+			m.heartbeat()
+			_, err := client.CoordinationV1().Leases("kube-system").Get(context.Background(), leaseName, metav1.GetOptions{})
+			if tc.expectLease && err != nil {
+				t.Errorf("expect lease is kept, but got %v", err)
+			} else if !tc.expectLease && !apierrors.IsNotFound(err) {
+				t.Errorf("expect lease is deleted, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestSwitchUpstream(t *testing.T) {
+	m := &MultiplexerManager{}
+	for _, upstream := range []string{"https://10.0.0.1:10269", "https://10.0.0.1:10269", CloudUpstreamForPoolScopeMetadata} {
+		m.SwitchUpstream(upstream)
+		if current, _ := m.upstream.Load().(string); current != upstream {
+			t.Errorf("expect upstream %s, but got %s", upstream, current)
+		}
+	}
+}
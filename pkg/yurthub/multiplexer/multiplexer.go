@@ -23,7 +23,10 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -34,8 +37,10 @@ import (
 
 	"github.com/openyurtio/openyurt/cmd/yurthub/app/config"
 	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker/leaderhub"
 	ystorage "github.com/openyurtio/openyurt/pkg/yurthub/multiplexer/storage"
 	"github.com/openyurtio/openyurt/pkg/yurthub/proxy/remote"
+	"github.com/openyurtio/openyurt/pkg/yurthub/transport"
 	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
 )
 
@@ -43,9 +48,15 @@ const (
 	PoolScopeMetadataKey = "pool-scoped-metadata"
 	LeaderEndpointsKey   = "leaders"
 	EnableLeaderElection = "enable-leader-election"
+	InterConnectivityKey = "interconnectivity"
+	LeaderCandidatesKey  = "candidates"
 
 	PoolSourceForPoolScopeMetadata      = "pool"
 	APIServerSourceForPoolScopeMetadata = "api"
+
+	// CloudUpstreamForPoolScopeMetadata is the upstream of requests for pool scope metadata
+	// when follower yurthub fails over to cloud kube-apiserver.
+	CloudUpstreamForPoolScopeMetadata = "cloud"
 )
 
 type MultiplexerManager struct {
@@ -54,14 +65,33 @@ type MultiplexerManager struct {
 	loadBalancerForLeaders  remote.Server
 	portForLeaderHub        int
 	nodeName                string
+	nodePoolName            string
 	multiplexerUserAgent    string
+	leaseNamespace          string
+	heartbeatInterval       time.Duration
+	transportMgr            transport.Interface
+	// lease is the lease of leader hub on this node which is renewed by heartbeat, and only accessed by heartbeat.
+	lease *coordinationv1.Lease
+	// leaseChecked is set after the lease left by the previous yurthub process is checked.
+	leaseChecked bool
+	upstream     atomic.Value
+	// pingFunc is used for probing leader hubs and candidates when electing temporary leader hub.
+	pingFunc func(*url.URL) bool
+
+	// electionLock protects leader addresses and states of temporary leader hub election, which are
+	// updated by configmap event handler and election of temporary leader hub.
+	electionLock     sync.Mutex
+	leaderAddresses  sets.Set[string]
+	candidates       []leaderCandidate
+	configuredSource string
+	temporaryLeader  string
 
 	sync.RWMutex
 	lazyLoadedGVRCache            map[string]Interface
 	lazyLoadedGVRCacheDestroyFunc map[string]func()
 	sourceForPoolScopeMetadata    string
 	poolScopeMetadata             sets.Set[string]
-	leaderAddresses               sets.Set[string]
+	isLeader                      bool
 	configMapSynced               cache.InformerSynced
 }
 
@@ -86,7 +116,12 @@ func NewRequestMultiplexerManager(
 		leaderAddresses:               sets.New[string](),
 		portForLeaderHub:              cfg.PortForMultiplexer,
 		nodeName:                      cfg.NodeName,
+		nodePoolName:                  cfg.NodePoolName,
 		multiplexerUserAgent:          hubutil.MultiplexerProxyClientUserAgentPrefix + cfg.NodeName,
+		leaseNamespace:                cfg.YurtHubNamespace,
+		heartbeatInterval:             cfg.LeaderHeartbeatInterval,
+		transportMgr:                  cfg.TransportAndDirectClientManager,
+		pingFunc:                      leaderhub.PingServer,
 		configMapSynced:               configmapInformer.HasSynced,
 	}
 
@@ -164,6 +199,25 @@ func (m *MultiplexerManager) updateLeaderHubConfiguration(cm *corev1.ConfigMap)
 		}
 	}
 
+	// candidates are only used when nodes in the pool can access with each other.
+	var newCandidates []leaderCandidate
+	if cm.Data[EnableLeaderElection] == "true" && cm.Data[InterConnectivityKey] == "true" && len(cm.Data[LeaderCandidatesKey]) != 0 {
+		for _, part := range strings.Split(cm.Data[LeaderCandidatesKey], ",") {
+			subParts := strings.Split(part, "/")
+			if len(subParts) == 2 {
+				newCandidates = append(newCandidates, leaderCandidate{name: subParts[0], address: subParts[1]})
+			}
+		}
+	}
+
+	isLeader := cm.Data[EnableLeaderElection] == "true" && newLeaderNames.Has(m.nodeName)
+	m.Lock()
+	if m.isLeader != isLeader {
+		klog.Infof("node %s is elected as leader hub: %v", m.nodeName, isLeader)
+	}
+	m.isLeader = isLeader
+	m.Unlock()
+
 	newSource := APIServerSourceForPoolScopeMetadata
 	// enable-leader-election is enabled and node is not elected as leader hub,
 	// multiplexer will list/watch pool scope metadata from leader yurthub.
@@ -172,14 +226,41 @@ func (m *MultiplexerManager) updateLeaderHubConfiguration(cm *corev1.ConfigMap)
 		newSource = PoolSourceForPoolScopeMetadata
 	}
 
+	m.electionLock.Lock()
+	defer m.electionLock.Unlock()
 	// LeaderHubEndpoints are changed, related health checker and load balancer are need to be updated.
-	if !m.leaderAddresses.Equal(newLeaderAddresses) {
+	// temporary leader hub is dropped too, because leader hubs elected by yurt-manager take precedence.
+	if !m.leaderAddresses.Equal(newLeaderAddresses) || len(m.temporaryLeader) != 0 {
+		if len(m.temporaryLeader) != 0 {
+			klog.Infof("temporary leader hub %s is dropped, leader hubs %v are configured", m.temporaryLeader, newLeaderAddresses.UnsortedList())
+			m.temporaryLeader = ""
+		}
 		servers := m.resolveLeaderHubServers(newLeaderAddresses)
 		m.healthCheckerForLeaders.UpdateBackends(servers)
 		m.loadBalancerForLeaders.UpdateBackends(servers)
 		m.leaderAddresses = newLeaderAddresses
 	}
+	m.candidates = newCandidates
+	m.configuredSource = newSource
+
+	m.updatePoolScopeMetadata(newSource, newPoolScopeMetadata)
+}
+
+// SetPoolScopeResources updates the pool scope resources served by multiplexer at runtime, and caches of
+// removed resources are destroyed. the resources will be overwritten by the following updates of leader hub
+// configmap, because pool scope metadata of leader hub configmap are kept in line with the whole pool.
+func (m *MultiplexerManager) SetPoolScopeResources(gvrs []schema.GroupVersionResource) {
+	newPoolScopeMetadata := sets.New[string]()
+	for i := range gvrs {
+		newPoolScopeMetadata.Insert(gvrs[i].String())
+	}
+	m.updatePoolScopeMetadata(m.SourceForPoolScopeMetadata(), newPoolScopeMetadata)
+	klog.Infof("pool scope resources are updated to %v", newPoolScopeMetadata)
+}
 
+func (m *MultiplexerManager) updatePoolScopeMetadata(newSource string, newPoolScopeMetadata sets.Set[string]) {
+	m.Lock()
+	defer m.Unlock()
 	if m.sourceForPoolScopeMetadata == newSource &&
 		m.poolScopeMetadata.Equal(newPoolScopeMetadata) {
 		return
@@ -187,9 +268,6 @@ func (m *MultiplexerManager) updateLeaderHubConfiguration(cm *corev1.ConfigMap)
 
 	// if pool scope metadata are removed, related GVR cache should be destroyed.
 	deletedPoolScopeMetadata := m.poolScopeMetadata.Difference(newPoolScopeMetadata)
-
-	m.Lock()
-	defer m.Unlock()
 	m.sourceForPoolScopeMetadata = newSource
 	m.poolScopeMetadata = newPoolScopeMetadata
 	for _, gvrStr := range deletedPoolScopeMetadata.UnsortedList() {
@@ -254,16 +332,22 @@ func (m *MultiplexerManager) ResolveRequestForPoolScopeMetadata(req *http.Reques
 func (m *MultiplexerManager) resolveLeaderHubServers(leaderAddresses sets.Set[string]) []*url.URL {
 	servers := make([]*url.URL, 0, leaderAddresses.Len())
 	for _, internalIP := range leaderAddresses.UnsortedList() {
-		u, err := url.Parse(fmt.Sprintf("https://%s:%d", internalIP, m.portForLeaderHub))
-		if err != nil {
-			klog.Errorf("couldn't parse url(%s), %v", fmt.Sprintf("https://%s:%d", internalIP, m.portForLeaderHub), err)
-			continue
+		if u := m.resolveLeaderHubServer(internalIP); u != nil {
+			servers = append(servers, u)
 		}
-		servers = append(servers, u)
 	}
 	return servers
 }
 
+func (m *MultiplexerManager) resolveLeaderHubServer(internalIP string) *url.URL {
+	u, err := url.Parse(fmt.Sprintf("https://%s:%d", internalIP, m.portForLeaderHub))
+	if err != nil {
+		klog.Errorf("couldn't parse url(%s), %v", fmt.Sprintf("https://%s:%d", internalIP, m.portForLeaderHub), err)
+		return nil
+	}
+	return u
+}
+
 func (m *MultiplexerManager) Ready(gvr *schema.GroupVersionResource) bool {
 	fs, err := m.filterStoreManager.FilterStore(gvr)
 	if err != nil {
@@ -20,8 +20,11 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+
+	hubmeta "github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/meta"
 )
 
 type StorageProvider interface {
@@ -64,6 +67,10 @@ func (sm *apiServerStorageProvider) restClient(gvr *schema.GroupVersionResource)
 	}
 
 	configShallowCopy := *sm.config
+	if !hubmeta.IsSchemeResource(*gvr) {
+		// custom resources are not registered in scheme, so they are decoded as unstructured objects.
+		configShallowCopy = *dynamic.ConfigFor(sm.config)
+	}
 	configShallowCopy.APIPath = getAPIPath(gvr)
 
 	gv := gvr.GroupVersion()
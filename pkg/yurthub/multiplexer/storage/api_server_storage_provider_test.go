@@ -37,6 +37,12 @@ var endpointSlicesGVR = &schema.GroupVersionResource{
 	Resource: "endpointslices",
 }
 
+var crontabGVR = &schema.GroupVersionResource{
+	Group:    "stable.example.com",
+	Version:  "v1",
+	Resource: "crontabs",
+}
+
 func TestStorageManager_ResourceStorage(t *testing.T) {
 	sm := NewStorageProvider(&rest.Config{
 		Host:      "http://127.0.0.1:10261",
@@ -55,6 +61,10 @@ func TestStorageManager_ResourceStorage(t *testing.T) {
 			gvr: endpointSlicesGVR,
 			err: nil,
 		},
+		"get resource storage for custom resources": {
+			gvr: crontabGVR,
+			err: nil,
+		},
 	} {
 		t.Run(k, func(t *testing.T) {
 			restore, err := sm.ResourceStorage(tc.gvr)
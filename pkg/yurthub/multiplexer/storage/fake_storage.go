@@ -24,6 +24,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	discovery "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/apiserver/pkg/storage"
@@ -161,3 +162,39 @@ func (fs *FakeEndpointSliceStorage) AddWatchObject(eps *discovery.EndpointSlice)
 	eps.ResourceVersion = "101"
 	fs.watcher.Add(eps)
 }
+
+type FakeUnstructuredStorage struct {
+	*CommonFakeStorage
+	items   []unstructured.Unstructured
+	watcher *watch.FakeWatcher
+}
+
+func NewFakeUnstructuredStorage(items []unstructured.Unstructured) *FakeUnstructuredStorage {
+	return &FakeUnstructuredStorage{
+		CommonFakeStorage: &CommonFakeStorage{},
+		items:             items,
+		watcher:           watch.NewFake(),
+	}
+}
+
+func (fs *FakeUnstructuredStorage) GetList(ctx context.Context, key string, opts storage.ListOptions, listObj runtime.Object) error {
+	list := listObj.(*unstructured.UnstructuredList)
+	list.SetResourceVersion("100")
+
+	for _, item := range fs.items {
+		itemKey := fmt.Sprintf("/%s/%s", item.GetNamespace(), item.GetName())
+		if strings.HasPrefix(itemKey, key) {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+	return nil
+}
+
+func (fs *FakeUnstructuredStorage) Watch(ctx context.Context, key string, opts storage.ListOptions) (watch.Interface, error) {
+	return fs.watcher, nil
+}
+
+func (fs *FakeUnstructuredStorage) AddWatchObject(obj *unstructured.Unstructured) {
+	obj.SetResourceVersion("101")
+	fs.watcher.Add(obj)
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/apis/apps/v1beta2"
+)
+
+var nodePoolGVR = v1beta2.GroupVersion.WithResource("nodepools")
+
+// Informers list/watches policy resources, like YurtHubCachePolicy and YurtHubFilterPolicy, and the nodepool
+// of current node which is used for matching policies that select nodepools by labels. Informers are shared by
+// all of policy managers, so each resource is list/watched only once, and Start should be called after all of
+// policy managers have been created.
+type Informers struct {
+	nodePoolName     string
+	factory          dynamicinformer.DynamicSharedInformerFactory
+	nodePoolInformer informers.GenericInformer
+	nodePoolSynced   cache.InformerSynced
+}
+
+// NewInformers creates a *Informers for the node in nodepool nodePoolName.
+func NewInformers(dynamicClient dynamic.Interface, nodePoolName string) *Informers {
+	i := &Informers{
+		nodePoolName: nodePoolName,
+		factory:      dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 24*time.Hour),
+	}
+
+	// only the nodepool of current node is list/watched.
+	if len(nodePoolName) != 0 {
+		i.nodePoolInformer = dynamicinformer.NewFilteredDynamicInformer(dynamicClient, nodePoolGVR, metav1.NamespaceAll, 24*time.Hour, cache.Indexers{}, func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", nodePoolName).String()
+		})
+		i.nodePoolSynced = syncedOrNotFound(i.nodePoolInformer.Informer(), nodePoolGVR)
+	}
+	return i
+}
+
+// syncedOrNotFound returns the function for checking whether informer has synced, and informer is regarded as
+// synced when the resource is not found, like the CRD has not been installed, so readiness of yurthub is not
+// blocked by it. informer keeps retrying, and objects are handled after the resource is installed.
+func syncedOrNotFound(informer cache.SharedIndexInformer, gvr schema.GroupVersionResource) cache.InformerSynced {
+	var notFound atomic.Bool
+	err := informer.SetWatchErrorHandlerWithContext(func(ctx context.Context, r *cache.Reflector, err error) {
+		if apierrors.IsNotFound(err) {
+			if !notFound.Swap(true) {
+				klog.Warningf("%s is not found, regard it as no objects until it's installed, %v", gvr.String(), err)
+			}
+			return
+		}
+		cache.DefaultWatchErrorHandler(ctx, r, err)
+	})
+	if err != nil {
+		klog.Errorf("could not set watch error handler for %s, %v", gvr.String(), err)
+	}
+
+	return func() bool {
+		return informer.HasSynced() || notFound.Load()
+	}
+}
+
+// NodePoolName returns the name of nodepool which current node belongs to.
+func (i *Informers) NodePoolName() string {
+	return i.nodePoolName
+}
+
+// Watch registers handler for the changes of policies in gvr and the nodepool of current node, because the policies
+// selected by labels of nodepool should be updated when labels of nodepool are changed. The lister of policies and
+// the function for checking whether informers have synced are returned.
+func (i *Informers) Watch(gvr schema.GroupVersionResource, handler func(action string)) (cache.GenericLister, cache.InformerSynced) {
+	eventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			handler("add")
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			handler("update")
+		},
+		DeleteFunc: func(obj interface{}) {
+			handler("delete")
+		},
+	}
+
+	policyInformer := i.factory.ForResource(gvr)
+	policyInformer.Informer().AddEventHandler(eventHandler)
+	policySynced := syncedOrNotFound(policyInformer.Informer(), gvr)
+	if i.nodePoolInformer == nil {
+		return policyInformer.Lister(), policySynced
+	}
+
+	i.nodePoolInformer.Informer().AddEventHandler(eventHandler)
+	return policyInformer.Lister(), func() bool {
+		return policySynced() && i.nodePoolSynced()
+	}
+}
+
+// Start starts all of informers which have been registered by Watch.
+func (i *Informers) Start(stopCh <-chan struct{}) {
+	i.factory.Start(stopCh)
+	if i.nodePoolInformer != nil {
+		go i.nodePoolInformer.Informer().Run(stopCh)
+	}
+}
+
+// NodePoolLabels returns labels of the nodepool of current node, and nil is returned if current node
+// doesn't belong to any nodepool or the nodepool is not found.
+func (i *Informers) NodePoolLabels() labels.Set {
+	if i.nodePoolInformer == nil {
+		return nil
+	}
+
+	obj, err := i.nodePoolInformer.Lister().Get(i.nodePoolName)
+	if err != nil {
+		klog.V(4).Infof("could not get nodepool %s for policies, %v", i.nodePoolName, err)
+		return nil
+	}
+	if pool, ok := obj.(metav1.Object); ok {
+		return labels.Set(pool.GetLabels())
+	}
+	return nil
+}
+
+// AppliedToNodePool checks whether the policy which selects nodepools by nodePools and selector is applied to
+// the nodepool of current node, and poolLabels are labels of the nodepool. The policy is applied to all nodes
+// when both nodePools and selector are not specified.
+func (i *Informers) AppliedToNodePool(nodePools []string, selector *metav1.LabelSelector, poolLabels labels.Set) (bool, error) {
+	if len(nodePools) == 0 && selector == nil {
+		return true, nil
+	}
+	if len(i.nodePoolName) == 0 {
+		return false, nil
+	}
+	if slices.Contains(nodePools, i.nodePoolName) {
+		return true, nil
+	}
+	if selector != nil && poolLabels != nil {
+		s, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return false, err
+		}
+		return s.Matches(poolLabels), nil
+	}
+	return false, nil
+}
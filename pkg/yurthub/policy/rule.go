@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"slices"
+	"sort"
+
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// Resource is the resource matched by a rule of policy. Group is the API group of resource and "*" matches
+// all groups, empty Version matches all versions, and Resource is the plural name of resource and "*" matches
+// all resources.
+type Resource struct {
+	Group    string
+	Version  string
+	Resource string
+}
+
+// Rule is the common part of rules in policies, which matches requests by user agent and resource.
+type Rule struct {
+	// Policy is the name of policy which the rule belongs to.
+	Policy string
+	// Priority is the priority of policy which the rule belongs to.
+	Priority int32
+	// Index is the index of rule in the policy.
+	Index      int
+	UserAgents []string
+	// Resources are the resources of requests, empty list matches all resources.
+	Resources []Resource
+}
+
+// PolicyRule returns the common part of rule.
+func (r *Rule) PolicyRule() *Rule {
+	return r
+}
+
+// MatchesRequest checks whether the request sent by component comp is matched by user agents and resources of rule.
+func (r *Rule) MatchesRequest(comp string, info *apirequest.RequestInfo) bool {
+	if !slices.Contains(r.UserAgents, "*") && !slices.Contains(r.UserAgents, comp) {
+		return false
+	}
+
+	if len(r.Resources) == 0 {
+		return true
+	}
+	for _, res := range r.Resources {
+		if (res.Group == "*" || res.Group == info.APIGroup) &&
+			(len(res.Version) == 0 || res.Version == info.APIVersion) &&
+			(res.Resource == "*" || res.Resource == info.Resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// SortRules sorts rules by priority of policy(higher first), name of policy and index of rule.
+func SortRules[T interface{ PolicyRule() *Rule }](rules []T) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		ri, rj := rules[i].PolicyRule(), rules[j].PolicyRule()
+		if ri.Priority != rj.Priority {
+			return ri.Priority > rj.Priority
+		}
+		if ri.Policy != rj.Policy {
+			return ri.Policy < rj.Policy
+		}
+		return ri.Index < rj.Index
+	})
+}
@@ -167,6 +167,14 @@ func (ap *AutonomyProxy) tryUpdateNodeConditions(tryNumber int, req *http.Reques
 	}
 
 	if client == nil {
+		if tryNumber == 0 {
+			// the cloud is unreachable, accept node status locally, so local components
+			// can observe the autonomy condition of node.
+			if err := ap.cacheMgr.AcceptLocalWrite(req, changedNode); err != nil {
+				return originalNode, err
+			}
+			return changedNode, nil
+		}
 		return nil, fmt.Errorf("no healthy remote server can be found for updating node condition")
 	}
 
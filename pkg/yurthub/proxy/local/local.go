@@ -31,14 +31,17 @@ import (
 	metainternalversionscheme "k8s.io/apimachinery/pkg/apis/meta/internalversion/scheme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	apirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/klog/v2"
 
 	yurtutil "github.com/openyurtio/openyurt/pkg/util"
 	manager "github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
 	hubmeta "github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/meta"
+	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
 	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
 	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/writequeue"
 )
 
 const (
@@ -53,14 +56,22 @@ type LocalProxy struct {
 	cacheMgr          manager.CacheManager
 	isCloudHealthy    IsHealthy
 	minRequestTimeout time.Duration
+	writeQueue        *writequeue.Queue
 }
 
 // NewLocalProxy creates a *LocalProxy
 func NewLocalProxy(cacheMgr manager.CacheManager, isCloudHealthy IsHealthy, minRequestTimeout time.Duration) *LocalProxy {
+	return NewLocalProxyWithWriteQueue(cacheMgr, isCloudHealthy, minRequestTimeout, nil)
+}
+
+// NewLocalProxyWithWriteQueue creates a *LocalProxy which records mutating requests of replayable
+// resources into writeQueue while the cloud is unreachable. nil writeQueue means nothing is recorded.
+func NewLocalProxyWithWriteQueue(cacheMgr manager.CacheManager, isCloudHealthy IsHealthy, minRequestTimeout time.Duration, writeQueue *writequeue.Queue) *LocalProxy {
 	return &LocalProxy{
 		cacheMgr:          cacheMgr,
 		isCloudHealthy:    isCloudHealthy,
 		minRequestTimeout: minRequestTimeout,
+		writeQueue:        writeQueue,
 	}
 }
 
@@ -70,6 +81,13 @@ func (lp *LocalProxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	ctx := req.Context()
 	if reqInfo, ok := apirequest.RequestInfoFrom(ctx); ok && reqInfo != nil && reqInfo.IsResourceRequest {
 		klog.V(3).Infof("go into local proxy for request %s", hubutil.ReqString(req))
+		if lp.writeQueue != nil && lp.writeQueue.Replayable(reqInfo) && !lp.isCloudHealthy() {
+			// the request is still handled locally after it is recorded, and will be replayed
+			// against cloud kube-apiserver after reconnecting.
+			if err := lp.writeQueue.Record(req); err != nil {
+				klog.Errorf("could not record %s into offline write queue, %v", hubutil.ReqString(req), err)
+			}
+		}
 		switch reqInfo.Verb {
 		case "watch":
 			err = lp.localWatch(w, req)
@@ -195,6 +213,20 @@ func (lp *LocalProxy) localWatch(w http.ResponseWriter, req *http.Request) error
 	defer watchTimer.Stop()
 	defer intervalTicker.Stop()
 
+	// synthetic events of objects written locally are delivered while the cloud is unreachable,
+	// and the watch is closed when cloud becomes healthy, so the client can resync from cloud.
+	var events <-chan watch.Event
+	var s *serializer.Serializer
+	if localWatcher, err := lp.cacheMgr.WatchLocal(req); err != nil {
+		klog.V(4).Infof("could not watch local writes for %s, %v", hubutil.ReqString(req), err)
+	} else {
+		defer localWatcher.Stop()
+		events = localWatcher.ResultChan()
+		if info, ok := apirequest.RequestInfoFrom(ctx); ok && info != nil {
+			s = serializer.YurtHubSerializer.CreateSerializer(contentType, info.APIGroup, info.APIVersion, info.Resource)
+		}
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -207,6 +239,19 @@ func (lp *LocalProxy) localWatch(w http.ResponseWriter, req *http.Request) error
 			if lp.isCloudHealthy() {
 				return nil
 			}
+		case event, ok := <-events:
+			if !ok {
+				// local watcher is stopped, close the watch for resync
+				return nil
+			}
+			if s == nil {
+				continue
+			}
+			if _, err := s.WatchEncode(w, &event); err != nil {
+				klog.Errorf("could not encode local watch event for %s, %v", hubutil.ReqString(req), err)
+				return nil
+			}
+			flusher.Flush()
 		}
 	}
 }
@@ -219,6 +264,16 @@ func (lp *LocalProxy) localReqCache(w http.ResponseWriter, req *http.Request) er
 	}
 
 	obj, err := lp.cacheMgr.QueryCache(req)
+	if err == nil && obj != nil && !lp.isCloudHealthy() {
+		// accept the write request locally while the cloud is unreachable
+		if reqInfo, ok := apirequest.RequestInfoFrom(req.Context()); ok && reqInfo != nil && (reqInfo.Verb == "update" || reqInfo.Verb == "patch") {
+			if written, err := lp.localWrite(req, reqInfo, obj); err == nil {
+				return hubutil.WriteObject(http.StatusOK, written, w, req)
+			} else if !errors.Is(err, manager.ErrLocalWriteNotSupported) {
+				klog.Warningf("could not write %s locally, %v", hubutil.ReqString(req), err)
+			}
+		}
+	}
 	if errors.Is(err, storage.ErrStorageNotFound) || errors.Is(err, hubmeta.ErrGVRNotRecognized) {
 		klog.Errorf("object not found for %s", hubutil.ReqString(req))
 		reqInfo, ok := apirequest.RequestInfoFrom(req.Context())
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	yurtutil "github.com/openyurtio/openyurt/pkg/util"
+	manager "github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
+	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
+	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+// localWrite applies update or patch request on the cached object and writes the result into local cache,
+// so local components can observe the written object by get and watch requests while the cloud is unreachable.
+// only node and lease of kubelet(like node status and lease renewals) can be written locally.
+func (lp *LocalProxy) localWrite(req *http.Request, info *apirequest.RequestInfo, cached runtime.Object) (runtime.Object, error) {
+	if comp, _ := hubutil.TruncatedClientComponentFrom(req.Context()); comp != "kubelet" || (info.Resource != "nodes" && info.Resource != "leases") {
+		return nil, manager.ErrLocalWriteNotSupported
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	contentType, _, err := mime.ParseMediaType(req.Header.Get(yurtutil.HTTPHeaderContentType))
+	if err != nil {
+		return nil, err
+	}
+
+	var obj runtime.Object
+	switch info.Verb {
+	case "update":
+		s := serializer.YurtHubSerializer.CreateSerializer(contentType, info.APIGroup, info.APIVersion, info.Resource)
+		if s == nil {
+			return nil, fmt.Errorf("could not create serializer for %s", contentType)
+		}
+		obj, err = s.Decode(body)
+	case "patch":
+		obj, err = applyPatch(cached, types.PatchType(contentType), body)
+	default:
+		return nil, manager.ErrLocalWriteNotSupported
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lp.cacheMgr.AcceptLocalWrite(req, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// applyPatch applies patch on the typed object and returns the patched object.
+func applyPatch(original runtime.Object, patchType types.PatchType, patch []byte) (runtime.Object, error) {
+	originalJSON, err := json.Marshal(original)
+	if err != nil {
+		return nil, err
+	}
+
+	newObject := func() runtime.Object {
+		return reflect.New(reflect.TypeOf(original).Elem()).Interface().(runtime.Object)
+	}
+
+	var patched []byte
+	switch patchType {
+	case types.StrategicMergePatchType:
+		patched, err = strategicpatch.StrategicMergePatch(originalJSON, patch, newObject())
+	case types.MergePatchType:
+		patched, err = jsonpatch.MergePatch(originalJSON, patch)
+	case types.JSONPatchType:
+		var p jsonpatch.Patch
+		if p, err = jsonpatch.DecodePatch(patch); err == nil {
+			patched, err = p.Apply(originalJSON)
+		}
+	default:
+		return nil, fmt.Errorf("patch type %s is not supported", patchType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	obj := newObject()
+	if err := json.Unmarshal(patched, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package local
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/endpoints/filters"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
+	"github.com/openyurtio/openyurt/pkg/yurthub/configuration"
+	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
+	proxyutil "github.com/openyurtio/openyurt/pkg/yurthub/proxy/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/disk"
+)
+
+func TestApplyPatch(t *testing.T) {
+	node := &v1.Node{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+		ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"foo": "bar"}},
+		Status: v1.NodeStatus{
+			Conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue},
+				{Type: v1.NodeMemoryPressure, Status: v1.ConditionFalse},
+			},
+		},
+	}
+
+	testcases := map[string]struct {
+		patchType types.PatchType
+		patch     string
+		isErr     bool
+		check     func(node *v1.Node) bool
+	}{
+		"strategic merge patch merges conditions by type": {
+			patchType: types.StrategicMergePatchType,
+			patch:     `{"status":{"conditions":[{"type":"Ready","status":"False"}]}}`,
+			check: func(node *v1.Node) bool {
+				return len(node.Status.Conditions) == 2 && node.Status.Conditions[0].Status == v1.ConditionFalse
+			},
+		},
+		"merge patch": {
+			patchType: types.MergePatchType,
+			patch:     `{"metadata":{"labels":{"foo":null,"a":"b"}}}`,
+			check: func(node *v1.Node) bool {
+				return len(node.Labels) == 1 && node.Labels["a"] == "b"
+			},
+		},
+		"json patch": {
+			patchType: types.JSONPatchType,
+			patch:     `[{"op":"replace","path":"/metadata/labels/foo","value":"baz"}]`,
+			check: func(node *v1.Node) bool {
+				return node.Labels["foo"] == "baz"
+			},
+		},
+		"apply patch is not supported": {
+			patchType: types.ApplyPatchType,
+			patch:     `{}`,
+			isErr:     true,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			obj, err := applyPatch(node, tc.patchType, []byte(tc.patch))
+			if tc.isErr {
+				if err == nil {
+					t.Errorf("expect error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("could not apply patch, %v", err)
+			}
+			if patched, ok := obj.(*v1.Node); !ok || !tc.check(patched) {
+				t.Errorf("got unexpected patched object %#v", obj)
+			}
+		})
+	}
+}
+
+func TestLocalWriteAndWatch(t *testing.T) {
+	dStorage, err := disk.NewDiskStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create disk storage, %v", err)
+	}
+	sWrapper := cachemanager.NewStorageWrapper(dStorage)
+	serializerM := serializer.NewSerializerManager()
+	fakeSharedInformerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	configManager := configuration.NewConfigurationManager("node1", fakeSharedInformerFactory)
+	cacheM := cachemanager.NewCacheManager(sWrapper, serializerM, nil, configManager)
+
+	objs := map[storage.KeyBuildInfo]runtime.Object{
+		{Component: "kubelet", Resources: "nodes", Version: "v1", Name: "node1"}: &v1.Node{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+			ObjectMeta: metav1.ObjectMeta{Name: "node1", ResourceVersion: "10"},
+			Status:     v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}},
+		},
+		{Component: "kubelet", Resources: "leases", Group: "coordination.k8s.io", Version: "v1", Namespace: "kube-node-lease", Name: "node1"}: &coordinationv1.Lease{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "coordination.k8s.io/v1", Kind: "Lease"},
+			ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "kube-node-lease", ResourceVersion: "11"},
+		},
+	}
+	for info, obj := range objs {
+		key, _ := sWrapper.KeyFunc(info)
+		if err := sWrapper.Create(key, obj); err != nil {
+			t.Fatalf("could not create object, %v", err)
+		}
+	}
+
+	lp := NewLocalProxy(cacheM, func() bool { return false }, 0)
+	var handler http.Handler = lp
+	handler = proxyutil.WithRequestClientComponent(handler)
+	handler = proxyutil.WithRequestContentType(handler)
+	handler = filters.WithRequestInfo(handler, newTestRequestInfoResolver())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	do := func(method, path, contentType, body string) *http.Response {
+		req, _ := http.NewRequest(method, server.URL+path, bytes.NewBufferString(body))
+		req.Header.Set("User-Agent", "kubelet")
+		req.Header.Set("Accept", "application/json")
+		if len(contentType) != 0 {
+			req.Header.Set("Content-Type", contentType)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("could not send request %s %s, %v", method, path, err)
+		}
+		return resp
+	}
+
+	watchResp := do("GET", "/api/v1/nodes?watch=true&fieldSelector=metadata.name%3Dnode1&timeoutSeconds=5", "", "")
+	defer watchResp.Body.Close()
+	otherWatchResp := do("GET", "/api/v1/nodes?watch=true&fieldSelector=metadata.name%3Dnode2&timeoutSeconds=2", "", "")
+	defer otherWatchResp.Body.Close()
+	// make sure local watchers are registered
+	time.Sleep(500 * time.Millisecond)
+
+	resp := do("PATCH", "/api/v1/nodes/node1/status", string(types.StrategicMergePatchType), `{"status":{"conditions":[{"type":"Ready","status":"False"}]}}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expect status code 200 for patching node status, but got %d", resp.StatusCode)
+	}
+
+	s := serializerM.CreateSerializer("application/json", "", "v1", "nodes")
+	d, err := s.WatchDecoder(watchResp.Body)
+	if err != nil {
+		t.Fatalf("could not create watch decoder, %v", err)
+	}
+	eventType, obj, err := d.Decode()
+	if err != nil {
+		t.Fatalf("could not decode watch event, %v", err)
+	}
+	if node, ok := obj.(*v1.Node); eventType != watch.Modified || !ok || node.Status.Conditions[0].Status != v1.ConditionFalse || node.ResourceVersion != "10" {
+		t.Errorf("got unexpected event %s %#v", eventType, obj)
+	}
+
+	otherDecoder, _ := s.WatchDecoder(otherWatchResp.Body)
+	if eventType, obj, err := otherDecoder.Decode(); err == nil {
+		t.Errorf("expect no event for watcher of other node, but got %s %#v", eventType, obj)
+	}
+
+	lease := &coordinationv1.Lease{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "coordination.k8s.io/v1", Kind: "Lease"},
+		ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "kube-node-lease", ResourceVersion: "11"},
+		Spec:       coordinationv1.LeaseSpec{RenewTime: &metav1.MicroTime{Time: time.Now().Truncate(time.Second)}},
+	}
+	leaseBody, _ := json.Marshal(lease)
+	resp = do("PUT", "/apis/coordination.k8s.io/v1/namespaces/kube-node-lease/leases/node1", "application/json", string(leaseBody))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expect status code 200 for updating lease, but got %d", resp.StatusCode)
+	}
+
+	resp = do("GET", "/apis/coordination.k8s.io/v1/namespaces/kube-node-lease/leases/node1", "", "")
+	defer resp.Body.Close()
+	got := &coordinationv1.Lease{}
+	if err := json.NewDecoder(resp.Body).Decode(got); err != nil {
+		t.Fatalf("could not decode lease, %v", err)
+	}
+	if got.Spec.RenewTime == nil || !got.Spec.RenewTime.Equal(lease.Spec.RenewTime) {
+		t.Errorf("expect renew time %v of lease, but got %v", lease.Spec.RenewTime, got.Spec.RenewTime)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/versioning"
 	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
 	"k8s.io/apiserver/pkg/endpoints/handlers"
 	"k8s.io/apiserver/pkg/endpoints/request"
@@ -95,18 +96,27 @@ func (sp *multiplexerProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func (sp *multiplexerProxy) getReqScope(gvr *schema.GroupVersionResource) (*handlers.RequestScope, error) {
-	_, fqKindToRegister := sp.restMapperManager.KindFor(*gvr)
+	isScheme, fqKindToRegister := sp.restMapperManager.KindFor(*gvr)
 	if fqKindToRegister.Empty() {
 		return nil, fmt.Errorf("gvk is not found for gvr: %v", *gvr)
 	}
 
+	// field labels of custom resources are accepted by convertor, and objects are selected by fields extracted by multiplexer.
+	var serializer runtime.NegotiatedSerializer = scheme.Codecs
+	var convertor runtime.ObjectConvertor = fieldLabelConvertor{ObjectConvertor: scheme.Scheme}
+	unsafeConvertor := runtime.UnsafeObjectConvertor(scheme.Scheme)
+	if !isScheme {
+		unsafeConvertor = convertor
+		serializer = unstructuredNegotiatedSerializer{NegotiatedSerializer: scheme.Codecs, convertor: convertor}
+	}
+
 	return &handlers.RequestScope{
-		Serializer:      scheme.Codecs,
+		Serializer:      serializer,
 		ParameterCodec:  scheme.ParameterCodec,
-		Convertor:       scheme.Scheme,
+		Convertor:       convertor,
 		Defaulter:       scheme.Scheme,
 		Typer:           scheme.Scheme,
-		UnsafeConvertor: runtime.UnsafeObjectConvertor(scheme.Scheme),
+		UnsafeConvertor: unsafeConvertor,
 		Authorizer:      authorizerfactory.NewAlwaysAllowAuthorizer(),
 
 		EquivalentResourceMapper: runtime.NewEquivalentResourceRegistry(),
@@ -128,3 +138,55 @@ func (sp *multiplexerProxy) getReqScope(gvr *schema.GroupVersionResource) (*hand
 		},
 	}, nil
 }
+
+// unstructuredNegotiatedSerializer is used for custom resources which are unstructured objects,
+// and protobuf is not supported for unstructured objects.
+type unstructuredNegotiatedSerializer struct {
+	runtime.NegotiatedSerializer
+	convertor runtime.ObjectConvertor
+}
+
+func (s unstructuredNegotiatedSerializer) SupportedMediaTypes() []runtime.SerializerInfo {
+	infos := make([]runtime.SerializerInfo, 0)
+	for _, info := range s.NegotiatedSerializer.SupportedMediaTypes() {
+		if info.MediaType == runtime.ContentTypeProtobuf {
+			continue
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+func (s unstructuredNegotiatedSerializer) EncoderForVersion(encoder runtime.Encoder, gv runtime.GroupVersioner) runtime.Encoder {
+	return versioning.NewCodec(encoder, nil, s.convertor, scheme.Scheme, scheme.Scheme, scheme.Scheme, gv, nil, "multiplexer")
+}
+
+func (s unstructuredNegotiatedSerializer) DecoderToVersion(decoder runtime.Decoder, gv runtime.GroupVersioner) runtime.Decoder {
+	return versioning.NewCodec(nil, decoder, s.convertor, scheme.Scheme, scheme.Scheme, scheme.Scheme, nil, gv, "multiplexer")
+}
+
+// fieldLabelConvertor is used for all resources served by multiplexer, because field label conversion functions
+// of custom resources are not registered in scheme. field labels of resources which are not recognized by scheme
+// are accepted as they are, and objects are selected by fields which are extracted from unstructured objects by
+// multiplexer. field labels of typed resources are converted by scheme, so unsupported field labels are rejected
+// just like kube-apiserver instead of selecting objects by fields which are not extracted.
+type fieldLabelConvertor struct {
+	runtime.ObjectConvertor
+}
+
+// ConvertToVersion returns unstructured objects as they are, because custom resources are cached by multiplexer
+// in the requested version.
+func (c fieldLabelConvertor) ConvertToVersion(in runtime.Object, target runtime.GroupVersioner) (runtime.Object, error) {
+	if _, ok := in.(runtime.Unstructured); ok {
+		return in, nil
+	}
+	return c.ObjectConvertor.ConvertToVersion(in, target)
+}
+
+func (c fieldLabelConvertor) ConvertFieldLabel(gvk schema.GroupVersionKind, label, value string) (string, string, error) {
+	convertedLabel, convertedValue, err := c.ObjectConvertor.ConvertFieldLabel(gvk, label, value)
+	if err == nil || scheme.Scheme.Recognizes(gvk) {
+		return convertedLabel, convertedValue, err
+	}
+	return label, value, nil
+}
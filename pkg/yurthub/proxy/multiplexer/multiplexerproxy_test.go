@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	discovery "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -195,6 +196,158 @@ func TestShareProxy_ServeHTTP_LIST(t *testing.T) {
 	}
 }
 
+var crontabGVR = schema.GroupVersionResource{Group: "stable.example.com", Version: "v1", Resource: "crontabs"}
+
+func newCronTab(namespace, name, image string, labels map[string]string) unstructured.Unstructured {
+	obj := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "stable.example.com/v1",
+			"kind":       "CronTab",
+			"spec": map[string]interface{}{
+				"image":    image,
+				"replicas": int64(1),
+			},
+		},
+	}
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestShareProxy_ServeHTTP_LIST_CustomResources(t *testing.T) {
+	tmpDir := t.TempDir()
+	restMapperManager, _ := meta.NewRESTMapperManager(tmpDir)
+	if err := restMapperManager.UpdateKindFor(crontabGVR, crontabGVR.GroupVersion().WithKind("CronTab")); err != nil {
+		t.Fatalf("could not update kind for crontabs, %v", err)
+	}
+
+	clientset := fake.NewSimpleClientset()
+	factory := informers.NewSharedInformerFactory(clientset, 0)
+
+	for k, tc := range map[string]struct {
+		url           string
+		expectedNames []string
+	}{
+		"list custom resources": {
+			url:           "/apis/stable.example.com/v1/crontabs",
+			expectedNames: []string{"backup", "cleanup", "report"},
+		},
+		"list custom resources with namespace": {
+			url:           "/apis/stable.example.com/v1/namespaces/default/crontabs",
+			expectedNames: []string{"backup", "report"},
+		},
+		"list custom resources with label selector": {
+			url:           "/apis/stable.example.com/v1/crontabs?labelSelector=app%3Ddb",
+			expectedNames: []string{"backup", "cleanup"},
+		},
+		"list custom resources with field selector": {
+			url:           "/apis/stable.example.com/v1/crontabs?fieldSelector=spec.image%3Dbusybox",
+			expectedNames: []string{"backup", "report"},
+		},
+		"list custom resources with label and field selector": {
+			url:           "/apis/stable.example.com/v1/crontabs?labelSelector=app%3Ddb&fieldSelector=spec.image%21%3Dbusybox",
+			expectedNames: []string{"cleanup"},
+		},
+	} {
+		t.Run(k, func(t *testing.T) {
+			w := &httptest.ResponseRecorder{
+				Body: &bytes.Buffer{},
+			}
+
+			healthChecker := fakeHealthChecker.NewFakeChecker(map[*url.URL]bool{})
+			loadBalancer := remote.NewLoadBalancer("round-robin", []*url.URL{}, nil, nil, healthChecker, nil, context.Background().Done())
+			dsm := multiplexerstorage.NewDummyStorageManager(map[string]storage.Interface{
+				crontabGVR.String(): multiplexerstorage.NewFakeUnstructuredStorage([]unstructured.Unstructured{
+					newCronTab(metav1.NamespaceDefault, "backup", "busybox", map[string]string{"app": "db"}),
+					newCronTab(metav1.NamespaceDefault, "report", "busybox", map[string]string{"app": "web"}),
+					newCronTab(metav1.NamespaceSystem, "cleanup", "alpine", map[string]string{"app": "db"}),
+				}),
+			})
+			cfg := &config.YurtHubConfiguration{
+				PoolScopeResources:       []schema.GroupVersionResource{crontabGVR},
+				RESTMapperManager:        restMapperManager,
+				SharedFactory:            factory,
+				LoadBalancerForLeaderHub: loadBalancer,
+			}
+			rmm := multiplexer.NewRequestMultiplexerManager(cfg, dsm, healthChecker)
+
+			informerSynced := func() bool {
+				return rmm.Ready(&crontabGVR)
+			}
+			stopCh := make(chan struct{})
+			if ok := cache.WaitForCacheSync(stopCh, informerSynced); !ok {
+				t.Errorf("configuration manager is not ready")
+				return
+			}
+
+			sp := NewMultiplexerProxy(rmm, restMapperManager, make(<-chan struct{}))
+			sp.ServeHTTP(w, newEndpointSliceListRequest(tc.url, nil))
+
+			list := &unstructured.UnstructuredList{}
+			if err := list.UnmarshalJSON(w.Body.Bytes()); err != nil {
+				t.Fatalf("could not decode list %s, %v", w.Body.String(), err)
+			}
+			names := make([]string, 0, len(list.Items))
+			for i := range list.Items {
+				names = append(names, list.Items[i].GetName())
+			}
+			sort.Strings(names)
+			assert.Equal(t, tc.expectedNames, names, w.Body.String())
+		})
+	}
+}
+
+func TestGetReqScopeConvertFieldLabel(t *testing.T) {
+	restMapperManager, _ := meta.NewRESTMapperManager(t.TempDir())
+	if err := restMapperManager.UpdateKindFor(crontabGVR, crontabGVR.GroupVersion().WithKind("CronTab")); err != nil {
+		t.Fatalf("could not update kind for crontabs, %v", err)
+	}
+	sp := &multiplexerProxy{restMapperManager: restMapperManager}
+
+	for k, tc := range map[string]struct {
+		gvr          schema.GroupVersionResource
+		field        string
+		expectReject bool
+	}{
+		"metadata field of builtin resource": {
+			gvr:   endpointSliceGVR,
+			field: "metadata.name",
+		},
+		"unsupported field of builtin resource": {
+			gvr:          endpointSliceGVR,
+			field:        "spec.nodeName",
+			expectReject: true,
+		},
+		"metadata field of custom resource": {
+			gvr:   crontabGVR,
+			field: "metadata.name",
+		},
+		"spec field of custom resource": {
+			gvr:   crontabGVR,
+			field: "spec.nodeName",
+		},
+	} {
+		t.Run(k, func(t *testing.T) {
+			reqScope, err := sp.getReqScope(&tc.gvr)
+			if err != nil {
+				t.Fatalf("could not get req scope, %v", err)
+			}
+			label, value, err := reqScope.Convertor.ConvertFieldLabel(reqScope.Kind, tc.field, "node1")
+			switch {
+			case tc.expectReject:
+				if err == nil {
+					t.Errorf("expect field %s is rejected, but got %s=%s", tc.field, label, value)
+				}
+			case err != nil:
+				t.Errorf("expect field %s is accepted, but got %v", tc.field, err)
+			case label != tc.field || value != "node1":
+				t.Errorf("expect field %s=node1, but got %s=%s", tc.field, label, value)
+			}
+		})
+	}
+}
+
 func expectEndpointSliceListNoFilter() *discovery.EndpointSliceList {
 	return &discovery.EndpointSliceList{
 		TypeMeta: metav1.TypeMeta{
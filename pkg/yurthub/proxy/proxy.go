@@ -20,6 +20,7 @@ import (
 	"net/http"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/endpoints/filters"
@@ -30,6 +31,8 @@ import (
 	"github.com/openyurtio/openyurt/cmd/yurthub/app/config"
 	"github.com/openyurtio/openyurt/pkg/projectinfo"
 	yurtutil "github.com/openyurtio/openyurt/pkg/util"
+	"github.com/openyurtio/openyurt/pkg/util/tracing"
+	"github.com/openyurtio/openyurt/pkg/yurthub/audit"
 	"github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
 	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
 	basemultiplexer "github.com/openyurtio/openyurt/pkg/yurthub/multiplexer"
@@ -39,6 +42,7 @@ import (
 	"github.com/openyurtio/openyurt/pkg/yurthub/proxy/nonresourcerequest"
 	"github.com/openyurtio/openyurt/pkg/yurthub/proxy/remote"
 	"github.com/openyurtio/openyurt/pkg/yurthub/proxy/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/proxy/watchresume"
 	"github.com/openyurtio/openyurt/pkg/yurthub/tenant"
 	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
 )
@@ -50,6 +54,7 @@ type yurtReverseProxy struct {
 	loadBalancer             remote.Server
 	loadBalancerForLeaderHub remote.Server
 	localProxy               http.Handler
+	localCacheMgr            cachemanager.CacheManager
 	autonomyProxy            http.Handler
 	multiplexerProxy         http.Handler
 	multiplexerManager       *basemultiplexer.MultiplexerManager
@@ -71,41 +76,42 @@ func NewYurtReverseProxyHandler(
 	}
 	resolver := server.NewRequestInfoResolver(cfg)
 
-	lb := remote.NewLoadBalancer(
-		yurtHubCfg.LBMode,
-		yurtHubCfg.RemoteServers,
-		localCacheMgr,
-		yurtHubCfg.TransportAndDirectClientManager,
-		cloudHealthChecker,
-		yurtHubCfg.FilterFinder,
-		stopCh)
-
 	var localProxy, autonomyProxy http.Handler
 	if !yurtutil.IsNil(cloudHealthChecker) && !yurtutil.IsNil(localCacheMgr) {
 		// When yurthub works in Edge mode, health checker and cache manager are prepared.
 		// so we may use local proxy and autonomy proxy to handle the request when offline.
-		localProxy = local.NewLocalProxy(localCacheMgr,
-			cloudHealthChecker.IsHealthy,
+		// requests are served by local proxy while cloud is degraded, so degraded cloud is
+		// considered as unhealthy for local proxy.
+		isCloudHealthy := cloudHealthChecker.IsHealthy
+		if degradedChecker, ok := cloudHealthChecker.(healthchecker.DegradedChecker); ok {
+			isCloudHealthy = func() bool {
+				return cloudHealthChecker.IsHealthy() && !degradedChecker.IsDegraded()
+			}
+		}
+		localProxy = local.NewLocalProxyWithWriteQueue(localCacheMgr,
+			isCloudHealthy,
 			yurtHubCfg.MinRequestTimeout,
+			yurtHubCfg.OfflineWriteQueue,
 		)
-		localProxy = local.WithFakeTokenInject(localProxy, yurtHubCfg.SerializerManager)
+		localProxy = withSource(local.WithFakeTokenInject(localProxy, yurtHubCfg.SerializerManager), audit.SourceCache)
 
-		autonomyProxy = autonomy.NewAutonomyProxy(
+		autonomyProxy = withSource(autonomy.NewAutonomyProxy(
 			cloudHealthChecker,
 			yurtHubCfg.TransportAndDirectClientManager,
 			localCacheMgr,
-		)
+		), audit.SourceAutonomy)
 	}
 
-	multiplexerProxy := multiplexer.NewMultiplexerProxy(requestMultiplexerManager, yurtHubCfg.RESTMapperManager, stopCh)
+	multiplexerProxy := withSource(multiplexer.NewMultiplexerProxy(requestMultiplexerManager, yurtHubCfg.RESTMapperManager, stopCh), audit.SourceMultiplexer)
 
 	yurtProxy := &yurtReverseProxy{
 		cfg:                      yurtHubCfg,
 		resolver:                 resolver,
-		loadBalancer:             lb,
+		loadBalancer:             yurtHubCfg.LoadBalancer,
 		loadBalancerForLeaderHub: yurtHubCfg.LoadBalancerForLeaderHub,
 		cloudHealthChecker:       cloudHealthChecker,
 		localProxy:               localProxy,
+		localCacheMgr:            localCacheMgr,
 		autonomyProxy:            autonomyProxy,
 		multiplexerProxy:         multiplexerProxy,
 		multiplexerManager:       requestMultiplexerManager,
@@ -131,6 +137,12 @@ func (p *yurtReverseProxy) buildHandlerChain(handler http.Handler) http.Handler
 		// prevent this case.
 		handler = util.WithListRequestSelector(handler)
 	}
+	if p.cfg.ConfigManager != nil {
+		handler = util.WithFlowControl(handler, p.cfg.ConfigManager.FlowController())
+	}
+	if p.cfg.Auditor != nil {
+		handler = util.WithAudit(handler, p.cfg.Auditor)
+	}
 	handler = util.WithRequestClientComponent(handler)
 	handler = util.WithPartialObjectMetadataRequest(handler)
 	handler = util.WithRequestForPoolScopeMetadata(handler, p.multiplexerManager.ResolveRequestForPoolScopeMetadata)
@@ -142,11 +154,20 @@ func (p *yurtReverseProxy) buildHandlerChain(handler http.Handler) http.Handler
 	}
 
 	handler = filters.WithRequestInfo(handler, p.resolver)
+	if p.cfg.TracerProvider != nil {
+		handler = tracing.WithTracing(handler, p.cfg.TracerProvider, "yurthub")
+	}
 
 	return handler
 }
 
 func (p *yurtReverseProxy) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	// the span of proxy decision covers the whole serving of request, and which handler
+	// serves the request is recorded as an attribute of span.
+	ctx, span := tracing.Start(req.Context(), "ProxyDecision")
+	defer span.End()
+	req = req.WithContext(ctx)
+
 	// reject all requests from outside of yurthub when yurthub is not ready.
 	// and allow requests from yurthub itself because yurthub need to get resource from cloud kube-apiserver for initializing.
 	if !p.IsRequestFromHubSelf(req) {
@@ -174,9 +195,12 @@ func (p *yurtReverseProxy) ServeHTTP(rw http.ResponseWriter, req *http.Request)
 			if p.multiplexerManager.SourceForPoolScopeMetadata() == basemultiplexer.PoolSourceForPoolScopeMetadata {
 				// list/watch pool scope metadata from leader yurthub
 				if backend := p.loadBalancerForLeaderHub.PickOne(req); !yurtutil.IsNil(backend) {
+					p.multiplexerManager.SwitchUpstream(backend.Name())
 					backend.ServeHTTP(rw, req)
 					return
 				}
+				// no healthy leader yurthub, fail over to cloud kube-apiserver.
+				p.multiplexerManager.SwitchUpstream(basemultiplexer.CloudUpstreamForPoolScopeMetadata)
 			}
 
 			// otherwise, list/watch pool scope metadata from cloud kube-apiserver or local cache.
@@ -210,12 +234,17 @@ func (p *yurtReverseProxy) ServeHTTP(rw http.ResponseWriter, req *http.Request)
 		}
 		// if the request have not been served, fall into failure serve.
 	default:
-		// handling the request with cloud apiserver or local cache, otherwise fail to serve
-		if backend := p.loadBalancer.PickOne(req); !yurtutil.IsNil(backend) {
-			backend.ServeHTTP(rw, req)
+		// handling the request with cloud apiserver or local cache, otherwise fail to serve.
+		// watch request is resumed from local watch history at first if possible.
+		// read requests are served by local cache while cloud is degraded.
+		if p.shouldReadFromCache(req) {
+			p.localProxy.ServeHTTP(rw, req)
+			return
+		} else if backend := p.loadBalancer.PickOne(req); !yurtutil.IsNil(backend) {
+			backend.ServeHTTP(watchresume.Resume(p.localCacheMgr, rw, req))
 			return
 		} else if !yurtutil.IsNil(p.localProxy) {
-			p.localProxy.ServeHTTP(rw, req)
+			p.localProxy.ServeHTTP(watchresume.Resume(p.localCacheMgr, rw, req))
 			return
 		}
 		// if the request have not been served, fall into failure serve.
@@ -225,6 +254,29 @@ func (p *yurtReverseProxy) ServeHTTP(rw http.ResponseWriter, req *http.Request)
 	http.Error(rw, "no healthy backends available.", http.StatusBadGateway)
 }
 
+// shouldReadFromCache checks the read request should be served by local cache or not when cloud is degraded.
+func (p *yurtReverseProxy) shouldReadFromCache(req *http.Request) bool {
+	if yurtutil.IsNil(p.localProxy) {
+		return false
+	}
+	degradedChecker, ok := p.cloudHealthChecker.(healthchecker.DegradedChecker)
+	if !ok || !degradedChecker.IsDegraded() {
+		return false
+	}
+
+	info, ok := apirequest.RequestInfoFrom(req.Context())
+	if !ok || info == nil || !info.IsResourceRequest {
+		return false
+	}
+	switch info.Verb {
+	case "get", "list", "watch":
+		// the request may be served by remote server later, so the check should have no side effects.
+		return p.localCacheMgr.IsCacheableFor(req)
+	default:
+		return false
+	}
+}
+
 func (p *yurtReverseProxy) handleKubeletLease(rw http.ResponseWriter, req *http.Request) bool {
 	// node lease request should be served by local handler if local proxy is enabled.
 	// otherwise, forward node lease request by load balancer.
@@ -267,6 +319,15 @@ func (p *yurtReverseProxy) IsRequestFromHubSelf(req *http.Request) bool {
 	return false
 }
 
+// withSource records the handler which serves the request into audit events and trace span.
+func withSource(handler http.Handler, source audit.Source) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		audit.RecorderFrom(req.Context()).SetSource(source, "")
+		tracing.SetAttributes(req.Context(), attribute.String(tracing.AttributeServedBy, string(source)))
+		handler.ServeHTTP(rw, req)
+	})
+}
+
 func IsRequestForPoolScopeMetadata(req *http.Request) bool {
 	isRequestForPoolScopeMetadata, ok := hubutil.IsRequestForPoolScopeMetadataFrom(req.Context())
 	if ok && isRequestForPoolScopeMetadata {
@@ -23,11 +23,13 @@ import (
 	"hash/fnv"
 	"io"
 	"maps"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
 	"slices"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	apirequest "k8s.io/apiserver/pkg/endpoints/request"
@@ -37,6 +39,7 @@ import (
 	"github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter"
 	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
 	"github.com/openyurtio/openyurt/pkg/yurthub/transport"
 	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
 )
@@ -45,6 +48,8 @@ const (
 	roundRobinStrategy        = "round-robin"
 	priorityStrategy          = "priority"
 	consistentHashingStrategy = "consistent-hashing"
+	latencyAwareStrategy      = "latency-aware"
+	stickyStrategy            = "sticky"
 )
 
 // LoadBalancingStrategy defines the interface for different load balancing strategies.
@@ -160,6 +165,69 @@ func (prio *PriorityStrategy) PickOne(_ *http.Request) *RemoteProxy {
 	return nil
 }
 
+// StickyStrategy keeps picking the backend picked last time until it becomes unhealthy, and then
+// the next healthy backend in order is picked and stuck to. it's used for forwarding requests to
+// leader hubs, so watch requests from a follower are not spread across leader hubs.
+type StickyStrategy struct {
+	BaseLoadBalancingStrategy
+	currentLock sync.Mutex
+	current     *RemoteProxy
+}
+
+// Name returns the name of the strategy.
+func (s *StickyStrategy) Name() string {
+	return stickyStrategy
+}
+
+// UpdateBackends updates the list of backends, and keeps sticking to the current backend if it's still in the list.
+func (s *StickyStrategy) UpdateBackends(backends []*RemoteProxy) {
+	s.Lock()
+	defer s.Unlock()
+	s.backends = backends
+
+	s.currentLock.Lock()
+	defer s.currentLock.Unlock()
+	if s.current == nil {
+		return
+	}
+	index := slices.IndexFunc(backends, func(backend *RemoteProxy) bool {
+		return backend.Name() == s.current.Name()
+	})
+	if index < 0 {
+		s.current = nil
+	} else {
+		s.current = backends[index]
+	}
+}
+
+// PickOne returns the current backend if it's healthy, otherwise switches to the next healthy backend.
+func (s *StickyStrategy) PickOne(_ *http.Request) *RemoteProxy {
+	s.RLock()
+	defer s.RUnlock()
+	s.currentLock.Lock()
+	defer s.currentLock.Unlock()
+
+	start := 0
+	if s.current != nil {
+		index := slices.Index(s.backends, s.current)
+		if backend := s.checkAndReturnHealthyBackend(index); backend != nil {
+			return backend
+		}
+		start = index + 1
+	}
+
+	for i := 0; i < len(s.backends); i++ {
+		if backend := s.checkAndReturnHealthyBackend((start + i) % len(s.backends)); backend != nil {
+			if s.current != nil {
+				klog.Infof("sticky backend is switched from %s to %s", s.current.Name(), backend.Name())
+			}
+			s.current = backend
+			return backend
+		}
+	}
+	return nil
+}
+
 // ConsistentHashingStrategy implements consistent hashing load balancing.
 type ConsistentHashingStrategy struct {
 	BaseLoadBalancingStrategy
@@ -240,6 +308,102 @@ func (ch *ConsistentHashingStrategy) UpdateBackends(backends []*RemoteProxy) {
 	ch.hashes = slices.Sorted(maps.Keys(updatedNodes))
 }
 
+// LatencyAwareStrategy implements load balancing based on latency and outstanding requests of backends.
+// Two healthy backends are sampled randomly(power of two choices) and the one with lower score is picked,
+// score of backend is the moving average of latency multiplied by the number of in-flight requests plus one.
+// Backends are picked in priority order when their latency is not observed recently.
+type LatencyAwareStrategy struct {
+	BaseLoadBalancingStrategy
+}
+
+// Name returns the name of the strategy.
+func (la *LatencyAwareStrategy) Name() string {
+	return latencyAwareStrategy
+}
+
+// UpdateBackends updates the list of backends, and scores of removed backends are deleted.
+func (la *LatencyAwareStrategy) UpdateBackends(backends []*RemoteProxy) {
+	la.Lock()
+	defer la.Unlock()
+	for _, backend := range la.backends {
+		if !slices.ContainsFunc(backends, func(b *RemoteProxy) bool { return b.Name() == backend.Name() }) {
+			metrics.Metrics.DeleteBackendScore(backend.Name())
+		}
+	}
+	la.backends = backends
+}
+
+// PickOne selects a backend with lower latency and fewer outstanding requests.
+func (la *LatencyAwareStrategy) PickOne(_ *http.Request) *RemoteProxy {
+	la.RLock()
+	defer la.RUnlock()
+
+	now := time.Now()
+	var unknown, pending *RemoteProxy
+	candidates := make([]*RemoteProxy, 0, len(la.backends))
+	scores := make([]float64, 0, len(la.backends))
+	for i := range la.backends {
+		backend := la.checkAndReturnHealthyBackend(i)
+		if backend == nil {
+			metrics.Metrics.DeleteBackendScore(la.backends[i].Name())
+			continue
+		}
+
+		if score, ok := latencyScore(backend, now); ok {
+			metrics.Metrics.ObserveBackendScore(backend.Name(), score)
+			candidates = append(candidates, backend)
+			scores = append(scores, score)
+			continue
+		}
+
+		metrics.Metrics.DeleteBackendScore(backend.Name())
+		if backend.InflightRequests() == 0 {
+			// latency of backend is unknown, pick it in priority order for observing its latency.
+			if unknown == nil {
+				unknown = backend
+			}
+		} else if pending == nil {
+			// latency of backend is being observed, don't send more requests to it
+			// unless there is no other choice.
+			pending = backend
+		}
+	}
+
+	switch {
+	case unknown != nil:
+		return unknown
+	case len(candidates) == 0:
+		return pending
+	case len(candidates) == 1:
+		return candidates[0]
+	}
+
+	i := rand.IntN(len(candidates))
+	j := rand.IntN(len(candidates) - 1)
+	if j >= i {
+		j++
+	}
+	if i > j {
+		i, j = j, i
+	}
+
+	// backend in front wins when scores are equal.
+	if scores[j] < scores[i] {
+		return candidates[j]
+	}
+	return candidates[i]
+}
+
+// latencyScore returns the score of backend, and the backend with lower score is preferred.
+// false is returned if latency of backend is unknown.
+func latencyScore(backend *RemoteProxy, now time.Time) (float64, bool) {
+	latency, ok := backend.Latency(now)
+	if !ok {
+		return 0, false
+	}
+	return latency.Seconds() * float64(backend.InflightRequests()+1), true
+}
+
 // getHash returns the hash of a string key.
 // It uses the FNV-1a algorithm to calculate the hash.
 func getHash(key string) uint32 {
@@ -249,7 +413,7 @@ func getHash(key string) uint32 {
 }
 
 // Server is an interface for proxying http request to remote server
-// based on the load balance mode(round-robin or priority)
+// based on the load balance mode(round-robin, priority, latency-aware or sticky)
 type Server interface {
 	UpdateBackends(remoteServers []*url.URL)
 	PickOne(req *http.Request) *RemoteProxy
@@ -258,11 +422,14 @@ type Server interface {
 
 // LoadBalancer is a struct that holds the load balancing strategy and backends.
 type LoadBalancer struct {
+	sync.RWMutex
 	strategy      LoadBalancingStrategy
+	backends      []*RemoteProxy
 	localCacheMgr cachemanager.CacheManager
 	filterFinder  filter.FilterFinder
 	transportMgr  transport.Interface
 	healthChecker healthchecker.Interface
+	negotiator    *Negotiator
 	mode          string
 	stopCh        <-chan struct{}
 }
@@ -276,12 +443,27 @@ func NewLoadBalancer(
 	healthChecker healthchecker.Interface,
 	filterFinder filter.FilterFinder,
 	stopCh <-chan struct{}) *LoadBalancer {
+	return NewLoadBalancerWithNegotiator(lbMode, remoteServers, localCacheMgr, transportMgr, healthChecker, filterFinder, nil, stopCh)
+}
+
+// NewLoadBalancerWithNegotiator creates a loadbalancer for specified remote servers, and content encoding
+// and content type of responses are negotiated with remote servers by negotiator.
+func NewLoadBalancerWithNegotiator(
+	lbMode string,
+	remoteServers []*url.URL,
+	localCacheMgr cachemanager.CacheManager,
+	transportMgr transport.Interface,
+	healthChecker healthchecker.Interface,
+	filterFinder filter.FilterFinder,
+	negotiator *Negotiator,
+	stopCh <-chan struct{}) *LoadBalancer {
 	lb := &LoadBalancer{
 		mode:          lbMode,
 		localCacheMgr: localCacheMgr,
 		filterFinder:  filterFinder,
 		transportMgr:  transportMgr,
 		healthChecker: healthChecker,
+		negotiator:    negotiator,
 		stopCh:        stopCh,
 	}
 
@@ -300,32 +482,69 @@ func (lb *LoadBalancer) UpdateBackends(remoteServers []*url.URL) {
 			klog.Errorf("could not create proxy for backend %s, %v", server.String(), err)
 			continue
 		}
+		if observer, ok := lb.healthChecker.(healthchecker.RequestObserver); ok {
+			proxy.observer = observer
+		}
+		proxy.negotiator = lb.negotiator
 		newBackends = append(newBackends, proxy)
 	}
 
+	lb.Lock()
+	defer lb.Unlock()
 	if lb.strategy == nil {
-		switch lb.mode {
-		case "consistent-hashing":
-			lb.strategy = &ConsistentHashingStrategy{
-				BaseLoadBalancingStrategy: BaseLoadBalancingStrategy{checker: lb.healthChecker},
-				nodes:                     make(map[uint32]*RemoteProxy),
-				hashes:                    make([]uint32, 0, len(newBackends)),
-			}
-		case "priority":
-			lb.strategy = &PriorityStrategy{BaseLoadBalancingStrategy{checker: lb.healthChecker}}
-		default:
-			lb.strategy = &RoundRobinStrategy{BaseLoadBalancingStrategy{checker: lb.healthChecker}, 0}
+		lb.strategy = lb.newStrategy(lb.mode, len(newBackends))
+	}
+	lb.backends = newBackends
+	lb.strategy.UpdateBackends(newBackends)
+}
+
+// UpdateMode switches the load balancing strategy into the specified mode, and backends
+// are handed over to the new strategy.
+func (lb *LoadBalancer) UpdateMode(mode string) {
+	lb.Lock()
+	defer lb.Unlock()
+	if lb.mode == mode {
+		return
+	}
+	strategy := lb.newStrategy(mode, len(lb.backends))
+	strategy.UpdateBackends(lb.backends)
+	if lb.mode == latencyAwareStrategy {
+		// scores are only evaluated by latency-aware strategy.
+		for _, backend := range lb.backends {
+			metrics.Metrics.DeleteBackendScore(backend.Name())
 		}
 	}
+	klog.Infof("load balancing mode is switched from %s to %s", lb.mode, mode)
+	lb.mode = mode
+	lb.strategy = strategy
+}
 
-	lb.strategy.UpdateBackends(newBackends)
+func (lb *LoadBalancer) newStrategy(mode string, size int) LoadBalancingStrategy {
+	switch mode {
+	case consistentHashingStrategy:
+		return &ConsistentHashingStrategy{
+			BaseLoadBalancingStrategy: BaseLoadBalancingStrategy{checker: lb.healthChecker},
+			nodes:                     make(map[uint32]*RemoteProxy),
+			hashes:                    make([]uint32, 0, size),
+		}
+	case priorityStrategy:
+		return &PriorityStrategy{BaseLoadBalancingStrategy{checker: lb.healthChecker}}
+	case latencyAwareStrategy:
+		return &LatencyAwareStrategy{BaseLoadBalancingStrategy{checker: lb.healthChecker}}
+	case stickyStrategy:
+		return &StickyStrategy{BaseLoadBalancingStrategy: BaseLoadBalancingStrategy{checker: lb.healthChecker}}
+	default:
+		return &RoundRobinStrategy{BaseLoadBalancingStrategy{checker: lb.healthChecker}, 0}
+	}
 }
 
 func (lb *LoadBalancer) PickOne(req *http.Request) *RemoteProxy {
-	return lb.strategy.PickOne(req)
+	return lb.CurrentStrategy().PickOne(req)
 }
 
 func (lb *LoadBalancer) CurrentStrategy() LoadBalancingStrategy {
+	lb.RLock()
+	defer lb.RUnlock()
 	return lb.strategy
 }
 
@@ -18,10 +18,13 @@ package remote
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"sort"
 	"testing"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
@@ -276,6 +279,192 @@ func TestLoadBalancingStrategy(t *testing.T) {
 	}
 }
 
+func TestLatencyAwareStrategy(t *testing.T) {
+	type backend struct {
+		healthy  bool
+		latency  time.Duration
+		stale    bool
+		inflight int64
+	}
+	testcases := map[string]struct {
+		backends []backend
+		expect   []string
+	}{
+		"no backend server": {
+			expect: []string{""},
+		},
+		"pick in priority order when latency is unknown": {
+			backends: []backend{
+				{healthy: true},
+				{healthy: true, latency: time.Millisecond},
+			},
+			expect: []string{"127.0.0.1:8080"},
+		},
+		"skip unhealthy backend": {
+			backends: []backend{
+				{healthy: false},
+				{healthy: true, latency: time.Second},
+				{healthy: true},
+			},
+			expect: []string{"127.0.0.1:8082"},
+		},
+		"stale latency is considered as unknown": {
+			backends: []backend{
+				{healthy: true, latency: time.Millisecond},
+				{healthy: true, latency: time.Second, stale: true},
+			},
+			expect: []string{"127.0.0.1:8081"},
+		},
+		"skip backend whose latency is being observed": {
+			backends: []backend{
+				{healthy: true, inflight: 1},
+				{healthy: true, latency: time.Second},
+			},
+			expect: []string{"127.0.0.1:8081"},
+		},
+		"pick backend whose latency is being observed when there is no other choice": {
+			backends: []backend{
+				{healthy: false},
+				{healthy: true, inflight: 1},
+				{healthy: true, inflight: 2},
+			},
+			expect: []string{"127.0.0.1:8081"},
+		},
+		"pick backend with lower latency": {
+			backends: []backend{
+				{healthy: true, latency: 200 * time.Millisecond},
+				{healthy: true, latency: 20 * time.Millisecond},
+			},
+			expect: []string{"127.0.0.1:8081"},
+		},
+		"pick backend with fewer outstanding requests": {
+			backends: []backend{
+				{healthy: true, latency: 20 * time.Millisecond, inflight: 9},
+				{healthy: true, latency: 100 * time.Millisecond},
+			},
+			expect: []string{"127.0.0.1:8081"},
+		},
+		"backend with highest score is never picked": {
+			backends: []backend{
+				{healthy: true, latency: 20 * time.Millisecond},
+				{healthy: true, latency: time.Second},
+				{healthy: true, latency: 30 * time.Millisecond},
+			},
+			expect: []string{"127.0.0.1:8080", "127.0.0.1:8082"},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			servers := make([]*url.URL, 0, len(tc.backends))
+			healthyServers := make(map[*url.URL]bool)
+			for i, b := range tc.backends {
+				server := &url.URL{Host: fmt.Sprintf("127.0.0.1:%d", 8080+i)}
+				servers = append(servers, server)
+				healthyServers[server] = b.healthy
+			}
+			checker := fakeHealthChecker.NewFakeChecker(healthyServers)
+			lb := NewLoadBalancer(latencyAwareStrategy, servers, nil, transportMgr, checker, nil, neverStop)
+
+			strategy, ok := lb.CurrentStrategy().(*LatencyAwareStrategy)
+			if !ok {
+				t.Fatalf("expect latency-aware strategy, but got %s", lb.CurrentStrategy().Name())
+			}
+			for i, b := range tc.backends {
+				rp := strategy.backends[i]
+				if b.latency != 0 {
+					rp.observeLatency(b.latency, false)
+				}
+				if b.stale {
+					rp.latencyUpdate = time.Now().Add(-2 * latencyExpiration)
+				}
+				rp.inflight.Store(b.inflight)
+			}
+
+			for i := 0; i < 20; i++ {
+				host := ""
+				if backend := strategy.PickOne(&http.Request{}); backend != nil {
+					host = backend.RemoteServer().Host
+				}
+				if !slices.Contains(tc.expect, host) {
+					t.Fatalf("expect one of %v, but got %q", tc.expect, host)
+				}
+			}
+		})
+	}
+}
+
+func TestStickyStrategy(t *testing.T) {
+	servers := []*url.URL{
+		{Host: "10.0.0.1:10269"},
+		{Host: "10.0.0.2:10269"},
+		{Host: "10.0.0.3:10269"},
+	}
+	lb := NewLoadBalancer(stickyStrategy, servers, nil, transportMgr, fakeHealthChecker.NewFakeChecker(map[*url.URL]bool{}), nil, neverStop)
+	strategy, ok := lb.CurrentStrategy().(*StickyStrategy)
+	if !ok {
+		t.Fatalf("expect sticky strategy, but got %s", lb.CurrentStrategy().Name())
+	}
+
+	steps := []struct {
+		healthy map[int]bool
+		expect  string
+	}{
+		{healthy: map[int]bool{}, expect: ""},
+		{healthy: map[int]bool{1: true, 2: true}, expect: "10.0.0.2:10269"},
+		// backend in front becomes healthy, but the current backend is still picked.
+		{healthy: map[int]bool{0: true, 1: true, 2: true}, expect: "10.0.0.2:10269"},
+		// the next healthy backend is picked when the current backend is unhealthy.
+		{healthy: map[int]bool{0: true, 2: true}, expect: "10.0.0.3:10269"},
+		{healthy: map[int]bool{0: true, 1: true}, expect: "10.0.0.1:10269"},
+	}
+	for i, step := range steps {
+		healthyServers := make(map[*url.URL]bool)
+		for j := range servers {
+			healthyServers[servers[j]] = step.healthy[j]
+		}
+		strategy.checker = fakeHealthChecker.NewFakeChecker(healthyServers)
+
+		for k := 0; k < 3; k++ {
+			host := ""
+			if backend := lb.PickOne(&http.Request{}); backend != nil {
+				host = backend.RemoteServer().Host
+			}
+			if host != step.expect {
+				t.Fatalf("expect %q picked in step %d, but got %q", step.expect, i, host)
+			}
+		}
+	}
+
+	// the current backend is kept after backends are updated.
+	lb.UpdateBackends([]*url.URL{servers[2], servers[0]})
+	if backend := lb.PickOne(&http.Request{}); backend == nil || backend.RemoteServer().Host != servers[0].Host {
+		t.Errorf("expect backend %s is kept after backends are updated, but got %v", servers[0].Host, backend)
+	}
+}
+
+func TestUpdateMode(t *testing.T) {
+	servers := []*url.URL{
+		{Host: "10.0.0.1:8080"},
+		{Host: "10.0.0.2:8080"},
+	}
+	checker := fakeHealthChecker.NewFakeChecker(map[*url.URL]bool{servers[0]: true, servers[1]: true})
+	lb := NewLoadBalancer(roundRobinStrategy, servers, nil, transportMgr, checker, nil, neverStop)
+	if _, ok := lb.CurrentStrategy().(*RoundRobinStrategy); !ok {
+		t.Fatalf("expect round-robin strategy, but got %T", lb.CurrentStrategy())
+	}
+
+	lb.UpdateMode(priorityStrategy)
+	if _, ok := lb.CurrentStrategy().(*PriorityStrategy); !ok {
+		t.Fatalf("expect priority strategy, but got %T", lb.CurrentStrategy())
+	}
+	for i := 0; i < 3; i++ {
+		if backend := lb.PickOne(&http.Request{}); backend == nil || backend.RemoteServer().Host != servers[0].Host {
+			t.Errorf("expect backend %s is picked by priority strategy, but got %v", servers[0].Host, backend)
+		}
+	}
+}
+
 func TestGetHash(t *testing.T) {
 	testCases := map[string]struct {
 		key      string
@@ -26,6 +26,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	apirequest "k8s.io/apiserver/pkg/endpoints/request"
 
 	"github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
@@ -58,6 +59,10 @@ func (m *mockCacheManager) CanCacheFor(req *http.Request) bool {
 	return true
 }
 
+func (m *mockCacheManager) IsCacheableFor(req *http.Request) bool {
+	return m.CanCacheFor(req)
+}
+
 func (m *mockCacheManager) DeleteKindFor(gvr schema.GroupVersionResource) error {
 	if m.deleteKindForFunc != nil {
 		return m.deleteKindForFunc(gvr)
@@ -69,6 +74,18 @@ func (m *mockCacheManager) QueryCacheResult() cachemanager.CacheResult {
 	return cachemanager.CacheResult{}
 }
 
+func (m *mockCacheManager) WatchEventsSince(req *http.Request) ([]watch.Event, string, bool) {
+	return nil, "", false
+}
+
+func (m *mockCacheManager) WatchLocal(req *http.Request) (watch.Interface, error) {
+	return nil, nil
+}
+
+func (m *mockCacheManager) AcceptLocalWrite(req *http.Request, obj runtime.Object) error {
+	return nil
+}
+
 type mockFilterFinder struct {
 	findResponseFilterFunc func(req *http.Request) (filter.ResponseFilter, bool)
 }
@@ -0,0 +1,321 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/watch"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+
+	hubmeta "github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/meta"
+	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+// Negotiator negotiates content encoding and content type of responses with kube-apiserver in order
+// to reduce traffic on the link to cloud, and responses are converted into the format accepted by clients.
+type Negotiator struct {
+	acceptEncoding    string
+	preferProtobuf    bool
+	serializerManager *serializer.SerializerManager
+}
+
+// NewNegotiator creates a negotiator, nil is returned if neither compression nor protobuf is preferred.
+func NewNegotiator(encodings []string, preferProtobuf bool, serializerManager *serializer.SerializerManager) *Negotiator {
+	if len(encodings) == 0 && !preferProtobuf {
+		return nil
+	}
+	if serializerManager == nil {
+		preferProtobuf = false
+	}
+
+	return &Negotiator{
+		acceptEncoding:    strings.Join(encodings, ", "),
+		preferProtobuf:    preferProtobuf,
+		serializerManager: serializerManager,
+	}
+}
+
+// negotiatedRequest records what the client accepts, so the response can be converted back for the client.
+type negotiatedRequest struct {
+	n              *Negotiator
+	original       *http.Request
+	gvr            schema.GroupVersionResource
+	isWatch        bool
+	clientEncoding string
+	protobuf       bool
+}
+
+// negotiate returns the request sent to kube-apiserver with negotiated headers, and the original
+// request is not modified. nil is returned when request is not negotiated.
+func (n *Negotiator) negotiate(req *http.Request) (*http.Request, *negotiatedRequest) {
+	info, ok := apirequest.RequestInfoFrom(req.Context())
+	if !ok || !info.IsResourceRequest || httpstream.IsUpgradeRequest(req) {
+		return req, nil
+	}
+
+	nr := &negotiatedRequest{
+		n:              n,
+		original:       req,
+		gvr:            schema.GroupVersionResource{Group: info.APIGroup, Version: info.APIVersion, Resource: info.Resource},
+		isWatch:        info.Verb == "watch",
+		clientEncoding: req.Header.Get("Accept-Encoding"),
+	}
+	nr.protobuf = n.preferProtobuf && canConvertToJSON(info, req.Header.Get("Accept"))
+
+	outReq := req.Clone(req.Context())
+	if len(n.acceptEncoding) != 0 {
+		outReq.Header.Set("Accept-Encoding", n.acceptEncoding)
+	}
+	if nr.protobuf {
+		outReq.Header.Set("Accept", runtime.ContentTypeProtobuf+", "+runtime.ContentTypeJSON)
+	}
+	return outReq, nr
+}
+
+// convert decompresses the response if the client doesn't accept its content encoding, and
+// converts protobuf response into json if the client doesn't ask for protobuf. responses are
+// filtered and cached after conversion, and only gzip can be decompressed there, so responses
+// in other encodings are always decompressed here. bodies of failed responses are not objects
+// of the resource, so they are not converted.
+func (nr *negotiatedRequest) convert(resp *http.Response) (*http.Response, error) {
+	resp.Request = nr.original
+	encoding := strings.TrimSpace(resp.Header.Get("Content-Encoding"))
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	succeeded := resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices
+	needConvert := nr.protobuf && succeeded && mediaType == runtime.ContentTypeProtobuf
+	needDecompress := len(encoding) != 0 &&
+		(needConvert || !strings.EqualFold(encoding, hubutil.CompressionGzip) || !acceptsEncoding(nr.clientEncoding, encoding))
+	if !needConvert && !needDecompress {
+		return resp, nil
+	}
+
+	wire := &countingReadCloser{ReadCloser: resp.Body}
+	var body io.ReadCloser = wire
+	if needDecompress {
+		body = &decompressReadCloser{encoding: encoding, rc: wire}
+		resp.Header.Del("Content-Encoding")
+	}
+
+	if needConvert {
+		var err error
+		if body, err = nr.convertToJSON(resp.Header.Get("Content-Type"), body); err != nil {
+			klog.Errorf("could not convert protobuf response of %s into json, %v", hubutil.ReqString(nr.original), err)
+			return nil, err
+		}
+		resp.Header.Set("Content-Type", runtime.ContentTypeJSON)
+	}
+
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Body = &savedTrafficReadCloser{ReadCloser: body, wire: wire, gvr: nr.gvr}
+	return resp, nil
+}
+
+// convertToJSON decodes protobuf objects from body and encodes them into json.
+func (nr *negotiatedRequest) convertToJSON(contentType string, body io.ReadCloser) (io.ReadCloser, error) {
+	pbSerializer := nr.n.serializerManager.CreateSerializer(contentType, nr.gvr.Group, nr.gvr.Version, nr.gvr.Resource)
+	jsonSerializer := nr.n.serializerManager.CreateSerializer(runtime.ContentTypeJSON, nr.gvr.Group, nr.gvr.Version, nr.gvr.Resource)
+
+	if !nr.isWatch {
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		obj, err := pbSerializer.Decode(data)
+		if err != nil {
+			return nil, err
+		}
+		out, err := jsonSerializer.Encode(obj)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(out)), nil
+	}
+
+	decoder, err := pbSerializer.WatchDecoder(body)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		defer decoder.Close()
+		for {
+			eventType, obj, err := decoder.Decode()
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if _, err := jsonSerializer.WatchEncode(pw, &watch.Event{Type: eventType, Object: obj}); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return &pipeReadCloser{PipeReader: pr, upstream: body}, nil
+}
+
+// canConvertToJSON checks the response of request can be requested in protobuf and converted into json,
+// only objects of built-in resources are converted, and requests for tables or partial object
+// metadata are not converted.
+func canConvertToJSON(info *apirequest.RequestInfo, accept string) bool {
+	if info.Verb != "get" && info.Verb != "list" && info.Verb != "watch" {
+		return false
+	}
+	if len(info.Subresource) != 0 {
+		return false
+	}
+	if !hubmeta.IsSchemeResource(schema.GroupVersionResource{Group: info.APIGroup, Version: info.APIVersion, Resource: info.Resource}) {
+		return false
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		if len(strings.TrimSpace(part)) == 0 {
+			continue
+		}
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			return false
+		}
+		if mediaType != runtime.ContentTypeJSON && mediaType != "*/*" {
+			return false
+		}
+		for key := range params {
+			if key != "q" && key != "charset" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// acceptsEncoding checks the content encoding is in the Accept-Encoding header of client.
+func acceptsEncoding(acceptEncoding, encoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, _, _ := strings.Cut(part, ";")
+		coding = strings.TrimSpace(coding)
+		if coding == "*" || strings.EqualFold(coding, encoding) {
+			return true
+		}
+	}
+	return false
+}
+
+// decompressReadCloser decompresses the body lazily, so headers of compressed stream
+// are not read until the body is read by client.
+type decompressReadCloser struct {
+	encoding string
+	rc       io.ReadCloser
+	r        io.Reader
+	release  func()
+}
+
+func (d *decompressReadCloser) Read(p []byte) (int, error) {
+	if d.r == nil {
+		switch d.encoding {
+		case hubutil.CompressionGzip:
+			gr, err := gzip.NewReader(d.rc)
+			if err != nil {
+				return 0, err
+			}
+			d.r = gr
+		case hubutil.CompressionZstd:
+			zr, err := zstd.NewReader(d.rc)
+			if err != nil {
+				return 0, err
+			}
+			d.r, d.release = zr, zr.Close
+		default:
+			return 0, fmt.Errorf("content encoding %s is not supported", d.encoding)
+		}
+	}
+	return d.r.Read(p)
+}
+
+func (d *decompressReadCloser) Close() error {
+	if d.release != nil {
+		d.release()
+	}
+	return d.rc.Close()
+}
+
+type pipeReadCloser struct {
+	*io.PipeReader
+	upstream io.Closer
+}
+
+// Close closes the upstream body too, so the goroutine which waits for watch events can exit.
+func (p *pipeReadCloser) Close() error {
+	p.PipeReader.Close()
+	return p.upstream.Close()
+}
+
+type countingReadCloser struct {
+	io.ReadCloser
+	lock sync.Mutex
+	n    int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.lock.Lock()
+	c.n += int64(n)
+	c.lock.Unlock()
+	return n, err
+}
+
+func (c *countingReadCloser) count() int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.n
+}
+
+// savedTrafficReadCloser reports the difference between bytes delivered to client and bytes
+// received from kube-apiserver as saved traffic of the resource.
+type savedTrafficReadCloser struct {
+	io.ReadCloser
+	wire      *countingReadCloser
+	gvr       schema.GroupVersionResource
+	delivered int64
+	reported  int64
+}
+
+func (s *savedTrafficReadCloser) Read(p []byte) (int, error) {
+	n, err := s.ReadCloser.Read(p)
+	s.delivered += int64(n)
+	if saved := s.delivered - s.wire.count(); saved > s.reported {
+		metrics.Metrics.AddSavedTrafficBytes(s.gvr.Group, s.gvr.Version, s.gvr.Resource, saved-s.reported)
+		s.reported = saved
+	}
+	return n, err
+}
@@ -0,0 +1,352 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCanConvertToJSON(t *testing.T) {
+	testcases := map[string]struct {
+		info   *apirequest.RequestInfo
+		accept string
+		expect bool
+	}{
+		"list pods without accept": {
+			info:   &apirequest.RequestInfo{Verb: "list", APIVersion: "v1", Resource: "pods"},
+			expect: true,
+		},
+		"watch pods in json": {
+			info:   &apirequest.RequestInfo{Verb: "watch", APIVersion: "v1", Resource: "pods"},
+			accept: "application/json, */*",
+			expect: true,
+		},
+		"client asks for protobuf": {
+			info:   &apirequest.RequestInfo{Verb: "list", APIVersion: "v1", Resource: "pods"},
+			accept: "application/vnd.kubernetes.protobuf, application/json",
+		},
+		"client asks for partial object metadata": {
+			info:   &apirequest.RequestInfo{Verb: "list", APIVersion: "v1", Resource: "pods"},
+			accept: "application/json;as=PartialObjectMetadataList;v=v1;g=meta.k8s.io",
+		},
+		"custom resource": {
+			info:   &apirequest.RequestInfo{Verb: "list", APIGroup: "apps.openyurt.io", APIVersion: "v1beta2", Resource: "nodepools"},
+			accept: "application/json",
+		},
+		"subresource": {
+			info:   &apirequest.RequestInfo{Verb: "get", APIVersion: "v1", Resource: "pods", Subresource: "log"},
+			accept: "application/json",
+		},
+		"create request": {
+			info:   &apirequest.RequestInfo{Verb: "create", APIVersion: "v1", Resource: "pods"},
+			accept: "application/json",
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			if got := canConvertToJSON(tc.info, tc.accept); got != tc.expect {
+				t.Errorf("expect %v, but got %v", tc.expect, got)
+			}
+		})
+	}
+}
+
+func TestAcceptsEncoding(t *testing.T) {
+	testcases := map[string]struct {
+		acceptEncoding string
+		encoding       string
+		expect         bool
+	}{
+		"accepts gzip":           {acceptEncoding: "gzip, deflate", encoding: "gzip", expect: true},
+		"accepts with q value":   {acceptEncoding: "zstd;q=1.0, gzip;q=0.5", encoding: "zstd", expect: true},
+		"accepts any":            {acceptEncoding: "*", encoding: "zstd", expect: true},
+		"doesn't accept zstd":    {acceptEncoding: "gzip", encoding: "zstd"},
+		"no accept encoding set": {encoding: "gzip"},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			if got := acceptsEncoding(tc.acceptEncoding, tc.encoding); got != tc.expect {
+				t.Errorf("expect %v, but got %v", tc.expect, got)
+			}
+		})
+	}
+}
+
+func newNegotiatedRequest(verb, accept, acceptEncoding string) *http.Request {
+	req, _ := http.NewRequest("GET", "/api/v1/pods", nil)
+	if len(accept) != 0 {
+		req.Header.Set("Accept", accept)
+	}
+	if len(acceptEncoding) != 0 {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	ctx := apirequest.WithRequestInfo(req.Context(), &apirequest.RequestInfo{
+		IsResourceRequest: true,
+		Verb:              verb,
+		APIVersion:        "v1",
+		Resource:          "pods",
+	})
+	return req.WithContext(ctx)
+}
+
+func TestNegotiateList(t *testing.T) {
+	sm := serializer.NewSerializerManager()
+	podList := &v1.PodList{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+		ListMeta: metav1.ListMeta{ResourceVersion: "100"},
+		Items: []v1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", ResourceVersion: "99"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "default", ResourceVersion: "100"}},
+		},
+	}
+	pbData, err := sm.CreateSerializer(runtime.ContentTypeProtobuf, "", "v1", "pods").Encode(podList)
+	if err != nil {
+		t.Fatalf("could not encode pod list, %v", err)
+	}
+	var gzipData bytes.Buffer
+	gw := gzip.NewWriter(&gzipData)
+	gw.Write(pbData)
+	gw.Close()
+
+	rp := &RemoteProxy{
+		remoteServer: &url.URL{Host: "127.0.0.1:6443"},
+		negotiator:   NewNegotiator([]string{"zstd", "gzip"}, true, sm),
+		currentTransport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("Accept-Encoding"); got != "zstd, gzip" {
+				t.Errorf("expect Accept-Encoding %q, but got %q", "zstd, gzip", got)
+			}
+			if got := req.Header.Get("Accept"); got != "application/vnd.kubernetes.protobuf, application/json" {
+				t.Errorf("expect protobuf is preferred, but got Accept %q", got)
+			}
+			header := http.Header{}
+			header.Set("Content-Type", runtime.ContentTypeProtobuf)
+			header.Set("Content-Encoding", "gzip")
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(bytes.NewReader(gzipData.Bytes())), Request: req}, nil
+		}),
+	}
+
+	// client accepts gzip, but response should be decompressed for converting.
+	req := newNegotiatedRequest("list", "application/json", "gzip")
+	resp, err := rp.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("could not round trip, %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.Request != req {
+		t.Errorf("expect request of response is the original request")
+	}
+	if req.Header.Get("Accept") != "application/json" {
+		t.Errorf("expect original request is not modified, but got Accept %q", req.Header.Get("Accept"))
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != runtime.ContentTypeJSON {
+		t.Errorf("expect content type %s, but got %s", runtime.ContentTypeJSON, ct)
+	}
+	if ce := resp.Header.Get("Content-Encoding"); len(ce) != 0 {
+		t.Errorf("expect content encoding is removed, but got %s", ce)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("could not read body, %v", err)
+	}
+	result := &v1.PodList{}
+	if err := json.Unmarshal(data, result); err != nil {
+		t.Fatalf("could not decode json response, %v, %s", err, string(data))
+	}
+	if result.Kind != "PodList" || result.ResourceVersion != "100" || len(result.Items) != 2 || result.Items[1].Name != "bar" {
+		t.Errorf("expect pod list is converted, but got %s", string(data))
+	}
+}
+
+func TestNegotiateCompression(t *testing.T) {
+	content := bytes.Repeat([]byte(`{"kind":"PodList","apiVersion":"v1","items":[]}`), 10)
+	var gzipData bytes.Buffer
+	gw := gzip.NewWriter(&gzipData)
+	gw.Write(content)
+	gw.Close()
+	zw, _ := zstd.NewWriter(nil)
+	zstdData := zw.EncodeAll(content, nil)
+	zw.Close()
+
+	testcases := map[string]struct {
+		encoding       string
+		acceptEncoding string
+		expectEncoding string
+	}{
+		"client accepts gzip": {
+			encoding:       "gzip",
+			acceptEncoding: "gzip",
+			expectEncoding: "gzip",
+		},
+		"client doesn't accept gzip": {
+			encoding: "gzip",
+		},
+		"zstd is decompressed even if client accepts it": {
+			encoding:       "zstd",
+			acceptEncoding: "zstd",
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			body := gzipData.Bytes()
+			if tc.encoding == "zstd" {
+				body = zstdData
+			}
+			rp := &RemoteProxy{
+				remoteServer: &url.URL{Host: "127.0.0.1:6443"},
+				negotiator:   NewNegotiator([]string{tc.encoding}, false, nil),
+				currentTransport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					if got := req.Header.Get("Accept"); got != "application/json" {
+						t.Errorf("expect Accept is not changed, but got %q", got)
+					}
+					header := http.Header{}
+					header.Set("Content-Type", runtime.ContentTypeJSON)
+					header.Set("Content-Encoding", tc.encoding)
+					return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(bytes.NewReader(body)), Request: req}, nil
+				}),
+			}
+
+			resp, err := rp.RoundTrip(newNegotiatedRequest("list", "application/json", tc.acceptEncoding))
+			if err != nil {
+				t.Fatalf("could not round trip, %v", err)
+			}
+			defer resp.Body.Close()
+			if ce := resp.Header.Get("Content-Encoding"); ce != tc.expectEncoding {
+				t.Errorf("expect content encoding %q, but got %q", tc.expectEncoding, ce)
+			}
+
+			data, _ := io.ReadAll(resp.Body)
+			expect := content
+			if len(tc.expectEncoding) != 0 {
+				expect = gzipData.Bytes()
+			}
+			if !bytes.Equal(data, expect) {
+				t.Errorf("expect body %q, but got %q", string(expect), string(data))
+			}
+		})
+	}
+}
+
+func TestNegotiateFailedResponse(t *testing.T) {
+	sm := serializer.NewSerializerManager()
+	status := &metav1.Status{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"},
+		Status:   metav1.StatusFailure,
+		Reason:   metav1.StatusReasonNotFound,
+		Code:     http.StatusNotFound,
+	}
+	data, err := sm.CreateSerializer(runtime.ContentTypeProtobuf, "", "v1", "pods").Encode(status)
+	if err != nil {
+		t.Fatalf("could not encode status, %v", err)
+	}
+
+	rp := &RemoteProxy{
+		remoteServer: &url.URL{Host: "127.0.0.1:6443"},
+		negotiator:   NewNegotiator(nil, true, sm),
+		currentTransport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			header := http.Header{}
+			header.Set("Content-Type", runtime.ContentTypeProtobuf)
+			return &http.Response{StatusCode: http.StatusNotFound, Header: header, Body: io.NopCloser(bytes.NewReader(data)), Request: req}, nil
+		}),
+	}
+
+	resp, err := rp.RoundTrip(newNegotiatedRequest("get", "application/json", ""))
+	if err != nil {
+		t.Fatalf("could not round trip, %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != runtime.ContentTypeProtobuf {
+		t.Errorf("expect failed response is not converted, but got content type %s", ct)
+	}
+	if got, _ := io.ReadAll(resp.Body); !bytes.Equal(got, data) {
+		t.Errorf("expect body of failed response is kept, but got %q", string(got))
+	}
+}
+
+func TestNegotiateWatch(t *testing.T) {
+	sm := serializer.NewSerializerManager()
+	pbSerializer := sm.CreateSerializer(runtime.ContentTypeProtobuf, "", "v1", "pods")
+	events := []watch.Event{
+		{Type: watch.Added, Object: &v1.Pod{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}, ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", ResourceVersion: "1"}}},
+		{Type: watch.Deleted, Object: &v1.Pod{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}, ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", ResourceVersion: "2"}}},
+	}
+	var stream bytes.Buffer
+	for i := range events {
+		if _, err := pbSerializer.WatchEncode(&stream, &events[i]); err != nil {
+			t.Fatalf("could not encode watch event, %v", err)
+		}
+	}
+
+	rp := &RemoteProxy{
+		remoteServer: &url.URL{Host: "127.0.0.1:6443"},
+		negotiator:   NewNegotiator(nil, true, sm),
+		currentTransport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			header := http.Header{}
+			header.Set("Content-Type", runtime.ContentTypeProtobuf+";stream=watch")
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(&stream), Request: req}, nil
+		}),
+	}
+
+	resp, err := rp.RoundTrip(newNegotiatedRequest("watch", "", ""))
+	if err != nil {
+		t.Fatalf("could not round trip, %v", err)
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for i := range events {
+		var event metav1.WatchEvent
+		if err := decoder.Decode(&event); err != nil {
+			t.Fatalf("could not decode watch event %d, %v", i, err)
+		}
+		pod := &v1.Pod{}
+		if err := json.Unmarshal(event.Object.Raw, pod); err != nil {
+			t.Fatalf("could not decode pod of event %d, %v", i, err)
+		}
+		if event.Type != string(events[i].Type) || pod.Kind != "Pod" || pod.ResourceVersion != events[i].Object.(*v1.Pod).ResourceVersion {
+			t.Errorf("expect event %s with pod %s, but got %s with %#v", events[i].Type, events[i].Object.(*v1.Pod).ResourceVersion, event.Type, pod)
+		}
+	}
+	var event metav1.WatchEvent
+	if err := decoder.Decode(&event); err != io.EOF {
+		t.Errorf("expect watch stream is ended, but got %v", err)
+	}
+}
@@ -22,17 +22,33 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/apimachinery/pkg/util/proxy"
 	apirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/klog/v2"
 
+	"github.com/openyurtio/openyurt/pkg/util/tracing"
+	"github.com/openyurtio/openyurt/pkg/yurthub/audit"
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
 	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
 	"github.com/openyurtio/openyurt/pkg/yurthub/transport"
 	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
 )
 
+const (
+	// latencyDecay is the weight of the latest request latency in the moving average.
+	latencyDecay = 0.3
+	// latencyExpiration is the period after which the moving average of latency is considered as stale.
+	latencyExpiration = time.Minute
+	// failedRequestLatency is the min latency recorded for failed requests.
+	failedRequestLatency = 10 * time.Second
+)
+
 // RemoteProxy is an reverse proxy for remote server
 type RemoteProxy struct {
 	reverseProxy         *httputil.ReverseProxy
@@ -41,7 +57,15 @@ type RemoteProxy struct {
 	bearerTransport      http.RoundTripper
 	upgradeHandler       *proxy.UpgradeAwareHandler
 	bearerUpgradeHandler *proxy.UpgradeAwareHandler
+	observer             healthchecker.RequestObserver
+	negotiator           *Negotiator
 	stopCh               <-chan struct{}
+
+	// inflight and latency of requests are used by latency-aware load balancing strategy.
+	inflight      atomic.Int64
+	latencyLock   sync.RWMutex
+	latencyEWMA   time.Duration
+	latencyUpdate time.Time
 }
 
 type responder struct{}
@@ -100,6 +124,18 @@ func (rp *RemoteProxy) RemoteServer() *url.URL {
 
 func (rp *RemoteProxy) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	ctx := req.Context()
+	audit.RecorderFrom(ctx).SetSource(audit.SourceRemote, rp.Name())
+	tracing.SetAttributes(ctx, attribute.String(tracing.AttributeServedBy, string(audit.SourceRemote)), attribute.String(tracing.AttributeBackend, rp.Name()))
+
+	// trace context of the span is propagated to remote server, so spans of kube-apiserver
+	// can be linked with spans of yurthub.
+	ctx, span := tracing.Start(ctx, "ForwardToRemote", attribute.String(tracing.AttributeBackend, rp.Name()))
+	defer span.End()
+	if span.SpanContext().IsValid() {
+		req = req.Clone(ctx)
+		tracing.InjectHeader(ctx, req.Header)
+	}
+
 	isRequestForPoolScopeMetadata, ok := hubutil.IsRequestForPoolScopeMetadataFrom(ctx)
 	shouldBeForwarded, _ := hubutil.ForwardRequestForPoolScopeMetadataFrom(ctx)
 	if ok && isRequestForPoolScopeMetadata && shouldBeForwarded {
@@ -129,11 +165,97 @@ func (rp *RemoteProxy) RoundTrip(req *http.Request) (*http.Response, error) {
 	// when edge client(like kube-proxy, flannel, etc) use service account(default InClusterConfig) to access yurthub,
 	// Authorization header will be set in request. and when edge client(like kubelet) use x509 certificate to access
 	// yurthub, Authorization header in request will be empty.
+	rt := rp.currentTransport
 	if isBearerRequest(req) {
-		return rp.bearerTransport.RoundTrip(req)
+		rt = rp.bearerTransport
+	}
+	original := req
+	var negotiated *negotiatedRequest
+	if rp.negotiator != nil {
+		req, negotiated = rp.negotiator.negotiate(req)
+	}
+
+	// traffic is accounted by component, so chatty components on the link to remote server can be figured out.
+	key := trafficKeyFor(req, rp.Name())
+	var requestBody *countingReadCloser
+	if req.Body != nil && req.Body != http.NoBody {
+		requestBody = &countingReadCloser{ReadCloser: req.Body}
+		req = req.WithContext(req.Context())
+		req.Body = requestBody
+	}
+
+	// latency is observed until response header is received, so watch requests can also be observed.
+	rp.inflight.Add(1)
+	start := time.Now()
+	resp, err := rt.RoundTrip(req)
+	latency := time.Since(start)
+	rp.inflight.Add(-1)
+
+	failed := isFailedRequest(req, resp, err)
+	// requests canceled by clients say nothing about the latency of remote server.
+	if err == nil || failed {
+		rp.observeLatency(latency, failed)
+	}
+	if rp.observer != nil {
+		rp.observer.ObserveRequest(rp.remoteServer, latency, failed)
+	}
+
+	metrics.Metrics.IncRemoteRequests(key)
+	if requestBody != nil {
+		metrics.Metrics.AddRemoteTrafficBytes(key, 0, requestBody.count())
 	}
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Request = original
+	resp.Body = &trafficAccountingReadCloser{ReadCloser: resp.Body, key: key, start: start, isWatch: key.Verb == "watch", lastFlush: start}
+	if negotiated != nil {
+		return negotiated.convert(resp)
+	}
+	return resp, nil
+}
 
-	return rp.currentTransport.RoundTrip(req)
+// observeLatency updates the exponentially weighted moving average of request latency,
+// and failed requests are penalized so the remote server will be avoided.
+func (rp *RemoteProxy) observeLatency(latency time.Duration, failed bool) {
+	if failed && latency < failedRequestLatency {
+		latency = failedRequestLatency
+	}
+
+	rp.latencyLock.Lock()
+	defer rp.latencyLock.Unlock()
+	if rp.latencyUpdate.IsZero() {
+		rp.latencyEWMA = latency
+	} else {
+		rp.latencyEWMA = time.Duration(latencyDecay*float64(latency) + (1-latencyDecay)*float64(rp.latencyEWMA))
+	}
+	rp.latencyUpdate = time.Now()
+}
+
+// Latency returns the moving average of request latency, false is returned if latency
+// has not been observed since now-latencyExpiration.
+func (rp *RemoteProxy) Latency(now time.Time) (time.Duration, bool) {
+	rp.latencyLock.RLock()
+	defer rp.latencyLock.RUnlock()
+	if rp.latencyUpdate.IsZero() || now.Sub(rp.latencyUpdate) > latencyExpiration {
+		return rp.latencyEWMA, false
+	}
+	return rp.latencyEWMA, true
+}
+
+// InflightRequests returns the number of requests which are waiting for response from remote server.
+func (rp *RemoteProxy) InflightRequests() int64 {
+	return rp.inflight.Load()
+}
+
+// isFailedRequest checks the request is failed because of remote server or not,
+// requests canceled by clients are not considered as failed.
+func isFailedRequest(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+	return resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
 }
 
 func isBearerRequest(req *http.Request) bool {
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestIsFailedRequest(t *testing.T) {
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testcases := map[string]struct {
+		ctx    context.Context
+		resp   *http.Response
+		err    error
+		expect bool
+	}{
+		"successful request": {
+			ctx:  context.Background(),
+			resp: &http.Response{StatusCode: http.StatusOK},
+		},
+		"not found is not failed": {
+			ctx:  context.Background(),
+			resp: &http.Response{StatusCode: http.StatusNotFound},
+		},
+		"internal server error": {
+			ctx:    context.Background(),
+			resp:   &http.Response{StatusCode: http.StatusInternalServerError},
+			expect: true,
+		},
+		"too many requests": {
+			ctx:    context.Background(),
+			resp:   &http.Response{StatusCode: http.StatusTooManyRequests},
+			expect: true,
+		},
+		"transport error": {
+			ctx:    context.Background(),
+			err:    errors.New("connection reset by peer"),
+			expect: true,
+		},
+		"request canceled by client": {
+			ctx: canceledCtx,
+			err: context.Canceled,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			req, _ := http.NewRequestWithContext(tc.ctx, "GET", "/api/v1/pods", nil)
+			if failed := isFailedRequest(req, tc.resp, tc.err); failed != tc.expect {
+				t.Errorf("expect failed %v, but got %v", tc.expect, failed)
+			}
+		})
+	}
+}
+
+func TestObserveLatency(t *testing.T) {
+	testcases := map[string]struct {
+		latencies []time.Duration
+		failed    []bool
+		expect    time.Duration
+	}{
+		"first latency is used as average": {
+			latencies: []time.Duration{100 * time.Millisecond},
+			failed:    []bool{false},
+			expect:    100 * time.Millisecond,
+		},
+		"latency is averaged with decay": {
+			latencies: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+			failed:    []bool{false, false},
+			expect:    130 * time.Millisecond,
+		},
+		"failed request is penalized": {
+			latencies: []time.Duration{time.Millisecond},
+			failed:    []bool{true},
+			expect:    failedRequestLatency,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			rp := &RemoteProxy{}
+			if _, ok := rp.Latency(time.Now()); ok {
+				t.Fatalf("expect no latency before requests are observed")
+			}
+			for i := range tc.latencies {
+				rp.observeLatency(tc.latencies[i], tc.failed[i])
+			}
+
+			latency, ok := rp.Latency(time.Now())
+			if !ok || latency != tc.expect {
+				t.Errorf("expect latency %v, but got %v(%v)", tc.expect, latency, ok)
+			}
+			if _, ok := rp.Latency(time.Now().Add(2 * latencyExpiration)); ok {
+				t.Errorf("expect latency is stale after expiration")
+			}
+		})
+	}
+}
+
+func TestTracePropagation(t *testing.T) {
+	var traceparent string
+	remoteServer := &url.URL{Scheme: "https", Host: "127.0.0.1:6443"}
+	rp := &RemoteProxy{
+		reverseProxy: httputil.NewSingleHostReverseProxy(remoteServer),
+		remoteServer: remoteServer,
+		currentTransport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			traceparent = req.Header.Get("traceparent")
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+		}),
+	}
+	rp.reverseProxy.Transport = rp
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "ProxyDecision")
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil).WithContext(ctx)
+	rp.ServeHTTP(httptest.NewRecorder(), req)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 || spans[0].Name != "ForwardToRemote" {
+		t.Fatalf("expect ForwardToRemote span is recorded, but got %v", spans)
+	}
+	if expect := spans[0].SpanContext.SpanID().String(); !strings.Contains(traceparent, expect) {
+		t.Errorf("expect traceparent with span %s is forwarded to remote server, but got %q", expect, traceparent)
+	}
+	if len(req.Header.Get("traceparent")) != 0 {
+		t.Errorf("expect header of original request is not changed")
+	}
+
+	// trace context is not injected if there is no span
+	traceparent = ""
+	rp.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+	if len(traceparent) != 0 {
+		t.Errorf("expect no traceparent without span, but got %q", traceparent)
+	}
+}
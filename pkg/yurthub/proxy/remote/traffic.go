@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+const (
+	unknownComponent = "unknown"
+	// trafficFlushInterval is the interval for reporting traffic of watch requests.
+	trafficFlushInterval = 10 * time.Second
+)
+
+// trafficKeyFor returns the key for accounting traffic of request forwarded to the backend.
+func trafficKeyFor(req *http.Request, backend string) metrics.TrafficKey {
+	key := metrics.TrafficKey{
+		Component: unknownComponent,
+		Verb:      strings.ToLower(req.Method),
+		Backend:   backend,
+	}
+	if comp, ok := hubutil.TruncatedClientComponentFrom(req.Context()); ok && len(comp) != 0 {
+		key.Component = comp
+	}
+	if info, ok := apirequest.RequestInfoFrom(req.Context()); ok && info != nil {
+		key.Verb = info.Verb
+		if info.IsResourceRequest {
+			key.Group, key.Version, key.Resource = info.APIGroup, info.APIVersion, info.Resource
+		}
+	}
+	return key
+}
+
+// trafficAccountingReadCloser accounts bytes of response received from remote server, and the duration
+// of watch request is accounted when the response is closed. bytes are accumulated in the reader and
+// reported when the response is closed, or periodically for long-running watch requests, so metrics
+// are not updated on every read.
+type trafficAccountingReadCloser struct {
+	io.ReadCloser
+	key       metrics.TrafficKey
+	start     time.Time
+	isWatch   bool
+	pending   atomic.Int64
+	lastFlush time.Time
+	once      sync.Once
+}
+
+func (t *trafficAccountingReadCloser) Read(p []byte) (int, error) {
+	n, err := t.ReadCloser.Read(p)
+	t.pending.Add(int64(n))
+	if t.isWatch {
+		if now := time.Now(); now.Sub(t.lastFlush) >= trafficFlushInterval {
+			t.lastFlush = now
+			t.flush()
+		}
+	}
+	return n, err
+}
+
+func (t *trafficAccountingReadCloser) Close() error {
+	t.once.Do(func() {
+		t.flush()
+		if t.isWatch {
+			metrics.Metrics.ObserveRemoteWatchDuration(t.key, time.Since(t.start))
+		}
+	})
+	return t.ReadCloser.Close()
+}
+
+func (t *trafficAccountingReadCloser) flush() {
+	if n := t.pending.Swap(0); n > 0 {
+		metrics.Metrics.AddRemoteTrafficBytes(t.key, n, 0)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+func TestTrafficAccounting(t *testing.T) {
+	metrics.Metrics.Reset()
+	defer metrics.Metrics.Reset()
+
+	rp := &RemoteProxy{
+		remoteServer: &url.URL{Scheme: "https", Host: "127.0.0.1:6443"},
+		currentTransport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Body != nil {
+				io.Copy(io.Discard, req.Body)
+				req.Body.Close()
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("0123456789")), Request: req}, nil
+		}),
+	}
+
+	newRequest := func(method, verb string, body io.Reader) *http.Request {
+		req, _ := http.NewRequest(method, "/apis/apps/v1/namespaces/default/deployments", body)
+		ctx := apirequest.WithRequestInfo(req.Context(), &apirequest.RequestInfo{
+			IsResourceRequest: true,
+			Verb:              verb,
+			APIGroup:          "apps",
+			APIVersion:        "v1",
+			Resource:          "deployments",
+		})
+		ctx = hubutil.WithClientComponent(ctx, "operator/v1.0.0")
+		return req.WithContext(ctx)
+	}
+
+	for _, req := range []*http.Request{
+		newRequest(http.MethodPost, "create", bytes.NewReader([]byte("hello"))),
+		newRequest(http.MethodGet, "list", nil),
+		newRequest(http.MethodGet, "watch", nil),
+	} {
+		resp, err := rp.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("could not round trip, %v", err)
+		}
+		if resp.Request != req {
+			t.Errorf("expect request of response is the original request")
+		}
+		io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+
+	items, _ := metrics.Metrics.TopRemoteTraffic(0, metrics.TrafficSortByBytes)
+	if len(items) != 3 {
+		t.Fatalf("expect 3 traffic summaries, but got %#v", items)
+	}
+	for _, item := range items {
+		if item.Component != "operator" || item.Resource != "deployments" || item.Backend != "https://127.0.0.1:6443" {
+			t.Errorf("expect traffic of operator for deployments on https://127.0.0.1:6443, but got %#v", item.TrafficKey)
+		}
+		if item.Requests != 1 || item.BytesIn != 10 {
+			t.Errorf("expect 1 request and 10 bytes in for %s, but got %d requests and %d bytes in", item.Verb, item.Requests, item.BytesIn)
+		}
+		switch item.Verb {
+		case "create":
+			if item.BytesOut != 5 {
+				t.Errorf("expect 5 bytes out for create request, but got %d", item.BytesOut)
+			}
+		case "watch":
+			if item.WatchSeconds <= 0 {
+				t.Errorf("expect duration of watch request is accounted, but got %v", item.WatchSeconds)
+			}
+		}
+	}
+}
+
+func TestTrafficAccountingFlush(t *testing.T) {
+	metrics.Metrics.Reset()
+	defer metrics.Metrics.Reset()
+
+	key := metrics.TrafficKey{Component: "operator", Verb: "watch", Version: "v1", Resource: "pods", Backend: "https://127.0.0.1:6443"}
+	bytesIn := func() int64 {
+		items, _ := metrics.Metrics.TopRemoteTraffic(0, metrics.TrafficSortByBytes)
+		if len(items) == 0 {
+			return 0
+		}
+		return items[0].BytesIn
+	}
+
+	rc := &trafficAccountingReadCloser{ReadCloser: io.NopCloser(strings.NewReader("0123456789")), key: key, start: time.Now(), isWatch: true, lastFlush: time.Now()}
+	buf := make([]byte, 4)
+	rc.Read(buf)
+	if n := bytesIn(); n != 0 {
+		t.Errorf("expect bytes are not reported before flush interval, but got %d", n)
+	}
+
+	rc.lastFlush = time.Now().Add(-trafficFlushInterval)
+	rc.Read(buf)
+	if n := bytesIn(); n != 8 {
+		t.Errorf("expect 8 bytes are reported after flush interval, but got %d", n)
+	}
+
+	io.ReadAll(rc)
+	rc.Close()
+	if n := bytesIn(); n != 10 {
+		t.Errorf("expect 10 bytes are reported after close, but got %d", n)
+	}
+}
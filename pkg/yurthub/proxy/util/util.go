@@ -36,7 +36,9 @@ import (
 	apirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/klog/v2"
 
+	"github.com/openyurtio/openyurt/pkg/yurthub/audit"
 	"github.com/openyurtio/openyurt/pkg/yurthub/filter"
+	"github.com/openyurtio/openyurt/pkg/yurthub/flowcontrol"
 	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
 	"github.com/openyurtio/openyurt/pkg/yurthub/tenant"
 	"github.com/openyurtio/openyurt/pkg/yurthub/util"
@@ -198,6 +200,36 @@ func WithListRequestSelector(handler http.Handler) http.Handler {
 	})
 }
 
+// WithFlowControl limits requests by rate limits of clients and concurrency of priority levels,
+// and requests which exceed the limits are rejected with 429 status code.
+func WithFlowControl(handler http.Handler, controller *flowcontrol.Controller) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		release, err := controller.Acquire(req)
+		if err != nil {
+			util.Err(err, w, req)
+			return
+		}
+		defer release()
+
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// WithAudit records audit events of requests according to audit policy.
+func WithAudit(handler http.Handler, auditor *audit.Auditor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		recorder := auditor.NewRecorder(req)
+		if recorder == nil {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		recorder.RequestReceived()
+		defer recorder.Complete()
+		handler.ServeHTTP(recorder.WrapResponseWriter(w), req.WithContext(audit.WithRecorder(req.Context(), recorder)))
+	})
+}
+
 // WithRequestClientComponent adds user agent header in request context.
 func WithRequestClientComponent(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchresume
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+
+	yurtutil "github.com/openyurtio/openyurt/pkg/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
+	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+// Resume serves events of watch request from local watch history of cache manager, when the resourceVersion
+// of watch request is kept in the history. and returns the response writer and request for the following
+// handler(remote or local proxy), the resourceVersion of returned request has been moved to the latest
+// resourceVersion in the history, so only the delta events need to be fetched from kube-apiserver.
+// the response writer and request are returned directly if watch request can not be resumed.
+func Resume(cacheMgr cachemanager.CacheManager, rw http.ResponseWriter, req *http.Request) (http.ResponseWriter, *http.Request) {
+	if yurtutil.IsNil(cacheMgr) {
+		return rw, req
+	}
+
+	ctx := req.Context()
+	info, ok := apirequest.RequestInfoFrom(ctx)
+	if !ok || info == nil || !info.IsResourceRequest || info.Verb != "watch" {
+		return rw, req
+	}
+
+	events, latestRV, ok := cacheMgr.WatchEventsSince(req)
+	if !ok || len(events) == 0 {
+		return rw, req
+	}
+
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		return rw, req
+	}
+
+	contentType, _ := hubutil.ReqContentTypeFrom(ctx)
+	gvr := schema.GroupVersionResource{
+		Group:    info.APIGroup,
+		Version:  info.APIVersion,
+		Resource: info.Resource,
+	}
+	if convertGVK, ok := hubutil.ConvertGVKFrom(ctx); ok && convertGVK != nil {
+		gvr, _ = meta.UnsafeGuessKindToResource(*convertGVK)
+	}
+	s := serializer.YurtHubSerializer.CreateSerializer(contentType, gvr.Group, gvr.Version, gvr.Resource)
+	if s == nil {
+		klog.Errorf("could not create serializer for resuming watch %s", hubutil.ReqString(req))
+		return rw, req
+	}
+
+	// encode all of events before writing response, so request can still be forwarded
+	// with the original resourceVersion when encoding failed.
+	var buf bytes.Buffer
+	for i := range events {
+		if _, err := s.WatchEncode(&buf, &events[i]); err != nil {
+			klog.Errorf("could not encode watch event for resuming watch %s, %v", hubutil.ReqString(req), err)
+			return rw, req
+		}
+	}
+
+	rw.Header().Set(yurtutil.HTTPHeaderContentType, contentType)
+	rw.Header().Set(yurtutil.HTTPHeaderTransferEncoding, "chunked")
+	rw.WriteHeader(http.StatusOK)
+	if _, err := rw.Write(buf.Bytes()); err != nil {
+		klog.Errorf("could not write watch events for resuming watch %s, %v", hubutil.ReqString(req), err)
+	}
+	flusher.Flush()
+
+	comp, _ := hubutil.TruncatedClientComponentFrom(ctx)
+	metrics.Metrics.AddResumedWatchEvents(comp, info.Resource, len(events))
+	klog.Infof("resume watch %s with %d events from local watch history, and continue watching from resourceVersion %s", hubutil.ReqString(req), len(events), latestRV)
+
+	newReq := req.Clone(ctx)
+	query := newReq.URL.Query()
+	query.Set("resourceVersion", latestRV)
+	newReq.URL.RawQuery = query.Encode()
+	return &resumedResponseWriter{ResponseWriter: rw, flusher: flusher, req: newReq}, newReq
+}
+
+// errWatchFailedAfterResume is returned when writing response after the following handler failed to continue
+// the resumed watch, because status code has been written when resuming watch, and the response body of failure,
+// like a Status object, can not be written into the watch stream.
+var errWatchFailedAfterResume = errors.New("watch failed after it's resumed from local watch history")
+
+// resumedResponseWriter is used for continuing the watch response which status code and header
+// have been written when resuming watch. if the following handler responds with non-200 status code,
+// the response body is dropped and the watch stream is closed, so client will restart watch by itself.
+type resumedResponseWriter struct {
+	http.ResponseWriter
+	flusher http.Flusher
+	req     *http.Request
+	failed  bool
+}
+
+func (w *resumedResponseWriter) WriteHeader(statusCode int) {
+	if statusCode != http.StatusOK {
+		klog.Warningf("got status code %d after watch %s is resumed, close the watch", statusCode, hubutil.ReqString(w.req))
+		w.failed = true
+	}
+}
+
+func (w *resumedResponseWriter) Write(b []byte) (int, error) {
+	if w.failed {
+		return 0, errWatchFailedAfterResume
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *resumedResponseWriter) Flush() {
+	if w.failed {
+		return
+	}
+	w.flusher.Flush()
+}
+
+func (w *resumedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
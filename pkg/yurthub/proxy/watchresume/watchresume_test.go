@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchresume
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/endpoints/filters"
+	"k8s.io/apiserver/pkg/server"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
+	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
+	proxyutil "github.com/openyurtio/openyurt/pkg/yurthub/proxy/util"
+)
+
+type fakeCacheManager struct {
+	cachemanager.CacheManager
+	events   []watch.Event
+	latestRV string
+	ok       bool
+}
+
+func (cm *fakeCacheManager) WatchEventsSince(_ *http.Request) ([]watch.Event, string, bool) {
+	return cm.events, cm.latestRV, cm.ok
+}
+
+func newPod(name, rv string) *v1.Pod {
+	return &v1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", ResourceVersion: rv},
+	}
+}
+
+func TestResume(t *testing.T) {
+	testcases := map[string]struct {
+		path           string
+		cacheMgr       *fakeCacheManager
+		expectResumed  bool
+		expectRV       string
+		expectEventCnt int
+	}{
+		"resume watch with events in history": {
+			path: "/api/v1/namespaces/default/pods?watch=true&resourceVersion=2",
+			cacheMgr: &fakeCacheManager{
+				events:   []watch.Event{{Type: watch.Modified, Object: newPod("mypod1", "4")}, {Type: watch.Deleted, Object: newPod("mypod2", "6")}},
+				latestRV: "8",
+				ok:       true,
+			},
+			expectResumed:  true,
+			expectRV:       "8",
+			expectEventCnt: 2,
+		},
+		"watch not in history": {
+			path:     "/api/v1/namespaces/default/pods?watch=true&resourceVersion=2",
+			cacheMgr: &fakeCacheManager{},
+			expectRV: "2",
+		},
+		"no events after resource version": {
+			path:     "/api/v1/namespaces/default/pods?watch=true&resourceVersion=8",
+			cacheMgr: &fakeCacheManager{events: []watch.Event{}, latestRV: "8", ok: true},
+			expectRV: "8",
+		},
+		"not watch request": {
+			path: "/api/v1/namespaces/default/pods?resourceVersion=2",
+			cacheMgr: &fakeCacheManager{
+				events:   []watch.Event{{Type: watch.Modified, Object: newPod("mypod1", "4")}},
+				latestRV: "8",
+				ok:       true,
+			},
+			expectRV: "2",
+		},
+	}
+
+	resolver := server.NewRequestInfoResolver(&server.Config{LegacyAPIGroupPrefixes: sets.NewString(server.DefaultLegacyAPIPrefix)})
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tc.path, nil)
+			req.Header.Set("User-Agent", "kubelet")
+			req.Header.Set("Accept", "application/json")
+
+			recorder := httptest.NewRecorder()
+			var handler http.Handler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				newRW, newReq := Resume(tc.cacheMgr, rw, req)
+				if resumed := newRW != rw; resumed != tc.expectResumed {
+					t.Errorf("expect resumed %v, but got %v", tc.expectResumed, resumed)
+				}
+				if rv := newReq.URL.Query().Get("resourceVersion"); rv != tc.expectRV {
+					t.Errorf("expect resource version %s, but got %s", tc.expectRV, rv)
+				}
+				// the following handler writes its own status code
+				newRW.WriteHeader(http.StatusOK)
+			})
+			handler = proxyutil.WithRequestContentType(handler)
+			handler = proxyutil.WithRequestClientComponent(handler)
+			handler = filters.WithRequestInfo(handler, resolver)
+			handler.ServeHTTP(recorder, req)
+
+			if !tc.expectResumed {
+				return
+			}
+			s := serializer.NewSerializerManager().CreateSerializer("application/json", "", "v1", "pods")
+			d, err := s.WatchDecoder(io.NopCloser(recorder.Body))
+			if err != nil {
+				t.Fatalf("could not create watch decoder, %v", err)
+			}
+			cnt := 0
+			for {
+				eventType, obj, err := d.Decode()
+				if err != nil {
+					break
+				}
+				expected := tc.cacheMgr.events[cnt]
+				if eventType != expected.Type || obj.(*v1.Pod).ResourceVersion != expected.Object.(*v1.Pod).ResourceVersion {
+					t.Errorf("expect event %s %v, but got %s %v", expected.Type, expected.Object, eventType, obj)
+				}
+				cnt++
+			}
+			if cnt != tc.expectEventCnt {
+				t.Errorf("expect %d events, but got %d", tc.expectEventCnt, cnt)
+			}
+		})
+	}
+}
+
+func TestResumedWatchFailed(t *testing.T) {
+	cacheMgr := &fakeCacheManager{
+		events:   []watch.Event{{Type: watch.Modified, Object: newPod("mypod1", "4")}},
+		latestRV: "8",
+		ok:       true,
+	}
+	req, _ := http.NewRequest("GET", "/api/v1/namespaces/default/pods?watch=true&resourceVersion=2", nil)
+	req.Header.Set("User-Agent", "kubelet")
+	req.Header.Set("Accept", "application/json")
+
+	resolver := server.NewRequestInfoResolver(&server.Config{LegacyAPIGroupPrefixes: sets.NewString(server.DefaultLegacyAPIPrefix)})
+	recorder := httptest.NewRecorder()
+	var handler http.Handler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		newRW, _ := Resume(cacheMgr, rw, req)
+		// the following handler fails with a Status object, like resourceVersion is too old.
+		newRW.WriteHeader(http.StatusGone)
+		if _, err := newRW.Write([]byte(`{"kind":"Status","code":410}`)); err == nil {
+			t.Errorf("expect error when writing response after resumed watch failed, but got nil")
+		}
+	})
+	handler = proxyutil.WithRequestContentType(handler)
+	handler = proxyutil.WithRequestClientComponent(handler)
+	handler = filters.WithRequestInfo(handler, resolver)
+	handler.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expect status code 200 of resumed watch, but got %d", recorder.Code)
+	}
+	if strings.Contains(recorder.Body.String(), "Status") {
+		t.Errorf("expect Status not to be written into watch stream, but got %s", recorder.Body.String())
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repair
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/utils"
+)
+
+var (
+	defaultRepairInterval = time.Minute
+	defaultRequestTimeout = 10 * time.Second
+)
+
+// RepairManager is responsible for repairing the quarantined objects of local cache.
+// When the cloud kube-apiserver is healthy, quarantined objects will be re-fetched through
+// the yurthub proxy server on behalf of the component that cached them, so the objects will be
+// filtered and cached again in the same way as the original requests.
+type RepairManager struct {
+	verifier      storage.Verifier
+	healthChecker healthchecker.Interface
+	proxyAddr     string
+	client        *http.Client
+	interval      time.Duration
+	stopCh        <-chan struct{}
+}
+
+// NewRepairManager creates a *RepairManager object. proxyAddr is the address of yurthub insecure proxy server.
+func NewRepairManager(verifier storage.Verifier, healthChecker healthchecker.Interface, proxyAddr string, stopCh <-chan struct{}) *RepairManager {
+	return &RepairManager{
+		verifier:      verifier,
+		healthChecker: healthChecker,
+		proxyAddr:     proxyAddr,
+		client:        &http.Client{},
+		interval:      defaultRepairInterval,
+		stopCh:        stopCh,
+	}
+}
+
+// Run starts RepairManager
+func (m *RepairManager) Run() {
+	go wait.JitterUntil(m.repair, m.interval, 0.5, true, m.stopCh)
+}
+
+func (m *RepairManager) repair() {
+	keys, err := m.verifier.ListQuarantinedKeys()
+	if errors.Is(err, storage.ErrVerifyNotSupported) {
+		klog.V(4).Infof("storage does not support integrity verification, skip repairing cache")
+		return
+	} else if err != nil {
+		klog.Errorf("could not list quarantined keys, %v", err)
+		return
+	} else if len(keys) == 0 {
+		return
+	}
+
+	repaired := 0
+	for _, key := range keys {
+		// check the health status for each key, because the response of yurthub proxy server
+		// comes from local cache instead of cloud when cloud is unhealthy.
+		if !m.healthChecker.IsHealthy() {
+			klog.V(2).Infof("cloud kube-apiserver is unhealthy, skip repairing %d quarantined objects", len(keys)-repaired)
+			return
+		}
+
+		if err := m.refetch(key); err != nil {
+			klog.Warningf("could not re-fetch quarantined object %s, %v", key.Key(), err)
+			continue
+		}
+		if err := m.verifier.DeleteQuarantinedKey(key); err != nil {
+			klog.Errorf("could not delete quarantined key %s, %v", key.Key(), err)
+			continue
+		}
+		repaired++
+	}
+	klog.Infof("repaired %d of %d quarantined objects in local cache", repaired, len(keys))
+}
+
+// refetch gets the object of key through yurthub proxy server, and the object will be cached
+// by cache manager when the response is received. If the object has been deleted in the cloud,
+// there's nothing need to be repaired, so nil is returned too.
+func (m *RepairManager) refetch(key storage.Key) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+	req, err := m.newRequest(ctx, key)
+	if err != nil {
+		klog.Warningf("unrecognized quarantined key %s, drop it, %v", key.Key(), err)
+		return nil
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// the object is cached when the response body is read.
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+}
+
+// newRequest builds a get request for the object of key in the format of
+// <Component>/<Resource.Version.Group>/[<Namespace>/]<Name>, and component may be
+// attached with convert gvk for partial object metadata requests.
+func (m *RepairManager) newRequest(ctx context.Context, key storage.Key) (*http.Request, error) {
+	comp, gvr, ns, name, ok := utils.ParseObjectKey(key.Key())
+	if !ok {
+		return nil, storage.ErrUnrecognizedKey
+	}
+
+	accept := "application/json"
+	if agent, convert, found := strings.Cut(comp, "/"); found {
+		convertGVR, ok := utils.ParseResourceSegment(convert)
+		if !ok {
+			return nil, storage.ErrUnrecognizedKey
+		}
+		comp = agent
+		accept = fmt.Sprintf("application/json;as=PartialObjectMetadata;g=%s;v=%s", convertGVR.Group, convertGVR.Version)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s%s", m.proxyAddr, objectPath(gvr, ns, name)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", comp)
+	req.Header.Set("Accept", accept)
+	return req, nil
+}
+
+func objectPath(gvr schema.GroupVersionResource, namespace, name string) string {
+	prefix := path.Join("/apis", gvr.Group, gvr.Version)
+	if gvr.Group == "" {
+		prefix = path.Join("/api", gvr.Version)
+	}
+	if namespace != "" {
+		return path.Join(prefix, "namespaces", namespace, gvr.Resource, name)
+	}
+	return path.Join(prefix, gvr.Resource, name)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repair
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker/fake"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+)
+
+type fakeKey string
+
+func (k fakeKey) Key() string {
+	return string(k)
+}
+
+type fakeVerifier struct {
+	sync.Mutex
+	keys map[string]struct{}
+}
+
+func (v *fakeVerifier) Verify() (*storage.VerifyResult, error) {
+	return &storage.VerifyResult{}, nil
+}
+
+func (v *fakeVerifier) ListQuarantinedKeys() ([]storage.Key, error) {
+	v.Lock()
+	defer v.Unlock()
+	keys := make([]storage.Key, 0, len(v.keys))
+	for k := range v.keys {
+		keys = append(keys, fakeKey(k))
+	}
+	return keys, nil
+}
+
+func (v *fakeVerifier) DeleteQuarantinedKey(key storage.Key) error {
+	v.Lock()
+	defer v.Unlock()
+	delete(v.keys, key.Key())
+	return nil
+}
+
+func (v *fakeVerifier) remainingKeys() []string {
+	v.Lock()
+	defer v.Unlock()
+	keys := make([]string, 0, len(v.keys))
+	for k := range v.keys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestRepair(t *testing.T) {
+	type request struct {
+		userAgent string
+		accept    string
+	}
+	var mu sync.Mutex
+	requests := map[string]request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path] = request{userAgent: r.UserAgent(), accept: r.Header.Get("Accept")}
+		mu.Unlock()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/gone"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/forbidden"):
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+
+	testcases := map[string]struct {
+		healthy         bool
+		keys            []string
+		expectRequests  map[string]request
+		expectRemaining []string
+	}{
+		"repair quarantined objects when cloud is healthy": {
+			healthy: true,
+			keys: []string{
+				"kubelet/pods.v1.core/default/foo",
+				"kube-proxy/endpointslices.v1.discovery.k8s.io/default/gone",
+				"kubelet/nodes.v1.core/forbidden",
+				"coredns/partialobjectmetadatas.v1.meta.k8s.io/services.v1.core/default/bar",
+				"kubelet/legacy",
+			},
+			expectRequests: map[string]request{
+				"/api/v1/namespaces/default/pods/foo":                              {userAgent: "kubelet", accept: "application/json"},
+				"/apis/discovery.k8s.io/v1/namespaces/default/endpointslices/gone": {userAgent: "kube-proxy", accept: "application/json"},
+				"/api/v1/nodes/forbidden":                                          {userAgent: "kubelet", accept: "application/json"},
+				"/api/v1/namespaces/default/services/bar":                          {userAgent: "coredns", accept: "application/json;as=PartialObjectMetadata;g=meta.k8s.io;v=v1"},
+			},
+			expectRemaining: []string{"kubelet/nodes.v1.core/forbidden"},
+		},
+		"skip repairing when cloud is unhealthy": {
+			healthy:         false,
+			keys:            []string{"kubelet/pods.v1.core/default/foo"},
+			expectRequests:  map[string]request{},
+			expectRemaining: []string{"kubelet/pods.v1.core/default/foo"},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			mu.Lock()
+			requests = map[string]request{}
+			mu.Unlock()
+			verifier := &fakeVerifier{keys: map[string]struct{}{}}
+			for _, key := range tc.keys {
+				verifier.keys[key] = struct{}{}
+			}
+			checker := fake.NewFakeChecker(map[*url.URL]bool{serverURL: tc.healthy})
+
+			m := NewRepairManager(verifier, checker, serverURL.Host, make(chan struct{}))
+			m.repair()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !reflect.DeepEqual(requests, tc.expectRequests) {
+				t.Errorf("expect requests %v, but got %v", tc.expectRequests, requests)
+			}
+			if remaining := verifier.remainingKeys(); !reflect.DeepEqual(remaining, tc.expectRemaining) {
+				t.Errorf("expect remaining keys %v, but got %v", tc.expectRemaining, remaining)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
+	otautil "github.com/openyurtio/openyurt/pkg/yurthub/otaupdate/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/utils"
+)
+
+const (
+	redactedValue            = "REDACTED"
+	lastAppliedConfigAnnoKey = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// ComponentCache describes resources cached for a component.
+type ComponentCache struct {
+	// Component is the name of component, like kubelet.
+	Component string `json:"component"`
+	// Resources contains all resources cached for the component.
+	Resources []ResourceCache `json:"resources"`
+}
+
+// ResourceCache describes objects of a resource cached for a component.
+type ResourceCache struct {
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	Resource string `json:"resource"`
+	// Count is the number of cached objects.
+	Count int `json:"count"`
+	// LastUpdateTime is the time when the objects were updated at last, it's
+	// nil when the store can not provide the last update time.
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// ObjectCache describes a cached object.
+type ObjectCache struct {
+	Namespace      string       `json:"namespace,omitempty"`
+	Name           string       `json:"name"`
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// listCachedComponents returns all components which have resources cached in the local cache,
+// and the object count and last update time of each resource.
+func listCachedComponents(sw cachemanager.StorageWrapper) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		components, err := sw.ListComponents()
+		if err != nil {
+			klog.Errorf("could not list cached components, %v", err)
+			otautil.WriteErr(w, fmt.Sprintf("could not list cached components, %v", err), http.StatusInternalServerError)
+			return
+		}
+		sort.Strings(components)
+
+		inspector, _ := sw.GetStorage().(storage.Inspector)
+		result := make([]ComponentCache, 0, len(components))
+		for _, comp := range components {
+			gvrs, err := sw.ListResourcesOfComponent(comp)
+			if err != nil {
+				klog.Errorf("could not list cached resources of component %s, %v", comp, err)
+				otautil.WriteErr(w, fmt.Sprintf("could not list cached resources of component %s, %v", comp, err), http.StatusInternalServerError)
+				return
+			}
+			sort.Slice(gvrs, func(i, j int) bool {
+				return gvrs[i].String() < gvrs[j].String()
+			})
+
+			componentCache := ComponentCache{Component: comp, Resources: make([]ResourceCache, 0, len(gvrs))}
+			for _, gvr := range gvrs {
+				keys, err := sw.ListResourceKeysOfComponent(comp, gvr)
+				if err != nil && !errors.Is(err, storage.ErrStorageNotFound) {
+					klog.Errorf("could not list cached keys of %s for component %s, %v", gvr.String(), comp, err)
+					otautil.WriteErr(w, fmt.Sprintf("could not list cached keys of %s for component %s, %v", gvr.String(), comp, err), http.StatusInternalServerError)
+					return
+				}
+
+				resourceCache := ResourceCache{
+					Group:    gvr.Group,
+					Version:  gvr.Version,
+					Resource: gvr.Resource,
+					Count:    len(keys),
+				}
+				for _, key := range keys {
+					t := lastUpdateTime(inspector, key)
+					if t != nil && (resourceCache.LastUpdateTime == nil || resourceCache.LastUpdateTime.Before(t)) {
+						resourceCache.LastUpdateTime = t
+					}
+				}
+				componentCache.Resources = append(componentCache.Resources, resourceCache)
+			}
+			result = append(result, componentCache)
+		}
+
+		writeJSON(w, result)
+	})
+}
+
+// listCachedObjects returns all objects of a resource cached for a component.
+func listCachedObjects(sw cachemanager.StorageWrapper) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		comp, gvr, err := componentResourceFrom(r)
+		if err != nil {
+			otautil.WriteErr(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		namespace := r.URL.Query().Get("namespace")
+
+		keys, err := sw.ListResourceKeysOfComponent(comp, gvr)
+		if errors.Is(err, storage.ErrStorageNotFound) {
+			otautil.WriteErr(w, fmt.Sprintf("%s is not cached for component %s", gvr.String(), comp), http.StatusNotFound)
+			return
+		} else if err != nil {
+			klog.Errorf("could not list cached keys of %s for component %s, %v", gvr.String(), comp, err)
+			otautil.WriteErr(w, fmt.Sprintf("could not list cached keys of %s for component %s, %v", gvr.String(), comp, err), http.StatusInternalServerError)
+			return
+		}
+
+		inspector, _ := sw.GetStorage().(storage.Inspector)
+		result := make([]ObjectCache, 0, len(keys))
+		for _, key := range keys {
+			objectCache := ObjectCache{Name: key.Key(), LastUpdateTime: lastUpdateTime(inspector, key)}
+			if _, _, ns, name, ok := utils.ParseObjectKey(key.Key()); ok {
+				objectCache.Namespace = ns
+				objectCache.Name = name
+			}
+			if len(namespace) != 0 && objectCache.Namespace != namespace {
+				continue
+			}
+			result = append(result, objectCache)
+		}
+		sort.Slice(result, func(i, j int) bool {
+			if result[i].Namespace != result[j].Namespace {
+				return result[i].Namespace < result[j].Namespace
+			}
+			return result[i].Name < result[j].Name
+		})
+
+		writeJSON(w, result)
+	})
+}
+
+// sensitiveResourceSet returns secrets and sensitiveResources(like resources which are encrypted in
+// the local cache), and objects of them are redacted when they are inspected.
+func sensitiveResourceSet(sensitiveResources []schema.GroupVersionResource) sets.Set[schema.GroupResource] {
+	sensitive := sets.New[schema.GroupResource](schema.GroupResource{Resource: "secrets"})
+	for _, gvr := range sensitiveResources {
+		sensitive.Insert(gvr.GroupResource())
+	}
+	return sensitive
+}
+
+// getCachedObject returns a cached object, and objects of secrets and sensitiveResources are redacted.
+// the object is read from the backend storage directly, so inspecting the object doesn't mark it as
+// used recently for the eviction of cache quota.
+func getCachedObject(sw cachemanager.StorageWrapper, sensitiveResources []schema.GroupVersionResource) http.Handler {
+	sensitive := sensitiveResourceSet(sensitiveResources)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		comp, gvr, err := componentResourceFrom(r)
+		if err != nil {
+			otautil.WriteErr(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		namespace, name := r.URL.Query().Get("namespace"), r.URL.Query().Get("name")
+		if len(name) == 0 {
+			otautil.WriteErr(w, "name is not specified", http.StatusBadRequest)
+			return
+		}
+
+		key, err := sw.KeyFunc(storage.KeyBuildInfo{
+			Component: comp,
+			Namespace: namespace,
+			Name:      name,
+			Resources: gvr.Resource,
+			Group:     gvr.Group,
+			Version:   gvr.Version,
+		})
+		if err != nil {
+			otautil.WriteErr(w, fmt.Sprintf("could not get cache key, %v", err), http.StatusBadRequest)
+			return
+		}
+
+		data, err := sw.GetStorage().Get(key)
+		if errors.Is(err, storage.ErrStorageNotFound) || (err == nil && len(data) == 0) {
+			otautil.WriteErr(w, fmt.Sprintf("%s is not cached", key.Key()), http.StatusNotFound)
+			return
+		} else if err != nil {
+			klog.Errorf("could not get cached object %s, %v", key.Key(), err)
+			otautil.WriteErr(w, fmt.Sprintf("could not get cached object %s, %v", key.Key(), err), http.StatusInternalServerError)
+			return
+		}
+		obj := &unstructured.Unstructured{}
+		if err := json.Unmarshal(data, &obj.Object); err != nil {
+			otautil.WriteErr(w, fmt.Sprintf("could not decode cached object %s, %v", key.Key(), err), http.StatusInternalServerError)
+			return
+		}
+
+		content, err := redactedContent(obj, sensitive.Has(gvr.GroupResource()))
+		if err != nil {
+			otautil.WriteErr(w, fmt.Sprintf("could not convert cached object %s, %v", key.Key(), err), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, content)
+	})
+}
+
+// componentResourceFrom gets component and gvr from query parameters of request.
+func componentResourceFrom(r *http.Request) (string, schema.GroupVersionResource, error) {
+	query := r.URL.Query()
+	comp := query.Get("component")
+	gvr := schema.GroupVersionResource{
+		Group:    query.Get("group"),
+		Version:  query.Get("version"),
+		Resource: query.Get("resource"),
+	}
+	if len(comp) == 0 {
+		return "", gvr, errors.New("component is not specified")
+	}
+	if len(gvr.Resource) == 0 || len(gvr.Version) == 0 {
+		return "", gvr, errors.New("resource and version are not specified")
+	}
+	return comp, gvr, nil
+}
+
+func lastUpdateTime(inspector storage.Inspector, key storage.Key) *metav1.Time {
+	if inspector == nil {
+		return nil
+	}
+	t, err := inspector.LastUpdateTime(key)
+	if err != nil {
+		return nil
+	}
+	mt := metav1.NewTime(t)
+	return &mt
+}
+
+// redactedContent converts object into unstructured content. If the object is sensitive, like secrets and
+// resources which are encrypted in the local cache, all fields except apiVersion, kind and metadata are redacted,
+// only keys of map fields like data of secrets are kept, and last applied configuration which may contain the
+// sensitive data is removed.
+func redactedContent(obj runtime.Object, sensitive bool) (map[string]interface{}, error) {
+	var content map[string]interface{}
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		content = u.DeepCopy().UnstructuredContent()
+	} else {
+		var err error
+		if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+			return nil, err
+		}
+	}
+
+	if !sensitive {
+		return content, nil
+	}
+	for field, value := range content {
+		switch field {
+		case "apiVersion", "kind", "metadata":
+			continue
+		}
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			content[field] = redactedValue
+			continue
+		}
+		for k := range data {
+			data[k] = redactedValue
+		}
+	}
+	unstructured.RemoveNestedField(content, "metadata", "annotations", lastAppliedConfigAnnoKey)
+	return content, nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		otautil.WriteErr(w, fmt.Sprintf("could not encode response, %v", err), http.StatusInternalServerError)
+		return
+	}
+	otautil.WriteJSONResponse(w, data)
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/disk"
+)
+
+// directReadStorageWrapper fails the test when objects are read through the storage wrapper,
+// because reading through it marks the objects as used recently for cache quota.
+type directReadStorageWrapper struct {
+	cachemanager.StorageWrapper
+	t *testing.T
+}
+
+func (sw *directReadStorageWrapper) Get(key storage.Key) (runtime.Object, error) {
+	sw.t.Errorf("expect %s is read from backend storage directly", key.Key())
+	return sw.StorageWrapper.Get(key)
+}
+
+func TestInspectCache(t *testing.T) {
+	store, err := disk.NewDiskStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("could not new disk storage, %v", err)
+	}
+	sw := cachemanager.NewStorageWrapper(store)
+
+	objs := []struct {
+		resource string
+		obj      runtime.Object
+	}{
+		{
+			resource: "pods",
+			obj: &v1.Pod{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", ResourceVersion: "1"},
+			},
+		},
+		{
+			resource: "pods",
+			obj: &v1.Pod{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "kube-system", ResourceVersion: "2"},
+			},
+		},
+		{
+			resource: "secrets",
+			obj: &v1.Secret{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "token",
+					Namespace:       "default",
+					ResourceVersion: "3",
+					Annotations:     map[string]string{lastAppliedConfigAnnoKey: `{"data":{"token":"c2VjcmV0"}}`, "foo": "bar"},
+				},
+				Data: map[string][]byte{"token": []byte("secret")},
+			},
+		},
+	}
+	for _, o := range objs {
+		accessor, _ := o.obj.(metav1.Object)
+		key, err := sw.KeyFunc(storage.KeyBuildInfo{
+			Component: "kubelet",
+			Namespace: accessor.GetNamespace(),
+			Name:      accessor.GetName(),
+			Resources: o.resource,
+			Version:   "v1",
+		})
+		if err != nil {
+			t.Fatalf("could not get key, %v", err)
+		}
+		if err := sw.Create(key, o.obj); err != nil {
+			t.Fatalf("could not create object, %v", err)
+		}
+	}
+
+	router := mux.NewRouter()
+	router.Handle("/cache/components", listCachedComponents(sw)).Methods("GET")
+	router.Handle("/cache/objects", listCachedObjects(sw)).Methods("GET")
+	router.Handle("/cache/object", getCachedObject(&directReadStorageWrapper{StorageWrapper: sw, t: t}, []schema.GroupVersionResource{{Version: "v1", Resource: "configmaps"}})).Methods("GET")
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("list components", func(t *testing.T) {
+		resp := serve("/cache/components")
+		if resp.Code != http.StatusOK {
+			t.Fatalf("expect status 200, but got %d, %s", resp.Code, resp.Body.String())
+		}
+		var components []ComponentCache
+		if err := json.Unmarshal(resp.Body.Bytes(), &components); err != nil {
+			t.Fatalf("could not decode response, %v", err)
+		}
+		if len(components) != 1 || components[0].Component != "kubelet" || len(components[0].Resources) != 2 {
+			t.Fatalf("expect 2 resources cached for kubelet, but got %v", components)
+		}
+		for _, res := range components[0].Resources {
+			expectCount := map[string]int{"pods": 2, "secrets": 1}[res.Resource]
+			if res.Count != expectCount || res.LastUpdateTime == nil {
+				t.Errorf("expect %d %s with last update time, but got %#v", expectCount, res.Resource, res)
+			}
+		}
+	})
+
+	t.Run("list objects", func(t *testing.T) {
+		resp := serve("/cache/objects?component=kubelet&version=v1&resource=pods")
+		if resp.Code != http.StatusOK {
+			t.Fatalf("expect status 200, but got %d, %s", resp.Code, resp.Body.String())
+		}
+		var objects []ObjectCache
+		if err := json.Unmarshal(resp.Body.Bytes(), &objects); err != nil {
+			t.Fatalf("could not decode response, %v", err)
+		}
+		names := make([]string, 0, len(objects))
+		for _, obj := range objects {
+			names = append(names, obj.Namespace+"/"+obj.Name)
+		}
+		if !reflect.DeepEqual(names, []string{"default/foo", "kube-system/bar"}) {
+			t.Errorf("expect objects [default/foo kube-system/bar], but got %v", names)
+		}
+
+		if resp := serve("/cache/objects?component=kubelet&version=v1&resource=configmaps"); resp.Code != http.StatusNotFound {
+			t.Errorf("expect status 404 for resource not cached, but got %d", resp.Code)
+		}
+		if resp := serve("/cache/objects?component=kubelet"); resp.Code != http.StatusBadRequest {
+			t.Errorf("expect status 400 for resource not specified, but got %d", resp.Code)
+		}
+	})
+
+	t.Run("get redacted secret", func(t *testing.T) {
+		resp := serve("/cache/object?component=kubelet&version=v1&resource=secrets&namespace=default&name=token")
+		if resp.Code != http.StatusOK {
+			t.Fatalf("expect status 200, but got %d, %s", resp.Code, resp.Body.String())
+		}
+		var secret map[string]interface{}
+		if err := json.Unmarshal(resp.Body.Bytes(), &secret); err != nil {
+			t.Fatalf("could not decode response, %v", err)
+		}
+		if data := secret["data"].(map[string]interface{}); data["token"] != redactedValue {
+			t.Errorf("expect data of secret is redacted, but got %v", data)
+		}
+		annotations := secret["metadata"].(map[string]interface{})["annotations"].(map[string]interface{})
+		if _, ok := annotations[lastAppliedConfigAnnoKey]; ok || annotations["foo"] != "bar" {
+			t.Errorf("expect only last applied configuration is removed, but got %v", annotations)
+		}
+
+		if resp := serve("/cache/object?component=kubelet&version=v1&resource=pods&namespace=default&name=baz"); resp.Code != http.StatusNotFound {
+			t.Errorf("expect status 404 for object not cached, but got %d", resp.Code)
+		}
+	})
+}
+
+func TestRedactedContent(t *testing.T) {
+	configMap := &v1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cfg",
+			Namespace:   "default",
+			Annotations: map[string]string{lastAppliedConfigAnnoKey: `{"data":{"password":"foo"}}`},
+		},
+		Data:       map[string]string{"password": "foo"},
+		BinaryData: map[string][]byte{"cert": []byte("bar")},
+		Immutable:  new(bool),
+	}
+
+	testcases := map[string]struct {
+		sensitive bool
+		expect    map[string]interface{}
+	}{
+		"sensitive object is redacted": {
+			sensitive: true,
+			expect: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": "cfg", "namespace": "default", "annotations": map[string]interface{}{}},
+				"data":       map[string]interface{}{"password": redactedValue},
+				"binaryData": map[string]interface{}{"cert": redactedValue},
+				"immutable":  redactedValue,
+			},
+		},
+		"other object is kept": {
+			expect: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": "cfg", "namespace": "default", "annotations": map[string]interface{}{lastAppliedConfigAnnoKey: `{"data":{"password":"foo"}}`}},
+				"data":       map[string]interface{}{"password": "foo"},
+				"binaryData": map[string]interface{}{"cert": "YmFy"},
+				"immutable":  false,
+			},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			content, err := redactedContent(configMap, tc.sensitive)
+			if err != nil {
+				t.Fatalf("could not redact content, %v", err)
+			}
+			if !reflect.DeepEqual(content, tc.expect) {
+				t.Errorf("expect %v, but got %v", tc.expect, content)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/cmd/yurthub/app/config"
+	yurtutil "github.com/openyurtio/openyurt/pkg/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/filter/objectfilter"
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
+	otautil "github.com/openyurtio/openyurt/pkg/yurthub/otaupdate/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+const (
+	dryRunSourceCache  = "cache"
+	dryRunSourceRemote = "remote"
+)
+
+// FilterDryRun is the result of executing the filter chain approved for a request on the object
+// from local cache or remote server, and only objects which are changed or dropped are listed.
+type FilterDryRun struct {
+	Path      string                      `json:"path"`
+	UserAgent string                      `json:"userAgent"`
+	Source    string                      `json:"source,omitempty"`
+	Filters   []string                    `json:"filters"`
+	Total     int                         `json:"total"`
+	Objects   []objectfilter.ObjectResult `json:"objects"`
+}
+
+// filterDryRun runs the filter chain approved for the get or list request which is specified by
+// query parameters path and userAgent, and returns the effects of each filter. the object is got
+// from local cache or remote server(specified by query parameter source), and remote server is used
+// by default when it's healthy. filters are executed on copies of object, so nothing is changed.
+// changes of secrets and sensitiveResources are redacted in the same way as getCachedObject.
+func filterDryRun(cfg *config.YurtHubConfiguration, healthChecker healthchecker.Interface, sensitiveResources []schema.GroupVersionResource) http.Handler {
+	sensitive := sensitiveResourceSet(sensitiveResources)
+	resolver := genericapiserver.NewRequestInfoResolver(&genericapiserver.Config{
+		LegacyAPIGroupPrefixes: sets.NewString(genericapiserver.DefaultLegacyAPIPrefix),
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		path, userAgent, source := query.Get("path"), query.Get("userAgent"), query.Get("source")
+		if !strings.HasPrefix(path, "/") || len(userAgent) == 0 {
+			otautil.WriteErr(w, "path and userAgent should be specified, and path should start with /", http.StatusBadRequest)
+			return
+		}
+
+		req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, path, nil)
+		if err != nil {
+			otautil.WriteErr(w, fmt.Sprintf("invalid path %q, %v", path, err), http.StatusBadRequest)
+			return
+		}
+		req.Header.Set("User-Agent", userAgent)
+		info, err := resolver.NewRequestInfo(req)
+		if err != nil || !info.IsResourceRequest || (info.Verb != "get" && info.Verb != "list") {
+			otautil.WriteErr(w, fmt.Sprintf("path %q is not a get or list request of resource", path), http.StatusBadRequest)
+			return
+		}
+		ctx := apirequest.WithRequestInfo(req.Context(), info)
+		ctx = util.WithClientComponent(ctx, strings.ToLower(userAgent))
+		req = req.WithContext(ctx)
+
+		result := FilterDryRun{Path: path, UserAgent: userAgent, Filters: []string{}, Objects: []objectfilter.ObjectResult{}}
+		objFilter, ok := cfg.FilterFinder.FindObjectFilter(req)
+		if !ok {
+			writeJSON(w, result)
+			return
+		}
+		for _, f := range objectfilter.Filters(objFilter) {
+			result.Filters = append(result.Filters, f.Name())
+		}
+
+		if len(source) == 0 {
+			source = dryRunSourceCache
+			if !yurtutil.IsNil(healthChecker) && healthChecker.IsHealthy() {
+				source = dryRunSourceRemote
+			}
+		}
+		var obj runtime.Object
+		var status int
+		switch source {
+		case dryRunSourceCache:
+			obj, status, err = objectFromCache(cfg, req)
+		case dryRunSourceRemote:
+			obj, status, err = objectFromRemote(cfg, healthChecker, req, info)
+		default:
+			otautil.WriteErr(w, fmt.Sprintf("invalid parameter source %q, only cache and remote are supported", source), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			otautil.WriteErr(w, err.Error(), status)
+			return
+		}
+		result.Source = source
+
+		result.Total = 1
+		if meta.IsListType(obj) {
+			result.Total = meta.LenList(obj)
+		}
+		objects, err := objectfilter.DryRun(objFilter, obj, r.Context().Done())
+		if err != nil {
+			klog.Errorf("could not dry run filters %v for %s, %v", result.Filters, util.ReqString(req), err)
+			otautil.WriteErr(w, fmt.Sprintf("could not dry run filters, %v", err), http.StatusInternalServerError)
+			return
+		}
+		if sensitive.Has(schema.GroupResource{Group: info.APIGroup, Resource: info.Resource}) {
+			redactChanges(objects)
+		}
+		result.Objects = objects
+		writeJSON(w, result)
+	})
+}
+
+func objectFromCache(cfg *config.YurtHubConfiguration, req *http.Request) (runtime.Object, int, error) {
+	if yurtutil.IsNil(cfg.CacheManager) {
+		return nil, http.StatusBadRequest, fmt.Errorf("local cache is not available in %s mode", cfg.WorkingMode)
+	}
+	obj, err := cfg.CacheManager.QueryCache(req)
+	if err != nil {
+		return nil, http.StatusNotFound, fmt.Errorf("could not get object from local cache, %v", err)
+	}
+	return obj, http.StatusOK, nil
+}
+
+// objectFromRemote gets object from a healthy remote server, the request is sent directly,
+// so the response is neither cached nor filtered.
+func objectFromRemote(cfg *config.YurtHubConfiguration, healthChecker healthchecker.Interface, req *http.Request, info *apirequest.RequestInfo) (runtime.Object, int, error) {
+	var server *url.URL
+	if !yurtutil.IsNil(healthChecker) {
+		server = healthChecker.PickOneHealthyBackend()
+	}
+	if server == nil {
+		return nil, http.StatusServiceUnavailable, fmt.Errorf("no healthy remote server")
+	}
+
+	remoteReq := req.Clone(req.Context())
+	remoteReq.URL = server.ResolveReference(&url.URL{Path: req.URL.Path, RawQuery: req.URL.RawQuery})
+	remoteReq.Host = remoteReq.URL.Host
+	remoteReq.RequestURI = ""
+	remoteReq.Header.Set("Accept", runtime.ContentTypeJSON)
+	resp, err := cfg.TransportAndDirectClientManager.CurrentTransport().RoundTrip(remoteReq)
+	if err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("could not get object from %s, %v", server.Host, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("could not read response from %s, %v", server.Host, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode, fmt.Errorf("could not get object from %s, %s", server.Host, string(body))
+	}
+
+	s := cfg.SerializerManager.CreateSerializer(runtime.ContentTypeJSON, info.APIGroup, info.APIVersion, info.Resource)
+	if s == nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("could not create serializer for %s", util.ReqInfoString(info))
+	}
+	obj, err := s.Decode(body)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("could not decode response from %s, %v", server.Host, err)
+	}
+	return obj, http.StatusOK, nil
+}
+
+// redactChanges redacts values of changes of sensitive objects like redactedContent does, only changes
+// of apiVersion, kind and metadata except annotations are kept, because the last applied configuration
+// in annotations may contain the sensitive data.
+func redactChanges(objects []objectfilter.ObjectResult) {
+	for i := range objects {
+		for j := range objects[i].Effects {
+			changes := objects[i].Effects[j].Changes
+			for k := range changes {
+				if !isSensitivePath(changes[k].Path) {
+					continue
+				}
+				if changes[k].Old != nil {
+					changes[k].Old = redactedValue
+				}
+				if changes[k].New != nil {
+					changes[k].New = redactedValue
+				}
+			}
+		}
+	}
+}
+
+// isSensitivePath returns true if the change of path may carry sensitive data of object.
+func isSensitivePath(path string) bool {
+	switch {
+	case path == "/apiVersion" || path == "/kind":
+		return false
+	case path == "/metadata" || strings.HasPrefix(path, "/metadata/annotations"):
+		return true
+	case strings.HasPrefix(path, "/metadata/"):
+		return false
+	}
+	return true
+}
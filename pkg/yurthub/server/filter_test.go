@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/openyurtio/openyurt/cmd/yurthub/app/config"
+	"github.com/openyurtio/openyurt/pkg/yurthub/cachemanager"
+	"github.com/openyurtio/openyurt/pkg/yurthub/filter"
+	"github.com/openyurtio/openyurt/pkg/yurthub/filter/objectfilter"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+type clusterIPFilter struct{}
+
+func (f clusterIPFilter) Name() string {
+	return "clusterip"
+}
+
+func (f clusterIPFilter) Filter(obj runtime.Object, _ <-chan struct{}) runtime.Object {
+	switch o := obj.(type) {
+	case *v1.Service:
+		o.Spec.ClusterIP = "169.254.2.1"
+	case *v1.Secret:
+		o.Data["token"] = []byte("filtered")
+	case *v1.ConfigMap:
+		o.Data["password"] = "filtered"
+		o.Labels = map[string]string{"filtered": "true"}
+	}
+	return obj
+}
+
+type fakeFilterFinder struct {
+	filter.FilterFinder
+}
+
+func (f fakeFilterFinder) FindObjectFilter(req *http.Request) (filter.ObjectFilter, bool) {
+	comp, _ := util.ClientComponentFrom(req.Context())
+	if strings.Split(comp, "/")[0] != "kube-proxy" {
+		return nil, false
+	}
+	return objectfilter.CreateFilterChain([]filter.ObjectFilter{clusterIPFilter{}}), true
+}
+
+type fakeCacheManager struct {
+	cachemanager.CacheManager
+	objs map[string]runtime.Object
+}
+
+func (cm fakeCacheManager) QueryCache(req *http.Request) (runtime.Object, error) {
+	info, _ := apirequest.RequestInfoFrom(req.Context())
+	obj, ok := cm.objs[info.Resource]
+	if !ok {
+		return nil, storage.ErrStorageNotFound
+	}
+	return obj.DeepCopyObject(), nil
+}
+
+func TestFilterDryRun(t *testing.T) {
+	cfg := &config.YurtHubConfiguration{
+		FilterFinder: fakeFilterFinder{},
+		CacheManager: fakeCacheManager{objs: map[string]runtime.Object{
+			"services": &v1.ServiceList{Items: []v1.Service{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}, Spec: v1.ServiceSpec{ClusterIP: "10.96.0.10"}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bar"}, Spec: v1.ServiceSpec{ClusterIP: "169.254.2.1"}},
+			}},
+			"secrets": &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "token"},
+				Data:       map[string][]byte{"token": []byte("secret")},
+			},
+			"configmaps": &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "password"},
+				Data:       map[string]string{"password": "secret"},
+			},
+		}},
+	}
+	handler := filterDryRun(cfg, nil, []schema.GroupVersionResource{{Version: "v1", Resource: "configmaps"}})
+
+	testcases := map[string]struct {
+		path       string
+		userAgent  string
+		source     string
+		expectCode int
+		expect     *FilterDryRun
+	}{
+		"changes of list items are returned": {
+			path:       "/api/v1/services",
+			userAgent:  "kube-proxy/v1.28.0",
+			expectCode: http.StatusOK,
+			expect: &FilterDryRun{
+				Path:      "/api/v1/services",
+				UserAgent: "kube-proxy/v1.28.0",
+				Source:    "cache",
+				Filters:   []string{"clusterip"},
+				Total:     2,
+				Objects: []objectfilter.ObjectResult{
+					{
+						Namespace: "default",
+						Name:      "foo",
+						Effects: []objectfilter.Effect{
+							{Filter: "clusterip", Changes: []objectfilter.Change{{Path: "/spec/clusterIP", Old: "10.96.0.10", New: "169.254.2.1"}}},
+						},
+					},
+				},
+			},
+		},
+		"data of secrets is redacted": {
+			path:       "/api/v1/namespaces/default/secrets/token",
+			userAgent:  "kube-proxy",
+			expectCode: http.StatusOK,
+			expect: &FilterDryRun{
+				Path:      "/api/v1/namespaces/default/secrets/token",
+				UserAgent: "kube-proxy",
+				Source:    "cache",
+				Filters:   []string{"clusterip"},
+				Total:     1,
+				Objects: []objectfilter.ObjectResult{
+					{
+						Namespace: "default",
+						Name:      "token",
+						Effects: []objectfilter.Effect{
+							{Filter: "clusterip", Changes: []objectfilter.Change{{Path: "/data/token", Old: redactedValue, New: redactedValue}}},
+						},
+					},
+				},
+			},
+		},
+		"data of encrypted resources is redacted": {
+			path:       "/api/v1/namespaces/default/configmaps/password",
+			userAgent:  "kube-proxy",
+			expectCode: http.StatusOK,
+			expect: &FilterDryRun{
+				Path:      "/api/v1/namespaces/default/configmaps/password",
+				UserAgent: "kube-proxy",
+				Source:    "cache",
+				Filters:   []string{"clusterip"},
+				Total:     1,
+				Objects: []objectfilter.ObjectResult{
+					{
+						Namespace: "default",
+						Name:      "password",
+						Effects: []objectfilter.Effect{
+							{Filter: "clusterip", Changes: []objectfilter.Change{
+								{Path: "/data/password", Old: redactedValue, New: redactedValue},
+								{Path: "/metadata/labels", New: map[string]interface{}{"filtered": "true"}},
+							}},
+						},
+					},
+				},
+			},
+		},
+		"no filters for component": {
+			path:       "/api/v1/services",
+			userAgent:  "kubelet",
+			expectCode: http.StatusOK,
+			expect: &FilterDryRun{
+				Path:      "/api/v1/services",
+				UserAgent: "kubelet",
+				Filters:   []string{},
+				Objects:   []objectfilter.ObjectResult{},
+			},
+		},
+		"object is not cached": {
+			path:       "/api/v1/endpoints",
+			userAgent:  "kube-proxy",
+			expectCode: http.StatusNotFound,
+		},
+		"no healthy remote server": {
+			path:       "/api/v1/services",
+			userAgent:  "kube-proxy",
+			source:     "remote",
+			expectCode: http.StatusServiceUnavailable,
+		},
+		"watch request is not supported": {
+			path:       "/api/v1/services?watch=true",
+			userAgent:  "kube-proxy",
+			expectCode: http.StatusBadRequest,
+		},
+		"user agent is not specified": {
+			path:       "/api/v1/services",
+			expectCode: http.StatusBadRequest,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			query := url.Values{"path": {tc.path}, "userAgent": {tc.userAgent}}
+			if len(tc.source) != 0 {
+				query.Set("source", tc.source)
+			}
+			req, _ := http.NewRequest("GET", "/filters/dryrun?"+query.Encode(), nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			if resp.Code != tc.expectCode {
+				t.Fatalf("expect status %d, but got %d, %s", tc.expectCode, resp.Code, resp.Body.String())
+			}
+			if tc.expect == nil {
+				return
+			}
+
+			var result FilterDryRun
+			if err := json.Unmarshal(resp.Body.Bytes(), &result); err != nil {
+				t.Fatalf("could not decode response, %v", err)
+			}
+			if !reflect.DeepEqual(&result, tc.expect) {
+				t.Errorf("expect result %+v, but got %+v", tc.expect, result)
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package server
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -30,9 +32,11 @@ import (
 	"github.com/openyurtio/openyurt/cmd/yurthub/app/config"
 	yurtutil "github.com/openyurtio/openyurt/pkg/util"
 	"github.com/openyurtio/openyurt/pkg/util/profile"
+	"github.com/openyurtio/openyurt/pkg/yurthub/dynamicconfig"
 	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
 	ota "github.com/openyurtio/openyurt/pkg/yurthub/otaupdate"
 	otautil "github.com/openyurtio/openyurt/pkg/yurthub/otaupdate/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
 )
 
 // RunYurtHubServers is used to start up all servers for yurthub
@@ -95,6 +99,14 @@ func registerHandlers(c *mux.Router, cfg *config.YurtHubConfiguration, healthChe
 	// register handler for metrics
 	c.Handle("/metrics", promhttp.Handler())
 
+	// register handler for summary of traffic to remote servers
+	c.HandleFunc("/traffic/top", topTraffic).Methods("GET")
+
+	// register handler for status of dynamic config file
+	if cfg.DynamicConfigManager != nil {
+		c.Handle("/v1/config/status", configStatus(cfg.DynamicConfigManager)).Methods("GET")
+	}
+
 	// register handler for ota upgrade
 	if !yurtutil.IsNil(cfg.StorageWrapper) {
 		c.Handle("/pods", ota.GetPods(cfg.StorageWrapper)).Methods("GET")
@@ -107,6 +119,21 @@ func registerHandlers(c *mux.Router, cfg *config.YurtHubConfiguration, healthChe
 
 	c.Handle("/openyurt.io/v1/namespaces/{ns}/pods/{podname}/imagepull",
 		ota.HealthyCheck(healthChecker, cfg.TransportAndDirectClientManager, cfg.NodeName, ota.PullPodImage)).Methods("POST")
+
+	// register handler for verifying integrity of local cache
+	if !yurtutil.IsNil(cfg.StorageWrapper) {
+		c.Handle("/cache/verify", verifyCache(cfg.StorageWrapper.GetStorage())).Methods("POST")
+
+		// register handlers for inspecting local cache
+		c.Handle("/cache/components", listCachedComponents(cfg.StorageWrapper)).Methods("GET")
+		c.Handle("/cache/objects", listCachedObjects(cfg.StorageWrapper)).Methods("GET")
+		c.Handle("/cache/object", getCachedObject(cfg.StorageWrapper, cfg.CacheEncryptionResources)).Methods("GET")
+	}
+
+	// register handler for dry run of filters
+	if !yurtutil.IsNil(cfg.FilterFinder) {
+		c.Handle("/filters/dryrun", filterDryRun(cfg, healthChecker, cfg.CacheEncryptionResources)).Methods("GET")
+	}
 }
 
 // healthz returns ok for healthz request
@@ -150,3 +177,45 @@ func getPodList(sharedFactory informers.SharedInformerFactory) http.Handler {
 		otautil.WriteJSONResponse(w, data)
 	})
 }
+
+// configStatus returns the status of dynamic config file, including the applied generation.
+func configStatus(m *dynamicconfig.Manager) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := json.Marshal(m.Status())
+		if err != nil {
+			otautil.WriteErr(w, fmt.Sprintf("could not encode config status, %v", err), http.StatusInternalServerError)
+			return
+		}
+		otautil.WriteJSONResponse(w, data)
+	})
+}
+
+// verifyCache checks the integrity of local cache, and the corrupted objects will be quarantined
+// and re-fetched from cloud kube-apiserver when it's healthy.
+func verifyCache(store storage.Store) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verifier, ok := store.(storage.Verifier)
+		if !ok {
+			otautil.WriteErr(w, storage.ErrVerifyNotSupported.Error(), http.StatusNotImplemented)
+			return
+		}
+
+		result, err := verifier.Verify()
+		if errors.Is(err, storage.ErrVerifyNotSupported) {
+			otautil.WriteErr(w, err.Error(), http.StatusNotImplemented)
+			return
+		} else if err != nil {
+			klog.Errorf("could not verify local cache, %v", err)
+			otautil.WriteErr(w, fmt.Sprintf("could not verify local cache, %v", err), http.StatusInternalServerError)
+			return
+		}
+		klog.Infof("verified local cache, checked %d objects, skipped %d objects and quarantined %d objects", result.Checked, result.Skipped, len(result.Quarantined))
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			otautil.WriteErr(w, fmt.Sprintf("could not encode verify result, %v", err), http.StatusInternalServerError)
+			return
+		}
+		otautil.WriteJSONResponse(w, data)
+	})
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+	otautil "github.com/openyurtio/openyurt/pkg/yurthub/otaupdate/util"
+)
+
+const defaultTopTraffic = 10
+
+// TopTraffic is the summary of components which drive the most traffic to remote servers in the rolling window.
+type TopTraffic struct {
+	Window string                   `json:"window"`
+	SortBy string                   `json:"sortBy"`
+	Items  []metrics.TrafficSummary `json:"items"`
+}
+
+// topTraffic returns the top n(specified by query parameter n, default 10) traffic summaries
+// sorted by bytes, requests or watch duration(specified by query parameter sort, default bytes).
+func topTraffic(w http.ResponseWriter, r *http.Request) {
+	n := defaultTopTraffic
+	if value := r.URL.Query().Get("n"); len(value) != 0 {
+		var err error
+		if n, err = strconv.Atoi(value); err != nil || n <= 0 {
+			otautil.WriteErr(w, fmt.Sprintf("invalid parameter n %q, it should be a positive integer", value), http.StatusBadRequest)
+			return
+		}
+	}
+	sortBy := r.URL.Query().Get("sort")
+	if len(sortBy) == 0 {
+		sortBy = metrics.TrafficSortByBytes
+	} else if !metrics.IsSupportedTrafficSortBy(sortBy) {
+		otautil.WriteErr(w, fmt.Sprintf("invalid parameter sort %q, only bytes, requests and watch are supported", sortBy), http.StatusBadRequest)
+		return
+	}
+
+	items, window := metrics.Metrics.TopRemoteTraffic(n, sortBy)
+	writeJSON(w, TopTraffic{Window: window.String(), SortBy: sortBy, Items: items})
+}
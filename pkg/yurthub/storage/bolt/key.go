@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bolt
+
+import (
+	"path"
+	"strings"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+)
+
+type storageKey struct {
+	rootKey bool
+	path    string
+}
+
+func (k storageKey) Key() string {
+	return k.path
+}
+
+func (k storageKey) isRootKey() bool {
+	return k.rootKey
+}
+
+// prefix returns the prefix shared by all object keys under this key.
+func (k storageKey) prefix() []byte {
+	return []byte(k.path + "/")
+}
+
+// KeyFunc generates keys in the same layout as the disk storage running in
+// enhancement mode, so keys can be moved between the two stores verbatim:
+// <Component>/<Resource.Version.Group>/<Namespace>/<Name>, or
+// <Component>/<Resource.Version.Group>/<Name>, if there's no namespace provided in info.
+// <Component>/<Resource.Version.Group>/<Namespace>, if there's no name provided in info.
+// <Component>/<Resource.Version.Group>, if there's no namespace and name provided in info.
+func (bs *boltStorage) KeyFunc(info storage.KeyBuildInfo) (storage.Key, error) {
+	if info.Component == "" {
+		return nil, storage.ErrEmptyComponent
+	}
+	if info.Resources == "" {
+		return nil, storage.ErrEmptyResource
+	}
+
+	group := info.Group
+	if info.Group == "" {
+		group = "core"
+	}
+	resource := strings.Join([]string{info.Resources, info.Version, group}, ".")
+
+	var p string
+	if info.Resources == "namespaces" {
+		p = path.Join(info.Component, resource, info.Name)
+	} else {
+		p = path.Join(info.Component, resource, info.Namespace, info.Name)
+	}
+
+	return storageKey{
+		path:    p,
+		rootKey: info.Name == "",
+	}, nil
+}
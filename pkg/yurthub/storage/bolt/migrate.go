@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bolt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/disk"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util/fs"
+)
+
+const (
+	internalDirName = "_internal"
+	// migratedKey is recorded in the meta bucket after the disk cache has been imported.
+	migratedKey = "disk-cache-migrated"
+	// diskCacheRemovedKey is recorded in the meta bucket after the migrated disk cache has been removed.
+	diskCacheRemovedKey = "disk-cache-removed"
+	// migrateBatchSize is the number of entries which are imported in one transaction, so the memory
+	// usage of migration is not proportional to the size of disk cache.
+	migrateBatchSize = 512
+)
+
+// migrateFromDisk imports the cache written by the disk storage under dir into the database
+// on the first start of bolt storage. The disk cache is recovered by the disk storage at first,
+// then all objects, root dirs and cluster info are imported in batched transactions, and the
+// completion marker is committed with the last batch. Files of the disk cache are removed only
+// after the marker has been committed, so an interrupted migration will be restarted from scratch
+// on the next start, and the disk cache left by an interrupted removal is removed on the next start.
+//
+// Note:
+// Objects cached by the disk storage in non-enhancement mode have no version and group in
+// their keys, they can not be mapped into bolt keys and will be skipped.
+func (bs *boltStorage) migrateFromDisk(dir string) error {
+	var migrated, removed bool
+	if err := bs.db.View(func(tx *bolt.Tx) error {
+		migrated = tx.Bucket(metaBucket).Get([]byte(migratedKey)) != nil
+		removed = tx.Bucket(metaBucket).Get([]byte(diskCacheRemovedKey)) != nil
+		return nil
+	}); err != nil {
+		return err
+	}
+	if removed {
+		return nil
+	}
+
+	entries, err := diskCacheEntries(dir)
+	if err != nil {
+		return err
+	}
+	if migrated {
+		return bs.removeDiskCache(dir, entries)
+	}
+
+	if len(entries) != 0 {
+		// let disk storage roll back the writes which were interrupted.
+		if _, err := disk.NewDiskStorage(dir); err != nil {
+			return fmt.Errorf("could not recover disk cache at %s, %v", dir, err)
+		}
+	}
+
+	batch := &migrateBatch{db: bs.db}
+	objectCnt, skippedCnt, err := importDiskCache(dir, entries, batch)
+	if err == nil {
+		err = batch.put(metaBucket, migratedKey, []byte(time.Now().Format(time.RFC3339)))
+	}
+	if err == nil {
+		err = batch.flush()
+	}
+	if err != nil {
+		return fmt.Errorf("could not migrate disk cache at %s, %v", dir, err)
+	}
+	klog.Infof("migrated %d objects(skipped %d) from disk cache at %s into bolt storage", objectCnt, skippedCnt, dir)
+
+	return bs.removeDiskCache(dir, entries)
+}
+
+// importDiskCache puts objects, root dirs and cluster info of disk cache entries into batch.
+func importDiskCache(dir string, entries []string, batch *migrateBatch) (int, int, error) {
+	fsOperator := &fs.FileSystemOperator{}
+	objectCnt, skippedCnt := 0, 0
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry)
+		if ok, err := fs.IsRegularFile(path); err != nil {
+			return 0, 0, err
+		} else if ok {
+			// cluster info files are placed directly under the cache dir.
+			content, err := fsOperator.Read(path)
+			if err != nil {
+				return 0, 0, fmt.Errorf("could not read cluster info %s, %v", path, err)
+			}
+			if err := batch.put(clusterInfoBucket, entry, content); err != nil {
+				return 0, 0, err
+			}
+			continue
+		}
+
+		dirs, err := fsOperator.List(path, fs.ListModeDirs, true)
+		if err != nil {
+			return 0, 0, fmt.Errorf("could not list dirs under %s, %v", path, err)
+		}
+		for _, d := range dirs {
+			key := relativeKey(dir, d)
+			if !isEnhancementKey(key) {
+				continue
+			}
+			if err := batch.put(rootsBucket, key, []byte{}); err != nil {
+				return 0, 0, err
+			}
+		}
+
+		files, err := fsOperator.List(path, fs.ListModeFiles, true)
+		if err != nil {
+			return 0, 0, fmt.Errorf("could not list files under %s, %v", path, err)
+		}
+		for _, f := range files {
+			key := relativeKey(dir, f)
+			if elems := strings.Split(key, "/"); len(elems) < 3 || !isEnhancementKey(key) {
+				klog.Warningf("skip to migrate disk cache %s, unrecognized key format", f)
+				skippedCnt++
+				continue
+			}
+			content, err := fsOperator.Read(f)
+			if err != nil {
+				return 0, 0, fmt.Errorf("could not read file %s, %v", f, err)
+			}
+			if content, err = disk.DecodeContent(content); err != nil {
+				klog.Warningf("skip to migrate disk cache %s, %v", f, err)
+				skippedCnt++
+				continue
+			}
+			if err := batch.put(objectsBucket, key, content); err != nil {
+				return 0, 0, err
+			}
+			objectCnt++
+		}
+	}
+	return objectCnt, skippedCnt, nil
+}
+
+// removeDiskCache removes entries of migrated disk cache, and records that they have been removed, so disk cache
+// written after that(like switching back to the disk storage) is not removed on the next start.
+func (bs *boltStorage) removeDiskCache(dir string, entries []string) error {
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, entry)); err != nil {
+			klog.Errorf("could not remove migrated disk cache %s, %v", filepath.Join(dir, entry), err)
+			return nil
+		}
+	}
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(metaBucket).Put([]byte(diskCacheRemovedKey), []byte(time.Now().Format(time.RFC3339)))
+	})
+}
+
+type migrateItem struct {
+	bucket []byte
+	key    string
+	value  []byte
+}
+
+// migrateBatch buffers entries to import, and commits them in one transaction when the batch is full.
+type migrateBatch struct {
+	db    *bolt.DB
+	items []migrateItem
+}
+
+func (b *migrateBatch) put(bucket []byte, key string, value []byte) error {
+	b.items = append(b.items, migrateItem{bucket: bucket, key: key, value: value})
+	if len(b.items) < migrateBatchSize {
+		return nil
+	}
+	return b.flush()
+}
+
+func (b *migrateBatch) flush() error {
+	if len(b.items) == 0 {
+		return nil
+	}
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		for _, item := range b.items {
+			if err := tx.Bucket(item.bucket).Put([]byte(item.key), item.value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	b.items = b.items[:0]
+	return err
+}
+
+// diskCacheEntries returns names of entries under dir which are written by the disk storage.
+func diskCacheEntries(dir string) ([]string, error) {
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read dir %s, %v", dir, err)
+	}
+
+	entries := make([]string, 0, len(des))
+	for _, de := range des {
+		if de.Name() == internalDirName || de.Name() == DBFileName {
+			continue
+		}
+		entries = append(entries, de.Name())
+	}
+	return entries, nil
+}
+
+func relativeKey(baseDir, path string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(path, baseDir), "/")
+}
+
+// isEnhancementKey checks that key looks like <Component>/<Resource.Version.Group>[/...].
+func isEnhancementKey(key string) bool {
+	elems := strings.Split(key, "/")
+	if len(elems) < 2 {
+		return false
+	}
+	return len(strings.Split(elems[1], ".")) >= 3
+}
@@ -0,0 +1,505 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bolt
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/disk"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/utils"
+)
+
+const (
+	StorageName = "local-bolt"
+	// DBFileName is the name of database file which is created under the cache dir.
+	DBFileName = "cache.db"
+)
+
+var (
+	// objectsBucket holds cached objects keyed by storageKey.
+	objectsBucket = []byte("objects")
+	// rootsBucket holds root keys which have been created explicitly, so that an empty
+	// list of resources can be distinguished from a list that has never been cached.
+	rootsBucket = []byte("roots")
+	// clusterInfoBucket holds cluster info such as version and discovery documents.
+	clusterInfoBucket = []byte("cluster-info")
+	// metaBucket holds metadata of the store itself, such as the migration status.
+	metaBucket = []byte("meta")
+)
+
+// boltStorage is a storage.Store that keeps all cached data in a single bbolt
+// database file instead of one file per object. Every write is done in a bbolt
+// read-write transaction, so ReplaceComponentList either commits completely or
+// leaves the previous list untouched.
+type boltStorage struct {
+	db         *bolt.DB
+	serializer runtime.Serializer
+}
+
+// NewBoltStorage creates a storage.Store for caching data into an embedded key-value database
+// located at dir/cache.db. If dir contains a cache written by the disk storage, it will be
+// imported into the database on the first start.
+func NewBoltStorage(dir string) (storage.Store, error) {
+	if dir == "" {
+		klog.Infof("bolt cache path is empty, set it by default %s", disk.CacheBaseDir)
+		dir = disk.CacheBaseDir
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create cache path %s, %v", dir, err)
+	}
+
+	dbPath := filepath.Join(dir, DBFileName)
+	db, err := bolt.Open(dbPath, 0600, &bolt.Options{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("could not open bolt database %s, %v", dbPath, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{objectsBucket, rootsBucket, clusterInfoBucket, metaBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("could not initialize bolt database %s, %v", dbPath, err)
+	}
+
+	bs := &boltStorage{
+		db:         db,
+		serializer: json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme.Scheme, scheme.Scheme, json.SerializerOptions{}),
+	}
+
+	if err := bs.migrateFromDisk(dir); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return bs, nil
+}
+
+// NewReadOnlyBoltStorage opens the database at dir/cache.db in read-only mode, so it can be used for
+// reading the cache of a stopped yurthub. The disk cache under dir is not imported, and all writes
+// to the returned storage will fail.
+func NewReadOnlyBoltStorage(dir string) (storage.Store, error) {
+	dbPath := filepath.Join(dir, DBFileName)
+	db, err := bolt.Open(dbPath, 0600, &bolt.Options{Timeout: 10 * time.Second, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("could not open bolt database %s in read-only mode, %v", dbPath, err)
+	}
+
+	return &boltStorage{
+		db:         db,
+		serializer: json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme.Scheme, scheme.Scheme, json.SerializerOptions{}),
+	}, nil
+}
+
+// Name will return the name of this storage
+func (bs *boltStorage) Name() string {
+	return StorageName
+}
+
+// Close releases the database file, so it can be opened by others.
+func (bs *boltStorage) Close() error {
+	return bs.db.Close()
+}
+
+// Create will create content of key in the database. If key is a root key,
+// only the root key is recorded so that following List requests can find it.
+func (bs *boltStorage) Create(key storage.Key, content []byte) error {
+	if err := utils.ValidateKey(key, storageKey{}); err != nil {
+		return err
+	}
+	storageKey := key.(storageKey)
+
+	if !storageKey.isRootKey() && len(content) == 0 {
+		return storage.ErrKeyHasNoContent
+	}
+
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		if storageKey.isRootKey() {
+			return tx.Bucket(rootsBucket).Put([]byte(storageKey.Key()), []byte{})
+		}
+
+		objects := tx.Bucket(objectsBucket)
+		if objects.Get([]byte(storageKey.Key())) != nil {
+			return storage.ErrKeyExists
+		}
+		return objects.Put([]byte(storageKey.Key()), content)
+	})
+}
+
+// Delete will delete the content of key. If key is a root key, all objects under it will be deleted.
+func (bs *boltStorage) Delete(key storage.Key) error {
+	if err := utils.ValidateKey(key, storageKey{}); err != nil {
+		return err
+	}
+	storageKey := key.(storageKey)
+
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		if storageKey.isRootKey() {
+			return deleteUnder(tx, storageKey)
+		}
+		return tx.Bucket(objectsBucket).Delete([]byte(storageKey.Key()))
+	})
+}
+
+// Get will get content of the object specified by key.
+// If key is a root key, return ErrKeyHasNoContent.
+func (bs *boltStorage) Get(key storage.Key) ([]byte, error) {
+	if err := utils.ValidateKey(key, storageKey{}); err != nil {
+		return []byte{}, storage.ErrKeyIsEmpty
+	}
+	storageKey := key.(storageKey)
+
+	var buf []byte
+	err := bs.db.View(func(tx *bolt.Tx) error {
+		if storageKey.isRootKey() {
+			if existsUnder(tx, storageKey) {
+				return storage.ErrKeyHasNoContent
+			}
+			return storage.ErrStorageNotFound
+		}
+
+		v := tx.Bucket(objectsBucket).Get([]byte(storageKey.Key()))
+		if v == nil {
+			return storage.ErrStorageNotFound
+		}
+		buf = bytes.Clone(v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// List will get contents of all objects whose keys have the prefix of the key.
+// If nothing has been cached under the key, return ErrStorageNotFound.
+func (bs *boltStorage) List(key storage.Key) ([][]byte, error) {
+	if err := utils.ValidateKey(key, storageKey{}); err != nil {
+		return [][]byte{}, err
+	}
+	storageKey := key.(storageKey)
+
+	bb := make([][]byte, 0)
+	err := bs.db.View(func(tx *bolt.Tx) error {
+		if !storageKey.isRootKey() {
+			v := tx.Bucket(objectsBucket).Get([]byte(storageKey.Key()))
+			if v == nil {
+				return storage.ErrStorageNotFound
+			}
+			bb = append(bb, bytes.Clone(v))
+			return nil
+		}
+
+		if !existsUnder(tx, storageKey) {
+			return storage.ErrStorageNotFound
+		}
+		prefix := storageKey.prefix()
+		c := tx.Bucket(objectsBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			bb = append(bb, bytes.Clone(v))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bb, nil
+}
+
+// Update will update the object pointed by the key only when the rv in argument
+// is fresher than what is stored. It returns the content finally stored for the key.
+func (bs *boltStorage) Update(key storage.Key, content []byte, rv uint64) ([]byte, error) {
+	if err := utils.ValidateKV(key, content, storageKey{}); err != nil {
+		return nil, err
+	}
+	storageKey := key.(storageKey)
+
+	if storageKey.isRootKey() {
+		return nil, storage.ErrIsNotObjectKey
+	}
+
+	var old []byte
+	err := bs.db.Update(func(tx *bolt.Tx) error {
+		objects := tx.Bucket(objectsBucket)
+		v := objects.Get([]byte(storageKey.Key()))
+		if v == nil {
+			return storage.ErrStorageNotFound
+		}
+
+		ok, err := bs.ifFresherThan(v, rv)
+		if err != nil {
+			return fmt.Errorf("could not get rv of key %s, %v", storageKey.Key(), err)
+		}
+		if !ok {
+			old = bytes.Clone(v)
+			return storage.ErrUpdateConflict
+		}
+		return objects.Put([]byte(storageKey.Key()), content)
+	})
+	if err == storage.ErrUpdateConflict {
+		return old, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// ListResourceKeysOfComponent will get keys of all objects of the gvr belonging to the component.
+func (bs *boltStorage) ListResourceKeysOfComponent(component string, gvr schema.GroupVersionResource) ([]storage.Key, error) {
+	rootKey, err := bs.KeyFunc(storage.KeyBuildInfo{
+		Component: component,
+		Resources: gvr.Resource,
+		Group:     gvr.Group,
+		Version:   gvr.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rk := rootKey.(storageKey)
+
+	keys := make([]storage.Key, 0)
+	err = bs.db.View(func(tx *bolt.Tx) error {
+		if !existsUnder(tx, rk) {
+			return storage.ErrStorageNotFound
+		}
+		prefix := rk.prefix()
+		c := tx.Bucket(objectsBucket).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			keys = append(keys, storageKey{path: string(k)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ReplaceComponentList will replace all objects under the root key with contents in
+// one transaction, so a crash in the middle of replacing will keep the old list.
+func (bs *boltStorage) ReplaceComponentList(component string, gvr schema.GroupVersionResource, namespace string, contents map[storage.Key][]byte) error {
+	rootKey, err := bs.KeyFunc(storage.KeyBuildInfo{
+		Component: component,
+		Resources: gvr.Resource,
+		Group:     gvr.Group,
+		Version:   gvr.Version,
+		Namespace: namespace,
+	})
+	if err != nil {
+		return err
+	}
+	storageKey := rootKey.(storageKey)
+
+	for key := range contents {
+		if !strings.HasPrefix(key.Key(), rootKey.Key()) {
+			return storage.ErrInvalidContent
+		}
+	}
+
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		if err := deleteUnder(tx, storageKey); err != nil {
+			return err
+		}
+		if err := tx.Bucket(rootsBucket).Put([]byte(storageKey.Key()), []byte{}); err != nil {
+			return err
+		}
+
+		objects := tx.Bucket(objectsBucket)
+		for key, data := range contents {
+			if err := objects.Put([]byte(key.Key()), data); err != nil {
+				return fmt.Errorf("could not put data of %s, %v", key.Key(), err)
+			}
+			klog.V(4).Infof("[boltStorage] ReplaceComponentList store data at %s", key.Key())
+		}
+		return nil
+	})
+}
+
+// DeleteComponentResources will delete all resources cached for component.
+func (bs *boltStorage) DeleteComponentResources(component string) error {
+	if component == "" {
+		return storage.ErrEmptyComponent
+	}
+
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		return deleteUnder(tx, storageKey{path: component, rootKey: true})
+	})
+}
+
+// ListComponents will get names of all components which have objects or root keys in the database.
+func (bs *boltStorage) ListComponents() ([]string, error) {
+	components := make([]string, 0)
+	err := bs.db.View(func(tx *bolt.Tx) error {
+		seen := map[string]struct{}{}
+		for _, name := range [][]byte{rootsBucket, objectsBucket} {
+			c := tx.Bucket(name).Cursor()
+			for k, _ := c.First(); k != nil; {
+				comp, _, _ := strings.Cut(string(k), "/")
+				if _, ok := seen[comp]; !ok {
+					seen[comp] = struct{}{}
+					components = append(components, comp)
+				}
+				// '0' is the next byte of '/', so seeking to it skips all keys of the component.
+				k, _ = c.Seek([]byte(comp + "0"))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(components)
+	return components, nil
+}
+
+// ListResourcesOfComponent will get gvrs of all objects and root keys of the component in the database.
+func (bs *boltStorage) ListResourcesOfComponent(component string) ([]schema.GroupVersionResource, error) {
+	if component == "" {
+		return nil, storage.ErrEmptyComponent
+	}
+
+	prefix := component + "/"
+	gvrs := make([]schema.GroupVersionResource, 0)
+	err := bs.db.View(func(tx *bolt.Tx) error {
+		seen := map[string]struct{}{}
+		for _, name := range [][]byte{rootsBucket, objectsBucket} {
+			c := tx.Bucket(name).Cursor()
+			for k, _ := c.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); {
+				segment, _, _ := strings.Cut(strings.TrimPrefix(string(k), prefix), "/")
+				if _, ok := seen[segment]; !ok {
+					seen[segment] = struct{}{}
+					if gvr, ok := utils.ParseResourceSegment(segment); ok {
+						gvrs = append(gvrs, gvr)
+					}
+				}
+				k, _ = c.Seek([]byte(prefix + segment + "0"))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(gvrs) == 0 {
+		return nil, storage.ErrStorageNotFound
+	}
+	sort.Slice(gvrs, func(i, j int) bool { return gvrs[i].String() < gvrs[j].String() })
+	return gvrs, nil
+}
+
+func (bs *boltStorage) SaveClusterInfo(key storage.Key, content []byte) error {
+	if key.Key() == "" {
+		return storage.ErrUnknownClusterInfoType
+	}
+
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(clusterInfoBucket).Put([]byte(key.Key()), content)
+	})
+}
+
+func (bs *boltStorage) GetClusterInfo(key storage.Key) ([]byte, error) {
+	if key.Key() == "" {
+		return nil, storage.ErrUnknownClusterInfoType
+	}
+
+	var buf []byte
+	err := bs.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(clusterInfoBucket).Get([]byte(key.Key()))
+		if v == nil {
+			return storage.ErrStorageNotFound
+		}
+		buf = bytes.Clone(v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func (bs *boltStorage) ifFresherThan(oldObj []byte, newRV uint64) (bool, error) {
+	unstructuredObj := &unstructured.Unstructured{}
+	curObj, _, err := bs.serializer.Decode(oldObj, nil, unstructuredObj)
+	if err != nil {
+		return false, fmt.Errorf("could not decode obj, %v", err)
+	}
+	curRv, err := disk.ObjectResourceVersion(curObj)
+	if err != nil {
+		return false, fmt.Errorf("could not get rv of obj, %v", err)
+	}
+	if newRV < curRv {
+		return false, nil
+	}
+	return true, nil
+}
+
+// existsUnder checks whether the root key has been created explicitly, or whether any
+// object or root key has been stored under it.
+func existsUnder(tx *bolt.Tx, key storageKey) bool {
+	if tx.Bucket(rootsBucket).Get([]byte(key.Key())) != nil {
+		return true
+	}
+
+	prefix := key.prefix()
+	for _, name := range [][]byte{rootsBucket, objectsBucket} {
+		k, _ := tx.Bucket(name).Cursor().Seek(prefix)
+		if k != nil && bytes.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteUnder removes the root key and all objects and root keys under it.
+func deleteUnder(tx *bolt.Tx, key storageKey) error {
+	prefix := key.prefix()
+	for _, name := range [][]byte{rootsBucket, objectsBucket} {
+		bucket := tx.Bucket(name)
+		keys := [][]byte{}
+		c := bucket.Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			keys = append(keys, bytes.Clone(k))
+		}
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Bucket(rootsBucket).Delete([]byte(key.Key()))
+}
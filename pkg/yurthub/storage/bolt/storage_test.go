@@ -0,0 +1,359 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bolt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	bolt "go.etcd.io/bbolt"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+)
+
+var podsGVR = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}
+
+func podContent(name string, rv int) []byte {
+	return []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":%q,"namespace":"default","resourceVersion":"%d"}}`, name, rv))
+}
+
+func newTestStorage(t *testing.T) (*boltStorage, string) {
+	dir := t.TempDir()
+	s, err := NewBoltStorage(dir)
+	if err != nil {
+		t.Fatalf("could not create bolt storage, %v", err)
+	}
+	bs := s.(*boltStorage)
+	t.Cleanup(func() { bs.db.Close() })
+	return bs, dir
+}
+
+func podKey(t *testing.T, bs *boltStorage, comp, ns, name string) storage.Key {
+	key, err := bs.KeyFunc(storage.KeyBuildInfo{
+		Component: comp,
+		Resources: "pods",
+		Version:   "v1",
+		Namespace: ns,
+		Name:      name,
+	})
+	if err != nil {
+		t.Fatalf("could not get key, %v", err)
+	}
+	return key
+}
+
+func TestKeyFunc(t *testing.T) {
+	bs, _ := newTestStorage(t)
+	testcases := map[string]struct {
+		info    storage.KeyBuildInfo
+		key     string
+		isRoot  bool
+		wantErr error
+	}{
+		"object key with namespace": {
+			info: storage.KeyBuildInfo{Component: "kubelet", Resources: "pods", Version: "v1", Namespace: "default", Name: "foo"},
+			key:  "kubelet/pods.v1.core/default/foo",
+		},
+		"root key of resource": {
+			info:   storage.KeyBuildInfo{Component: "kubelet", Resources: "endpointslices", Version: "v1", Group: "discovery.k8s.io"},
+			key:    "kubelet/endpointslices.v1.discovery.k8s.io",
+			isRoot: true,
+		},
+		"namespaces key ignores namespace": {
+			info: storage.KeyBuildInfo{Component: "kubelet", Resources: "namespaces", Version: "v1", Namespace: "kube-system", Name: "kube-system"},
+			key:  "kubelet/namespaces.v1.core/kube-system",
+		},
+		"empty component": {
+			info:    storage.KeyBuildInfo{Resources: "pods"},
+			wantErr: storage.ErrEmptyComponent,
+		},
+		"empty resource": {
+			info:    storage.KeyBuildInfo{Component: "kubelet"},
+			wantErr: storage.ErrEmptyResource,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			key, err := bs.KeyFunc(tc.info)
+			if err != tc.wantErr {
+				t.Fatalf("expect error %v, but got %v", tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if key.Key() != tc.key || key.(storageKey).isRootKey() != tc.isRoot {
+				t.Errorf("expect key %s(root: %v), but got %s(root: %v)", tc.key, tc.isRoot, key.Key(), key.(storageKey).isRootKey())
+			}
+		})
+	}
+}
+
+func TestObjectOperations(t *testing.T) {
+	bs, _ := newTestStorage(t)
+	key := podKey(t, bs, "kubelet", "default", "foo")
+
+	if _, err := bs.Get(key); err != storage.ErrStorageNotFound {
+		t.Fatalf("expect ErrStorageNotFound before create, but got %v", err)
+	}
+	if err := bs.Create(key, nil); err != storage.ErrKeyHasNoContent {
+		t.Fatalf("expect ErrKeyHasNoContent, but got %v", err)
+	}
+	if err := bs.Create(key, podContent("foo", 10)); err != nil {
+		t.Fatalf("could not create key, %v", err)
+	}
+	if err := bs.Create(key, podContent("foo", 10)); err != storage.ErrKeyExists {
+		t.Fatalf("expect ErrKeyExists, but got %v", err)
+	}
+
+	old, err := bs.Update(key, podContent("foo", 5), 5)
+	if err != storage.ErrUpdateConflict || string(old) != string(podContent("foo", 10)) {
+		t.Fatalf("expect ErrUpdateConflict with stored content, but got %s, %v", string(old), err)
+	}
+	if _, err := bs.Update(key, podContent("foo", 20), 20); err != nil {
+		t.Fatalf("could not update key, %v", err)
+	}
+	buf, err := bs.Get(key)
+	if err != nil || string(buf) != string(podContent("foo", 20)) {
+		t.Fatalf("expect updated content, but got %s, %v", string(buf), err)
+	}
+
+	if err := bs.Delete(key); err != nil {
+		t.Fatalf("could not delete key, %v", err)
+	}
+	if _, err := bs.Update(key, podContent("foo", 30), 30); err != storage.ErrStorageNotFound {
+		t.Fatalf("expect ErrStorageNotFound after delete, but got %v", err)
+	}
+}
+
+func TestListAndReplaceComponentList(t *testing.T) {
+	bs, _ := newTestStorage(t)
+	rootKey, _ := bs.KeyFunc(storage.KeyBuildInfo{Component: "kubelet", Resources: "pods", Version: "v1"})
+
+	if _, err := bs.List(rootKey); err != storage.ErrStorageNotFound {
+		t.Fatalf("expect ErrStorageNotFound for uncached list, but got %v", err)
+	}
+
+	// an empty list should be recorded, so that it can be distinguished from uncached list.
+	if err := bs.ReplaceComponentList("kubelet", podsGVR, "", map[storage.Key][]byte{}); err != nil {
+		t.Fatalf("could not replace list, %v", err)
+	}
+	if bb, err := bs.List(rootKey); err != nil || len(bb) != 0 {
+		t.Fatalf("expect empty list, but got %d items, %v", len(bb), err)
+	}
+
+	contents := map[storage.Key][]byte{
+		podKey(t, bs, "kubelet", "default", "foo"): podContent("foo", 1),
+		podKey(t, bs, "kubelet", "default", "bar"): podContent("bar", 2),
+	}
+	if err := bs.ReplaceComponentList("kubelet", podsGVR, "", contents); err != nil {
+		t.Fatalf("could not replace list, %v", err)
+	}
+	contents = map[storage.Key][]byte{
+		podKey(t, bs, "kubelet", "default", "baz"): podContent("baz", 3),
+	}
+	if err := bs.ReplaceComponentList("kubelet", podsGVR, "default", contents); err != nil {
+		t.Fatalf("could not replace list, %v", err)
+	}
+
+	bb, err := bs.List(rootKey)
+	if err != nil || len(bb) != 1 || string(bb[0]) != string(podContent("baz", 3)) {
+		t.Fatalf("expect only replaced object in list, but got %d items, %v", len(bb), err)
+	}
+
+	keys, err := bs.ListResourceKeysOfComponent("kubelet", podsGVR)
+	if err != nil || len(keys) != 1 || keys[0].Key() != "kubelet/pods.v1.core/default/baz" {
+		t.Fatalf("unexpected keys %v, %v", keys, err)
+	}
+
+	invalid := map[storage.Key][]byte{
+		podKey(t, bs, "kube-proxy", "default", "foo"): podContent("foo", 1),
+	}
+	if err := bs.ReplaceComponentList("kubelet", podsGVR, "", invalid); err != storage.ErrInvalidContent {
+		t.Fatalf("expect ErrInvalidContent, but got %v", err)
+	}
+
+	if err := bs.DeleteComponentResources("kubelet"); err != nil {
+		t.Fatalf("could not delete component resources, %v", err)
+	}
+	if _, err := bs.ListResourceKeysOfComponent("kubelet", podsGVR); err != storage.ErrStorageNotFound {
+		t.Fatalf("expect ErrStorageNotFound after deleting component, but got %v", err)
+	}
+}
+
+func TestClusterInfo(t *testing.T) {
+	bs, _ := newTestStorage(t)
+	key := &storage.ClusterInfoKey{ClusterInfoType: storage.Version}
+
+	if _, err := bs.GetClusterInfo(key); err != storage.ErrStorageNotFound {
+		t.Fatalf("expect ErrStorageNotFound, but got %v", err)
+	}
+	for _, content := range []string{"v1", "v2"} {
+		if err := bs.SaveClusterInfo(key, []byte(content)); err != nil {
+			t.Fatalf("could not save cluster info, %v", err)
+		}
+		if buf, err := bs.GetClusterInfo(key); err != nil || string(buf) != content {
+			t.Fatalf("expect %s, but got %s, %v", content, string(buf), err)
+		}
+	}
+	if err := bs.SaveClusterInfo(&storage.ClusterInfoKey{ClusterInfoType: storage.Unknown}, []byte("x")); err != storage.ErrUnknownClusterInfoType {
+		t.Fatalf("expect ErrUnknownClusterInfoType, but got %v", err)
+	}
+}
+
+func TestMigrateFromDisk(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"kubelet/pods.v1.core/default/foo":     podContent("foo", 1),
+		"kubelet/pods.v1.core/kube-system/bar": podContent("bar", 2),
+		"kubelet/configmaps.v1.core/.keep":     nil,
+		"kube-proxy/services/default/legacy":   []byte("{}"),
+		"version":                              []byte(`{"major":"1"}`),
+		"_internal/restmapper/cache.conf":      []byte("{}"),
+	}
+	for p, content := range files {
+		path := filepath.Join(dir, p)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("could not create dir, %v", err)
+		}
+		if content == nil {
+			continue
+		}
+		if err := os.WriteFile(path, content, 0600); err != nil {
+			t.Fatalf("could not write file, %v", err)
+		}
+	}
+
+	s, err := NewBoltStorage(dir)
+	if err != nil {
+		t.Fatalf("could not create bolt storage, %v", err)
+	}
+	bs := s.(*boltStorage)
+
+	keys, err := bs.ListResourceKeysOfComponent("kubelet", podsGVR)
+	if err != nil {
+		t.Fatalf("could not list keys, %v", err)
+	}
+	got := []string{}
+	for _, k := range keys {
+		got = append(got, k.Key())
+	}
+	sort.Strings(got)
+	if len(got) != 2 || got[0] != "kubelet/pods.v1.core/default/foo" || got[1] != "kubelet/pods.v1.core/kube-system/bar" {
+		t.Errorf("unexpected migrated keys %v", got)
+	}
+
+	cmKey, _ := bs.KeyFunc(storage.KeyBuildInfo{Component: "kubelet", Resources: "configmaps", Version: "v1"})
+	if bb, err := bs.List(cmKey); err != nil || len(bb) != 0 {
+		t.Errorf("expect empty configmap list to be migrated, but got %d items, %v", len(bb), err)
+	}
+
+	cmGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	if gvrs, err := bs.ListResourcesOfComponent("kubelet"); err != nil || !reflect.DeepEqual(gvrs, []schema.GroupVersionResource{cmGVR, podsGVR}) {
+		t.Errorf("expect resources of kubelet are configmaps and pods, but got %v, %v", gvrs, err)
+	}
+	if _, err := bs.ListResourcesOfComponent("kube-proxy"); err != storage.ErrStorageNotFound {
+		t.Errorf("expect ErrStorageNotFound for legacy resources, but got %v", err)
+	}
+
+	if buf, err := bs.GetClusterInfo(&storage.ClusterInfoKey{ClusterInfoType: storage.Version}); err != nil || string(buf) != `{"major":"1"}` {
+		t.Errorf("expect cluster info to be migrated, but got %s, %v", string(buf), err)
+	}
+
+	for _, p := range []string{"kubelet", "kube-proxy", "version"} {
+		if _, err := os.Stat(filepath.Join(dir, p)); !os.IsNotExist(err) {
+			t.Errorf("expect %s to be removed after migration, but got %v", p, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "_internal/restmapper/cache.conf")); err != nil {
+		t.Errorf("expect internal files to be kept, but got %v", err)
+	}
+	bs.db.Close()
+
+	// migration should only happen once.
+	if err := os.MkdirAll(filepath.Join(dir, "kubelet/pods.v1.core/default"), 0755); err != nil {
+		t.Fatalf("could not create dir, %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "kubelet/pods.v1.core/default/new"), podContent("new", 3), 0600); err != nil {
+		t.Fatalf("could not write file, %v", err)
+	}
+	s, err = NewBoltStorage(dir)
+	if err != nil {
+		t.Fatalf("could not reopen bolt storage, %v", err)
+	}
+	bs = s.(*boltStorage)
+	defer bs.db.Close()
+	if keys, err := bs.ListResourceKeysOfComponent("kubelet", podsGVR); err != nil || len(keys) != 2 {
+		t.Errorf("expect no migration on the second start, but got %d keys, %v", len(keys), err)
+	}
+}
+
+func TestMigrateFromDiskInBatches(t *testing.T) {
+	dir := t.TempDir()
+	podDir := filepath.Join(dir, "kubelet/pods.v1.core/default")
+	if err := os.MkdirAll(podDir, 0755); err != nil {
+		t.Fatalf("could not create dir, %v", err)
+	}
+	podCnt := migrateBatchSize*2 + 1
+	for i := 0; i < podCnt; i++ {
+		name := fmt.Sprintf("pod-%d", i)
+		if err := os.WriteFile(filepath.Join(podDir, name), podContent(name, i+1), 0600); err != nil {
+			t.Fatalf("could not write file, %v", err)
+		}
+	}
+
+	s, err := NewBoltStorage(dir)
+	if err != nil {
+		t.Fatalf("could not create bolt storage, %v", err)
+	}
+	bs := s.(*boltStorage)
+	if keys, err := bs.ListResourceKeysOfComponent("kubelet", podsGVR); err != nil || len(keys) != podCnt {
+		t.Errorf("expect %d pods to be migrated, but got %d, %v", podCnt, len(keys), err)
+	}
+
+	// disk cache is removed on the next start when the removal is interrupted after migration.
+	if err := bs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(metaBucket).Delete([]byte(diskCacheRemovedKey))
+	}); err != nil {
+		t.Fatalf("could not delete removed marker, %v", err)
+	}
+	bs.db.Close()
+	if err := os.MkdirAll(podDir, 0755); err != nil {
+		t.Fatalf("could not create dir, %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(podDir, "left"), podContent("left", 1), 0600); err != nil {
+		t.Fatalf("could not write file, %v", err)
+	}
+
+	s, err = NewBoltStorage(dir)
+	if err != nil {
+		t.Fatalf("could not reopen bolt storage, %v", err)
+	}
+	bs = s.(*boltStorage)
+	defer bs.db.Close()
+	if _, err := os.Stat(filepath.Join(dir, "kubelet")); !os.IsNotExist(err) {
+		t.Errorf("expect left disk cache to be removed, but got %v", err)
+	}
+	if keys, err := bs.ListResourceKeysOfComponent("kubelet", podsGVR); err != nil || len(keys) != podCnt {
+		t.Errorf("expect left disk cache not to be migrated, but got %d keys, %v", len(keys), err)
+	}
+}
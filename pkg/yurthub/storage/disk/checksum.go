@@ -0,0 +1,233 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disk
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util/fs"
+)
+
+const (
+	// quarantineDir is the dir under baseDir for keeping corrupted files,
+	// and the files are placed at the same relative path as their keys.
+	quarantineDir = "_internal/quarantine"
+)
+
+// checksumPrefix is the prefix of header line which is written before the content of
+// each object file, and the header is in the format of #sha256:<hex encoded sha256 of content>.
+var checksumPrefix = []byte("#sha256:")
+
+// ErrContentCorrupted indicates that the content of cached file does not match its checksum.
+var ErrContentCorrupted = errors.New("cached content is corrupted")
+
+// encodeContent attaches the checksum header to content.
+func encodeContent(content []byte) []byte {
+	sum := sha256.Sum256(content)
+	buf := make([]byte, 0, len(checksumPrefix)+hex.EncodedLen(len(sum))+1+len(content))
+	buf = append(buf, checksumPrefix...)
+	buf = hex.AppendEncode(buf, sum[:])
+	buf = append(buf, '\n')
+	return append(buf, content...)
+}
+
+// DecodeContent verifies the checksum header of data read from the object file, and returns
+// the content without header. The files written by old versions of yurthub have no checksum
+// header, so they are only accepted when they are well-formed json.
+// ErrContentCorrupted will be returned if data can not pass the verification.
+func DecodeContent(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, checksumPrefix) {
+		if !json.Valid(data) {
+			return nil, ErrContentCorrupted
+		}
+		return data, nil
+	}
+
+	header, content, found := bytes.Cut(data, []byte("\n"))
+	if !found {
+		return nil, ErrContentCorrupted
+	}
+	expected, err := hex.DecodeString(string(header[len(checksumPrefix):]))
+	if err != nil {
+		return nil, ErrContentCorrupted
+	}
+	if sum := sha256.Sum256(content); !bytes.Equal(sum[:], expected) {
+		return nil, ErrContentCorrupted
+	}
+	return content, nil
+}
+
+// Verify will check checksums of all object files in the storage. The corrupted files
+// will be moved into the quarantine dir, so they will not be served any more. Files
+// which are being manipulated are skipped.
+func (ds *diskStorage) Verify() (*storage.VerifyResult, error) {
+	if ds.readOnly {
+		return nil, storage.ErrStorageReadOnly
+	}
+	result := &storage.VerifyResult{Quarantined: []string{}}
+	components, err := ds.ListComponents()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, component := range components {
+		compPath := filepath.Join(ds.baseDir, component)
+		files, err := ds.fsOperator.List(compPath, fs.ListModeFiles, true)
+		if err == fs.ErrNotExists {
+			continue
+		} else if err != nil {
+			return result, fmt.Errorf("could not list files under %s, %v", compPath, err)
+		}
+
+		for _, path := range files {
+			key := ds.keyOfPath(path)
+			if hasTmpElem(key.Key()) {
+				continue
+			}
+
+			corrupted, checked := ds.verifyFile(key, path)
+			if !checked {
+				result.Skipped++
+				continue
+			}
+			result.Checked++
+			if corrupted {
+				result.Quarantined = append(result.Quarantined, key.Key())
+			}
+		}
+	}
+	return result, nil
+}
+
+// verifyFile checks the file of key, and returns whether it has been quarantined and
+// whether it has been checked.
+func (ds *diskStorage) verifyFile(key storageKey, path string) (bool, bool) {
+	if !ds.lockKey(key) {
+		return false, false
+	}
+	defer ds.unLockKey(key)
+
+	data, err := ds.fsOperator.Read(path)
+	if err != nil {
+		// the file may be deleted or replaced after listing.
+		klog.V(4).Infof("skip to verify file %s, %v", path, err)
+		return false, false
+	}
+	if _, err := DecodeContent(data); err != nil {
+		ds.quarantine(key, path)
+		return true, true
+	}
+	return false, true
+}
+
+// ListQuarantinedKeys will get keys of all files in the quarantine dir.
+func (ds *diskStorage) ListQuarantinedKeys() ([]storage.Key, error) {
+	dir := filepath.Join(ds.baseDir, quarantineDir)
+	files, err := ds.fsOperator.List(dir, fs.ListModeFiles, true)
+	if err == fs.ErrNotExists {
+		return []storage.Key{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("could not list files under %s, %v", dir, err)
+	}
+
+	keys := make([]storage.Key, 0, len(files))
+	for _, path := range files {
+		keys = append(keys, storageKey{path: strings.TrimPrefix(strings.TrimPrefix(path, dir), "/")})
+	}
+	return keys, nil
+}
+
+// DeleteQuarantinedKey will delete the quarantined file of key.
+func (ds *diskStorage) DeleteQuarantinedKey(key storage.Key) error {
+	if key == nil || key.Key() == "" {
+		return storage.ErrKeyIsEmpty
+	}
+	if ds.readOnly {
+		return storage.ErrStorageReadOnly
+	}
+
+	path := filepath.Join(ds.baseDir, quarantineDir, key.Key())
+	if err := ds.fsOperator.DeleteFile(path); err != nil {
+		return fmt.Errorf("could not delete quarantined file %s, %v", path, err)
+	}
+	return nil
+}
+
+// quarantine moves the corrupted file at path into the quarantine dir. If it fails,
+// the corrupted file will be deleted, because it should never be served.
+// Caller should hold the lock of key.
+func (ds *diskStorage) quarantine(key storageKey, path string) {
+	klog.Warningf("cached file %s is corrupted, quarantine it", path)
+	target := filepath.Join(ds.baseDir, quarantineDir, key.Key())
+	if err := ds.fsOperator.CreateDir(filepath.Dir(target)); err != nil && err != fs.ErrExists {
+		klog.Errorf("could not create quarantine dir for %s, %v", path, err)
+	} else if err := os.Rename(path, target); err != nil {
+		klog.Errorf("could not move %s into quarantine dir, %v", path, err)
+	} else {
+		return
+	}
+
+	if err := ds.fsOperator.DeleteFile(path); err != nil {
+		klog.Errorf("could not delete corrupted file %s, %v", path, err)
+	}
+}
+
+// readObject reads the object file at path and verifies its checksum. The file will be
+// quarantined if it's corrupted unless the storage is read-only, and ErrContentCorrupted is returned.
+// Caller should hold the lock of key.
+func (ds *diskStorage) readObject(key storageKey, path string) ([]byte, error) {
+	data, err := ds.fsOperator.Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := DecodeContent(data)
+	if err != nil {
+		if ds.readOnly {
+			klog.Warningf("cached file %s is corrupted, skip it", path)
+		} else {
+			ds.quarantine(key, path)
+		}
+		return nil, err
+	}
+	return content, nil
+}
+
+// keyOfPath returns the key of object file at path.
+func (ds *diskStorage) keyOfPath(path string) storageKey {
+	return storageKey{path: strings.TrimPrefix(strings.TrimPrefix(path, ds.baseDir), "/")}
+}
+
+func hasTmpElem(keyPath string) bool {
+	for _, elem := range strings.Split(keyPath, "/") {
+		if strings.HasPrefix(elem, tmpPrefix) {
+			return true
+		}
+	}
+	return false
+}
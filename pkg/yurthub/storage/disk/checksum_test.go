@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+)
+
+func TestDecodeContent(t *testing.T) {
+	content := []byte(`{"kind":"Pod","apiVersion":"v1"}`)
+	encoded := encodeContent(content)
+	testcases := map[string]struct {
+		data    []byte
+		expect  []byte
+		wantErr bool
+	}{
+		"content with checksum": {
+			data:   encoded,
+			expect: content,
+		},
+		"legacy content without checksum": {
+			data:   content,
+			expect: content,
+		},
+		"truncated legacy content": {
+			data:    content[:10],
+			wantErr: true,
+		},
+		"truncated content": {
+			data:    encoded[:len(encoded)-5],
+			wantErr: true,
+		},
+		"truncated header": {
+			data:    encoded[:20],
+			wantErr: true,
+		},
+		"empty file": {
+			data:    []byte{},
+			wantErr: true,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			got, err := DecodeContent(tc.data)
+			if tc.wantErr {
+				if !errors.Is(err, ErrContentCorrupted) {
+					t.Errorf("expect ErrContentCorrupted, but got %v", err)
+				}
+				return
+			}
+			if err != nil || !bytes.Equal(got, tc.expect) {
+				t.Errorf("expect %s, but got %s, %v", string(tc.expect), string(got), err)
+			}
+		})
+	}
+}
+
+func TestVerifyAndQuarantine(t *testing.T) {
+	baseDir := t.TempDir()
+	store, err := NewDiskStorageWithFormat(baseDir, FormatVersionChecksum)
+	if err != nil {
+		t.Fatalf("could not create disk storage, %v", err)
+	}
+	keys := make([]storage.Key, 0, 3)
+	for i := 0; i < 3; i++ {
+		name := fmt.Sprintf("pod%d", i)
+		key, _ := store.KeyFunc(storage.KeyBuildInfo{Component: "kubelet", Resources: "pods", Version: "v1", Namespace: "default", Name: name})
+		content := []byte(fmt.Sprintf(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":%q,"namespace":"default","resourceVersion":"1"}}`, name))
+		if err := store.Create(key, content); err != nil {
+			t.Fatalf("could not create %s, %v", name, err)
+		}
+		keys = append(keys, key)
+	}
+
+	truncate := func(key storage.Key) {
+		path := filepath.Join(baseDir, key.Key())
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("could not read %s, %v", path, err)
+		}
+		if err := os.WriteFile(path, buf[:len(buf)-10], 0600); err != nil {
+			t.Fatalf("could not truncate %s, %v", path, err)
+		}
+	}
+
+	// corrupted file is quarantined when it's read.
+	truncate(keys[0])
+	if _, err := store.Get(keys[0]); !errors.Is(err, storage.ErrStorageNotFound) {
+		t.Errorf("expect ErrStorageNotFound for corrupted file, but got %v", err)
+	}
+	rootKey, _ := store.KeyFunc(storage.KeyBuildInfo{Component: "kubelet", Resources: "pods", Version: "v1"})
+	truncate(keys[1])
+	if bb, err := store.List(rootKey); err != nil || len(bb) != 1 {
+		t.Errorf("expect corrupted file to be skipped when listing, but got %d objects, %v", len(bb), err)
+	}
+
+	// corrupted file is quarantined by verification, and the storage is not verified when it's created.
+	if err := store.Create(keys[0], []byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"pod0"}}`)); err != nil {
+		t.Fatalf("could not recreate pod0, %v", err)
+	}
+	truncate(keys[2])
+	store, err = NewDiskStorage(baseDir)
+	if err != nil {
+		t.Fatalf("could not create disk storage, %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, keys[2].Key())); err != nil {
+		t.Errorf("expect pod2 not to be quarantined when creating storage, but got %v", err)
+	}
+
+	verifier := store.(storage.Verifier)
+	result, err := verifier.Verify()
+	if err != nil || result.Checked != 2 || len(result.Quarantined) != 1 || result.Quarantined[0] != keys[2].Key() {
+		t.Errorf("expect 2 objects checked and pod2 quarantined, but got %#v, %v", result, err)
+	}
+	quarantined, err := verifier.ListQuarantinedKeys()
+	if err != nil || len(quarantined) != 3 {
+		t.Fatalf("expect 3 quarantined keys, but got %v, %v", quarantined, err)
+	}
+	for i := range quarantined {
+		if quarantined[i].Key() != keys[i].Key() {
+			t.Errorf("expect quarantined key %s, but got %s", keys[i].Key(), quarantined[i].Key())
+		}
+		if err := verifier.DeleteQuarantinedKey(quarantined[i]); err != nil {
+			t.Errorf("could not delete quarantined key %s, %v", quarantined[i].Key(), err)
+		}
+	}
+	if quarantined, err := verifier.ListQuarantinedKeys(); err != nil || len(quarantined) != 0 {
+		t.Errorf("expect no quarantined keys, but got %v, %v", quarantined, err)
+	}
+}
+
+func TestConvertFormat(t *testing.T) {
+	baseDir := t.TempDir()
+	store, err := NewDiskStorage(baseDir)
+	if err != nil {
+		t.Fatalf("could not create disk storage, %v", err)
+	}
+	key, _ := store.KeyFunc(storage.KeyBuildInfo{Component: "kubelet", Resources: "pods", Version: "v1", Namespace: "default", Name: "pod1"})
+	content := []byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"pod1","namespace":"default","resourceVersion":"1"}}`)
+	if err := store.Create(key, content); err != nil {
+		t.Fatalf("could not create pod1, %v", err)
+	}
+
+	readFile := func() []byte {
+		buf, err := os.ReadFile(filepath.Join(baseDir, key.Key()))
+		if err != nil {
+			t.Fatalf("could not read pod1, %v", err)
+		}
+		return buf
+	}
+	// objects are written in plain format by default, so the cache can be read by old versions of yurthub.
+	if buf := readFile(); !bytes.Equal(buf, content) {
+		t.Errorf("expect pod1 in plain format, but got %s", string(buf))
+	}
+
+	for _, version := range []FormatVersion{FormatVersionChecksum, FormatVersionPlain} {
+		store, err = NewDiskStorageWithFormat(baseDir, version)
+		if err != nil {
+			t.Fatalf("could not create disk storage with format version %d, %v", version, err)
+		}
+		if buf := readFile(); bytes.HasPrefix(buf, checksumPrefix) != (version == FormatVersionChecksum) {
+			t.Errorf("expect pod1 in format version %d, but got %s", version, string(buf))
+		}
+		if got, err := store.Get(key); err != nil || !bytes.Equal(got, content) {
+			t.Errorf("expect pod1 %s, but got %s, %v", string(content), string(got), err)
+		}
+
+		// format version is kept when the storage is created without format version.
+		store, err = NewDiskStorage(baseDir)
+		if err != nil {
+			t.Fatalf("could not create disk storage, %v", err)
+		}
+		if got := store.(*diskStorage).formatVersion; got != version {
+			t.Errorf("expect format version %d, but got %d", version, got)
+		}
+	}
+}
+
+func TestReadOnlyDiskStorage(t *testing.T) {
+	baseDir := t.TempDir()
+	store, err := NewDiskStorageWithFormat(baseDir, FormatVersionChecksum)
+	if err != nil {
+		t.Fatalf("could not create disk storage, %v", err)
+	}
+	key, _ := store.KeyFunc(storage.KeyBuildInfo{Component: "kubelet", Resources: "pods", Version: "v1", Namespace: "default", Name: "pod0"})
+	if err := store.Create(key, []byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"pod0","namespace":"default"}}`)); err != nil {
+		t.Fatalf("could not create pod0, %v", err)
+	}
+	path := filepath.Join(baseDir, key.Key())
+	buf, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, buf[:len(buf)-10], 0600); err != nil {
+		t.Fatalf("could not truncate %s, %v", path, err)
+	}
+
+	roStore, err := NewReadOnlyDiskStorage(baseDir)
+	if err != nil {
+		t.Fatalf("could not open read-only disk storage, %v", err)
+	}
+	if _, err := roStore.Get(key); !errors.Is(err, storage.ErrStorageNotFound) {
+		t.Errorf("expect ErrStorageNotFound for corrupted file, but got %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expect corrupted file not to be quarantined by read-only storage, but got %v", err)
+	}
+	if err := roStore.Create(key, []byte("{}")); !errors.Is(err, storage.ErrStorageReadOnly) {
+		t.Errorf("expect ErrStorageReadOnly for creating, but got %v", err)
+	}
+	if _, err := roStore.(storage.Verifier).Verify(); !errors.Is(err, storage.ErrStorageReadOnly) {
+		t.Errorf("expect ErrStorageReadOnly for verifying, but got %v", err)
+	}
+
+	// interrupted writes can only be recovered by a writable storage.
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), tmpPrefix+"pod0"), buf, 0600); err != nil {
+		t.Fatalf("could not write tmp file, %v", err)
+	}
+	if _, err := NewReadOnlyDiskStorage(baseDir); err == nil {
+		t.Errorf("expect error for interrupted writes, but got nil")
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/util/fs"
+)
+
+// FormatVersion is the version of the format in which objects are written into object files.
+type FormatVersion int
+
+const (
+	// FormatVersionPlain writes objects as they are, and the object files can be read by all versions of yurthub.
+	FormatVersionPlain FormatVersion = 1
+	// FormatVersionChecksum writes a checksum header before each object for detecting corrupted files.
+	// yurthub which does not support this version can not read the object files, so the cache should be
+	// converted back to FormatVersionPlain before downgrading yurthub.
+	FormatVersionChecksum FormatVersion = 2
+
+	// formatVersionFile records the format version of object files under baseDir. The cache without this
+	// file is written by old versions of yurthub, and it's in FormatVersionPlain.
+	formatVersionFile = "_internal/format-version"
+)
+
+// IsSupportedFormatVersion checks whether the format version can be used by disk storage.
+func IsSupportedFormatVersion(version int) bool {
+	return version == int(FormatVersionPlain) || version == int(FormatVersionChecksum)
+}
+
+// NewDiskStorageWithFormat creates a disk storage which writes objects in the specified format version. The object
+// files in other format will be converted into the specified format version before the storage is returned.
+func NewDiskStorageWithFormat(dir string, version FormatVersion) (storage.Store, error) {
+	if !IsSupportedFormatVersion(int(version)) {
+		return nil, fmt.Errorf("format version %d of disk storage is not supported", version)
+	}
+
+	store, err := NewDiskStorage(dir)
+	if err != nil {
+		return nil, err
+	}
+	ds := store.(*diskStorage)
+	if ds.formatVersion == version {
+		return ds, nil
+	}
+
+	klog.Infof("convert format of disk storage %s from version %d to %d", ds.baseDir, ds.formatVersion, version)
+	if err := ds.convertFormat(version); err != nil {
+		return nil, fmt.Errorf("could not convert format of disk storage %s to version %d, %w", ds.baseDir, version, err)
+	}
+	return ds, nil
+}
+
+// readFormatVersion returns the format version recorded under baseDir.
+func readFormatVersion(baseDir string) (FormatVersion, error) {
+	path := filepath.Join(baseDir, formatVersionFile)
+	buf, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return FormatVersionPlain, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("could not read format version file %s, %v", path, err)
+	}
+
+	version, err := strconv.Atoi(strings.TrimSpace(string(buf)))
+	if err != nil || !IsSupportedFormatVersion(version) {
+		return 0, fmt.Errorf("format version %q in %s is not supported", strings.TrimSpace(string(buf)), path)
+	}
+	return FormatVersion(version), nil
+}
+
+// writeFormatVersion records the format version under baseDir.
+func writeFormatVersion(baseDir string, version FormatVersion) error {
+	path := filepath.Join(baseDir, formatVersionFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create dir for format version file %s, %v", path, err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(strconv.Itoa(int(version))), 0600); err != nil {
+		return fmt.Errorf("could not write format version file %s, %v", tmpPath, err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// encode returns data which is written into object file for content.
+func (ds *diskStorage) encode(content []byte) []byte {
+	if ds.formatVersion == FormatVersionChecksum {
+		return encodeContent(content)
+	}
+	return content
+}
+
+// convertFormat rewrites all object files in the specified format version, and the format version
+// is recorded after all object files are rewritten. object files are rewritten in the same backup
+// way as Update, so an interrupted conversion can be recovered and resumed at next start.
+func (ds *diskStorage) convertFormat(version FormatVersion) error {
+	components, err := ds.ListComponents()
+	if err != nil {
+		return err
+	}
+
+	ds.formatVersion = version
+	var cnt int
+	for _, component := range components {
+		compPath := filepath.Join(ds.baseDir, component)
+		files, err := ds.fsOperator.List(compPath, fs.ListModeFiles, true)
+		if err == fs.ErrNotExists {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("could not list files under %s, %v", compPath, err)
+		}
+
+		for _, path := range files {
+			key := ds.keyOfPath(path)
+			if hasTmpElem(key.Key()) {
+				continue
+			}
+			converted, err := ds.convertFile(key, path)
+			if err != nil {
+				return err
+			}
+			if converted {
+				cnt++
+			}
+		}
+	}
+
+	klog.Infof("converted %d object files of disk storage %s to format version %d", cnt, ds.baseDir, version)
+	return writeFormatVersion(ds.baseDir, version)
+}
+
+// convertFile rewrites the object file at path in the current format version of storage, and returns
+// whether the file has been rewritten. corrupted files are quarantined instead of being converted.
+func (ds *diskStorage) convertFile(key storageKey, path string) (bool, error) {
+	if !ds.lockKey(key) {
+		return false, fmt.Errorf("could not convert %s, %w", path, storage.ErrStorageAccessConflict)
+	}
+	defer ds.unLockKey(key)
+
+	content, err := ds.readObject(key, path)
+	if err == ErrContentCorrupted {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("could not read file %s, %v", path, err)
+	}
+
+	tmpPath := filepath.Join(ds.baseDir, getTmpKey(key).Key())
+	if err := ds.fsOperator.Rename(path, tmpPath); err != nil {
+		return false, fmt.Errorf("could not backup file %s, %v", path, err)
+	}
+	if err := ds.fsOperator.CreateFile(path, ds.encode(content)); err != nil {
+		return false, fmt.Errorf("could not write to file %s, %v", path, err)
+	}
+	if err := ds.fsOperator.DeleteFile(tmpPath); err != nil {
+		return false, fmt.Errorf("could not delete backup file %s, %v", tmpPath, err)
+	}
+	return true, nil
+}
@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -52,9 +53,13 @@ type diskStorage struct {
 	serializer       runtime.Serializer
 	fsOperator       *fs.FileSystemOperator
 	enhancementMode  bool
+	formatVersion    FormatVersion
+	// readOnly storage never writes baseDir, and corrupted files are skipped instead of being quarantined.
+	readOnly bool
 }
 
-// NewDiskStorage creates a storage.Store for caching data into local disk
+// NewDiskStorage creates a storage.Store for caching data into local disk, objects are written
+// in the format version recorded under dir.
 func NewDiskStorage(dir string) (storage.Store, error) {
 	if dir == "" {
 		klog.Infof("disk cache path is empty, set it by default %s", CacheBaseDir)
@@ -86,6 +91,10 @@ func NewDiskStorage(dir string) (storage.Store, error) {
 		klog.Info("yurthub disk storage will run in enhancement mode")
 	}
 
+	if ds.formatVersion, err = readFormatVersion(ds.baseDir); err != nil {
+		return nil, err
+	}
+
 	err = ds.Recover()
 	if err != nil {
 		// we should ensure that there no tmp file last when local storage start to work.
@@ -97,6 +106,50 @@ func NewDiskStorage(dir string) (storage.Store, error) {
 	return ds, nil
 }
 
+// NewReadOnlyDiskStorage opens the disk storage at dir without modifying it, so it can be used for reading
+// the cache of a stopped yurthub. Since the interrupted writes can not be rolled back without writing dir,
+// an error is returned if there are any interrupted writes, and they will be rolled back when yurthub starts.
+func NewReadOnlyDiskStorage(dir string) (storage.Store, error) {
+	dir = strings.TrimSuffix(dir, "/")
+	if ok, err := fs.IsDir(dir); err != nil || !ok {
+		return nil, fmt.Errorf("cache path %s is not a dir, %v", dir, err)
+	}
+
+	ds := &diskStorage{
+		keyPendingStatus: make(map[string]struct{}),
+		baseDir:          dir,
+		serializer:       json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme.Scheme, scheme.Scheme, json.SerializerOptions{}),
+		fsOperator:       &fs.FileSystemOperator{},
+		readOnly:         true,
+	}
+	enhancementMode, err := ifEnhancement(ds.baseDir, *ds.fsOperator)
+	if err != nil {
+		return nil, fmt.Errorf("cannot detect running mode of disk storage, %v", err)
+	}
+	ds.enhancementMode = enhancementMode
+	if ds.formatVersion, err = readFormatVersion(ds.baseDir); err != nil {
+		return nil, err
+	}
+
+	internalDir := filepath.Join(ds.baseDir, "_internal")
+	err = filepath.Walk(ds.baseDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == internalDir && info.IsDir() {
+			return filepath.SkipDir
+		}
+		if isTmpFile(path) {
+			return fmt.Errorf("%s is left by an interrupted write, start yurthub to recover the cache", path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not open local storage in read-only mode, %v", err)
+	}
+	return ds, nil
+}
+
 // Name will return the name of this storage
 func (ds *diskStorage) Name() string {
 	return StorageName
@@ -104,6 +157,9 @@ func (ds *diskStorage) Name() string {
 
 // Create will create a new file with content. key indicates the path of the file.
 func (ds *diskStorage) Create(key storage.Key, content []byte) error {
+	if ds.readOnly {
+		return storage.ErrStorageReadOnly
+	}
 	if err := utils.ValidateKey(key, storageKey{}); err != nil {
 		return err
 	}
@@ -123,7 +179,7 @@ func (ds *diskStorage) Create(key storage.Key, content []byte) error {
 		// If it is rootKey, create the dir for it. Refer to #258.
 		return ds.fsOperator.CreateDir(path)
 	}
-	err := ds.fsOperator.CreateFile(path, content)
+	err := ds.fsOperator.CreateFile(path, ds.encode(content))
 	if err == fs.ErrExists {
 		return storage.ErrKeyExists
 	}
@@ -135,6 +191,9 @@ func (ds *diskStorage) Create(key storage.Key, content []byte) error {
 
 // Delete will delete the file that specified by key.
 func (ds *diskStorage) Delete(key storage.Key) error {
+	if ds.readOnly {
+		return storage.ErrStorageReadOnly
+	}
 	if err := utils.ValidateKey(key, storageKey{}); err != nil {
 		return err
 	}
@@ -159,6 +218,7 @@ func (ds *diskStorage) Delete(key storage.Key) error {
 
 // Get will get content from the regular file that specified by key.
 // If key points to a dir, return ErrKeyHasNoContent.
+// If the file is corrupted, it will be quarantined and ErrStorageNotFound will be returned.
 func (ds *diskStorage) Get(key storage.Key) ([]byte, error) {
 	if err := utils.ValidateKey(key, storageKey{}); err != nil {
 		return []byte{}, storage.ErrKeyIsEmpty
@@ -171,11 +231,11 @@ func (ds *diskStorage) Get(key storage.Key) ([]byte, error) {
 	defer ds.unLockKey(storageKey)
 
 	path := filepath.Join(ds.baseDir, storageKey.Key())
-	buf, err := ds.fsOperator.Read(path)
+	buf, err := ds.readObject(storageKey, path)
 	switch err {
 	case nil:
 		return buf, nil
-	case fs.ErrNotExists:
+	case fs.ErrNotExists, ErrContentCorrupted:
 		return nil, storage.ErrStorageNotFound
 	case fs.ErrIsNotFile:
 		return nil, storage.ErrKeyHasNoContent
@@ -184,8 +244,29 @@ func (ds *diskStorage) Get(key storage.Key) ([]byte, error) {
 	}
 }
 
+// LastUpdateTime will get the modification time of the regular file that specified by key.
+// If key points to a dir, return ErrKeyHasNoContent.
+func (ds *diskStorage) LastUpdateTime(key storage.Key) (time.Time, error) {
+	if err := utils.ValidateKey(key, storageKey{}); err != nil {
+		return time.Time{}, storage.ErrKeyIsEmpty
+	}
+
+	path := filepath.Join(ds.baseDir, key.Key())
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return time.Time{}, storage.ErrStorageNotFound
+	} else if err != nil {
+		return time.Time{}, fmt.Errorf("could not stat file at %s, %v", path, err)
+	}
+	if info.IsDir() {
+		return time.Time{}, storage.ErrKeyHasNoContent
+	}
+	return info.ModTime(), nil
+}
+
 // List will get contents of all files recursively under the root dir pointed by the rootKey.
 // If the root dir of this rootKey does not exist, return ErrStorageNotFound.
+// Corrupted files will be quarantined and skipped, so they will not fail the whole list.
 func (ds *diskStorage) List(key storage.Key) ([][]byte, error) {
 	if err := utils.ValidateKey(key, storageKey{}); err != nil {
 		return [][]byte{}, err
@@ -204,8 +285,11 @@ func (ds *diskStorage) List(key storage.Key) ([][]byte, error) {
 	case nil:
 		// read all files and return
 		for _, filePath := range files {
-			buf, err := ds.fsOperator.Read(filePath)
-			if err != nil {
+			fileKey := ds.keyOfPath(filePath)
+			buf, err := ds.readObject(fileKey, filePath)
+			if err == ErrContentCorrupted {
+				continue
+			} else if err != nil {
 				return nil, fmt.Errorf("could not read file at %s, %v", filePath, err)
 			}
 			bb = append(bb, buf)
@@ -215,7 +299,9 @@ func (ds *diskStorage) List(key storage.Key) ([][]byte, error) {
 		return nil, storage.ErrStorageNotFound
 	case fs.ErrIsNotDir:
 		// possibly it is a regular file, try to read it directly
-		if buf, rerr := ds.fsOperator.Read(absPath); rerr != nil {
+		if buf, rerr := ds.readObject(storageKey, absPath); rerr == ErrContentCorrupted {
+			return nil, storage.ErrStorageNotFound
+		} else if rerr != nil {
 			return nil, fmt.Errorf("could not list file at %s, %v", absPath, rerr)
 		} else {
 			bb = append(bb, buf)
@@ -231,8 +317,12 @@ func (ds *diskStorage) List(key storage.Key) ([][]byte, error) {
 // stored obj and update it only when the rv in argument is fresher than what is stored.
 // It will return the content that finally stored in the file pointed by key.
 // Update works in a backup way, which means it will first backup the original file, and then
-// write the content into it.
+// write the content into it. If the original file is corrupted, it will be quarantined and
+// ErrStorageNotFound will be returned.
 func (ds *diskStorage) Update(key storage.Key, content []byte, rv uint64) ([]byte, error) {
+	if ds.readOnly {
+		return nil, storage.ErrStorageReadOnly
+	}
 	if err := utils.ValidateKV(key, content, storageKey{}); err != nil {
 		return nil, err
 	}
@@ -248,8 +338,8 @@ func (ds *diskStorage) Update(key storage.Key, content []byte, rv uint64) ([]byt
 	defer ds.unLockKey(storageKey)
 
 	absPath := filepath.Join(ds.baseDir, storageKey.Key())
-	old, err := ds.fsOperator.Read(absPath)
-	if err == fs.ErrNotExists {
+	old, err := ds.readObject(storageKey, absPath)
+	if err == fs.ErrNotExists || err == ErrContentCorrupted {
 		return nil, storage.ErrStorageNotFound
 	}
 	if err != nil {
@@ -270,7 +360,7 @@ func (ds *diskStorage) Update(key storage.Key, content []byte, rv uint64) ([]byt
 	if err := ds.fsOperator.Rename(absPath, tmpPath); err != nil {
 		return nil, fmt.Errorf("could not backup file %s, %v", absPath, err)
 	}
-	if err := ds.fsOperator.CreateFile(absPath, content); err != nil {
+	if err := ds.fsOperator.CreateFile(absPath, ds.encode(content)); err != nil {
 		// We can ensure that the file actually exists, so it should not be ErrNotExists
 		return nil, fmt.Errorf("could not write to file %s, %v", absPath, err)
 	}
@@ -308,11 +398,19 @@ func (ds *diskStorage) ListResourceKeysOfComponent(component string, gvr schema.
 		return nil, fmt.Errorf("could not list files at %s, %v", filepath.Join(ds.baseDir, storageKey.Key()), err)
 	}
 
-	keys := make([]storage.Key, len(files))
-	for i, filePath := range files {
-		_, _, ns, n, err := extractInfoFromPath(ds.baseDir, filePath, false)
-		if err != nil {
-			klog.Errorf("failed when list keys of resource %s of component %s, %v", component, gvr, err)
+	keys := make([]storage.Key, 0, len(files))
+	for _, filePath := range files {
+		// component may be attached with convert gvk, so namespace and name are
+		// extracted from the path relative to the resource dir.
+		var ns, n string
+		elems := strings.Split(strings.TrimPrefix(filePath, absPath+"/"), "/")
+		switch len(elems) {
+		case 1:
+			n = elems[0]
+		case 2:
+			ns, n = elems[0], elems[1]
+		default:
+			klog.Errorf("failed when list keys of resource %s of component %s, invalid path %s", component, gvr, filePath)
 			continue
 		}
 		// We can ensure that component and resource can't be empty
@@ -325,7 +423,7 @@ func (ds *diskStorage) ListResourceKeysOfComponent(component string, gvr schema.
 			Namespace: ns,
 			Name:      n,
 		})
-		keys[i] = key
+		keys = append(keys, key)
 	}
 	return keys, nil
 }
@@ -335,6 +433,9 @@ func (ds *diskStorage) ListResourceKeysOfComponent(component string, gvr schema.
 // original dir and write contents into it. If the yurthub break down and restart, interrupting the previous
 // ReplaceComponentList, the diskStorage will recover the data with backup in the tmpdir.
 func (ds *diskStorage) ReplaceComponentList(component string, gvr schema.GroupVersionResource, namespace string, contents map[storage.Key][]byte) error {
+	if ds.readOnly {
+		return storage.ErrStorageReadOnly
+	}
 	rootKey, err := ds.KeyFunc(storage.KeyBuildInfo{
 		Component: component,
 		Resources: gvr.Resource,
@@ -393,7 +494,7 @@ func (ds *diskStorage) ReplaceComponentList(component string, gvr schema.GroupVe
 			klog.Errorf("could not create dir at %s, %v", filepath.Dir(path), err)
 			return ds.restoreReplaceFromBackup(tmpPath, absPath, fmt.Errorf("could not create dir at %s, %v", filepath.Dir(path), err))
 		}
-		if err := ds.fsOperator.CreateFile(path, data); err != nil {
+		if err := ds.fsOperator.CreateFile(path, ds.encode(data)); err != nil {
 			klog.Errorf("could not write data to %s, %v", path, err)
 			return ds.restoreReplaceFromBackup(tmpPath, absPath, fmt.Errorf("could not write data to %s, %v", path, err))
 		}
@@ -406,6 +507,9 @@ func (ds *diskStorage) ReplaceComponentList(component string, gvr schema.GroupVe
 
 // DeleteComponentResources will delete all resources cached for component.
 func (ds *diskStorage) DeleteComponentResources(component string) error {
+	if ds.readOnly {
+		return storage.ErrStorageReadOnly
+	}
 	if component == "" {
 		return storage.ErrEmptyComponent
 	}
@@ -425,7 +529,58 @@ func (ds *diskStorage) DeleteComponentResources(component string) error {
 	return nil
 }
 
+// ListComponents will get names of all component dirs under the baseDir.
+func (ds *diskStorage) ListComponents() ([]string, error) {
+	compDirs, err := ds.fsOperator.List(ds.baseDir, fs.ListModeDirs, false)
+	if err != nil {
+		return nil, fmt.Errorf("could not list dirs under %s, %v", ds.baseDir, err)
+	}
+
+	components := make([]string, 0, len(compDirs))
+	for _, compDir := range compDirs {
+		_, dirName := filepath.Split(compDir)
+		if dirName == "_internal" || isTmpFile(compDir) {
+			continue
+		}
+		components = append(components, dirName)
+	}
+	return components, nil
+}
+
+// ListResourcesOfComponent will get gvrs of all resource dirs under the dir of component.
+// Resource dirs which are not in the enhancement format are skipped.
+func (ds *diskStorage) ListResourcesOfComponent(component string) ([]schema.GroupVersionResource, error) {
+	if component == "" {
+		return nil, storage.ErrEmptyComponent
+	}
+
+	absPath := filepath.Join(ds.baseDir, component)
+	resDirs, err := ds.fsOperator.List(absPath, fs.ListModeDirs, false)
+	if err == fs.ErrNotExists {
+		return nil, storage.ErrStorageNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("could not list dirs under %s, %v", absPath, err)
+	}
+
+	gvrs := make([]schema.GroupVersionResource, 0, len(resDirs))
+	for _, resDir := range resDirs {
+		if isTmpFile(resDir) {
+			continue
+		}
+		_, dirName := filepath.Split(resDir)
+		gvr, ok := utils.ParseResourceSegment(dirName)
+		if !ok {
+			continue
+		}
+		gvrs = append(gvrs, gvr)
+	}
+	return gvrs, nil
+}
+
 func (ds *diskStorage) SaveClusterInfo(key storage.Key, content []byte) error {
+	if ds.readOnly {
+		return storage.ErrStorageReadOnly
+	}
 	if key.Key() == "" {
 		return storage.ErrUnknownClusterInfoType
 	}
@@ -461,12 +616,14 @@ func (ds *diskStorage) GetClusterInfo(key storage.Key) ([]byte, error) {
 
 // Recover will walk the baseDir of this diskStorage, and try to recover the storage
 // using backup file. It works when yurthub or the node breaks down and restart.
+// The _internal dir under baseDir is skipped, because it's managed by other storages.
 //
 // Note:
 // If a dir/file is a tmp dir/file, then we assume that any parent path should not be tmp path.
 // Because we lock the path when manipulating it.
 func (ds *diskStorage) Recover() error {
 	recoveredDir := map[string]struct{}{}
+	internalDir := filepath.Join(ds.baseDir, "_internal")
 	err := filepath.Walk(ds.baseDir, func(path string, info os.FileInfo, err error) error {
 		for p := range recoveredDir {
 			if strings.HasPrefix(path, p) {
@@ -477,6 +634,9 @@ func (ds *diskStorage) Recover() error {
 		if err != nil {
 			return err
 		}
+		if path == internalDir && info.IsDir() {
+			return filepath.SkipDir
+		}
 
 		if isTmpFile(path) {
 			switch {
@@ -17,6 +17,7 @@ limitations under the License.
 package disk
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -1223,8 +1224,14 @@ var _ = Describe("Test DiskStorage Exposed Functions", func() {
 	})
 })
 
+// checkFileAt reads the file at path, and the checksum header will be stripped
+// if the file is written by diskStorage.
 func checkFileAt(path string) ([]byte, error) {
-	return os.ReadFile(path)
+	buf, err := os.ReadFile(path)
+	if err != nil || !bytes.HasPrefix(buf, checksumPrefix) {
+		return buf, err
+	}
+	return DecodeContent(buf)
 }
 
 func writeFileAt(path string, content []byte) error {
@@ -1320,7 +1327,7 @@ func getFilesUnderDir(dir string) (map[string][]byte, error) {
 	contents := map[string][]byte{}
 	for i := range infos {
 		if infos[i].Type().IsRegular() {
-			buf, err := os.ReadFile(filepath.Join(dir, infos[i].Name()))
+			buf, err := checkFileAt(filepath.Join(dir, infos[i].Name()))
 			if err != nil {
 				return nil, err
 			}
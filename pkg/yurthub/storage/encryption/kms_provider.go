@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	kmsapi "k8s.io/kms/apis/v2"
+)
+
+const KMSProviderName = "kms"
+
+// kmsKeyProvider wraps DEKs through a local KMS plugin which implements the kubernetes KMS v2 gRPC API,
+// so the same plugins used for kube-apiserver encryption at rest can be reused by yurthub.
+type kmsKeyProvider struct {
+	client  kmsapi.KeyManagementServiceClient
+	timeout time.Duration
+}
+
+// NewKMSKeyProvider creates a KeyProvider which connects to the KMS plugin listening on endpoint.
+// endpoint should be a unix socket in the format of unix:///path/to/socket or /path/to/socket.
+func NewKMSKeyProvider(endpoint string, timeout time.Duration) (KeyProvider, error) {
+	if !strings.HasPrefix(endpoint, "unix://") {
+		endpoint = "unix://" + endpoint
+	}
+
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to kms plugin %s, %w", endpoint, err)
+	}
+
+	return &kmsKeyProvider{
+		client:  kmsapi.NewKeyManagementServiceClient(conn),
+		timeout: timeout,
+	}, nil
+}
+
+func (p *kmsKeyProvider) Name() string {
+	return KMSProviderName
+}
+
+func (p *kmsKeyProvider) KeyID(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	resp, err := p.client.Status(ctx, &kmsapi.StatusRequest{})
+	if err != nil {
+		return "", fmt.Errorf("could not get status of kms plugin, %w", err)
+	}
+	if resp.Healthz != "ok" {
+		return "", fmt.Errorf("kms plugin is not healthy, %s", resp.Healthz)
+	}
+	if len(resp.KeyId) == 0 {
+		return "", fmt.Errorf("kms plugin returns empty key id")
+	}
+	return resp.KeyId, nil
+}
+
+func (p *kmsKeyProvider) Wrap(ctx context.Context, dek []byte) (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	resp, err := p.client.Encrypt(ctx, &kmsapi.EncryptRequest{
+		Plaintext: dek,
+		Uid:       uuid.New().String(),
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("could not encrypt data encryption key by kms plugin, %w", err)
+	}
+	return resp.KeyId, resp.Ciphertext, nil
+}
+
+func (p *kmsKeyProvider) Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	resp, err := p.client.Decrypt(ctx, &kmsapi.DecryptRequest{
+		Ciphertext: wrapped,
+		KeyId:      keyID,
+		Uid:        uuid.New().String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt data encryption key by kms plugin, %w", err)
+	}
+	return resp.Plaintext, nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	// ErrKeyNotFound indicates that the key encryption key used by the cached data is unknown to the provider.
+	ErrKeyNotFound = errors.New("key encryption key is not found")
+)
+
+// KeyProvider wraps and unwraps data encryption keys(DEK) with the key encryption key(KEK)
+// which is managed by the provider. The cached data is only encrypted with DEKs, so yurthub
+// only needs to call the provider when a new DEK is generated or a DEK is read at the first time.
+type KeyProvider interface {
+	// Name returns the name of provider, which will be recorded with the encrypted data.
+	Name() string
+	// KeyID returns id of the KEK which will be used for wrapping new DEKs.
+	// If the returned id is changed, the cached data will be re-encrypted with the new KEK.
+	KeyID(ctx context.Context) (string, error)
+	// Wrap encrypts dek with the current KEK and returns the id of KEK and the wrapped dek.
+	Wrap(ctx context.Context, dek []byte) (string, []byte, error)
+	// Unwrap decrypts the wrapped dek with KEK specified by keyID.
+	// If the KEK can not be found, ErrKeyNotFound will be returned.
+	Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+const FileProviderName = "file"
+
+type fileKeyProvider struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// NewFileKeyProvider creates a KeyProvider with KEKs loaded from the key file.
+// Each non-empty line of the key file is in the format of <name>:<base64 encoded key>, and the
+// key should be 16, 24 or 32 bytes for AES-128, AES-192 or AES-256. Lines start with # are ignored.
+// The first key is used for wrapping new DEKs, and the others are only used for unwrapping,
+// so KEK can be rotated by adding a new key at the head of the file and restarting yurthub.
+// The old key can be removed after the cached data has been re-encrypted.
+func NewFileKeyProvider(path string) (KeyProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read key file %s, %w", path, err)
+	}
+
+	p := &fileKeyProvider{
+		keys: make(map[string]cipher.AEAD),
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, encoded, found := strings.Cut(line, ":")
+		if !found || len(name) == 0 {
+			return nil, fmt.Errorf("invalid key in key file %s, the format should be <name>:<base64 encoded key>", path)
+		}
+		if _, ok := p.keys[name]; ok {
+			return nil, fmt.Errorf("key %s is duplicated in key file %s", name, path)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("could not decode key %s in key file %s, %w", name, path, err)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s in key file %s, %w", name, path, err)
+		}
+
+		if len(p.primary) == 0 {
+			p.primary = name
+		}
+		p.keys[name] = aead
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read key file %s, %w", path, err)
+	}
+
+	if len(p.primary) == 0 {
+		return nil, fmt.Errorf("no key is found in key file %s", path)
+	}
+	return p, nil
+}
+
+func (p *fileKeyProvider) Name() string {
+	return FileProviderName
+}
+
+func (p *fileKeyProvider) KeyID(_ context.Context) (string, error) {
+	return p.primary, nil
+}
+
+func (p *fileKeyProvider) Wrap(_ context.Context, dek []byte) (string, []byte, error) {
+	wrapped, err := seal(p.keys[p.primary], dek)
+	if err != nil {
+		return "", nil, err
+	}
+	return p.primary, wrapped, nil
+}
+
+func (p *fileKeyProvider) Unwrap(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := p.keys[keyID]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return open(aead, wrapped)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with a random nonce, and the nonce is placed at the head of returned data.
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("could not generate nonce, %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("encrypted data is too short")
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
@@ -0,0 +1,655 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+)
+
+const (
+	// maxDEKUsage is the number of objects which can be encrypted by one DEK, a new DEK will
+	// be generated after that in order to keep the random nonce of AES-GCM collision-safe.
+	maxDEKUsage = 1 << 20
+	// keyCheckPlaintext is encrypted into the key check file, and used for verifying KEK at startup.
+	keyCheckPlaintext = "openyurt.io/yurthub-cache-encryption"
+	// encryptedField is the field of envelope which holds the encrypted object.
+	encryptedField = "yurthubEncryptedContent"
+	// providerTimeout is the max duration of calling key provider, which may be a remote KMS.
+	providerTimeout = 5 * time.Second
+)
+
+var (
+	// ErrKeyMismatch indicates that the cache can not be decrypted by the configured key provider.
+	ErrKeyMismatch = errors.New("encryption key does not match the cache")
+
+	keyIDCheckPeriod = time.Minute
+)
+
+// envelope is the stored format of an encrypted object. apiVersion, kind and resourceVersion of
+// the object are kept in plaintext, so the backend storage can still compare resource versions
+// of the encrypted objects when updating them.
+type envelope struct {
+	APIVersion string            `json:"apiVersion,omitempty"`
+	Kind       string            `json:"kind,omitempty"`
+	Metadata   envelopeMetadata  `json:"metadata"`
+	Encrypted  *encryptedContent `json:"yurthubEncryptedContent,omitempty"`
+}
+
+type envelopeMetadata struct {
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type encryptedContent struct {
+	Provider string `json:"provider"`
+	KeyID    string `json:"keyID"`
+	DEK      []byte `json:"dek"`
+	Data     []byte `json:"data"`
+}
+
+// dataKey is a DEK which is used for encrypting objects.
+type dataKey struct {
+	keyID   string
+	wrapped []byte
+	aead    cipher.AEAD
+	usage   int
+}
+
+// encryptionKey records the gvr of key, so encryptedStorage can decide whether
+// the object of key should be encrypted or not.
+type encryptionKey struct {
+	inner storage.Key
+	gvr   schema.GroupVersionResource
+}
+
+func (k encryptionKey) Key() string {
+	return k.inner.Key()
+}
+
+// encryptedStorage is a storage.Store which encrypts objects of specified resources with AES-GCM
+// before writing them into the backend storage, and decrypts them transparently when reading.
+// Each object is encrypted with a data encryption key(DEK), and the DEK is wrapped by
+// the key encryption key(KEK) from KeyProvider and stored together with the object.
+type encryptedStorage struct {
+	sync.Mutex
+	store        storage.Store
+	provider     KeyProvider
+	resources    map[schema.GroupVersionResource]struct{}
+	keyCheckPath string
+	currentKeyID string
+	currentDEK   *dataKey
+	// deks caches unwrapped DEKs with the wrapped DEK as key.
+	deks map[string]cipher.AEAD
+	// unwrapGroup deduplicates concurrent unwrapping of the same DEK, so reads of objects
+	// encrypted by different DEKs are not serialized behind one call of the key provider.
+	unwrapGroup singleflight.Group
+	// wrapGroup deduplicates concurrent generating of new DEKs.
+	wrapGroup singleflight.Group
+}
+
+// NewEncryptedStorage wraps store with an encryption layer. Objects of resources will be encrypted
+// when they are written into store. keyCheckPath is used for saving a value encrypted by the KEK, and
+// if the value can not be decrypted at startup, ErrKeyMismatch is returned in order to refuse starting
+// with a wrong key. When the KEK is rotated, the cached objects will be re-encrypted in the background.
+func NewEncryptedStorage(store storage.Store, provider KeyProvider, resources []schema.GroupVersionResource, keyCheckPath string, stopCh <-chan struct{}) (storage.Store, error) {
+	keyID, err := provider.KeyID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("could not get key id from %s key provider, %w", provider.Name(), err)
+	}
+
+	es := &encryptedStorage{
+		store:        store,
+		provider:     provider,
+		resources:    make(map[schema.GroupVersionResource]struct{}),
+		keyCheckPath: keyCheckPath,
+		currentKeyID: keyID,
+		deks:         make(map[string]cipher.AEAD),
+	}
+	for _, gvr := range resources {
+		es.resources[gvr] = struct{}{}
+	}
+
+	checkedKeyID, err := es.verifyKey()
+	if err != nil {
+		return nil, err
+	}
+
+	go es.run(checkedKeyID, stopCh)
+	return es, nil
+}
+
+func (es *encryptedStorage) Name() string {
+	return es.store.Name()
+}
+
+func (es *encryptedStorage) KeyFunc(info storage.KeyBuildInfo) (storage.Key, error) {
+	key, err := es.store.KeyFunc(info)
+	if err != nil {
+		return nil, err
+	}
+	return encryptionKey{
+		inner: key,
+		gvr: schema.GroupVersionResource{
+			Group:    info.Group,
+			Version:  info.Version,
+			Resource: info.Resources,
+		},
+	}, nil
+}
+
+func (es *encryptedStorage) Create(key storage.Key, content []byte) error {
+	content, err := es.encryptFor(key, content)
+	if err != nil {
+		return err
+	}
+	return es.store.Create(innerKey(key), content)
+}
+
+func (es *encryptedStorage) Delete(key storage.Key) error {
+	return es.store.Delete(innerKey(key))
+}
+
+func (es *encryptedStorage) Get(key storage.Key) ([]byte, error) {
+	content, err := es.store.Get(innerKey(key))
+	if err != nil {
+		return content, err
+	}
+	return es.decrypt(content)
+}
+
+func (es *encryptedStorage) List(key storage.Key) ([][]byte, error) {
+	bb, err := es.store.List(innerKey(key))
+	if err != nil {
+		return bb, err
+	}
+
+	for i := range bb {
+		if bb[i], err = es.decrypt(bb[i]); err != nil {
+			return nil, fmt.Errorf("could not decrypt object under %s, %w", key.Key(), err)
+		}
+	}
+	return bb, nil
+}
+
+func (es *encryptedStorage) Update(key storage.Key, content []byte, rv uint64) ([]byte, error) {
+	encrypted, err := es.encryptFor(key, content)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := es.store.Update(innerKey(key), encrypted, rv)
+	if err != nil && err != storage.ErrUpdateConflict {
+		return stored, err
+	}
+	if err == nil {
+		return content, nil
+	}
+
+	old, derr := es.decrypt(stored)
+	if derr != nil {
+		return nil, derr
+	}
+	return old, err
+}
+
+func (es *encryptedStorage) ListResourceKeysOfComponent(component string, gvr schema.GroupVersionResource) ([]storage.Key, error) {
+	keys, err := es.store.ListResourceKeysOfComponent(component, gvr)
+	if err != nil {
+		return keys, err
+	}
+
+	for i := range keys {
+		keys[i] = encryptionKey{inner: keys[i], gvr: gvr}
+	}
+	return keys, nil
+}
+
+func (es *encryptedStorage) ReplaceComponentList(component string, gvr schema.GroupVersionResource, namespace string, contents map[storage.Key][]byte) error {
+	_, shouldEncrypt := es.resources[gvr]
+	innerContents := make(map[storage.Key][]byte, len(contents))
+	for key, content := range contents {
+		if shouldEncrypt {
+			encrypted, err := es.encrypt(content)
+			if err != nil {
+				return err
+			}
+			content = encrypted
+		}
+		innerContents[innerKey(key)] = content
+	}
+	return es.store.ReplaceComponentList(component, gvr, namespace, innerContents)
+}
+
+func (es *encryptedStorage) DeleteComponentResources(component string) error {
+	return es.store.DeleteComponentResources(component)
+}
+
+func (es *encryptedStorage) ListComponents() ([]string, error) {
+	return es.store.ListComponents()
+}
+
+func (es *encryptedStorage) ListResourcesOfComponent(component string) ([]schema.GroupVersionResource, error) {
+	return es.store.ListResourcesOfComponent(component)
+}
+
+func (es *encryptedStorage) SaveClusterInfo(key storage.Key, content []byte) error {
+	return es.store.SaveClusterInfo(key, content)
+}
+
+func (es *encryptedStorage) GetClusterInfo(key storage.Key) ([]byte, error) {
+	return es.store.GetClusterInfo(key)
+}
+
+func (es *encryptedStorage) Verify() (*storage.VerifyResult, error) {
+	verifier, ok := es.store.(storage.Verifier)
+	if !ok {
+		return nil, storage.ErrVerifyNotSupported
+	}
+	return verifier.Verify()
+}
+
+func (es *encryptedStorage) ListQuarantinedKeys() ([]storage.Key, error) {
+	verifier, ok := es.store.(storage.Verifier)
+	if !ok {
+		return nil, storage.ErrVerifyNotSupported
+	}
+	return verifier.ListQuarantinedKeys()
+}
+
+func (es *encryptedStorage) DeleteQuarantinedKey(key storage.Key) error {
+	verifier, ok := es.store.(storage.Verifier)
+	if !ok {
+		return storage.ErrVerifyNotSupported
+	}
+	return verifier.DeleteQuarantinedKey(innerKey(key))
+}
+
+func (es *encryptedStorage) LastUpdateTime(key storage.Key) (time.Time, error) {
+	inspector, ok := es.store.(storage.Inspector)
+	if !ok {
+		return time.Time{}, storage.ErrInspectNotSupported
+	}
+	return inspector.LastUpdateTime(innerKey(key))
+}
+
+func (es *encryptedStorage) encryptFor(key storage.Key, content []byte) ([]byte, error) {
+	k, ok := key.(encryptionKey)
+	if !ok || len(content) == 0 {
+		return content, nil
+	}
+	if _, ok := es.resources[k.gvr]; !ok {
+		return content, nil
+	}
+	return es.encrypt(content)
+}
+
+// encrypt seals content with the current DEK into an envelope.
+func (es *encryptedStorage) encrypt(content []byte) ([]byte, error) {
+	env := &envelope{}
+	if err := json.Unmarshal(content, env); err != nil {
+		return nil, fmt.Errorf("could not parse object for encryption, %w", err)
+	}
+
+	dek, err := es.dataKeyForWrite()
+	if err != nil {
+		return nil, err
+	}
+	data, err := seal(dek.aead, content)
+	if err != nil {
+		return nil, err
+	}
+
+	env.Encrypted = &encryptedContent{
+		Provider: es.provider.Name(),
+		KeyID:    dek.keyID,
+		DEK:      dek.wrapped,
+		Data:     data,
+	}
+	return json.Marshal(env)
+}
+
+// decrypt returns the original object of content. If content is not encrypted,
+// such as objects cached before encryption is enabled, it is returned directly.
+func (es *encryptedStorage) decrypt(content []byte) ([]byte, error) {
+	env, err := parseEnvelope(content)
+	if err != nil || env == nil {
+		return content, err
+	}
+
+	if env.Encrypted.Provider != es.provider.Name() {
+		return nil, fmt.Errorf("object is encrypted by %s key provider, but %s is configured, %w", env.Encrypted.Provider, es.provider.Name(), ErrKeyMismatch)
+	}
+	aead, err := es.dataKeyForRead(env.Encrypted.KeyID, env.Encrypted.DEK)
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, env.Encrypted.Data)
+}
+
+// dataKeyForWrite returns the current DEK, and a new DEK is generated when the current one is used up or
+// the KEK is rotated. the new DEK is wrapped without holding the lock, because key provider may be a remote
+// KMS, and reads and writes of cache should not be blocked by it.
+func (es *encryptedStorage) dataKeyForWrite() (*dataKey, error) {
+	es.Lock()
+	if dek := es.currentDEK; es.usableLocked(dek) {
+		dek.usage++
+		es.Unlock()
+		return dek, nil
+	}
+	es.Unlock()
+
+	v, err, _ := es.wrapGroup.Do("", func() (interface{}, error) {
+		return es.newDataKey()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	es.Lock()
+	defer es.Unlock()
+	// usage of the new DEK may exceed maxDEKUsage by the number of concurrent writers,
+	// which is far from the limit of random nonces.
+	dek := v.(*dataKey)
+	dek.usage++
+	return dek, nil
+}
+
+// newDataKey generates and wraps a new DEK, and it becomes the current DEK unless the current DEK
+// has been replaced by a usable one while wrapping.
+func (es *encryptedStorage) newDataKey() (*dataKey, error) {
+	es.Lock()
+	if dek := es.currentDEK; es.usableLocked(dek) {
+		es.Unlock()
+		return dek, nil
+	}
+	es.Unlock()
+
+	plain := make([]byte, 32)
+	if _, err := rand.Read(plain); err != nil {
+		return nil, fmt.Errorf("could not generate data encryption key, %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), providerTimeout)
+	defer cancel()
+	keyID, wrapped, err := es.provider.Wrap(ctx, plain)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	es.Lock()
+	defer es.Unlock()
+	if es.usableLocked(es.currentDEK) {
+		return es.currentDEK, nil
+	}
+	es.currentDEK = &dataKey{
+		keyID:   keyID,
+		wrapped: wrapped,
+		aead:    aead,
+	}
+	es.deks[string(wrapped)] = aead
+	return es.currentDEK, nil
+}
+
+func (es *encryptedStorage) usableLocked(dek *dataKey) bool {
+	return dek != nil && dek.keyID == es.currentKeyID && dek.usage < maxDEKUsage
+}
+
+func (es *encryptedStorage) dataKeyForRead(keyID string, wrapped []byte) (cipher.AEAD, error) {
+	es.Lock()
+	aead, ok := es.deks[string(wrapped)]
+	es.Unlock()
+	if ok {
+		return aead, nil
+	}
+
+	v, err, _ := es.unwrapGroup.Do(string(wrapped), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), providerTimeout)
+		defer cancel()
+		plain, err := es.provider.Unwrap(ctx, keyID, wrapped)
+		if err != nil {
+			return nil, fmt.Errorf("could not unwrap data encryption key with key %s, %v, %w", keyID, err, ErrKeyMismatch)
+		}
+		aead, err := newAEAD(plain)
+		if err != nil {
+			return nil, err
+		}
+
+		es.Lock()
+		es.deks[string(wrapped)] = aead
+		es.Unlock()
+		return aead, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(cipher.AEAD), nil
+}
+
+// verifyKey decrypts the key check file with the key provider, and returns the key id which
+// is used for the key check file. The key check file will be created if it does not exist, and
+// an empty key id is returned in order to encrypt objects which are cached before encryption is enabled.
+func (es *encryptedStorage) verifyKey() (string, error) {
+	content, err := os.ReadFile(es.keyCheckPath)
+	if os.IsNotExist(err) {
+		klog.Infof("key check file %s does not exist, create it with key %s", es.keyCheckPath, es.currentKeyID)
+		return "", es.writeKeyCheck()
+	} else if err != nil {
+		return "", fmt.Errorf("could not read key check file %s, %w", es.keyCheckPath, err)
+	}
+
+	env, err := parseEnvelope(content)
+	if err != nil || env == nil {
+		return "", fmt.Errorf("invalid key check file %s, %v", es.keyCheckPath, err)
+	}
+	plain, err := es.decrypt(content)
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt key check file %s, %v, %w", es.keyCheckPath, err, ErrKeyMismatch)
+	}
+	if string(plain) != checkObject() {
+		return "", fmt.Errorf("unexpected content in key check file %s, %w", es.keyCheckPath, ErrKeyMismatch)
+	}
+	return env.Encrypted.KeyID, nil
+}
+
+func (es *encryptedStorage) writeKeyCheck() error {
+	content, err := es.encrypt([]byte(checkObject()))
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(es.keyCheckPath), 0700); err != nil {
+		return fmt.Errorf("could not create dir for key check file %s, %w", es.keyCheckPath, err)
+	}
+	tmpPath := es.keyCheckPath + ".tmp"
+	if err := os.WriteFile(tmpPath, content, 0600); err != nil {
+		return fmt.Errorf("could not write key check file %s, %w", tmpPath, err)
+	}
+	return os.Rename(tmpPath, es.keyCheckPath)
+}
+
+// run re-encrypts the cached objects when the KEK used by the cache is different from the
+// current KEK of provider, and checks the KEK of provider periodically.
+func (es *encryptedStorage) run(checkedKeyID string, stopCh <-chan struct{}) {
+	wait.Until(func() {
+		keyID, err := es.provider.KeyID(context.Background())
+		if err != nil {
+			klog.Errorf("could not get key id from %s key provider, %v", es.provider.Name(), err)
+			return
+		}
+
+		es.Lock()
+		if keyID != es.currentKeyID {
+			klog.Infof("key encryption key is rotated from %s to %s", es.currentKeyID, keyID)
+			es.currentKeyID = keyID
+		}
+		es.Unlock()
+
+		if keyID == checkedKeyID {
+			return
+		}
+		if err := es.reencrypt(keyID, stopCh); err != nil {
+			klog.Errorf("could not re-encrypt cache with key %s, %v", keyID, err)
+			return
+		}
+		if err := es.writeKeyCheck(); err != nil {
+			klog.Errorf("could not update key check file after re-encryption, %v", err)
+			return
+		}
+		checkedKeyID = keyID
+	}, keyIDCheckPeriod, stopCh)
+}
+
+// reencrypt walks all objects of encrypted resources, and encrypts the objects which are not encrypted
+// or encrypted by other KEKs with current KEK.
+func (es *encryptedStorage) reencrypt(keyID string, stopCh <-chan struct{}) error {
+	components, err := es.store.ListComponents()
+	if err != nil {
+		return err
+	}
+
+	klog.Infof("start to re-encrypt cache with key %s", keyID)
+	var cnt, failed int
+	for _, comp := range components {
+		for gvr := range es.resources {
+			keys, err := es.store.ListResourceKeysOfComponent(comp, gvr)
+			if errors.Is(err, storage.ErrStorageNotFound) {
+				continue
+			} else if err != nil {
+				return fmt.Errorf("could not list keys of %s for %s, %w", gvr.String(), comp, err)
+			}
+
+			for _, key := range keys {
+				select {
+				case <-stopCh:
+					return errors.New("re-encryption is stopped")
+				default:
+				}
+
+				reencrypted, err := es.reencryptKey(key, keyID)
+				if err != nil {
+					klog.Errorf("could not re-encrypt %s, %v", key.Key(), err)
+					failed++
+					continue
+				}
+				if reencrypted {
+					cnt++
+				}
+			}
+		}
+	}
+
+	klog.Infof("re-encrypted %d objects with key %s, %d failed", cnt, keyID, failed)
+	if failed != 0 {
+		return fmt.Errorf("%d objects could not be re-encrypted", failed)
+	}
+	return nil
+}
+
+func (es *encryptedStorage) reencryptKey(key storage.Key, keyID string) (bool, error) {
+	content, err := es.store.Get(key)
+	if errors.Is(err, storage.ErrStorageNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	env, err := parseEnvelope(content)
+	if err != nil {
+		return false, err
+	}
+	if env != nil && env.Encrypted.Provider == es.provider.Name() && env.Encrypted.KeyID == keyID {
+		return false, nil
+	}
+
+	plain, err := es.decrypt(content)
+	if err != nil {
+		return false, err
+	}
+	encrypted, err := es.encrypt(plain)
+	if err != nil {
+		return false, err
+	}
+
+	meta := &envelope{}
+	if err := json.Unmarshal(plain, meta); err != nil {
+		return false, err
+	}
+	rv, _ := strconv.ParseUint(meta.Metadata.ResourceVersion, 10, 64)
+	_, err = es.store.Update(key, encrypted, rv)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, storage.ErrUpdateConflict), errors.Is(err, storage.ErrStorageNotFound), errors.Is(err, storage.ErrStorageAccessConflict):
+		// the object has been updated or deleted concurrently, and newer object is encrypted by current key.
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// IsEncrypted checks whether content is an object encrypted by encrypted storage.
+func IsEncrypted(content []byte) bool {
+	env, err := parseEnvelope(content)
+	return err == nil && env != nil
+}
+
+// parseEnvelope returns the envelope of content, and returns nil if content is not encrypted.
+func parseEnvelope(content []byte) (*envelope, error) {
+	if !bytes.Contains(content, []byte(encryptedField)) {
+		return nil, nil
+	}
+
+	env := &envelope{}
+	if err := json.Unmarshal(content, env); err != nil {
+		return nil, fmt.Errorf("could not parse envelope of encrypted object, %w", err)
+	}
+	if env.Encrypted == nil {
+		return nil, nil
+	}
+	return env, nil
+}
+
+func checkObject() string {
+	return fmt.Sprintf(`{"apiVersion":"v1","kind":"KeyCheck","metadata":{"name":%q}}`, keyCheckPlaintext)
+}
+
+func innerKey(key storage.Key) storage.Key {
+	if k, ok := key.(encryptionKey); ok {
+		return k.inner
+	}
+	return key
+}
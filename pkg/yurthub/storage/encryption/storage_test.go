@@ -0,0 +1,399 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/disk"
+)
+
+var (
+	secretsGVR = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	podsGVR    = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+)
+
+func writeKeyFile(t *testing.T, dir string, names ...string) string {
+	lines := []string{"# keys for testing"}
+	for _, name := range names {
+		key := bytes.Repeat([]byte(name[:1]), 32)
+		lines = append(lines, fmt.Sprintf("%s:%s", name, base64.StdEncoding.EncodeToString(key)))
+	}
+	path := filepath.Join(dir, "keys")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0600); err != nil {
+		t.Fatalf("could not write key file, %v", err)
+	}
+	return path
+}
+
+func newTestStorage(t *testing.T, cacheDir string, keyNames ...string) (*encryptedStorage, error) {
+	provider, err := NewFileKeyProvider(writeKeyFile(t, t.TempDir(), keyNames...))
+	if err != nil {
+		t.Fatalf("could not create key provider, %v", err)
+	}
+	store, err := disk.NewDiskStorage(cacheDir)
+	if err != nil {
+		t.Fatalf("could not create disk storage, %v", err)
+	}
+
+	// background re-encryption is disabled by a closed stopCh, tests will call reencrypt directly.
+	stopCh := make(chan struct{})
+	close(stopCh)
+	es, err := NewEncryptedStorage(store, provider, []schema.GroupVersionResource{secretsGVR}, filepath.Join(cacheDir, "_internal", "key-check"), stopCh)
+	if err != nil {
+		return nil, err
+	}
+	return es.(*encryptedStorage), nil
+}
+
+func objectContent(kind, name string, rv int) []byte {
+	return []byte(fmt.Sprintf(`{"apiVersion":"v1","kind":%q,"metadata":{"name":%q,"namespace":"default","resourceVersion":"%d"},"data":{"password":"c2VjcmV0"}}`, kind, name, rv))
+}
+
+func objectKey(t *testing.T, es *encryptedStorage, gvr schema.GroupVersionResource, name string) storage.Key {
+	key, err := es.KeyFunc(storage.KeyBuildInfo{
+		Component: "kubelet",
+		Resources: gvr.Resource,
+		Version:   gvr.Version,
+		Group:     gvr.Group,
+		Namespace: "default",
+		Name:      name,
+	})
+	if err != nil {
+		t.Fatalf("could not get key, %v", err)
+	}
+	return key
+}
+
+func TestNewFileKeyProvider(t *testing.T) {
+	testcases := map[string]struct {
+		content string
+		primary string
+		wantErr bool
+	}{
+		"first key is primary": {
+			content: "# comment\n\nnew:" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("n"), 32)) + "\nold:" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("o"), 16)),
+			primary: "new",
+		},
+		"no key": {
+			content: "# comment only",
+			wantErr: true,
+		},
+		"invalid key length": {
+			content: "foo:" + base64.StdEncoding.EncodeToString([]byte("short")),
+			wantErr: true,
+		},
+		"invalid format": {
+			content: base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("n"), 32)),
+			wantErr: true,
+		},
+		"duplicated key": {
+			content: "foo:" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("n"), 32)) + "\nfoo:" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("n"), 32)),
+			wantErr: true,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "keys")
+			if err := os.WriteFile(path, []byte(tc.content), 0600); err != nil {
+				t.Fatalf("could not write key file, %v", err)
+			}
+			p, err := NewFileKeyProvider(path)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expect error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, but got %v", err)
+			}
+			if keyID, _ := p.KeyID(t.Context()); keyID != tc.primary {
+				t.Errorf("expect primary key %s, but got %s", tc.primary, keyID)
+			}
+		})
+	}
+}
+
+func TestEncryptAndDecrypt(t *testing.T) {
+	cacheDir := t.TempDir()
+	es, err := newTestStorage(t, cacheDir, "key1")
+	if err != nil {
+		t.Fatalf("could not create encrypted storage, %v", err)
+	}
+
+	secretKey := objectKey(t, es, secretsGVR, "foo")
+	secret := objectContent("Secret", "foo", 10)
+	if err := es.Create(secretKey, secret); err != nil {
+		t.Fatalf("could not create secret, %v", err)
+	}
+	podKey := objectKey(t, es, podsGVR, "bar")
+	pod := objectContent("Pod", "bar", 10)
+	if err := es.Create(podKey, pod); err != nil {
+		t.Fatalf("could not create pod, %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(cacheDir, secretKey.Key()))
+	if err != nil {
+		t.Fatalf("could not read secret file, %v", err)
+	}
+	if bytes.Contains(raw, []byte("c2VjcmV0")) || !bytes.Contains(raw, []byte(encryptedField)) {
+		t.Errorf("expect secret to be encrypted on disk, but got %s", string(raw))
+	}
+	raw, err = os.ReadFile(filepath.Join(cacheDir, podKey.Key()))
+	if err == nil {
+		raw, err = disk.DecodeContent(raw)
+	}
+	if err != nil || !bytes.Equal(raw, pod) {
+		t.Errorf("expect pod to be stored in plaintext, but got %s, %v", string(raw), err)
+	}
+
+	if got, err := es.Get(secretKey); err != nil || !bytes.Equal(got, secret) {
+		t.Errorf("expect decrypted secret, but got %s, %v", string(got), err)
+	}
+
+	// the backend storage should still be able to compare resource version of encrypted objects.
+	if _, err := es.Update(secretKey, objectContent("Secret", "foo", 5), 5); !errors.Is(err, storage.ErrUpdateConflict) {
+		t.Errorf("expect ErrUpdateConflict, but got %v", err)
+	}
+	updated := objectContent("Secret", "foo", 20)
+	if _, err := es.Update(secretKey, updated, 20); err != nil {
+		t.Errorf("could not update secret, %v", err)
+	}
+
+	list := map[storage.Key][]byte{
+		objectKey(t, es, secretsGVR, "baz"): objectContent("Secret", "baz", 30),
+	}
+	if err := es.ReplaceComponentList("kubelet", secretsGVR, "default", list); err != nil {
+		t.Fatalf("could not replace list, %v", err)
+	}
+	rootKey, _ := es.KeyFunc(storage.KeyBuildInfo{Component: "kubelet", Resources: "secrets", Version: "v1"})
+	bb, err := es.List(rootKey)
+	if err != nil || len(bb) != 1 || !bytes.Equal(bb[0], objectContent("Secret", "baz", 30)) {
+		t.Errorf("expect decrypted list, but got %d items, %v", len(bb), err)
+	}
+}
+
+func TestRefuseMismatchedKey(t *testing.T) {
+	cacheDir := t.TempDir()
+	if _, err := newTestStorage(t, cacheDir, "key1"); err != nil {
+		t.Fatalf("could not create encrypted storage, %v", err)
+	}
+
+	if _, err := newTestStorage(t, cacheDir, "other"); !errors.Is(err, ErrKeyMismatch) {
+		t.Errorf("expect ErrKeyMismatch, but got %v", err)
+	}
+
+	if _, err := newTestStorage(t, cacheDir, "new", "key1"); err != nil {
+		t.Errorf("expect rotated keys to be accepted, but got %v", err)
+	}
+}
+
+func TestReencrypt(t *testing.T) {
+	cacheDir := t.TempDir()
+	store, err := disk.NewDiskStorage(cacheDir)
+	if err != nil {
+		t.Fatalf("could not create disk storage, %v", err)
+	}
+	plainKey, _ := store.KeyFunc(storage.KeyBuildInfo{Component: "kubelet", Resources: "secrets", Version: "v1", Namespace: "default", Name: "plain"})
+	if err := store.Create(plainKey, objectContent("Secret", "plain", 1)); err != nil {
+		t.Fatalf("could not create plaintext secret, %v", err)
+	}
+
+	es, err := newTestStorage(t, cacheDir, "key1")
+	if err != nil {
+		t.Fatalf("could not create encrypted storage, %v", err)
+	}
+	oldKey := objectKey(t, es, secretsGVR, "old")
+	if err := es.Create(oldKey, objectContent("Secret", "old", 2)); err != nil {
+		t.Fatalf("could not create secret, %v", err)
+	}
+
+	rotated, err := newTestStorage(t, cacheDir, "key2", "key1")
+	if err != nil {
+		t.Fatalf("could not create encrypted storage with rotated keys, %v", err)
+	}
+	if err := rotated.reencrypt("key2", make(chan struct{})); err != nil {
+		t.Fatalf("could not re-encrypt, %v", err)
+	}
+	if err := rotated.writeKeyCheck(); err != nil {
+		t.Fatalf("could not write key check file, %v", err)
+	}
+
+	for _, name := range []string{"plain", "old"} {
+		key := objectKey(t, rotated, secretsGVR, name)
+		raw, err := store.Get(innerKey(key))
+		if err != nil {
+			t.Fatalf("could not get %s, %v", name, err)
+		}
+		env, err := parseEnvelope(raw)
+		if err != nil || env == nil || env.Encrypted.KeyID != "key2" {
+			t.Errorf("expect %s to be encrypted by key2, but got %v, %v", name, env, err)
+		}
+	}
+
+	// old key can be removed after re-encryption.
+	only, err := newTestStorage(t, cacheDir, "key2")
+	if err != nil {
+		t.Fatalf("could not create encrypted storage with new key only, %v", err)
+	}
+	if got, err := only.Get(objectKey(t, only, secretsGVR, "old")); err != nil || !bytes.Equal(got, objectContent("Secret", "old", 2)) {
+		t.Errorf("expect secret to be decrypted by new key, but got %s, %v", string(got), err)
+	}
+}
+
+type countingKeyProvider struct {
+	KeyProvider
+	unwraps atomic.Int32
+	release chan struct{}
+}
+
+func (p *countingKeyProvider) Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	p.unwraps.Add(1)
+	<-p.release
+	return p.KeyProvider.Unwrap(ctx, keyID, wrapped)
+}
+
+func TestConcurrentUnwrap(t *testing.T) {
+	cacheDir := t.TempDir()
+	es, err := newTestStorage(t, cacheDir, "key1")
+	if err != nil {
+		t.Fatalf("could not create encrypted storage, %v", err)
+	}
+	secretKey := objectKey(t, es, secretsGVR, "foo")
+	if err := es.Create(secretKey, objectContent("Secret", "foo", 10)); err != nil {
+		t.Fatalf("could not create secret, %v", err)
+	}
+
+	// drop the cached DEKs, so all of readers need to unwrap the DEK by key provider.
+	provider := &countingKeyProvider{KeyProvider: es.provider, release: make(chan struct{})}
+	es.provider = provider
+	es.deks = make(map[string]cipher.AEAD)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := es.Get(secretKey)
+			errs <- err
+		}()
+	}
+
+	// the storage lock should not be held while unwrapping DEK.
+	if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return provider.unwraps.Load() > 0, nil
+	}); err != nil {
+		t.Fatalf("DEK is not unwrapped, %v", err)
+	}
+	if _, err := es.dataKeyForWrite(); err != nil {
+		t.Errorf("could not get data key for write while unwrapping, %v", err)
+	}
+
+	close(provider.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("could not get secret, %v", err)
+		}
+	}
+	if cnt := provider.unwraps.Load(); cnt != 1 {
+		t.Errorf("expect DEK to be unwrapped once, but got %d", cnt)
+	}
+}
+
+type blockingWrapKeyProvider struct {
+	KeyProvider
+	wraps   atomic.Int32
+	release chan struct{}
+}
+
+func (p *blockingWrapKeyProvider) Wrap(ctx context.Context, dek []byte) (string, []byte, error) {
+	p.wraps.Add(1)
+	<-p.release
+	return p.KeyProvider.Wrap(ctx, dek)
+}
+
+func TestWrapWithoutLock(t *testing.T) {
+	cacheDir := t.TempDir()
+	es, err := newTestStorage(t, cacheDir, "key1")
+	if err != nil {
+		t.Fatalf("could not create encrypted storage, %v", err)
+	}
+	oldKey := objectKey(t, es, secretsGVR, "old")
+	if err := es.Create(oldKey, objectContent("Secret", "old", 1)); err != nil {
+		t.Fatalf("could not create secret, %v", err)
+	}
+
+	// the current DEK is used up, so writers need to wrap a new DEK by key provider.
+	provider := &blockingWrapKeyProvider{KeyProvider: es.provider, release: make(chan struct{})}
+	es.provider = provider
+	es.Lock()
+	es.currentDEK.usage = maxDEKUsage
+	es.Unlock()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- es.Create(objectKey(t, es, secretsGVR, fmt.Sprintf("new-%d", i)), objectContent("Secret", "new", 2))
+		}(i)
+	}
+
+	// reads of cache should not be blocked while wrapping DEK.
+	if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return provider.wraps.Load() > 0, nil
+	}); err != nil {
+		t.Fatalf("DEK is not wrapped, %v", err)
+	}
+	if got, err := es.Get(oldKey); err != nil || !bytes.Equal(got, objectContent("Secret", "old", 1)) {
+		t.Errorf("expect secret to be read while wrapping, but got %s, %v", string(got), err)
+	}
+
+	close(provider.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("could not create secret, %v", err)
+		}
+	}
+	if cnt := provider.wraps.Load(); cnt != 1 {
+		t.Errorf("expect DEK to be wrapped once, but got %d", cnt)
+	}
+}
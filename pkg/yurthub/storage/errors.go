@@ -56,3 +56,12 @@ var ErrEmptyResource = errors.New("resource is empty")
 
 // ErrUnknownClusterInfoType indicates the ClusterInfo type is unknown to the storage.
 var ErrUnknownClusterInfoType = errors.New("unknown ClusterInfoType")
+
+// ErrVerifyNotSupported indicates that the storage can not verify the integrity of cached objects.
+var ErrVerifyNotSupported = errors.New("integrity verification is not supported by the storage")
+
+// ErrInspectNotSupported indicates that the storage can not provide the last update time of cached objects.
+var ErrInspectNotSupported = errors.New("last update time is not supported by the storage")
+
+// ErrStorageReadOnly indicates that the storage is opened in read-only mode and can not be written.
+var ErrStorageReadOnly = errors.New("storage is read-only")
@@ -38,6 +38,15 @@ type ClusterInfoKey struct {
 
 type ClusterInfoType string
 
+// ClusterInfoPaths contains url paths of non resource requests whose responses are cached as cluster info.
+var ClusterInfoPaths = map[string]ClusterInfoType{
+	"/version":                         Version,
+	"/apis/discovery.k8s.io/v1":        APIResourcesInfo,
+	"/apis/discovery.k8s.io/v1beta1":   APIResourcesInfo,
+	"/apis/raven.openyurt.io/v1alpha1": APIResourcesInfo,
+	"/apis/raven.openyurt.io/v1beta1":  APIResourcesInfo,
+}
+
 func (key *ClusterInfoKey) Key() string {
 	switch key.ClusterInfoType {
 	case APIsInfo, Version:
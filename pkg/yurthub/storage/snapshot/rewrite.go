@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"encoding/json"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// rewriteNodeName replaces oldNode with newNode in the object bound to the node, and returns
+// the name and content of object after rewriting. The following objects are rewritten:
+// 1. node: name and hostname label.
+// 2. node lease: name and holder identity.
+// 3. pod: node name, and name of mirror pod which is suffixed with node name.
+// Objects in other resources are returned directly.
+func rewriteNodeName(gvr schema.GroupVersionResource, namespace, name string, content []byte, oldNode, newNode string) (string, []byte, error) {
+	if oldNode == newNode {
+		return name, content, nil
+	}
+
+	if !isNodeBound(gvr, namespace, name, oldNode) {
+		return name, content, nil
+	}
+
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(content, &obj); err != nil {
+		return name, nil, err
+	}
+
+	newName := name
+	switch gvr.Resource {
+	case "nodes":
+		newName = newNode
+		if hostname, _, _ := unstructured.NestedString(obj, "metadata", "labels", corev1.LabelHostname); hostname == oldNode {
+			if err := unstructured.SetNestedField(obj, newNode, "metadata", "labels", corev1.LabelHostname); err != nil {
+				return name, nil, err
+			}
+		}
+	case "leases":
+		newName = newNode
+		if holder, _, _ := unstructured.NestedString(obj, "spec", "holderIdentity"); holder == oldNode {
+			if err := unstructured.SetNestedField(obj, newNode, "spec", "holderIdentity"); err != nil {
+				return name, nil, err
+			}
+		}
+	case "pods":
+		nodeName, _, _ := unstructured.NestedString(obj, "spec", "nodeName")
+		if nodeName != oldNode {
+			return name, content, nil
+		}
+		if err := unstructured.SetNestedField(obj, newNode, "spec", "nodeName"); err != nil {
+			return name, nil, err
+		}
+		// mirror pods of static pods are named with the node name as suffix.
+		if _, isMirror, _ := unstructured.NestedString(obj, "metadata", "annotations", corev1.MirrorPodAnnotationKey); isMirror && strings.HasSuffix(name, "-"+oldNode) {
+			newName = strings.TrimSuffix(name, oldNode) + newNode
+		}
+	}
+
+	if _, found, _ := unstructured.NestedString(obj, "metadata", "name"); found {
+		if err := unstructured.SetNestedField(obj, newName, "metadata", "name"); err != nil {
+			return name, nil, err
+		}
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return name, nil, err
+	}
+	return newName, data, nil
+}
+
+// isNodeBound checks whether the object may be bound to the node, and should be rewritten when node name is changed.
+func isNodeBound(gvr schema.GroupVersionResource, namespace, name, nodeName string) bool {
+	switch {
+	case gvr.Group == "" && gvr.Resource == "nodes":
+		return name == nodeName
+	case gvr.Group == "coordination.k8s.io" && gvr.Resource == "leases":
+		return namespace == corev1.NamespaceNodeLease && name == nodeName
+	case gvr.Group == "" && gvr.Resource == "pods":
+		return true
+	default:
+		return false
+	}
+}
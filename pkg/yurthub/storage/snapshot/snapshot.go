@@ -0,0 +1,379 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/encryption"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/utils"
+)
+
+const (
+	// Version is the version of snapshot format.
+	Version = "v1"
+
+	manifestFile   = "manifest.json"
+	objectsDir     = "objects"
+	clusterInfoDir = "clusterinfo"
+)
+
+// excludedResources are not exported into the snapshot, because they are useless
+// for running workloads in autonomy.
+var excludedResources = map[string]struct{}{
+	"events": {},
+}
+
+// Manifest describes the contents of a snapshot, and it's always the first file of the snapshot tarball.
+type Manifest struct {
+	Version   string `json:"version"`
+	NodeName  string `json:"nodeName"`
+	CreatedAt string `json:"createdAt"`
+	// Resources contains all resources cached for components, including
+	// resources which have no object cached.
+	Resources   []Resource    `json:"resources"`
+	ClusterInfo []ClusterInfo `json:"clusterInfo"`
+	// Checksums contains sha256 of all files in the snapshot except the manifest.
+	Checksums map[string]string `json:"checksums"`
+	// Encrypted contains the object files which are encrypted at rest by yurthub of the exporting node,
+	// and they can only be read by yurthub with the same encryption keys.
+	Encrypted []string `json:"encrypted,omitempty"`
+}
+
+// Resource is a resource cached for the component.
+type Resource struct {
+	Component string `json:"component"`
+	Group     string `json:"group"`
+	Version   string `json:"version"`
+	Resource  string `json:"resource"`
+	Objects   int    `json:"objects"`
+}
+
+// ClusterInfo is the cluster info saved by SaveClusterInfo.
+type ClusterInfo struct {
+	Type    storage.ClusterInfoType `json:"type"`
+	URLPath string                  `json:"urlPath,omitempty"`
+}
+
+func (r Resource) gvr() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource}
+}
+
+func (ci ClusterInfo) key() *storage.ClusterInfoKey {
+	return &storage.ClusterInfoKey{ClusterInfoType: ci.Type, URLPath: ci.URLPath}
+}
+
+// Export writes the cached objects and cluster info in store into w as a gzipped tarball.
+// nodeName is the name of node which the cache belongs to, and it will be replaced
+// when the snapshot is imported for another node.
+// The store should not be written by others when exporting, so a consistent snapshot can be got.
+func Export(store storage.Store, nodeName string, w io.Writer) (*Manifest, error) {
+	manifest := &Manifest{
+		Version:     Version,
+		NodeName:    nodeName,
+		CreatedAt:   time.Now().UTC().Format(time.RFC3339),
+		Resources:   []Resource{},
+		ClusterInfo: []ClusterInfo{},
+		Checksums:   map[string]string{},
+	}
+	files := map[string][]byte{}
+
+	components, err := store.ListComponents()
+	if err != nil {
+		return nil, fmt.Errorf("could not list components, %w", err)
+	}
+	for _, comp := range components {
+		if err := exportComponent(store, comp, manifest, files); err != nil {
+			return nil, err
+		}
+	}
+
+	clusterInfos := []ClusterInfo{{Type: storage.APIsInfo}}
+	for urlPath, infoType := range storage.ClusterInfoPaths {
+		clusterInfos = append(clusterInfos, ClusterInfo{Type: infoType, URLPath: urlPath})
+	}
+	for _, ci := range clusterInfos {
+		data, err := store.GetClusterInfo(ci.key())
+		if errors.Is(err, storage.ErrStorageNotFound) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("could not get cluster info %s, %w", ci.key().Key(), err)
+		}
+		manifest.ClusterInfo = append(manifest.ClusterInfo, ci)
+		files[path.Join(clusterInfoDir, ci.key().Key())] = data
+	}
+
+	sort.Slice(manifest.Resources, func(i, j int) bool {
+		ri, rj := manifest.Resources[i], manifest.Resources[j]
+		if ri.Component != rj.Component {
+			return ri.Component < rj.Component
+		}
+		return ri.gvr().String() < rj.gvr().String()
+	})
+	sort.Slice(manifest.ClusterInfo, func(i, j int) bool {
+		return manifest.ClusterInfo[i].key().Key() < manifest.ClusterInfo[j].key().Key()
+	})
+	for name, data := range files {
+		manifest.Checksums[name] = checksum(data)
+		if strings.HasPrefix(name, objectsDir+"/") && encryption.IsEncrypted(data) {
+			manifest.Encrypted = append(manifest.Encrypted, name)
+		}
+	}
+	sort.Strings(manifest.Encrypted)
+
+	if err := writeTarball(w, manifest, files); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func exportComponent(store storage.Store, component string, manifest *Manifest, files map[string][]byte) error {
+	gvrs, err := store.ListResourcesOfComponent(component)
+	if errors.Is(err, storage.ErrStorageNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("could not list resources of component %s, %w", component, err)
+	}
+
+	for _, gvr := range gvrs {
+		if gvr.Resource == "partialobjectmetadatas" {
+			// objects for requests with convert gvk are cached under the sub dir of component
+			if err := exportComponent(store, strings.Join([]string{component, gvr.Resource + "." + gvr.Version + "." + gvr.Group}, "/"), manifest, files); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, ok := excludedResources[gvr.Resource]; ok {
+			continue
+		}
+
+		res := Resource{Component: component, Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource}
+		keys, err := store.ListResourceKeysOfComponent(component, gvr)
+		if err != nil && !errors.Is(err, storage.ErrStorageNotFound) {
+			return fmt.Errorf("could not list keys of %s for component %s, %w", gvr.String(), component, err)
+		}
+		for _, key := range keys {
+			content, err := store.Get(key)
+			if errors.Is(err, storage.ErrStorageNotFound) {
+				continue
+			} else if err != nil {
+				return fmt.Errorf("could not get %s, %w", key.Key(), err)
+			}
+			files[path.Join(objectsDir, key.Key())] = content
+			res.Objects++
+		}
+		manifest.Resources = append(manifest.Resources, res)
+	}
+	return nil
+}
+
+// Import reads the snapshot from r and writes its contents into store. If nodeName is different
+// from the node name of snapshot, the node name in objects bound to the node, like pods, node and
+// node lease, will be replaced with nodeName.
+// Objects encrypted at rest are imported as they are, so yurthub on the importing node should use
+// the same encryption keys, otherwise they should be skipped by skipEncrypted.
+// The cached objects of resources in the snapshot will be replaced, and other resources in the store are kept.
+func Import(store storage.Store, r io.Reader, nodeName string, skipEncrypted bool) (*Manifest, error) {
+	manifest, files, err := readTarball(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodeName) == 0 {
+		nodeName = manifest.NodeName
+	}
+	encrypted := make(map[string]struct{}, len(manifest.Encrypted))
+	for _, name := range manifest.Encrypted {
+		encrypted[name] = struct{}{}
+	}
+
+	type resourceKey struct {
+		component string
+		gvr       schema.GroupVersionResource
+	}
+	contents := map[resourceKey]map[storage.Key][]byte{}
+	for _, res := range manifest.Resources {
+		contents[resourceKey{component: res.Component, gvr: res.gvr()}] = map[storage.Key][]byte{}
+	}
+	for name, data := range files {
+		if !strings.HasPrefix(name, objectsDir+"/") {
+			continue
+		}
+		comp, gvr, ns, objName, ok := utils.ParseObjectKey(strings.TrimPrefix(name, objectsDir+"/"))
+		if !ok {
+			klog.Warningf("skip to import unrecognized object %s in snapshot", name)
+			continue
+		}
+		objs, ok := contents[resourceKey{component: comp, gvr: gvr}]
+		if !ok {
+			return nil, fmt.Errorf("object %s is not in the resources of snapshot", name)
+		}
+
+		if _, ok := encrypted[name]; ok {
+			if skipEncrypted {
+				klog.Infof("skip to import encrypted object %s in snapshot", name)
+				continue
+			}
+			if manifest.NodeName != nodeName && isNodeBound(gvr, ns, objName, manifest.NodeName) {
+				return nil, fmt.Errorf("could not rewrite node name of encrypted object %s, it should be skipped", name)
+			}
+		} else {
+			objName, data, err = rewriteNodeName(gvr, ns, objName, data, manifest.NodeName, nodeName)
+			if err != nil {
+				return nil, fmt.Errorf("could not rewrite node name of %s, %w", name, err)
+			}
+		}
+		key, err := store.KeyFunc(storage.KeyBuildInfo{
+			Component: comp,
+			Resources: gvr.Resource,
+			Group:     gvr.Group,
+			Version:   gvr.Version,
+			Namespace: ns,
+			Name:      objName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not get key for %s, %w", name, err)
+		}
+		objs[key] = data
+	}
+
+	for res, objs := range contents {
+		if err := store.ReplaceComponentList(res.component, res.gvr, "", objs); err != nil {
+			return nil, fmt.Errorf("could not import %s for component %s, %w", res.gvr.String(), res.component, err)
+		}
+	}
+	for _, ci := range manifest.ClusterInfo {
+		data, ok := files[path.Join(clusterInfoDir, ci.key().Key())]
+		if !ok {
+			return nil, fmt.Errorf("cluster info %s is not found in snapshot", ci.key().Key())
+		}
+		if err := store.SaveClusterInfo(ci.key(), data); err != nil {
+			return nil, fmt.Errorf("could not import cluster info %s, %w", ci.key().Key(), err)
+		}
+	}
+	return manifest, nil
+}
+
+func writeTarball(w io.Writer, manifest *Manifest, files map[string][]byte) error {
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal manifest, %w", err)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	modTime := time.Now()
+	writeFile := func(name string, data []byte) error {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: modTime}); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	if err := writeFile(manifestFile, manifestData); err != nil {
+		return fmt.Errorf("could not write manifest, %w", err)
+	}
+	for _, name := range names {
+		if err := writeFile(name, files[name]); err != nil {
+			return fmt.Errorf("could not write %s, %w", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func readTarball(r io.Reader) (*Manifest, map[string][]byte, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read snapshot, %w", err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	var manifest *Manifest
+	files := map[string][]byte{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("could not read snapshot, %w", err)
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not read %s in snapshot, %w", hdr.Name, err)
+		}
+		if manifest == nil {
+			if hdr.Name != manifestFile {
+				return nil, nil, fmt.Errorf("the first file of snapshot should be %s, but got %s", manifestFile, hdr.Name)
+			}
+			manifest = &Manifest{}
+			if err := json.Unmarshal(data, manifest); err != nil {
+				return nil, nil, fmt.Errorf("could not parse manifest, %w", err)
+			}
+			if manifest.Version != Version {
+				return nil, nil, fmt.Errorf("unsupported snapshot version %q, only %q is supported", manifest.Version, Version)
+			}
+			continue
+		}
+
+		expected, ok := manifest.Checksums[hdr.Name]
+		if !ok {
+			return nil, nil, fmt.Errorf("%s in snapshot is not recorded in manifest", hdr.Name)
+		} else if checksum(data) != expected {
+			return nil, nil, fmt.Errorf("checksum of %s in snapshot mismatch", hdr.Name)
+		}
+		files[hdr.Name] = data
+	}
+
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("snapshot is empty")
+	}
+	if len(files) != len(manifest.Checksums) {
+		return nil, nil, fmt.Errorf("snapshot is incomplete, expect %d files, but got %d", len(manifest.Checksums), len(files))
+	}
+	return manifest, files, nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
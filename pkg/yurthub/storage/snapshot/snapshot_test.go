@@ -0,0 +1,310 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/bolt"
+	"github.com/openyurtio/openyurt/pkg/yurthub/storage/disk"
+)
+
+type testObject struct {
+	component string
+	gvr       schema.GroupVersionResource
+	namespace string
+	name      string
+	content   string
+}
+
+var (
+	nodesGVR  = schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
+	podsGVR   = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	leasesGVR = schema.GroupVersionResource{Group: "coordination.k8s.io", Version: "v1", Resource: "leases"}
+	eventsGVR = schema.GroupVersionResource{Version: "v1", Resource: "events"}
+	svcGVR    = schema.GroupVersionResource{Version: "v1", Resource: "services"}
+)
+
+func objectKey(t *testing.T, store storage.Store, obj testObject) storage.Key {
+	key, err := store.KeyFunc(storage.KeyBuildInfo{
+		Component: obj.component,
+		Resources: obj.gvr.Resource,
+		Group:     obj.gvr.Group,
+		Version:   obj.gvr.Version,
+		Namespace: obj.namespace,
+		Name:      obj.name,
+	})
+	if err != nil {
+		t.Fatalf("could not get key, %v", err)
+	}
+	return key
+}
+
+func prepareStore(t *testing.T, store storage.Store) {
+	objs := []testObject{
+		{"kubelet", nodesGVR, "", "node1", `{"kind":"Node","apiVersion":"v1","metadata":{"name":"node1","labels":{"kubernetes.io/hostname":"node1"}}}`},
+		{"kubelet", podsGVR, "default", "nginx", `{"kind":"Pod","apiVersion":"v1","metadata":{"name":"nginx","namespace":"default"},"spec":{"nodeName":"node1"}}`},
+		{"kubelet", podsGVR, "kube-system", "etcd-node1", `{"kind":"Pod","apiVersion":"v1","metadata":{"name":"etcd-node1","namespace":"kube-system","annotations":{"kubernetes.io/config.mirror":"hash"}},"spec":{"nodeName":"node1"}}`},
+		{"kubelet", leasesGVR, "kube-node-lease", "node1", `{"kind":"Lease","apiVersion":"coordination.k8s.io/v1","metadata":{"name":"node1","namespace":"kube-node-lease"},"spec":{"holderIdentity":"node1"}}`},
+		{"kubelet", eventsGVR, "default", "event1", `{"kind":"Event","apiVersion":"v1","metadata":{"name":"event1","namespace":"default"}}`},
+		{"kubelet/partialobjectmetadatas.v1.meta.k8s.io", nodesGVR, "", "node1", `{"kind":"PartialObjectMetadata","apiVersion":"meta.k8s.io/v1","metadata":{"name":"node1"}}`},
+	}
+	for _, obj := range objs {
+		if err := store.Create(objectKey(t, store, obj), []byte(obj.content)); err != nil {
+			t.Fatalf("could not create %s, %v", obj.name, err)
+		}
+	}
+	// resource without any object cached
+	if err := store.ReplaceComponentList("kube-proxy", svcGVR, "", map[storage.Key][]byte{}); err != nil {
+		t.Fatalf("could not create empty list, %v", err)
+	}
+	if err := store.SaveClusterInfo(&storage.ClusterInfoKey{ClusterInfoType: storage.Version}, []byte(`{"major":"1"}`)); err != nil {
+		t.Fatalf("could not save cluster info, %v", err)
+	}
+}
+
+func TestExportAndImport(t *testing.T) {
+	newStores := map[string]func(dir string) (storage.Store, error){
+		"disk": disk.NewDiskStorage,
+		"bolt": bolt.NewBoltStorage,
+	}
+
+	for name, newStore := range newStores {
+		t.Run(name, func(t *testing.T) {
+			src, err := newStore(t.TempDir())
+			if err != nil {
+				t.Fatalf("could not create source storage, %v", err)
+			}
+			prepareStore(t, src)
+
+			buf := &bytes.Buffer{}
+			manifest, err := Export(src, "node1", buf)
+			if err != nil {
+				t.Fatalf("could not export snapshot, %v", err)
+			}
+			if len(manifest.Resources) != 5 || len(manifest.ClusterInfo) != 1 {
+				t.Errorf("expect 5 resources and 1 cluster info, but got %#v", manifest)
+			}
+
+			dst, err := newStore(t.TempDir())
+			if err != nil {
+				t.Fatalf("could not create target storage, %v", err)
+			}
+			if _, err := Import(dst, bytes.NewReader(buf.Bytes()), "node2", false); err != nil {
+				t.Fatalf("could not import snapshot, %v", err)
+			}
+
+			expected := []testObject{
+				{"kubelet", nodesGVR, "", "node2", `{"apiVersion":"v1","kind":"Node","metadata":{"labels":{"kubernetes.io/hostname":"node2"},"name":"node2"}}`},
+				{"kubelet", podsGVR, "default", "nginx", `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"nginx","namespace":"default"},"spec":{"nodeName":"node2"}}`},
+				{"kubelet", podsGVR, "kube-system", "etcd-node2", `{"apiVersion":"v1","kind":"Pod","metadata":{"annotations":{"kubernetes.io/config.mirror":"hash"},"name":"etcd-node2","namespace":"kube-system"},"spec":{"nodeName":"node2"}}`},
+				{"kubelet", leasesGVR, "kube-node-lease", "node2", `{"apiVersion":"coordination.k8s.io/v1","kind":"Lease","metadata":{"name":"node2","namespace":"kube-node-lease"},"spec":{"holderIdentity":"node2"}}`},
+				{"kubelet/partialobjectmetadatas.v1.meta.k8s.io", nodesGVR, "", "node2", `{"apiVersion":"meta.k8s.io/v1","kind":"PartialObjectMetadata","metadata":{"name":"node2"}}`},
+			}
+			for _, obj := range expected {
+				got, err := dst.Get(objectKey(t, dst, obj))
+				if err != nil || string(got) != obj.content {
+					t.Errorf("expect %s, but got %s, %v", obj.content, string(got), err)
+				}
+			}
+
+			if _, err := dst.Get(objectKey(t, dst, testObject{"kubelet", eventsGVR, "default", "event1", ""})); !errors.Is(err, storage.ErrStorageNotFound) {
+				t.Errorf("expect events not to be imported, but got %v", err)
+			}
+			if objs, err := dst.List(objectKey(t, dst, testObject{component: "kube-proxy", gvr: svcGVR})); err != nil || len(objs) != 0 {
+				t.Errorf("expect empty list of services, but got %d objects, %v", len(objs), err)
+			}
+			if info, err := dst.GetClusterInfo(&storage.ClusterInfoKey{ClusterInfoType: storage.Version}); err != nil || string(info) != `{"major":"1"}` {
+				t.Errorf("expect version info to be imported, but got %s, %v", string(info), err)
+			}
+		})
+	}
+}
+
+func TestImportInvalidSnapshot(t *testing.T) {
+	src, err := disk.NewDiskStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("could not create source storage, %v", err)
+	}
+	prepareStore(t, src)
+	buf := &bytes.Buffer{}
+	if _, err := Export(src, "node1", buf); err != nil {
+		t.Fatalf("could not export snapshot, %v", err)
+	}
+
+	// rewrite modifies the files of snapshot and builds a new one.
+	rewrite := func(modify func(name string, data []byte) (string, []byte)) []byte {
+		gr, err := gzip.NewReader(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("could not read snapshot, %v", err)
+		}
+		out := &bytes.Buffer{}
+		gw := gzip.NewWriter(out)
+		tw := tar.NewWriter(gw)
+		tr := tar.NewReader(gr)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			data, _ := io.ReadAll(tr)
+			name, data := modify(hdr.Name, data)
+			if len(name) == 0 {
+				continue
+			}
+			tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(data))})
+			tw.Write(data)
+		}
+		tw.Close()
+		gw.Close()
+		return out.Bytes()
+	}
+
+	testcases := map[string][]byte{
+		"tampered object": rewrite(func(name string, data []byte) (string, []byte) {
+			if strings.HasPrefix(name, objectsDir) {
+				return name, append(data, ' ')
+			}
+			return name, data
+		}),
+		"missing object": rewrite(func(name string, data []byte) (string, []byte) {
+			if strings.HasSuffix(name, "nginx") {
+				return "", nil
+			}
+			return name, data
+		}),
+		"unsupported version": rewrite(func(name string, data []byte) (string, []byte) {
+			if name == manifestFile {
+				manifest := &Manifest{}
+				json.Unmarshal(data, manifest)
+				manifest.Version = "v0"
+				data, _ = json.Marshal(manifest)
+			}
+			return name, data
+		}),
+		"not a snapshot": []byte("foo"),
+	}
+
+	for k, data := range testcases {
+		t.Run(k, func(t *testing.T) {
+			dst, err := disk.NewDiskStorage(t.TempDir())
+			if err != nil {
+				t.Fatalf("could not create target storage, %v", err)
+			}
+			if _, err := Import(dst, bytes.NewReader(data), "node2", false); err == nil {
+				t.Errorf("expect error for invalid snapshot, but got nil")
+			}
+		})
+	}
+}
+
+func TestImportEncryptedObjects(t *testing.T) {
+	secretsGVR := schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+	encrypted := `{"apiVersion":"v1","kind":"Secret","metadata":{"resourceVersion":"1"},"yurthubEncryptedContent":{"provider":"file","keyID":"key1","dek":"ZGVr","data":"ZGF0YQ=="}}`
+	objs := []testObject{
+		{"kubelet", secretsGVR, "default", "secret1", encrypted},
+		{"kubelet", podsGVR, "default", "nginx", encrypted},
+	}
+
+	testcases := map[string]struct {
+		objs          []testObject
+		nodeName      string
+		skipEncrypted bool
+		expectErr     bool
+		expectObjs    int
+	}{
+		"import encrypted objects": {
+			objs:       objs[:1],
+			nodeName:   "node2",
+			expectObjs: 1,
+		},
+		"skip encrypted objects": {
+			objs:          objs,
+			nodeName:      "node2",
+			skipEncrypted: true,
+			expectObjs:    0,
+		},
+		"encrypted pod can not be rewritten": {
+			objs:      objs,
+			nodeName:  "node2",
+			expectErr: true,
+		},
+		"encrypted pod without rewriting": {
+			objs:       objs,
+			nodeName:   "node1",
+			expectObjs: 2,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			src, err := disk.NewDiskStorage(t.TempDir())
+			if err != nil {
+				t.Fatalf("could not create source storage, %v", err)
+			}
+			for _, obj := range tc.objs {
+				if err := src.Create(objectKey(t, src, obj), []byte(obj.content)); err != nil {
+					t.Fatalf("could not create %s, %v", obj.name, err)
+				}
+			}
+			buf := &bytes.Buffer{}
+			manifest, err := Export(src, "node1", buf)
+			if err != nil {
+				t.Fatalf("could not export snapshot, %v", err)
+			}
+			if len(manifest.Encrypted) != len(tc.objs) {
+				t.Errorf("expect %d encrypted objects in manifest, but got %v", len(tc.objs), manifest.Encrypted)
+			}
+
+			dst, err := disk.NewDiskStorage(t.TempDir())
+			if err != nil {
+				t.Fatalf("could not create target storage, %v", err)
+			}
+			_, err = Import(dst, bytes.NewReader(buf.Bytes()), tc.nodeName, tc.skipEncrypted)
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("expect error %v, but got %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				return
+			}
+
+			var cnt int
+			for _, obj := range tc.objs {
+				got, err := dst.Get(objectKey(t, dst, obj))
+				if err == nil {
+					cnt++
+					if string(got) != obj.content {
+						t.Errorf("expect encrypted object %s to be imported as it is, but got %s", obj.name, string(got))
+					}
+				}
+			}
+			if cnt != tc.expectObjs {
+				t.Errorf("expect %d objects imported, but got %d", tc.expectObjs, cnt)
+			}
+		})
+	}
+}
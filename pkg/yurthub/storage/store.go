@@ -17,6 +17,8 @@ limitations under the License.
 package storage
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -120,4 +122,46 @@ type componentRelatedHandler interface {
 	// DeleteComponentResources will delete all resources associated with the component.
 	// If component is Empty, ErrEmptyComponent will be returned.
 	DeleteComponentResources(component string) error
+
+	// ListComponents will get names of all components which have resources cached in the store.
+	// If no component has been cached, an empty slice will be returned.
+	ListComponents() ([]string, error)
+
+	// ListResourcesOfComponent will get all gvrs which have been cached for the component.
+	// If component is Empty, ErrEmptyComponent will be returned.
+	// If the cache of component can not be found, ErrStorageNotFound will be returned.
+	ListResourcesOfComponent(component string) ([]schema.GroupVersionResource, error)
+}
+
+// Verifier is an optional interface for stores which can verify the integrity of cached objects.
+type Verifier interface {
+	// Verify will check the integrity of all cached objects, and the corrupted objects will be
+	// quarantined, which means they are removed from the store and recorded as quarantined keys.
+	Verify() (*VerifyResult, error)
+
+	// ListQuarantinedKeys will get keys of all quarantined objects.
+	// If no object has been quarantined, an empty slice will be returned.
+	ListQuarantinedKeys() ([]Key, error)
+
+	// DeleteQuarantinedKey will delete the quarantine record of key, it's used when the object
+	// has been re-fetched from the cloud or it does not exist any more.
+	DeleteQuarantinedKey(key Key) error
+}
+
+// VerifyResult is the result of verifying the integrity of cached objects.
+type VerifyResult struct {
+	// Checked is the number of objects which have been checked.
+	Checked int `json:"checked"`
+	// Skipped is the number of objects which are being manipulated and skipped.
+	Skipped int `json:"skipped"`
+	// Quarantined contains keys of corrupted objects which have been quarantined.
+	Quarantined []string `json:"quarantined"`
+}
+
+// Inspector is an optional interface for stores which can provide the last update time of cached objects.
+type Inspector interface {
+	// LastUpdateTime will get the time when the content of key was written into the store at last.
+	// The key must indicate a specific resource.
+	// If this key does not exist in this store, ErrStorageNotFound will be returned.
+	LastUpdateTime(key Key) (time.Time, error)
 }
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ParseResourceSegment parses the resource segment of key in the format of <Resource>.<Version>.<Group>,
+// and core group is represented as "core" in the segment. false is returned if the segment
+// is not in the format, like the segment used by disk storage which is not in enhancement mode.
+func ParseResourceSegment(segment string) (schema.GroupVersionResource, bool) {
+	elems := strings.SplitN(segment, ".", 3)
+	if len(elems) != 3 || len(elems[0]) == 0 || len(elems[1]) == 0 || len(elems[2]) == 0 {
+		return schema.GroupVersionResource{}, false
+	}
+
+	gvr := schema.GroupVersionResource{
+		Resource: elems[0],
+		Version:  elems[1],
+		Group:    elems[2],
+	}
+	if gvr.Group == "core" {
+		gvr.Group = ""
+	}
+	return gvr, true
+}
+
+// ParseObjectKey parses the key in the format of <Component>/<Resource.Version.Group>/[<Namespace>/]<Name>,
+// which is used by both disk and bolt storage. The component may be attached with convert gvk,
+// like kubelet/partialobjectmetadatas.v1.meta.k8s.io.
+func ParseObjectKey(path string) (string, schema.GroupVersionResource, string, string, bool) {
+	elems := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(elems) > 3 && strings.HasPrefix(elems[1], "partialobjectmetadatas.") {
+		elems = append([]string{elems[0] + "/" + elems[1]}, elems[2:]...)
+	}
+	if len(elems) < 3 || len(elems) > 4 {
+		return "", schema.GroupVersionResource{}, "", "", false
+	}
+
+	gvr, ok := ParseResourceSegment(elems[1])
+	if !ok {
+		return "", schema.GroupVersionResource{}, "", "", false
+	}
+	if len(elems) == 3 {
+		return elems[0], gvr, "", elems[2], true
+	}
+	return elems[0], gvr, elems[2], elems[3], true
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseObjectKey(t *testing.T) {
+	testcases := map[string]struct {
+		path      string
+		component string
+		gvr       schema.GroupVersionResource
+		namespace string
+		name      string
+		ok        bool
+	}{
+		"namespaced object": {
+			path:      "kubelet/pods.v1.core/default/foo",
+			component: "kubelet",
+			gvr:       schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+			namespace: "default",
+			name:      "foo",
+			ok:        true,
+		},
+		"cluster scoped object": {
+			path:      "kubelet/nodes.v1.core/foo",
+			component: "kubelet",
+			gvr:       schema.GroupVersionResource{Version: "v1", Resource: "nodes"},
+			name:      "foo",
+			ok:        true,
+		},
+		"object with convert gvk": {
+			path:      "kubelet/partialobjectmetadatas.v1.meta.k8s.io/pods.v1.core/default/foo",
+			component: "kubelet/partialobjectmetadatas.v1.meta.k8s.io",
+			gvr:       schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+			namespace: "default",
+			name:      "foo",
+			ok:        true,
+		},
+		"legacy key": {
+			path: "kubelet/pods/default/foo",
+		},
+		"root key": {
+			path: "kubelet/pods.v1.core",
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			comp, gvr, ns, name, ok := ParseObjectKey(tc.path)
+			if ok != tc.ok {
+				t.Fatalf("expect ok %v, but got %v", tc.ok, ok)
+			}
+			if comp != tc.component || gvr != tc.gvr || ns != tc.namespace || name != tc.name {
+				t.Errorf("expect %s %v %s %s, but got %s %v %s %s", tc.component, tc.gvr, tc.namespace, tc.name, comp, gvr, ns, name)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"net/url"
 
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 type nopRoundTrip struct {
@@ -75,3 +76,16 @@ func (f *fakeTransportManager) GetDirectClientsetAtRandom() kubernetes.Interface
 func (f *fakeTransportManager) ListDirectClientset() map[string]kubernetes.Interface {
 	return f.serverToClientset
 }
+
+func (f *fakeTransportManager) UpdateServers(servers []*url.URL, _ int) error {
+	serverToClientset := make(map[string]kubernetes.Interface, len(servers))
+	for i := range servers {
+		if clientset, ok := f.serverToClientset[servers[i].String()]; ok {
+			serverToClientset[servers[i].String()] = clientset
+		} else {
+			serverToClientset[servers[i].String()] = fake.NewSimpleClientset()
+		}
+	}
+	f.serverToClientset = serverToClientset
+	return nil
+}
@@ -19,8 +19,10 @@ package transport
 import (
 	"crypto/tls"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	utilnet "k8s.io/apimachinery/pkg/util/net"
@@ -56,6 +58,9 @@ type Interface interface {
 	GetDirectClientsetAtRandom() kubernetes.Interface
 	// ListDirectClientset returns all clientsets
 	ListDirectClientset() map[string]kubernetes.Interface
+	// UpdateServers updates servers and timeout of direct clientsets at runtime,
+	// clientsets of unchanged servers are kept if timeout is not changed.
+	UpdateServers(servers []*url.URL, timeout int) error
 }
 
 type transportAndClientManager struct {
@@ -65,6 +70,8 @@ type transportAndClientManager struct {
 	closeAll          func()
 	close             func(string)
 	stopCh            <-chan struct{}
+	clientsetLock     sync.RWMutex
+	timeout           int
 	serverToClientset map[string]kubernetes.Interface
 }
 
@@ -111,17 +118,12 @@ func NewTransportAndClientManager(servers []*url.URL, timeout int, certGetter Ce
 		closeAll:          d.CloseAll,
 		close:             d.Close,
 		stopCh:            stopCh,
+		timeout:           timeout,
 		serverToClientset: make(map[string]kubernetes.Interface),
 	}
 
 	for i := range servers {
-		config := &rest.Config{
-			Host:      servers[i].String(),
-			Transport: t,
-			Timeout:   time.Duration(timeout) * time.Second,
-		}
-
-		clientset, err := kubernetes.NewForConfig(config)
+		clientset, err := tcm.newClientset(servers[i], timeout)
 		if err != nil {
 			return nil, err
 		}
@@ -149,6 +151,8 @@ func (tcm *transportAndClientManager) Close(address string) {
 }
 
 func (tcm *transportAndClientManager) GetDirectClientset(url *url.URL) kubernetes.Interface {
+	tcm.clientsetLock.RLock()
+	defer tcm.clientsetLock.RUnlock()
 	if url != nil {
 		return tcm.serverToClientset[url.String()]
 	}
@@ -156,6 +160,8 @@ func (tcm *transportAndClientManager) GetDirectClientset(url *url.URL) kubernete
 }
 
 func (tcm *transportAndClientManager) GetDirectClientsetAtRandom() kubernetes.Interface {
+	tcm.clientsetLock.RLock()
+	defer tcm.clientsetLock.RUnlock()
 	// iterating map uses random order
 	for server := range tcm.serverToClientset {
 		return tcm.serverToClientset[server]
@@ -165,7 +171,49 @@ func (tcm *transportAndClientManager) GetDirectClientsetAtRandom() kubernetes.In
 }
 
 func (tcm *transportAndClientManager) ListDirectClientset() map[string]kubernetes.Interface {
-	return tcm.serverToClientset
+	tcm.clientsetLock.RLock()
+	defer tcm.clientsetLock.RUnlock()
+	return maps.Clone(tcm.serverToClientset)
+}
+
+func (tcm *transportAndClientManager) UpdateServers(servers []*url.URL, timeout int) error {
+	tcm.clientsetLock.Lock()
+	defer tcm.clientsetLock.Unlock()
+
+	serverToClientset := make(map[string]kubernetes.Interface, len(servers))
+	for i := range servers {
+		if clientset, ok := tcm.serverToClientset[servers[i].String()]; ok && timeout == tcm.timeout {
+			serverToClientset[servers[i].String()] = clientset
+			continue
+		}
+
+		clientset, err := tcm.newClientset(servers[i], timeout)
+		if err != nil {
+			return err
+		}
+		serverToClientset[servers[i].String()] = clientset
+	}
+
+	// connections to removed servers are closed.
+	for server := range tcm.serverToClientset {
+		if _, ok := serverToClientset[server]; !ok {
+			if u, err := url.Parse(server); err == nil {
+				tcm.close(u.Host)
+			}
+		}
+	}
+	tcm.serverToClientset = serverToClientset
+	tcm.timeout = timeout
+	return nil
+}
+
+func (tcm *transportAndClientManager) newClientset(server *url.URL, timeout int) (kubernetes.Interface, error) {
+	config := &rest.Config{
+		Host:      server.String(),
+		Transport: tcm.currentTransport,
+		Timeout:   time.Duration(timeout) * time.Second,
+	}
+	return kubernetes.NewForConfig(config)
 }
 
 func (tcm *transportAndClientManager) start() {
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type EvictionPolicy string
+
+const (
+	// EvictionPolicyLRU evicts the least recently used objects first.
+	EvictionPolicyLRU EvictionPolicy = "lru"
+	// EvictionPolicyPriority evicts objects of resources with lower priority first,
+	// and objects with the same priority are evicted in LRU order.
+	EvictionPolicyPriority EvictionPolicy = "priority"
+)
+
+// QuotaLimit is the upper bound of cached objects, zero value of the field means no limit.
+type QuotaLimit struct {
+	Bytes   int64
+	Objects int64
+}
+
+// CacheQuotaConfig is the configuration of cache quotas and eviction policy of local cache.
+type CacheQuotaConfig struct {
+	// NodeName is used for recognizing objects which are needed for node autonomy,
+	// like node itself, pods on the node and secrets/configmaps referenced by these pods.
+	// These objects will never be evicted.
+	NodeName           string
+	ComponentQuotas    map[string]QuotaLimit
+	ResourceQuotas     map[schema.GroupVersionResource]QuotaLimit
+	EvictionPolicy     EvictionPolicy
+	ResourcePriorities map[schema.GroupVersionResource]int
+}
+
+// NewCacheQuotaConfig creates CacheQuotaConfig from the flag values, and nil will be returned
+// if neither component quotas nor resource quotas is specified.
+func NewCacheQuotaConfig(nodeName string, componentQuotas, resourceQuotas map[string]string, policy string, priorities map[string]string) (*CacheQuotaConfig, error) {
+	if len(policy) != 0 && policy != string(EvictionPolicyLRU) && policy != string(EvictionPolicyPriority) {
+		return nil, fmt.Errorf("eviction policy %s is not supported", policy)
+	}
+	compQuotas, err := ParseComponentQuotas(componentQuotas)
+	if err != nil {
+		return nil, err
+	}
+	resQuotas, err := ParseResourceQuotas(resourceQuotas)
+	if err != nil {
+		return nil, err
+	}
+	resPriorities, err := ParseResourcePriorities(priorities)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(compQuotas) == 0 && len(resQuotas) == 0 {
+		return nil, nil
+	}
+	return &CacheQuotaConfig{
+		NodeName:           nodeName,
+		ComponentQuotas:    compQuotas,
+		ResourceQuotas:     resQuotas,
+		EvictionPolicy:     EvictionPolicy(policy),
+		ResourcePriorities: resPriorities,
+	}, nil
+}
+
+// ParseQuotaLimit parses quota in the format of <bytes>[:<objects>], and bytes is a quantity like 100Mi.
+// bytes can be empty if only objects count is limited, like :1000.
+func ParseQuotaLimit(value string) (QuotaLimit, error) {
+	var limit QuotaLimit
+	bytesStr, objectsStr, _ := strings.Cut(value, ":")
+	if len(bytesStr) != 0 {
+		q, err := resource.ParseQuantity(bytesStr)
+		if err != nil {
+			return limit, fmt.Errorf("invalid bytes %s in quota %s, %w", bytesStr, value, err)
+		}
+		limit.Bytes = q.Value()
+	}
+	if len(objectsStr) != 0 {
+		objects, err := strconv.ParseInt(objectsStr, 10, 64)
+		if err != nil {
+			return limit, fmt.Errorf("invalid objects %s in quota %s, %w", objectsStr, value, err)
+		}
+		limit.Objects = objects
+	}
+
+	if limit.Bytes < 0 || limit.Objects < 0 || (limit.Bytes == 0 && limit.Objects == 0) {
+		return limit, fmt.Errorf("invalid quota %s, bytes or objects should be positive", value)
+	}
+	return limit, nil
+}
+
+// ParseComponentQuotas parses quotas in the format of component=<bytes>[:<objects>].
+func ParseComponentQuotas(values map[string]string) (map[string]QuotaLimit, error) {
+	quotas := make(map[string]QuotaLimit, len(values))
+	for comp, value := range values {
+		if len(comp) == 0 {
+			return nil, errors.New("component of quota should not be empty")
+		}
+		limit, err := ParseQuotaLimit(value)
+		if err != nil {
+			return nil, err
+		}
+		quotas[comp] = limit
+	}
+	return quotas, nil
+}
+
+// ParseResourceQuotas parses quotas in the format of group/version/resource=<bytes>[:<objects>].
+func ParseResourceQuotas(values map[string]string) (map[schema.GroupVersionResource]QuotaLimit, error) {
+	quotas := make(map[schema.GroupVersionResource]QuotaLimit, len(values))
+	for res, value := range values {
+		gvrs, err := ParseGroupVersionResources([]string{res})
+		if err != nil {
+			return nil, err
+		}
+		limit, err := ParseQuotaLimit(value)
+		if err != nil {
+			return nil, err
+		}
+		quotas[gvrs[0]] = limit
+	}
+	return quotas, nil
+}
+
+// ParseResourcePriorities parses priorities in the format of group/version/resource=<priority>.
+func ParseResourcePriorities(values map[string]string) (map[schema.GroupVersionResource]int, error) {
+	priorities := make(map[schema.GroupVersionResource]int, len(values))
+	for res, value := range values {
+		gvrs, err := ParseGroupVersionResources([]string{res})
+		if err != nil {
+			return nil, err
+		}
+		priority, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority %s of %s, %w", value, res, err)
+		}
+		priorities[gvrs[0]] = priority
+	}
+	return priorities, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestNewCacheQuotaConfig(t *testing.T) {
+	testcases := map[string]struct {
+		componentQuotas map[string]string
+		resourceQuotas  map[string]string
+		policy          string
+		priorities      map[string]string
+		expect          *CacheQuotaConfig
+		wantErr         bool
+	}{
+		"no quota": {
+			policy:     "priority",
+			priorities: map[string]string{"/v1/events": "-1"},
+			expect:     nil,
+		},
+		"valid quotas": {
+			componentQuotas: map[string]string{"kubelet": "1Ki:100", "kube-proxy": ":10"},
+			resourceQuotas:  map[string]string{"/v1/events": "1Mi"},
+			policy:          "lru",
+			expect: &CacheQuotaConfig{
+				NodeName: "foo",
+				ComponentQuotas: map[string]QuotaLimit{
+					"kubelet":    {Bytes: 1024, Objects: 100},
+					"kube-proxy": {Objects: 10},
+				},
+				ResourceQuotas: map[schema.GroupVersionResource]QuotaLimit{
+					{Version: "v1", Resource: "events"}: {Bytes: 1024 * 1024},
+				},
+				EvictionPolicy:     EvictionPolicyLRU,
+				ResourcePriorities: map[schema.GroupVersionResource]int{},
+			},
+		},
+		"invalid policy": {
+			componentQuotas: map[string]string{"kubelet": "1Ki"},
+			policy:          "fifo",
+			wantErr:         true,
+		},
+		"empty quota": {
+			componentQuotas: map[string]string{"kubelet": ":"},
+			wantErr:         true,
+		},
+		"invalid resource": {
+			resourceQuotas: map[string]string{"events": "1Mi"},
+			wantErr:        true,
+		},
+		"invalid priority": {
+			componentQuotas: map[string]string{"kubelet": "1Ki"},
+			priorities:      map[string]string{"/v1/events": "low"},
+			wantErr:         true,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			cfg, err := NewCacheQuotaConfig("foo", tc.componentQuotas, tc.resourceQuotas, tc.policy, tc.priorities)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expect error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect no error, but got %v", err)
+			}
+			if !reflect.DeepEqual(cfg, tc.expect) {
+				t.Errorf("expect %#v, but got %#v", tc.expect, cfg)
+			}
+		})
+	}
+}
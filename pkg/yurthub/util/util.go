@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
@@ -87,6 +88,20 @@ const (
 	YurtHubMultiplexerPort = 10269
 )
 
+const (
+	// StorageBackendDisk represents yurthub caches every object into a separate file under the disk cache path.
+	StorageBackendDisk = "disk"
+	// StorageBackendBolt represents yurthub caches all objects into an embedded key-value database under the disk cache path.
+	StorageBackendBolt = "bolt"
+)
+
+const (
+	// CompressionGzip represents responses from kube-apiserver are compressed by gzip.
+	CompressionGzip = "gzip"
+	// CompressionZstd represents responses from kube-apiserver are compressed by zstd.
+	CompressionZstd = "zstd"
+)
+
 var (
 	YurthubConfigMapName = fmt.Sprintf("%s-hub-cfg", strings.TrimRightFunc(projectinfo.GetProjectPrefix(), func(c rune) bool { return c == '-' }))
 )
@@ -362,10 +377,51 @@ func SplitKey(key string) (comp, resource, ns, name string) {
 	return
 }
 
+// IsSupportedCompression check content encoding for responses from kube-apiserver is supported or not
+func IsSupportedCompression(encoding string) bool {
+	switch encoding {
+	case CompressionGzip, CompressionZstd:
+		return true
+	}
+
+	return false
+}
+
+// ParseRemoteServers parses comma separated server addresses, https scheme is used if scheme is not specified.
+func ParseRemoteServers(serverAddr string) ([]*url.URL, error) {
+	if serverAddr == "" {
+		return make([]*url.URL, 0), fmt.Errorf("--server-addr should be set for hub agent")
+	}
+	servers := strings.Split(serverAddr, ",")
+	us := make([]*url.URL, 0, len(servers))
+	remoteServers := make([]string, 0, len(servers))
+	for _, server := range servers {
+		u, err := url.Parse(server)
+		if err != nil {
+			klog.Errorf("could not parse server address %q, %v", server, err)
+			return us, err
+		}
+		if u.Scheme == "" {
+			u.Scheme = "https"
+		} else if u.Scheme != "https" {
+			return us, fmt.Errorf("only https scheme is supported for server address(%s)", serverAddr)
+		}
+		us = append(us, u)
+		remoteServers = append(remoteServers, u.String())
+	}
+
+	if len(us) < 1 {
+		return us, fmt.Errorf("no server address is set, can not connect remote server")
+	}
+	klog.Infof("%s would connect remote servers: %s", projectinfo.GetHubName(), strings.Join(remoteServers, ","))
+
+	return us, nil
+}
+
 // IsSupportedLBMode check lb mode is supported or not
 func IsSupportedLBMode(lbMode string) bool {
 	switch lbMode {
-	case "rr", "priority":
+	case "rr", "priority", "latency-aware":
 		return true
 	}
 
@@ -382,6 +438,34 @@ func IsSupportedWorkingMode(workingMode WorkingMode) bool {
 	return false
 }
 
+// IsSupportedStorageBackend check storage backend is supported or not
+func IsSupportedStorageBackend(backend string) bool {
+	switch backend {
+	case StorageBackendDisk, StorageBackendBolt:
+		return true
+	}
+
+	return false
+}
+
+// ParseGroupVersionResources parses the list of Group/Version/Resource into GroupVersionResources.
+func ParseGroupVersionResources(values []string) ([]schema.GroupVersionResource, error) {
+	gvrs := make([]schema.GroupVersionResource, 0, len(values))
+	for _, value := range values {
+		subParts := strings.Split(value, "/")
+		if len(subParts) != 3 || len(strings.TrimSpace(subParts[2])) == 0 {
+			return nil, fmt.Errorf("invalid GVR format: %s, expected format is Group/Version/Resource", value)
+		}
+
+		gvrs = append(gvrs, schema.GroupVersionResource{
+			Group:    strings.TrimSpace(subParts[0]),
+			Version:  strings.TrimSpace(subParts[1]),
+			Resource: strings.TrimSpace(subParts[2]),
+		})
+	}
+	return gvrs, nil
+}
+
 // FileExists checks if specified file exists.
 func FileExists(filename string) (bool, error) {
 	if _, err := os.Stat(filename); os.IsNotExist(err) {
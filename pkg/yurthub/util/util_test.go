@@ -244,6 +244,7 @@ func TestIsSupportedLBMode(t *testing.T) {
 	}{
 		{"lb mode rr", args{"rr"}, true},
 		{"lb mode priority", args{"priority"}, true},
+		{"lb mode latency-aware", args{"latency-aware"}, true},
 		{"no lb mode", args{""}, false},
 		{"illegal lb mode", args{"illegal-mode"}, false},
 	}
@@ -256,6 +257,25 @@ func TestIsSupportedLBMode(t *testing.T) {
 	}
 }
 
+func TestIsSupportedCompression(t *testing.T) {
+	tests := map[string]struct {
+		encoding string
+		want     bool
+	}{
+		"gzip":    {encoding: "gzip", want: true},
+		"zstd":    {encoding: "zstd", want: true},
+		"deflate": {encoding: "deflate", want: false},
+		"empty":   {encoding: "", want: false},
+	}
+	for k, tt := range tests {
+		t.Run(k, func(t *testing.T) {
+			if got := IsSupportedCompression(tt.encoding); got != tt.want {
+				t.Errorf("IsSupportedCompression() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsSupportedWorkingMode(t *testing.T) {
 	type args struct {
 		workingMode WorkingMode
@@ -279,6 +299,26 @@ func TestIsSupportedWorkingMode(t *testing.T) {
 	}
 }
 
+func TestIsSupportedStorageBackend(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend string
+		want    bool
+	}{
+		{"storage backend disk", StorageBackendDisk, true},
+		{"storage backend bolt", StorageBackendBolt, true},
+		{"no storage backend", "", false},
+		{"illegal storage backend", "etcd", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSupportedStorageBackend(tt.backend); got != tt.want {
+				t.Errorf("IsSupportedStorageBackend() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFileExists(t *testing.T) {
 	dir, err := os.MkdirTemp("", "yurthub-util-file-exist")
 	if err != nil {
@@ -0,0 +1,342 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package writequeue
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+
+	yurtutil "github.com/openyurtio/openyurt/pkg/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+	hubutil "github.com/openyurtio/openyurt/pkg/yurthub/util"
+)
+
+const (
+	// DefaultMaxRecords is the max number of records in the queue, new requests will be
+	// rejected to be recorded when the queue is full.
+	DefaultMaxRecords = 10000
+
+	recordFileSuffix = ".json"
+	tmpFilePrefix    = "tmp_"
+)
+
+var ErrQueueFull = errors.New("offline write queue is full")
+
+// Record is a mutating request which is made while the cloud kube-apiserver is unreachable.
+type Record struct {
+	Seq         uint64    `json:"seq"`
+	Component   string    `json:"component"`
+	Verb        string    `json:"verb"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"`
+	Query       string    `json:"query,omitempty"`
+	ContentType string    `json:"contentType,omitempty"`
+	APIGroup    string    `json:"apiGroup,omitempty"`
+	Resource    string    `json:"resource"`
+	Subresource string    `json:"subresource,omitempty"`
+	Namespace   string    `json:"namespace,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Body        []byte    `json:"body,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// ResourceString returns the resource of record in the format of resource[/subresource]
+func (r *Record) ResourceString() string {
+	return resourceString(r.Resource, r.Subresource)
+}
+
+// supersedes returns true if the record r makes the earlier record old useless.
+// 1. an update request carries the full object, so earlier updates and patches of
+// the same object are stale, like status updates of pods and nodes.
+// 2. patches of events made by event recorder carry the whole count, timestamp and message
+// of event, so earlier patches of the same event are merged into the later one.
+func (r *Record) supersedes(old *Record) bool {
+	if len(r.Name) == 0 || r.APIGroup != old.APIGroup || r.Resource != old.Resource || r.Subresource != old.Subresource ||
+		r.Namespace != old.Namespace || r.Name != old.Name {
+		return false
+	}
+
+	switch r.Verb {
+	case "update":
+		return old.Verb == "update" || old.Verb == "patch"
+	case "patch":
+		return r.Resource == "events" && old.Verb == "patch"
+	}
+	return false
+}
+
+// Queue is a durable write-ahead queue which records mutating requests of replayable resources
+// while the cloud kube-apiserver is unreachable. each record is persisted as a file named by its
+// sequence number, so records can be replayed in order after yurthub restarts.
+type Queue struct {
+	sync.Mutex
+	dir        string
+	resources  sets.Set[string]
+	records    []*Record
+	nextSeq    uint64
+	maxRecords int
+}
+
+// NewQueue creates a *Queue which persists records in dir, and loads the records left in dir.
+// resources are in the format of resource[/subresource], like events, pods/status.
+func NewQueue(dir string, resources []string, maxRecords int) (*Queue, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	q := &Queue{
+		dir:        dir,
+		resources:  sets.New(resources...),
+		records:    make([]*Record, 0),
+		nextSeq:    1,
+		maxRecords: maxRecords,
+	}
+	if err := q.load(); err != nil {
+		return nil, err
+	}
+	metrics.Metrics.SetOfflineWriteQueueLength(len(q.records))
+	return q, nil
+}
+
+func (q *Queue) load() error {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			continue
+		}
+		if strings.HasPrefix(name, tmpFilePrefix) || !strings.HasSuffix(name, recordFileSuffix) {
+			klog.Infof("remove unexpected file %s in offline write queue", name)
+			os.Remove(filepath.Join(q.dir, name))
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(q.dir, name))
+		if err != nil {
+			return err
+		}
+		record := &Record{}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, recordFileSuffix), 10, 64)
+		if err == nil {
+			err = json.Unmarshal(b, record)
+		}
+		if err != nil || record.Seq != seq {
+			klog.Errorf("remove broken record %s in offline write queue, %v", name, err)
+			os.Remove(filepath.Join(q.dir, name))
+			continue
+		}
+		q.records = append(q.records, record)
+	}
+
+	sort.Slice(q.records, func(i, j int) bool {
+		return q.records[i].Seq < q.records[j].Seq
+	})
+	if len(q.records) != 0 {
+		q.nextSeq = q.records[len(q.records)-1].Seq + 1
+		klog.Infof("%d records are loaded from offline write queue", len(q.records))
+	}
+	return nil
+}
+
+// Replayable returns true if the request should be recorded into the queue.
+func (q *Queue) Replayable(info *apirequest.RequestInfo) bool {
+	if info == nil || !info.IsResourceRequest {
+		return false
+	}
+
+	switch info.Verb {
+	case "create", "update", "patch":
+		return q.resources.Has(resourceString(info.Resource, info.Subresource))
+	default:
+		return false
+	}
+}
+
+// Record reads the body of request and records the request into the queue, and the body of
+// request is restored for the following handlers.
+func (q *Queue) Record(req *http.Request) error {
+	ctx := req.Context()
+	info, ok := apirequest.RequestInfoFrom(ctx)
+	if !ok || !q.Replayable(info) {
+		return fmt.Errorf("request %s is not replayable", hubutil.ReqString(req))
+	}
+
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return err
+		}
+		body = b
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	comp, _ := hubutil.TruncatedClientComponentFrom(ctx)
+
+	return q.Add(&Record{
+		Component:   comp,
+		Verb:        info.Verb,
+		Method:      req.Method,
+		Path:        req.URL.Path,
+		Query:       req.URL.RawQuery,
+		ContentType: req.Header.Get(yurtutil.HTTPHeaderContentType),
+		APIGroup:    info.APIGroup,
+		Resource:    info.Resource,
+		Subresource: info.Subresource,
+		Namespace:   info.Namespace,
+		Name:        info.Name,
+		Body:        body,
+		Timestamp:   time.Now(),
+	})
+}
+
+// Add appends the record into the tail of queue, and the records superseded by the new record are removed.
+func (q *Queue) Add(record *Record) error {
+	q.Lock()
+	defer q.Unlock()
+
+	remaining := make([]*Record, 0, len(q.records)+1)
+	for _, old := range q.records {
+		if record.supersedes(old) {
+			if err := q.deleteFile(old.Seq); err != nil {
+				return err
+			}
+			metrics.Metrics.IncOfflineWrites(old.ResourceString(), "superseded")
+			continue
+		}
+		remaining = append(remaining, old)
+	}
+	q.records = remaining
+
+	if q.maxRecords > 0 && len(q.records) >= q.maxRecords {
+		metrics.Metrics.SetOfflineWriteQueueLength(len(q.records))
+		metrics.Metrics.IncOfflineWrites(record.ResourceString(), "rejected")
+		return ErrQueueFull
+	}
+
+	record.Seq = q.nextSeq
+	if err := q.writeFile(record); err != nil {
+		return err
+	}
+	q.nextSeq++
+	q.records = append(q.records, record)
+	metrics.Metrics.SetOfflineWriteQueueLength(len(q.records))
+	metrics.Metrics.IncOfflineWrites(record.ResourceString(), "queued")
+	return nil
+}
+
+// Peek returns the oldest record in the queue, nil is returned if the queue is empty.
+func (q *Queue) Peek() *Record {
+	q.Lock()
+	defer q.Unlock()
+	if len(q.records) == 0 {
+		return nil
+	}
+	return q.records[0]
+}
+
+// Remove deletes the record specified by seq from the queue.
+func (q *Queue) Remove(seq uint64) error {
+	q.Lock()
+	defer q.Unlock()
+	for i := range q.records {
+		if q.records[i].Seq != seq {
+			continue
+		}
+		if err := q.deleteFile(seq); err != nil {
+			return err
+		}
+		q.records = append(q.records[:i], q.records[i+1:]...)
+		metrics.Metrics.SetOfflineWriteQueueLength(len(q.records))
+		return nil
+	}
+	return nil
+}
+
+// Len returns the number of records in the queue.
+func (q *Queue) Len() int {
+	q.Lock()
+	defer q.Unlock()
+	return len(q.records)
+}
+
+func (q *Queue) writeFile(record *Record) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	name := recordFileName(record.Seq)
+	tmpPath := filepath.Join(q.dir, tmpFilePrefix+name)
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	// make sure the record is on disk before the request is acknowledged.
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, filepath.Join(q.dir, name))
+}
+
+func (q *Queue) deleteFile(seq uint64) error {
+	if err := os.Remove(filepath.Join(q.dir, recordFileName(seq))); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func recordFileName(seq uint64) string {
+	return fmt.Sprintf("%020d%s", seq, recordFileSuffix)
+}
+
+func resourceString(resource, subresource string) string {
+	if len(subresource) == 0 {
+		return resource
+	}
+	return resource + "/" + subresource
+}
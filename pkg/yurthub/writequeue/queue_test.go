@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package writequeue
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/endpoints/filters"
+	"k8s.io/apiserver/pkg/server"
+
+	proxyutil "github.com/openyurtio/openyurt/pkg/yurthub/proxy/util"
+)
+
+var defaultResources = []string{"events", "pods/status", "nodes/status"}
+
+func queueSeqs(q *Queue) []uint64 {
+	q.Lock()
+	defer q.Unlock()
+	seqs := make([]uint64, 0, len(q.records))
+	for _, r := range q.records {
+		seqs = append(seqs, r.Seq)
+	}
+	return seqs
+}
+
+func TestQueueAdd(t *testing.T) {
+	podStatus := func(verb, name string) *Record {
+		return &Record{Verb: verb, Resource: "pods", Subresource: "status", Namespace: "default", Name: name}
+	}
+	event := func(verb, name string) *Record {
+		return &Record{Verb: verb, Resource: "events", Namespace: "default", Name: name}
+	}
+
+	testcases := map[string]struct {
+		records    []*Record
+		expectSeqs []uint64
+	}{
+		"records of different objects are kept in order": {
+			records:    []*Record{podStatus("patch", "foo"), podStatus("patch", "bar"), event("create", "")},
+			expectSeqs: []uint64{1, 2, 3},
+		},
+		"status update supersedes earlier patches and updates": {
+			records:    []*Record{podStatus("patch", "foo"), podStatus("patch", "bar"), podStatus("update", "foo"), podStatus("update", "foo")},
+			expectSeqs: []uint64{2, 4},
+		},
+		"status patches are not superseded by later patches": {
+			records:    []*Record{podStatus("patch", "foo"), podStatus("patch", "foo")},
+			expectSeqs: []uint64{1, 2},
+		},
+		"event patch supersedes earlier patches of the same event": {
+			records:    []*Record{event("create", ""), event("patch", "ev1"), event("patch", "ev2"), event("patch", "ev1")},
+			expectSeqs: []uint64{1, 3, 4},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			q, err := NewQueue(t.TempDir(), defaultResources, DefaultMaxRecords)
+			if err != nil {
+				t.Fatalf("could not new queue, %v", err)
+			}
+			for _, r := range tc.records {
+				if err := q.Add(r); err != nil {
+					t.Fatalf("could not add record, %v", err)
+				}
+			}
+			if seqs := queueSeqs(q); !reflect.DeepEqual(seqs, tc.expectSeqs) {
+				t.Errorf("expect records %v, but got %v", tc.expectSeqs, seqs)
+			}
+			entries, _ := os.ReadDir(q.dir)
+			if len(entries) != len(tc.expectSeqs) {
+				t.Errorf("expect %d record files, but got %d", len(tc.expectSeqs), len(entries))
+			}
+		})
+	}
+}
+
+func TestQueueReload(t *testing.T) {
+	dir := t.TempDir()
+	q, err := NewQueue(dir, defaultResources, DefaultMaxRecords)
+	if err != nil {
+		t.Fatalf("could not new queue, %v", err)
+	}
+	for _, name := range []string{"foo", "bar", "baz"} {
+		if err := q.Add(&Record{Verb: "patch", Resource: "pods", Subresource: "status", Name: name, Body: []byte(name)}); err != nil {
+			t.Fatalf("could not add record, %v", err)
+		}
+	}
+	if err := q.Remove(1); err != nil {
+		t.Fatalf("could not remove record, %v", err)
+	}
+	// broken and temporary files are removed when the queue is loaded
+	os.WriteFile(filepath.Join(dir, recordFileName(7)), []byte("broken"), 0600)
+	os.WriteFile(filepath.Join(dir, tmpFilePrefix+recordFileName(8)), []byte("{}"), 0600)
+
+	q, err = NewQueue(dir, defaultResources, DefaultMaxRecords)
+	if err != nil {
+		t.Fatalf("could not reload queue, %v", err)
+	}
+	if seqs := queueSeqs(q); !reflect.DeepEqual(seqs, []uint64{2, 3}) {
+		t.Errorf("expect records [2 3], but got %v", seqs)
+	}
+	if record := q.Peek(); record == nil || string(record.Body) != "bar" {
+		t.Errorf("expect the oldest record bar, but got %v", record)
+	}
+	if err := q.Add(&Record{Verb: "create", Resource: "events"}); err != nil {
+		t.Fatalf("could not add record, %v", err)
+	}
+	if seqs := queueSeqs(q); !reflect.DeepEqual(seqs, []uint64{2, 3, 4}) {
+		t.Errorf("expect records [2 3 4], but got %v", seqs)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 3 {
+		t.Errorf("expect 3 record files, but got %d", len(entries))
+	}
+}
+
+func TestQueueFull(t *testing.T) {
+	q, err := NewQueue(t.TempDir(), defaultResources, 2)
+	if err != nil {
+		t.Fatalf("could not new queue, %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := q.Add(&Record{Verb: "create", Resource: "events"}); err != nil {
+			t.Fatalf("could not add record, %v", err)
+		}
+	}
+	if err := q.Add(&Record{Verb: "create", Resource: "events"}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expect ErrQueueFull, but got %v", err)
+	}
+	if q.Len() != 2 {
+		t.Errorf("expect 2 records, but got %d", q.Len())
+	}
+}
+
+func TestQueueRecord(t *testing.T) {
+	resolver := server.NewRequestInfoResolver(&server.Config{LegacyAPIGroupPrefixes: sets.NewString(server.DefaultLegacyAPIPrefix)})
+	testcases := map[string]struct {
+		method       string
+		path         string
+		expectRecord *Record
+	}{
+		"patch pod status": {
+			method: "PATCH",
+			path:   "/api/v1/namespaces/default/pods/foo/status?fieldManager=kubelet",
+			expectRecord: &Record{
+				Seq: 1, Component: "kubelet", Verb: "patch", Method: "PATCH", Path: "/api/v1/namespaces/default/pods/foo/status",
+				Query: "fieldManager=kubelet", ContentType: "application/strategic-merge-patch+json", Resource: "pods",
+				Subresource: "status", Namespace: "default", Name: "foo", Body: []byte("body"),
+			},
+		},
+		"create event": {
+			method: "POST",
+			path:   "/api/v1/namespaces/default/events",
+			expectRecord: &Record{
+				Seq: 1, Component: "kubelet", Verb: "create", Method: "POST", Path: "/api/v1/namespaces/default/events",
+				ContentType: "application/strategic-merge-patch+json", Resource: "events", Namespace: "default", Body: []byte("body"),
+			},
+		},
+		"patch pod is not replayable": {
+			method: "PATCH",
+			path:   "/api/v1/namespaces/default/pods/foo",
+		},
+		"delete event is not replayable": {
+			method: "DELETE",
+			path:   "/api/v1/namespaces/default/events/foo",
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			q, err := NewQueue(t.TempDir(), defaultResources, DefaultMaxRecords)
+			if err != nil {
+				t.Fatalf("could not new queue, %v", err)
+			}
+			req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBufferString("body"))
+			req.Header.Set("User-Agent", "kubelet")
+			req.Header.Set("Content-Type", "application/strategic-merge-patch+json")
+
+			var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				err := q.Record(req)
+				if (err == nil) != (tc.expectRecord != nil) {
+					t.Errorf("expect recorded %v, but got err %v", tc.expectRecord != nil, err)
+				}
+				if body, _ := io.ReadAll(req.Body); string(body) != "body" {
+					t.Errorf("expect request body is restored, but got %s", string(body))
+				}
+			})
+			handler = proxyutil.WithRequestClientComponent(handler)
+			handler = filters.WithRequestInfo(handler, resolver)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			record := q.Peek()
+			if tc.expectRecord == nil {
+				if record != nil {
+					t.Errorf("expect no record, but got %v", record)
+				}
+				return
+			}
+			if record == nil {
+				t.Fatalf("expect record, but got nil")
+			}
+			record.Timestamp = tc.expectRecord.Timestamp
+			if !reflect.DeepEqual(record, tc.expectRecord) {
+				t.Errorf("expect record %#v, but got %#v", tc.expectRecord, record)
+			}
+		})
+	}
+}
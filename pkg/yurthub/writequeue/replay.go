@@ -0,0 +1,295 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package writequeue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	yurtutil "github.com/openyurtio/openyurt/pkg/util"
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker"
+	"github.com/openyurtio/openyurt/pkg/yurthub/kubernetes/serializer"
+	"github.com/openyurtio/openyurt/pkg/yurthub/metrics"
+	"github.com/openyurtio/openyurt/pkg/yurthub/transport"
+)
+
+const (
+	resultReplayed   = "replayed"
+	resultMerged     = "merged"
+	resultConflicted = "conflicted"
+	resultStale      = "stale"
+	resultFailed     = "failed"
+)
+
+var (
+	defaultReplayInterval = 5 * time.Second
+	defaultRequestTimeout = 10 * time.Second
+	// the head record is dropped when it can not be replayed after it has been retried for
+	// defaultMaxReplayAttempts times and defaultMaxRetryDuration, so the following records
+	// are not stalled by it forever.
+	defaultMaxReplayAttempts = 10
+	defaultMaxRetryDuration  = 10 * time.Minute
+)
+
+// ReplayManager is responsible for replaying the records of offline write queue in order against
+// the cloud kube-apiserver when the cloud becomes healthy. the records are replayed with the direct
+// clients of cloud kube-apiserver instead of yurthub proxy server, because requests of proxy server
+// will be recorded again if the cloud becomes unhealthy during replaying.
+//
+// Note:
+// the direct clients use the identity of yurthub on the node(system:node:{nodeName}) instead of the
+// identity of original requester, because the credentials of requester are not persisted in records.
+// so records are authorized as the node in the cloud, and only resources which are written by node
+// components with the node identity(like kubelet) should be configured to be replayed.
+type ReplayManager struct {
+	queue            *Queue
+	healthChecker    healthchecker.Interface
+	transportManager transport.Interface
+	interval         time.Duration
+	maxAttempts      int
+	maxRetryDuration time.Duration
+	stopCh           <-chan struct{}
+
+	// retrySeq is the sequence of head record which is being retried, retryAttempts and
+	// retryStart are the attempts and time of the first failure of it.
+	retrySeq      uint64
+	retryAttempts int
+	retryStart    time.Time
+}
+
+// NewReplayManager creates a *ReplayManager object.
+func NewReplayManager(queue *Queue, healthChecker healthchecker.Interface, transportManager transport.Interface, stopCh <-chan struct{}) *ReplayManager {
+	return &ReplayManager{
+		queue:            queue,
+		healthChecker:    healthChecker,
+		transportManager: transportManager,
+		interval:         defaultReplayInterval,
+		maxAttempts:      defaultMaxReplayAttempts,
+		maxRetryDuration: defaultMaxRetryDuration,
+		stopCh:           stopCh,
+	}
+}
+
+// Run starts ReplayManager
+func (m *ReplayManager) Run() {
+	go wait.JitterUntil(m.replay, m.interval, 0.5, true, m.stopCh)
+}
+
+func (m *ReplayManager) replay() {
+	replayed := 0
+	for {
+		// check the health status for each record, and records are kept in order when cloud is unhealthy.
+		if !m.healthChecker.IsHealthy() {
+			return
+		}
+
+		record := m.queue.Peek()
+		if record == nil {
+			if replayed != 0 {
+				klog.Infof("%d records of offline write queue have been replayed", replayed)
+			}
+			return
+		}
+
+		result, err := m.replayRecord(record)
+		if err != nil {
+			if !m.retryExhausted(record) {
+				klog.Warningf("could not replay %s %s, and will retry later, %v", record.Verb, record.Path, err)
+				return
+			}
+			klog.Errorf("drop record %s %s of offline write queue after %d attempts in %v, %v",
+				record.Verb, record.Path, m.retryAttempts, time.Since(m.retryStart).Round(time.Second), err)
+			result = resultFailed
+		}
+		metrics.Metrics.IncOfflineWrites(record.ResourceString(), result)
+		if err := m.queue.Remove(record.Seq); err != nil {
+			klog.Errorf("could not remove record %d from offline write queue, %v", record.Seq, err)
+			return
+		}
+		replayed++
+	}
+}
+
+// retryExhausted records a failed attempt of the head record, and returns true if the record has been
+// retried for maxAttempts times and maxRetryDuration. the attempts are kept in memory, so they are
+// counted from scratch after yurthub restarts.
+func (m *ReplayManager) retryExhausted(record *Record) bool {
+	if m.retrySeq != record.Seq {
+		m.retrySeq = record.Seq
+		m.retryAttempts = 0
+		m.retryStart = time.Now()
+	}
+	m.retryAttempts++
+	return m.retryAttempts >= m.maxAttempts && time.Since(m.retryStart) >= m.maxRetryDuration
+}
+
+// replayRecord sends the record to cloud kube-apiserver and returns the result of record.
+// the conflicts are handled as following:
+// 1. status updates which conflict with the object in the cloud are stale, so they are dropped.
+// 2. status writes which are older than the status of object in the cloud are stale, so they are dropped.
+// 3. events which have been created in the cloud are merged into the existing events.
+// 4. requests rejected by cloud kube-apiserver are dropped and reported as failed.
+// an error is returned only when the record should be retried later, like network errors.
+func (m *ReplayManager) replayRecord(record *Record) (string, error) {
+	client := m.transportManager.GetDirectClientsetAtRandom()
+	if yurtutil.IsNil(client) {
+		return "", errors.New("no client for cloud kube-apiserver")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+	if record.Subresource == "status" {
+		stale, err := isStaleStatus(ctx, client, record)
+		if err != nil {
+			return "", err
+		} else if stale {
+			klog.Infof("drop stale record %s %s of offline write queue, status has been written in the cloud after it's recorded", record.Verb, record.Path)
+			return resultStale, nil
+		}
+	}
+
+	err := m.send(ctx, client, record)
+	if err != nil && apierrors.IsAlreadyExists(err) && isCoreEvent(record) && record.Verb == "create" {
+		err = mergeEvent(ctx, client, record)
+		if err == nil {
+			return resultMerged, nil
+		}
+	}
+
+	switch {
+	case err == nil:
+		return resultReplayed, nil
+	case apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err):
+		klog.Infof("drop stale record %s %s of offline write queue, %v", record.Verb, record.Path, err)
+		return resultConflicted, nil
+	case isRejected(err):
+		klog.Errorf("drop record %s %s of offline write queue which is rejected by cloud, %v", record.Verb, record.Path, err)
+		return resultFailed, nil
+	default:
+		return "", err
+	}
+}
+
+func (m *ReplayManager) send(ctx context.Context, client kubernetes.Interface, record *Record) error {
+	req := client.CoreV1().RESTClient().Verb(record.Method).AbsPath(record.Path).Body(record.Body)
+	if len(record.ContentType) != 0 {
+		req = req.SetHeader(yurtutil.HTTPHeaderContentType, record.ContentType)
+	}
+	query, err := url.ParseQuery(record.Query)
+	if err != nil {
+		return apierrors.NewBadRequest(fmt.Sprintf("invalid query %s, %v", record.Query, err))
+	}
+	for k, vs := range query {
+		for _, v := range vs {
+			req = req.Param(k, v)
+		}
+	}
+	return req.Do(ctx).Error()
+}
+
+// isStaleStatus checks whether the status of object has been written in the cloud after the record is recorded.
+// status writes like pods/status and nodes/status patches carry no resourceVersion, so a stale status would
+// overwrite the fresh status which is reported by kubelet after reconnecting to cloud. the time when status is
+// written in the cloud is got from managedFields of the status subresource, and it's compared with the time when
+// the record is recorded, so the clock of node should be synchronized with cloud. the status record is replayed if
+// the object is not found, and the request will be rejected by cloud kube-apiserver.
+func isStaleStatus(ctx context.Context, client kubernetes.Interface, record *Record) (bool, error) {
+	data, err := client.CoreV1().RESTClient().Get().AbsPath(strings.TrimSuffix(record.Path, "/status")).
+		SetHeader("Accept", "application/json").Do(ctx).Raw()
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	obj := &metav1.PartialObjectMetadata{}
+	if err := json.Unmarshal(data, obj); err != nil {
+		return false, fmt.Errorf("could not decode object of %s, %v", record.Path, err)
+	}
+	for _, entry := range obj.ManagedFields {
+		if entry.Subresource == "status" && entry.Time != nil && entry.Time.After(record.Timestamp) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// mergeEvent merges the event of record into the event which has been created in the cloud,
+// the count of events is accumulated and the message of the latest event is kept.
+func mergeEvent(ctx context.Context, client kubernetes.Interface, record *Record) error {
+	s := serializer.YurtHubSerializer.CreateSerializer(record.ContentType, "", "v1", "events")
+	if s == nil {
+		return apierrors.NewBadRequest(fmt.Sprintf("unsupported content type %s", record.ContentType))
+	}
+	obj, err := s.Decode(record.Body)
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	event, ok := obj.(*v1.Event)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("unexpected object %T for event", obj))
+	}
+	namespace := event.Namespace
+	if len(namespace) == 0 {
+		namespace = record.Namespace
+	}
+
+	existing, err := client.CoreV1().Events(namespace).Get(ctx, event.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	count := event.Count
+	if count == 0 {
+		count = 1
+	}
+	existing.Count += count
+	if event.LastTimestamp.After(existing.LastTimestamp.Time) {
+		existing.LastTimestamp = event.LastTimestamp
+		existing.Message = event.Message
+	}
+	_, err = client.CoreV1().Events(namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+func isCoreEvent(record *Record) bool {
+	return record.APIGroup == "" && record.Resource == "events" && record.Subresource == ""
+}
+
+// isRejected returns true if the request is rejected by kube-apiserver and will never
+// succeed by retrying, like bad request, not found, forbidden and invalid. unauthorized
+// requests are retried because the client certificate may be rotated.
+func isRejected(err error) bool {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return false
+	}
+	code := status.Status().Code
+	return code >= http.StatusBadRequest && code < http.StatusInternalServerError && code != http.StatusTooManyRequests && code != http.StatusUnauthorized
+}
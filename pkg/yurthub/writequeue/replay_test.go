@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package writequeue
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openyurtio/openyurt/pkg/yurthub/healthchecker/fake"
+	"github.com/openyurtio/openyurt/pkg/yurthub/transport"
+)
+
+type fakeTransportManager struct {
+	transport.Interface
+	client kubernetes.Interface
+}
+
+func (tm *fakeTransportManager) GetDirectClientsetAtRandom() kubernetes.Interface {
+	return tm.client
+}
+
+func writeStatus(w http.ResponseWriter, err *apierrors.StatusError) {
+	status := err.ErrStatus
+	status.Kind = "Status"
+	status.APIVersion = "v1"
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(int(status.Code))
+	json.NewEncoder(w).Encode(&status)
+}
+
+func TestReplay(t *testing.T) {
+	lastTimestamp := metav1.NewTime(time.Now().Truncate(time.Second))
+	existingEvent := &v1.Event{
+		TypeMeta:      metav1.TypeMeta{APIVersion: "v1", Kind: "Event"},
+		ObjectMeta:    metav1.ObjectMeta{Name: "ev1", Namespace: "default", ResourceVersion: "10"},
+		Count:         2,
+		Message:       "old message",
+		LastTimestamp: metav1.NewTime(lastTimestamp.Add(-time.Minute)),
+	}
+	offlineEvent := &v1.Event{
+		TypeMeta:      metav1.TypeMeta{APIVersion: "v1", Kind: "Event"},
+		ObjectMeta:    metav1.ObjectMeta{Name: "ev1", Namespace: "default"},
+		Count:         3,
+		Message:       "new message",
+		LastTimestamp: lastTimestamp,
+	}
+	offlineEventBody, _ := json.Marshal(offlineEvent)
+	recordTime := time.Now().Add(-time.Minute)
+	// objectWithStatusTime returns the object whose status is written at statusTime in the cloud.
+	objectWithStatusTime := func(kind, name string, statusTime time.Time) *metav1.PartialObjectMetadata {
+		writeTime := metav1.NewTime(statusTime)
+		return &metav1.PartialObjectMetadata{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: kind},
+			ObjectMeta: metav1.ObjectMeta{Name: name, ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kubelet", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status", Time: &writeTime},
+			}},
+		}
+	}
+
+	var mu sync.Mutex
+	var requests []string
+	var updatedEvent *v1.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "PATCH /api/v1/namespaces/default/pods/foo/status":
+			if r.URL.Query().Get("fieldManager") != "kubelet" || r.Header.Get("Content-Type") != "application/strategic-merge-patch+json" {
+				writeStatus(w, apierrors.NewBadRequest("unexpected request"))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"apiVersion":"v1","kind":"Pod"}`))
+		case "PUT /api/v1/nodes/node1/status":
+			writeStatus(w, apierrors.NewConflict(schema.GroupResource{Resource: "nodes"}, "node1", nil))
+		case "GET /api/v1/namespaces/default/pods/foo", "GET /api/v1/namespaces/default/pods/retry":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(objectWithStatusTime("Pod", "foo", recordTime.Add(-time.Minute)))
+		case "GET /api/v1/nodes/node1":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(objectWithStatusTime("Node", "node1", recordTime.Add(-time.Minute)))
+		case "GET /api/v1/namespaces/default/pods/fresh":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(objectWithStatusTime("Pod", "fresh", time.Now()))
+		case "GET /api/v1/namespaces/default/pods/gone":
+			writeStatus(w, apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "gone"))
+		case "PATCH /api/v1/namespaces/default/pods/gone/status":
+			writeStatus(w, apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "gone"))
+		case "POST /api/v1/namespaces/default/events":
+			writeStatus(w, apierrors.NewAlreadyExists(schema.GroupResource{Resource: "events"}, "ev1"))
+		case "GET /api/v1/namespaces/default/events/ev1":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(existingEvent)
+		case "PUT /api/v1/namespaces/default/events/ev1":
+			b, _ := io.ReadAll(r.Body)
+			updatedEvent = &v1.Event{}
+			json.Unmarshal(b, updatedEvent)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(b)
+		default:
+			writeStatus(w, apierrors.NewServiceUnavailable("unavailable"))
+		}
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}})
+	if err != nil {
+		t.Fatalf("could not new client, %v", err)
+	}
+
+	records := []*Record{
+		{Verb: "patch", Method: "PATCH", Path: "/api/v1/namespaces/default/pods/foo/status", Query: "fieldManager=kubelet",
+			ContentType: "application/strategic-merge-patch+json", Resource: "pods", Subresource: "status", Namespace: "default", Name: "foo", Body: []byte(`{}`)},
+		{Verb: "update", Method: "PUT", Path: "/api/v1/nodes/node1/status", ContentType: "application/json",
+			Resource: "nodes", Subresource: "status", Name: "node1", Body: []byte(`{}`)},
+		{Verb: "patch", Method: "PATCH", Path: "/api/v1/namespaces/default/pods/gone/status",
+			ContentType: "application/strategic-merge-patch+json", Resource: "pods", Subresource: "status", Namespace: "default", Name: "gone", Body: []byte(`{}`)},
+		{Verb: "create", Method: "POST", Path: "/api/v1/namespaces/default/events", ContentType: "application/json",
+			Resource: "events", Namespace: "default", Body: offlineEventBody},
+		{Verb: "patch", Method: "PATCH", Path: "/api/v1/namespaces/default/pods/fresh/status",
+			ContentType: "application/strategic-merge-patch+json", Resource: "pods", Subresource: "status", Namespace: "default", Name: "fresh", Body: []byte(`{}`)},
+		{Verb: "patch", Method: "PATCH", Path: "/api/v1/namespaces/default/pods/retry/status",
+			ContentType: "application/strategic-merge-patch+json", Resource: "pods", Subresource: "status", Namespace: "default", Name: "retry", Body: []byte(`{}`)},
+		{Verb: "patch", Method: "PATCH", Path: "/api/v1/namespaces/default/pods/foo/status", Query: "fieldManager=kubelet",
+			ContentType: "application/strategic-merge-patch+json", Resource: "pods", Subresource: "status", Namespace: "default", Name: "foo", Body: []byte(`{}`)},
+	}
+
+	testcases := map[string]struct {
+		healthy         bool
+		expectRequests  []string
+		expectRemaining []uint64
+	}{
+		"records are kept when cloud is unhealthy": {
+			expectRequests:  nil,
+			expectRemaining: []uint64{1, 2, 3, 4, 5, 6, 7},
+		},
+		"records are replayed in order until retriable error": {
+			healthy: true,
+			expectRequests: []string{
+				"GET /api/v1/namespaces/default/pods/foo",
+				"PATCH /api/v1/namespaces/default/pods/foo/status",
+				"GET /api/v1/nodes/node1",
+				"PUT /api/v1/nodes/node1/status",
+				"GET /api/v1/namespaces/default/pods/gone",
+				"PATCH /api/v1/namespaces/default/pods/gone/status",
+				"POST /api/v1/namespaces/default/events",
+				"GET /api/v1/namespaces/default/events/ev1",
+				"PUT /api/v1/namespaces/default/events/ev1",
+				"GET /api/v1/namespaces/default/pods/fresh",
+				"GET /api/v1/namespaces/default/pods/retry",
+				"PATCH /api/v1/namespaces/default/pods/retry/status",
+			},
+			expectRemaining: []uint64{6, 7},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			mu.Lock()
+			requests = nil
+			updatedEvent = nil
+			mu.Unlock()
+			q, err := NewQueue(t.TempDir(), defaultResources, DefaultMaxRecords)
+			if err != nil {
+				t.Fatalf("could not new queue, %v", err)
+			}
+			for _, r := range records {
+				record := *r
+				record.Timestamp = recordTime
+				if err := q.Add(&record); err != nil {
+					t.Fatalf("could not add record, %v", err)
+				}
+			}
+
+			checker := fake.NewFakeChecker(map[*url.URL]bool{serverURL: tc.healthy})
+			m := NewReplayManager(q, checker, &fakeTransportManager{client: client}, make(chan struct{}))
+			m.replay()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !reflect.DeepEqual(requests, tc.expectRequests) {
+				t.Errorf("expect requests %v, but got %v", tc.expectRequests, requests)
+			}
+			if seqs := queueSeqs(q); !reflect.DeepEqual(seqs, tc.expectRemaining) {
+				t.Errorf("expect remaining records %v, but got %v", tc.expectRemaining, seqs)
+			}
+			if tc.healthy {
+				if updatedEvent == nil || updatedEvent.Count != 5 || updatedEvent.Message != "new message" || !updatedEvent.LastTimestamp.Equal(&lastTimestamp) {
+					t.Errorf("expect event is merged, but got %#v", updatedEvent)
+				}
+			}
+		})
+	}
+}
+
+func TestReplayDropsRecordAfterRetries(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/v1/namespaces/default/events":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"apiVersion":"v1","kind":"Event"}`))
+		default:
+			writeStatus(w, apierrors.NewServiceUnavailable("unavailable"))
+		}
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}})
+	if err != nil {
+		t.Fatalf("could not new client, %v", err)
+	}
+
+	q, err := NewQueue(t.TempDir(), defaultResources, DefaultMaxRecords)
+	if err != nil {
+		t.Fatalf("could not new queue, %v", err)
+	}
+	for _, r := range []*Record{
+		{Verb: "create", Method: "POST", Path: "/api/v1/namespaces/default/pods", ContentType: "application/json",
+			Resource: "pods", Namespace: "default", Body: []byte(`{}`)},
+		{Verb: "create", Method: "POST", Path: "/api/v1/namespaces/default/events", ContentType: "application/json",
+			Resource: "events", Namespace: "default", Body: []byte(`{}`)},
+	} {
+		r.Timestamp = time.Now()
+		if err := q.Add(r); err != nil {
+			t.Fatalf("could not add record, %v", err)
+		}
+	}
+
+	checker := fake.NewFakeChecker(map[*url.URL]bool{serverURL: true})
+	m := NewReplayManager(q, checker, &fakeTransportManager{client: client}, make(chan struct{}))
+	m.maxAttempts = 3
+	m.maxRetryDuration = 0
+
+	for i := 1; i < m.maxAttempts; i++ {
+		m.replay()
+		if seqs := queueSeqs(q); !reflect.DeepEqual(seqs, []uint64{1, 2}) {
+			t.Errorf("expect records are kept after %d attempts, but got %v", i, seqs)
+		}
+	}
+
+	m.replay()
+	if seqs := queueSeqs(q); len(seqs) != 0 {
+		t.Errorf("expect the failing record is dropped and the following record is replayed, but got %v", seqs)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != m.maxAttempts+1 || requests[len(requests)-1] != "POST /api/v1/namespaces/default/events" {
+		t.Errorf("expect %d requests ending with event creation, but got %v", m.maxAttempts+1, requests)
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the License);
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an AS IS BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hubleader
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/openyurtio/openyurt/pkg/apis/apps"
+	appsv1beta2 "github.com/openyurtio/openyurt/pkg/apis/apps/v1beta2"
+)
+
+const (
+	// weights of each factor in the score of candidate, the full score is 100.
+	cpuWeight     = 30
+	memoryWeight  = 30
+	networkWeight = 20
+	uptimeWeight  = 20
+
+	// churnPenalty is deducted from the score for every time the candidate lost its leadership in churnWindow.
+	churnPenalty = 20
+	churnWindow  = time.Hour
+
+	// uptimeSaturation is the uptime with which candidate gets the full uptime score.
+	uptimeSaturation = 24 * time.Hour
+)
+
+// networkTierScores are the ratios of network score for values of network tier label,
+// nodes without the label are regarded as medium.
+var networkTierScores = map[string]float64{
+	"high":   1,
+	"medium": 0.5,
+	"low":    0,
+}
+
+// leaderChurn records the times when nodes lost their leadership recently. it's persisted in the annotation
+// of nodepool, so the history is not lost when yurt-manager restarts or fails over.
+type leaderChurn map[string][]time.Time
+
+// getLeaderChurn returns the leadership losses of nodes in churn window from the annotation of nodepool.
+func getLeaderChurn(nodepool *appsv1beta2.NodePool, now time.Time) leaderChurn {
+	churn := make(leaderChurn)
+	data, ok := nodepool.Annotations[apps.LeaderChurnAnnotation]
+	if !ok {
+		return churn
+	}
+	if err := json.Unmarshal([]byte(data), &churn); err != nil {
+		klog.Warningf("could not parse leader churn of nodepool %s, %v", nodepool.Name, err)
+		return make(leaderChurn)
+	}
+
+	for nodeName, times := range churn {
+		recent := slices.DeleteFunc(times, func(t time.Time) bool {
+			return now.Sub(t) > churnWindow
+		})
+		if len(recent) == 0 {
+			delete(churn, nodeName)
+		} else {
+			churn[nodeName] = recent
+		}
+	}
+	return churn
+}
+
+// setLeaderChurn records the leadership losses of nodes in the annotation of nodepool.
+func setLeaderChurn(nodepool *appsv1beta2.NodePool, churn leaderChurn) error {
+	if len(churn) == 0 {
+		delete(nodepool.Annotations, apps.LeaderChurnAnnotation)
+		return nil
+	}
+
+	data, err := json.Marshal(churn)
+	if err != nil {
+		return err
+	}
+	if nodepool.Annotations == nil {
+		nodepool.Annotations = make(map[string]string)
+	}
+	nodepool.Annotations[apps.LeaderChurnAnnotation] = string(data)
+	return nil
+}
+
+func (c leaderChurn) record(nodeName string, now time.Time) {
+	c[nodeName] = append(c[nodeName], now)
+}
+
+// count returns the number of times the node lost its leadership in churn window.
+func (c leaderChurn) count(nodeName string) int {
+	return len(c[nodeName])
+}
+
+// scoreCandidates scores candidates by allocatable cpu and memory(relative to the largest candidate in the pool),
+// network tier label, uptime since node became ready and the number of recent leadership losses.
+func scoreCandidates(candidates map[appsv1beta2.Leader]*corev1.Node, churn leaderChurn, now time.Time) map[appsv1beta2.Leader]int {
+	var maxCPU, maxMemory int64
+	for _, node := range candidates {
+		maxCPU = max(maxCPU, node.Status.Allocatable.Cpu().MilliValue())
+		maxMemory = max(maxMemory, node.Status.Allocatable.Memory().Value())
+	}
+
+	scores := make(map[appsv1beta2.Leader]int, len(candidates))
+	for leader, node := range candidates {
+		var score float64
+		if maxCPU > 0 {
+			score += cpuWeight * float64(node.Status.Allocatable.Cpu().MilliValue()) / float64(maxCPU)
+		}
+		if maxMemory > 0 {
+			score += memoryWeight * float64(node.Status.Allocatable.Memory().Value()) / float64(maxMemory)
+		}
+
+		tier, ok := networkTierScores[node.Labels[apps.LeaderNetworkTierLabel]]
+		if !ok {
+			tier = networkTierScores["medium"]
+		}
+		score += networkWeight * tier
+
+		if uptime := nodeUptime(node, now); uptime > 0 {
+			score += uptimeWeight * min(1, float64(uptime)/float64(uptimeSaturation))
+		}
+
+		score -= float64(churnPenalty * churn.count(node.Name))
+		scores[leader] = max(0, int(math.Round(score)))
+	}
+	return scores
+}
+
+// nodeUptime returns the duration since node became ready.
+func nodeUptime(node *corev1.Node, now time.Time) time.Duration {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady && c.Status == corev1.ConditionTrue && !c.LastTransitionTime.IsZero() {
+			return now.Sub(c.LastTransitionTime.Time)
+		}
+	}
+	return 0
+}
+
+// electLeadersByCapacity elects N leaders from candidates which are not current leaders. candidates in the
+// topology zone with the fewest leaders are preferred, and the candidate with the highest score is elected
+// from them, so leaders are spread across zones. nodes without zone label are regarded as in the same zone.
+func electLeadersByCapacity(
+	numLeaders int,
+	candidates map[appsv1beta2.Leader]*corev1.Node,
+	currentLeaders []appsv1beta2.Leader,
+	scores map[appsv1beta2.Leader]int,
+) []appsv1beta2.Leader {
+	zoneLeaders := make(map[string]int)
+	for _, leader := range currentLeaders {
+		if node, ok := candidates[leader]; ok {
+			zoneLeaders[node.Labels[corev1.LabelTopologyZone]]++
+		}
+	}
+
+	ranked := make([]appsv1beta2.Leader, 0, len(candidates))
+	for leader := range candidates {
+		if !slices.Contains(currentLeaders, leader) {
+			ranked = append(ranked, leader)
+		}
+	}
+	slices.SortFunc(ranked, func(a, b appsv1beta2.Leader) int {
+		if c := cmp.Compare(scores[b], scores[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.NodeName, b.NodeName)
+	})
+
+	leaders := make([]appsv1beta2.Leader, 0, numLeaders)
+	for len(leaders) < numLeaders && len(ranked) != 0 {
+		best := 0
+		for i := range ranked {
+			if zoneLeaders[candidates[ranked[i]].Labels[corev1.LabelTopologyZone]] <
+				zoneLeaders[candidates[ranked[best]].Labels[corev1.LabelTopologyZone]] {
+				best = i
+			}
+		}
+		leaders = append(leaders, ranked[best])
+		zoneLeaders[candidates[ranked[best]].Labels[corev1.LabelTopologyZone]]++
+		ranked = slices.Delete(ranked, best, best+1)
+	}
+	return leaders
+}
+
+// setLeaderScoreCondition records scores of leaders in the message of LeaderReady condition.
+func setLeaderScoreCondition(nodepool *appsv1beta2.NodePool, leaders []appsv1beta2.Leader, scores map[appsv1beta2.Leader]int) {
+	condition := appsv1beta2.NodePoolCondition{
+		Type:               appsv1beta2.LeaderStatus,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "LeaderElected",
+	}
+	if len(leaders) == 0 {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = "NoCandidate"
+		condition.Message = "no ready node can be elected as leader"
+	} else {
+		leaderScores := make([]string, 0, len(leaders))
+		for _, leader := range leaders {
+			leaderScores = append(leaderScores, fmt.Sprintf("%s=%d", leader.NodeName, scores[leader]))
+		}
+		condition.Message = fmt.Sprintf("leaders are elected by capacity, scores: %s", strings.Join(leaderScores, ", "))
+	}
+
+	for i := range nodepool.Status.Conditions {
+		if nodepool.Status.Conditions[i].Type != condition.Type {
+			continue
+		}
+		if nodepool.Status.Conditions[i].Status == condition.Status {
+			condition.LastTransitionTime = nodepool.Status.Conditions[i].LastTransitionTime
+		}
+		nodepool.Status.Conditions[i] = condition
+		return
+	}
+	nodepool.Status.Conditions = append(nodepool.Status.Conditions, condition)
+}
@@ -21,9 +21,15 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"time"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -55,9 +61,11 @@ func Add(ctx context.Context, cfg *appconfig.CompletedConfig, mgr manager.Manage
 	klog.Infof("hubleader-controller add controller %s", controllerKind.String())
 
 	reconciler := &ReconcileHubLeader{
-		Client:        yurtClient.GetClientByControllerNameOrDie(mgr, names.HubLeaderController),
-		recorder:      mgr.GetEventRecorderFor(names.HubLeaderController),
-		Configuration: cfg.ComponentConfig.HubLeaderController,
+		Client:         yurtClient.GetClientByControllerNameOrDie(mgr, names.HubLeaderController),
+		recorder:       mgr.GetEventRecorderFor(names.HubLeaderController),
+		Configuration:  cfg.ComponentConfig.HubLeaderController,
+		leaseNamespace: cfg.ComponentConfig.HubLeaderConfigController.HubLeaderNamespace,
+		leaseObserver:  newLeaseObserver(),
 	}
 
 	// Create a new controller
@@ -123,7 +131,37 @@ func Add(ctx context.Context, cfg *appconfig.CompletedConfig, mgr manager.Manage
 		return err
 	}
 
-	return nil
+	// Watch for creation and deletion of leases renewed by leader hubs, expiration of leases is
+	// checked by requeueing nodepool when the lease is going to expire. the lease is deleted by
+	// hub when it's not leader anymore, and nodes with expired leases can be elected again.
+	leasePredicate := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			_, ok := e.Object.GetLabels()[projectinfo.GetHubLeaderLeaseLabel()]
+			return ok
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			_, ok := e.Object.GetLabels()[projectinfo.GetHubLeaderLeaseLabel()]
+			return ok
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+	return c.Watch(
+		source.Kind[client.Object](
+			mgr.GetCache(),
+			&coordinationv1.Lease{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return []reconcile.Request{
+					{NamespacedName: types.NamespacedName{Name: obj.GetLabels()[projectinfo.GetHubLeaderLeaseLabel()]}},
+				}
+			}),
+			leasePredicate,
+		),
+	)
 }
 
 var _ reconcile.Reconciler = &ReconcileHubLeader{}
@@ -133,10 +171,14 @@ type ReconcileHubLeader struct {
 	client.Client
 	recorder      record.EventRecorder
 	Configuration config.HubLeaderControllerConfiguration
+	// leaseNamespace is the namespace of leases renewed by leader hubs.
+	leaseNamespace string
+	leaseObserver  *leaseObserver
 }
 
 // +kubebuilder:rbac:groups=apps.openyurt.io,resources=nodepools,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.openyurt.io,resources=nodepools/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch
 
 // Reconcile reads that state of the cluster for a HubLeader object and makes changes based on the state read
 // and what is in the HubLeader.Spec
@@ -146,28 +188,40 @@ func (r *ReconcileHubLeader) Reconcile(ctx context.Context, request reconcile.Re
 	// Fetch the NodePool instance
 	nodepool := &appsv1beta2.NodePool{}
 	if err := r.Get(ctx, request.NamespacedName, nodepool); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.leaseObserver.forget(request.Name)
+		}
 		return reconcile.Result{}, client.IgnoreNotFound(err)
 	}
 
 	// Reconcile the NodePool
-	if err := r.reconcileHubLeader(ctx, nodepool); err != nil {
+	requeueAfter, err := r.reconcileHubLeader(ctx, nodepool)
+	if err != nil {
 		r.recorder.Eventf(nodepool, corev1.EventTypeWarning, "ReconcileError", "Failed to reconcile NodePool: %v", err)
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
-func (r *ReconcileHubLeader) reconcileHubLeader(ctx context.Context, nodepool *appsv1beta2.NodePool) error {
+// reconcileHubLeader elects leaders for the nodepool, and returns the duration after which the nodepool
+// should be reconciled again for checking leases of leader hubs.
+func (r *ReconcileHubLeader) reconcileHubLeader(ctx context.Context, nodepool *appsv1beta2.NodePool) (time.Duration, error) {
 	if !nodepool.Spec.EnableLeaderElection {
 		if len(nodepool.Status.LeaderEndpoints) == 0 {
-			return nil
+			return 0, nil
 		}
 		// If the NodePool doesn't have pool scope metadata enabled, it should drop leaders (if any)
 		nodepool.Status.LeaderEndpoints = nil
 		nodepool.Status.LeaderNum = 0
 		nodepool.Status.LeaderLastElectedTime = metav1.Now()
-		return r.Status().Update(ctx, nodepool)
+		return 0, r.Status().Update(ctx, nodepool)
+	}
+
+	// Leader hubs whose lease is expired can't be leaders
+	expiredNodes, requeueAfter, err := r.checkLeaderLeases(ctx, nodepool)
+	if err != nil {
+		return 0, err
 	}
 
 	// Get all nodes that belong to the nodepool
@@ -181,9 +235,9 @@ func (r *ReconcileHubLeader) reconcileHubLeader(ctx context.Context, nodepool *a
 	}
 	matchLabels[projectinfo.GetNodePoolLabel()] = nodepool.GetName()
 
-	err := r.List(ctx, &currentNodeList, client.MatchingLabels(matchLabels))
+	err = r.List(ctx, &currentNodeList, client.MatchingLabels(matchLabels))
 	if err != nil {
-		return client.IgnoreNotFound(err)
+		return 0, client.IgnoreNotFound(err)
 	}
 
 	// Copy the nodepool to update
@@ -206,12 +260,28 @@ func (r *ReconcileHubLeader) reconcileHubLeader(ctx context.Context, nodepool *a
 			continue
 		}
 
+		if expiredNodes.Has(n.Name) {
+			klog.V(5).InfoS("Lease of hub leader is expired, skip consideration for hub leader", "node", n.Name)
+			continue
+		}
+
 		leadersMap[appsv1beta2.Leader{
 			Address:  internalIP,
 			NodeName: n.Name,
 		}] = &n
 	}
 
+	// Candidates are scored before current leaders are removed from candidates,
+	// so that scores of all leaders can be recorded.
+	now := time.Now()
+	isCapacityStrategy := nodepool.Spec.LeaderElectionStrategy == string(appsv1beta2.ElectionStrategyCapacity)
+	var churn leaderChurn
+	var scores map[appsv1beta2.Leader]int
+	if isCapacityStrategy {
+		churn = getLeaderChurn(nodepool, now)
+		scores = scoreCandidates(leadersMap, churn, now)
+	}
+
 	// Delete leaders that are not in leaders map
 	// They are either not ready or not longer the node list and need to be removed
 	leaderDeleteFn := func(leader appsv1beta2.Leader) bool {
@@ -221,7 +291,15 @@ func (r *ReconcileHubLeader) reconcileHubLeader(ctx context.Context, nodepool *a
 	updatedLeaders := slices.DeleteFunc(updatedNodePool.Status.LeaderEndpoints, leaderDeleteFn)
 
 	// If the number of leaders is not equal to the desired number of leaders
-	if len(updatedLeaders) < int(nodepool.Spec.LeaderReplicas) {
+	if len(updatedLeaders) < int(nodepool.Spec.LeaderReplicas) && isCapacityStrategy {
+		leaders := electLeadersByCapacity(
+			int(nodepool.Spec.LeaderReplicas)-len(updatedLeaders),
+			leadersMap,
+			updatedLeaders,
+			scores,
+		)
+		updatedLeaders = append(updatedLeaders, leaders...)
+	} else if len(updatedLeaders) < int(nodepool.Spec.LeaderReplicas) {
 		// Remove current leaders from candidates
 		for _, leader := range updatedLeaders {
 			delete(leadersMap, leader)
@@ -234,7 +312,7 @@ func (r *ReconcileHubLeader) reconcileHubLeader(ctx context.Context, nodepool *a
 		)
 		if !ok {
 			klog.Errorf("Failed to elect a leader for NodePool %s", nodepool.Name)
-			return fmt.Errorf("failed to elect a leader for NodePool %s", nodepool.Name)
+			return 0, fmt.Errorf("failed to elect a leader for NodePool %s", nodepool.Name)
 		}
 
 		updatedLeaders = append(updatedLeaders, leaders...)
@@ -245,19 +323,95 @@ func (r *ReconcileHubLeader) reconcileHubLeader(ctx context.Context, nodepool *a
 
 	updatedNodePool.Status.LeaderEndpoints = updatedLeaders
 
-	if !nodepoolutil.HasSliceContentChanged(nodepool.Status.LeaderEndpoints, updatedNodePool.Status.LeaderEndpoints) {
-		return nil
+	// scores of leaders are recorded on every evaluation, because scores change even if leaders are not changed.
+	leadersChanged := nodepoolutil.HasSliceContentChanged(nodepool.Status.LeaderEndpoints, updatedNodePool.Status.LeaderEndpoints)
+	if isCapacityStrategy {
+		setLeaderScoreCondition(updatedNodePool, updatedLeaders, scores)
+	}
+	if !leadersChanged && apiequality.Semantic.DeepEqual(nodepool.Status.Conditions, updatedNodePool.Status.Conditions) {
+		return requeueAfter, nil
 	}
 
 	// Update Status since changed
-	updatedNodePool.Status.LeaderLastElectedTime = metav1.Now()
-	updatedNodePool.Status.LeaderNum = int32(len(updatedLeaders))
+	if leadersChanged {
+		updatedNodePool.Status.LeaderLastElectedTime = metav1.Now()
+		updatedNodePool.Status.LeaderNum = int32(len(updatedLeaders))
+	}
 	if err = r.Status().Update(ctx, updatedNodePool); err != nil {
 		klog.ErrorS(err, "Update NodePool status error", "nodepool", updatedNodePool.Name)
-		return err
+		return 0, err
+	}
+
+	var demoted bool
+	for _, leader := range nodepool.Status.LeaderEndpoints {
+		if !slices.Contains(updatedLeaders, leader) {
+			demoted = true
+			if isCapacityStrategy {
+				churn.record(leader.NodeName, now)
+			}
+		}
+		if expiredNodes.Has(leader.NodeName) {
+			r.recorder.Eventf(nodepool, corev1.EventTypeWarning, "LeaderDemoted",
+				"Hub leader %s is demoted because its lease is not renewed", leader.NodeName)
+		}
 	}
 
-	return nil
+	// leader churn is persisted in annotation of nodepool, so it's not lost when yurt-manager restarts.
+	if demoted && isCapacityStrategy {
+		churnPool := nodepool.DeepCopy()
+		if err = setLeaderChurn(churnPool, churn); err != nil {
+			return 0, fmt.Errorf("could not set leader churn of nodepool %s, %w", nodepool.Name, err)
+		}
+		if err = r.Patch(ctx, churnPool, client.MergeFrom(nodepool)); err != nil {
+			klog.ErrorS(err, "Patch leader churn of NodePool error", "nodepool", nodepool.Name)
+			return 0, err
+		}
+	}
+
+	return requeueAfter, nil
+}
+
+// checkLeaderLeases returns the nodes whose leases of leader hub are expired, and the duration after which the
+// first unexpired lease expires. leader hub renews its lease only when its multiplexer is ready, so leader hubs
+// with expired leases are unhealthy. leases are expired by the clock of yurt-manager instead of renew time, because
+// clocks of edge nodes may be skewed. expired leases are not deleted, so that the demoted nodes are not elected
+// again until hubs delete the leases after their multiplexers become ready. leader hubs without leases are not
+// affected, because leases may be disabled or not created yet.
+func (r *ReconcileHubLeader) checkLeaderLeases(ctx context.Context, nodepool *appsv1beta2.NodePool) (sets.Set[string], time.Duration, error) {
+	var leaseList coordinationv1.LeaseList
+	if err := r.List(ctx, &leaseList,
+		client.InNamespace(r.leaseNamespace),
+		client.MatchingLabels{projectinfo.GetHubLeaderLeaseLabel(): nodepool.Name}); err != nil {
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	expireTimes := r.leaseObserver.observe(nodepool.Name, leaseList.Items, now)
+	expiredNodes := sets.New[string]()
+	var requeueAfter time.Duration
+	for i := range leaseList.Items {
+		lease := &leaseList.Items[i]
+		if lease.Spec.HolderIdentity == nil {
+			continue
+		}
+
+		expireTime := expireTimes[lease.Name]
+		if expireTime.After(now) {
+			if d := expireTime.Sub(now); requeueAfter == 0 || d < requeueAfter {
+				requeueAfter = d
+			}
+			continue
+		}
+
+		klog.V(4).InfoS("Lease of hub leader is expired", "nodepool", nodepool.Name, "node", *lease.Spec.HolderIdentity, "expireTime", expireTime)
+		expiredNodes.Insert(*lease.Spec.HolderIdentity)
+	}
+
+	// requeue a little later than expiration, so the expired lease is not missed
+	if requeueAfter != 0 {
+		requeueAfter += time.Second
+	}
+	return expiredNodes, requeueAfter, nil
 }
 
 // electNLeaders elects N leaders from the candidates based on the strategy
@@ -19,21 +19,27 @@ package hubleader
 import (
 	"cmp"
 	"context"
+	"maps"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/openyurtio/openyurt/pkg/apis"
+	"github.com/openyurtio/openyurt/pkg/apis/apps"
 	appsv1beta2 "github.com/openyurtio/openyurt/pkg/apis/apps/v1beta2"
 	"github.com/openyurtio/openyurt/pkg/projectinfo"
 	"github.com/openyurtio/openyurt/pkg/yurtmanager/controller/hubleader/config"
@@ -804,6 +810,7 @@ func TestReconcile(t *testing.T) {
 				Client:        c,
 				Configuration: config.HubLeaderControllerConfiguration{},
 				recorder:      record.NewFakeRecorder(1000),
+				leaseObserver: newLeaseObserver(),
 			}
 			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: tc.pool.Name}}
 			_, err := r.Reconcile(ctx, req)
@@ -833,3 +840,331 @@ func TestReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileWithLeaderLeases(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, apis.AddToScheme(scheme))
+
+	newLease := func(nodeName string, renewTime time.Time) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      projectinfo.GetHubLeaderLeaseName(nodeName),
+				Namespace: "kube-system",
+				Labels:    map[string]string{projectinfo.GetHubLeaderLeaseLabel(): "shanghai"},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(nodeName),
+				LeaseDurationSeconds: ptr.To[int32](15),
+				RenewTime:            &metav1.MicroTime{Time: renewTime},
+			},
+		}
+	}
+	leader := appsv1beta2.Leader{NodeName: "ready with internal IP and marked as leader", Address: "10.0.0.2"}
+	secondLeader := appsv1beta2.Leader{NodeName: "ready with internal IP and marked as 2nd leader", Address: "10.0.0.5"}
+
+	testCases := map[string]struct {
+		leaders         []appsv1beta2.Leader
+		leases          []client.Object
+		expectedLeaders []appsv1beta2.Leader
+		expectedLeases  []string
+		expectRequeue   bool
+		skewed          bool
+	}{
+		"leader with expired lease is demoted": {
+			leaders: []appsv1beta2.Leader{leader},
+			leases: []client.Object{
+				newLease(leader.NodeName, time.Now().Add(-time.Minute)),
+				newLease(secondLeader.NodeName, time.Now()),
+			},
+			expectedLeaders: []appsv1beta2.Leader{secondLeader},
+			expectedLeases: []string{
+				projectinfo.GetHubLeaderLeaseName(secondLeader.NodeName),
+				projectinfo.GetHubLeaderLeaseName(leader.NodeName),
+			},
+			expectRequeue: true,
+		},
+		"node with expired lease is not elected again": {
+			leases: []client.Object{
+				newLease(leader.NodeName, time.Now().Add(-time.Minute)),
+			},
+			expectedLeaders: []appsv1beta2.Leader{secondLeader},
+			expectedLeases:  []string{projectinfo.GetHubLeaderLeaseName(leader.NodeName)},
+		},
+		"leader with renewed lease is kept": {
+			leaders: []appsv1beta2.Leader{leader},
+			leases: []client.Object{
+				newLease(leader.NodeName, time.Now()),
+			},
+			expectedLeaders: []appsv1beta2.Leader{leader},
+			expectedLeases:  []string{projectinfo.GetHubLeaderLeaseName(leader.NodeName)},
+			expectRequeue:   true,
+		},
+		"leader with lease renewed by skewed clock is kept": {
+			leaders: []appsv1beta2.Leader{leader},
+			leases: []client.Object{
+				newLease(leader.NodeName, time.Now().Add(-time.Hour)),
+			},
+			expectedLeaders: []appsv1beta2.Leader{leader},
+			expectedLeases:  []string{projectinfo.GetHubLeaderLeaseName(leader.NodeName)},
+			expectRequeue:   true,
+			skewed:          true,
+		},
+		"leader without lease is kept": {
+			leaders:         []appsv1beta2.Leader{leader},
+			expectedLeaders: []appsv1beta2.Leader{leader},
+			expectedLeases:  []string{},
+		},
+		"lease in other namespace is ignored": {
+			leaders: []appsv1beta2.Leader{leader},
+			leases: []client.Object{
+				func() client.Object {
+					lease := newLease(leader.NodeName, time.Now().Add(-time.Minute))
+					lease.Namespace = "default"
+					return lease
+				}(),
+			},
+			expectedLeaders: []appsv1beta2.Leader{leader},
+			expectedLeases:  []string{projectinfo.GetHubLeaderLeaseName(leader.NodeName)},
+		},
+	}
+
+	ctx := context.TODO()
+	for k, tc := range testCases {
+		t.Run(k, func(t *testing.T) {
+			pool := &appsv1beta2.NodePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "shanghai"},
+				Spec: appsv1beta2.NodePoolSpec{
+					Type:                    appsv1beta2.Edge,
+					LeaderElectionStrategy:  string(appsv1beta2.ElectionStrategyMark),
+					LeaderReplicas:          1,
+					LeaderNodeLabelSelector: map[string]string{"apps.openyurt.io/leader": "true"},
+					EnableLeaderElection:    true,
+				},
+				Status: appsv1beta2.NodePoolStatus{
+					LeaderEndpoints: tc.leaders,
+					LeaderNum:       int32(len(tc.leaders)),
+				},
+			}
+			c := fakeclient.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(pool).
+				WithStatusSubresource(pool).
+				WithObjects(prepareNodes()...).
+				WithObjects(tc.leases...).
+				Build()
+
+			r := &ReconcileHubLeader{
+				Client:         c,
+				Configuration:  config.HubLeaderControllerConfiguration{},
+				recorder:       record.NewFakeRecorder(1000),
+				leaseNamespace: "kube-system",
+				leaseObserver:  newLeaseObserver(),
+			}
+			// leases are observed by yurt-manager when they are renewed, unless the clock of node is skewed.
+			var storedLeases coordinationv1.LeaseList
+			require.NoError(t, c.List(ctx, &storedLeases))
+			observed := make(map[string]observedLease)
+			for _, lease := range storedLeases.Items {
+				if !tc.skewed {
+					observed[lease.Name] = observedLease{spec: lease.Spec, observedTime: lease.Spec.RenewTime.Time}
+				}
+			}
+			r.leaseObserver.leases[pool.Name] = observed
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pool.Name}}
+			result, err := r.Reconcile(ctx, req)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectRequeue, result.RequeueAfter > 0)
+			require.LessOrEqual(t, result.RequeueAfter, 16*time.Second)
+
+			var actualPool appsv1beta2.NodePool
+			require.NoError(t, r.Get(ctx, req.NamespacedName, &actualPool))
+			require.Equal(t, tc.expectedLeaders, actualPool.Status.LeaderEndpoints)
+
+			var leases coordinationv1.LeaseList
+			require.NoError(t, r.List(ctx, &leases))
+			leaseNames := make([]string, 0, len(leases.Items))
+			for _, lease := range leases.Items {
+				leaseNames = append(leaseNames, lease.Name)
+			}
+			require.Equal(t, tc.expectedLeases, leaseNames)
+		})
+	}
+}
+
+func TestReconcileWithCapacityStrategy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, apis.AddToScheme(scheme))
+
+	newNode := func(name, ip, cpu, memory string, labels map[string]string) client.Object {
+		nodeLabels := map[string]string{projectinfo.GetNodePoolLabel(): "beijing"}
+		maps.Copy(nodeLabels, labels)
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:               corev1.NodeReady,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(time.Now().Add(-48 * time.Hour)),
+					},
+				},
+				Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: ip}},
+			},
+		}
+	}
+	nodes := []client.Object{
+		newNode("big1", "10.0.1.1", "8", "32Gi", map[string]string{
+			corev1.LabelTopologyZone:    "zone-a",
+			apps.LeaderNetworkTierLabel: "high",
+		}),
+		newNode("big2", "10.0.1.2", "8", "32Gi", map[string]string{corev1.LabelTopologyZone: "zone-a"}),
+		newNode("medium", "10.0.1.3", "4", "16Gi", map[string]string{corev1.LabelTopologyZone: "zone-b"}),
+		newNode("raspberry-pi", "10.0.1.4", "1", "2Gi", map[string]string{
+			corev1.LabelTopologyZone:    "zone-b",
+			apps.LeaderNetworkTierLabel: "low",
+		}),
+	}
+	big1 := appsv1beta2.Leader{NodeName: "big1", Address: "10.0.1.1"}
+	big2 := appsv1beta2.Leader{NodeName: "big2", Address: "10.0.1.2"}
+	medium := appsv1beta2.Leader{NodeName: "medium", Address: "10.0.1.3"}
+
+	testCases := map[string]struct {
+		leaderReplicas  int32
+		currentLeaders  []appsv1beta2.Leader
+		demotedNodes    []string
+		conditions      []appsv1beta2.NodePoolCondition
+		expectedLeaders []appsv1beta2.Leader
+		expectedMessage string
+		expectedChurn   []string
+	}{
+		"candidate with highest score is elected": {
+			leaderReplicas:  1,
+			expectedLeaders: []appsv1beta2.Leader{big1},
+			expectedMessage: "leaders are elected by capacity, scores: big1=100",
+		},
+		"leaders are spread across zones": {
+			leaderReplicas:  2,
+			expectedLeaders: []appsv1beta2.Leader{big1, medium},
+			expectedMessage: "leaders are elected by capacity, scores: big1=100, medium=60",
+		},
+		"zones of current leaders are considered": {
+			leaderReplicas:  2,
+			currentLeaders:  []appsv1beta2.Leader{medium},
+			expectedLeaders: []appsv1beta2.Leader{medium, big1},
+			expectedMessage: "leaders are elected by capacity, scores: medium=60, big1=100",
+		},
+		"candidate with recent leader churn is penalized": {
+			leaderReplicas:  1,
+			demotedNodes:    []string{"big1"},
+			expectedLeaders: []appsv1beta2.Leader{big2},
+			expectedMessage: "leaders are elected by capacity, scores: big2=90",
+			expectedChurn:   []string{"big1"},
+		},
+		"scores are recorded when leaders are not changed": {
+			leaderReplicas: 1,
+			currentLeaders: []appsv1beta2.Leader{big2},
+			conditions: []appsv1beta2.NodePoolCondition{
+				{
+					Type:    appsv1beta2.LeaderStatus,
+					Status:  corev1.ConditionTrue,
+					Reason:  "LeaderElected",
+					Message: "leaders are elected by capacity, scores: big2=50",
+				},
+			},
+			expectedLeaders: []appsv1beta2.Leader{big2},
+			expectedMessage: "leaders are elected by capacity, scores: big2=90",
+		},
+		"leadership loss is recorded": {
+			leaderReplicas:  1,
+			currentLeaders:  []appsv1beta2.Leader{big1, big2},
+			expectedLeaders: []appsv1beta2.Leader{big1},
+			expectedMessage: "leaders are elected by capacity, scores: big1=100",
+			expectedChurn:   []string{"big2"},
+		},
+	}
+
+	ctx := context.TODO()
+	for k, tc := range testCases {
+		t.Run(k, func(t *testing.T) {
+			pool := &appsv1beta2.NodePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "beijing"},
+				Spec: appsv1beta2.NodePoolSpec{
+					Type:                   appsv1beta2.Edge,
+					LeaderElectionStrategy: string(appsv1beta2.ElectionStrategyCapacity),
+					LeaderReplicas:         tc.leaderReplicas,
+					EnableLeaderElection:   true,
+				},
+				Status: appsv1beta2.NodePoolStatus{
+					LeaderEndpoints: tc.currentLeaders,
+					LeaderNum:       int32(len(tc.currentLeaders)),
+					Conditions:      tc.conditions,
+				},
+			}
+			churn := make(leaderChurn)
+			for _, node := range tc.demotedNodes {
+				churn.record(node, time.Now())
+			}
+			require.NoError(t, setLeaderChurn(pool, churn))
+			c := fakeclient.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(pool).
+				WithStatusSubresource(pool).
+				WithObjects(nodes...).
+				Build()
+
+			r := &ReconcileHubLeader{
+				Client:        c,
+				Configuration: config.HubLeaderControllerConfiguration{},
+				recorder:      record.NewFakeRecorder(1000),
+				leaseObserver: newLeaseObserver(),
+			}
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pool.Name}}
+			_, err := r.Reconcile(ctx, req)
+			require.NoError(t, err)
+
+			var actualPool appsv1beta2.NodePool
+			require.NoError(t, r.Get(ctx, req.NamespacedName, &actualPool))
+			require.Equal(t, tc.expectedLeaders, actualPool.Status.LeaderEndpoints)
+			require.Len(t, actualPool.Status.Conditions, 1)
+			require.Equal(t, appsv1beta2.LeaderStatus, actualPool.Status.Conditions[0].Type)
+			require.Equal(t, corev1.ConditionTrue, actualPool.Status.Conditions[0].Status)
+			require.Equal(t, tc.expectedMessage, actualPool.Status.Conditions[0].Message)
+
+			actualChurn := getLeaderChurn(&actualPool, time.Now())
+			require.ElementsMatch(t, tc.expectedChurn, slices.Collect(maps.Keys(actualChurn)))
+		})
+	}
+}
+
+func TestLeaderChurn(t *testing.T) {
+	now := time.Now()
+	churn := make(leaderChurn)
+	churn.record("node1", now.Add(-2*churnWindow))
+	churn.record("node1", now.Add(-time.Minute))
+	churn.record("node1", now)
+	churn.record("node2", now.Add(-2*churnWindow))
+
+	pool := &appsv1beta2.NodePool{ObjectMeta: metav1.ObjectMeta{Name: "hangzhou"}}
+	require.NoError(t, setLeaderChurn(pool, churn))
+
+	if count := getLeaderChurn(pool, now).count("node1"); count != 2 {
+		t.Errorf("expect 2 leadership losses in churn window, but got %d", count)
+	}
+	if count := getLeaderChurn(pool, now.Add(2*churnWindow)).count("node1"); count != 0 {
+		t.Errorf("expect no leadership loss in churn window, but got %d", count)
+	}
+	if recent := getLeaderChurn(pool, now); len(recent) != 1 {
+		t.Errorf("expect leadership losses out of churn window are pruned, but got %v", recent)
+	}
+
+	pool.Annotations[apps.LeaderChurnAnnotation] = "invalid"
+	if recent := getLeaderChurn(pool, now); len(recent) != 0 {
+		t.Errorf("expect no leadership loss for invalid annotation, but got %v", recent)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The OpenYurt Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hubleader
+
+import (
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+
+	leaseutil "github.com/openyurtio/openyurt/pkg/util/lease"
+)
+
+// observedLease is the spec of lease and the time when it's observed by yurt-manager.
+type observedLease struct {
+	spec         coordinationv1.LeaseSpec
+	observedTime time.Time
+}
+
+// leaseObserver tracks the renewals of leases of leader hubs in the same way as client-go leader election.
+// renew time of lease is written with the clock of edge node, which may be skewed from yurt-manager, so leases
+// are expired after their durations since yurt-manager observed the last changes of them.
+type leaseObserver struct {
+	sync.Mutex
+	// leases is the observed leases of nodepools, nodepool name -> lease name -> observedLease.
+	leases map[string]map[string]observedLease
+}
+
+func newLeaseObserver() *leaseObserver {
+	return &leaseObserver{
+		leases: make(map[string]map[string]observedLease),
+	}
+}
+
+// observe records leases of nodepool which are observed at now, and returns the expire time of each lease.
+// leases which are not in the list are forgotten.
+func (o *leaseObserver) observe(nodePool string, leases []coordinationv1.Lease, now time.Time) map[string]time.Time {
+	o.Lock()
+	defer o.Unlock()
+	last := o.leases[nodePool]
+	current := make(map[string]observedLease, len(leases))
+	expireTimes := make(map[string]time.Time, len(leases))
+	for i := range leases {
+		lease := &leases[i]
+		observed, ok := last[lease.Name]
+		if !ok || !apiequality.Semantic.DeepEqual(observed.spec, lease.Spec) {
+			observed = observedLease{spec: *lease.Spec.DeepCopy(), observedTime: now}
+		}
+		current[lease.Name] = observed
+		expireTimes[lease.Name] = observed.observedTime.Add(leaseutil.Duration(lease))
+	}
+
+	if len(current) == 0 {
+		delete(o.leases, nodePool)
+	} else {
+		o.leases[nodePool] = current
+	}
+	return expireTimes
+}
+
+// forget removes the observed leases of nodepool which is deleted.
+func (o *leaseObserver) forget(nodePool string) {
+	o.Lock()
+	defer o.Unlock()
+	delete(o.leases, nodePool)
+}
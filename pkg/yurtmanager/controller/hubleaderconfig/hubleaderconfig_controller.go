@@ -45,6 +45,7 @@ import (
 	appsv1beta2 "github.com/openyurtio/openyurt/pkg/apis/apps/v1beta2"
 	"github.com/openyurtio/openyurt/pkg/projectinfo"
 	"github.com/openyurtio/openyurt/pkg/yurtmanager/controller/hubleaderconfig/config"
+	nodeutil "github.com/openyurtio/openyurt/pkg/yurtmanager/controller/util/node"
 	nodepoolutil "github.com/openyurtio/openyurt/pkg/yurtmanager/controller/util/nodepool"
 )
 
@@ -94,14 +95,19 @@ func Add(ctx context.Context, cfg *appconfig.CompletedConfig, mgr manager.Manage
 				return false
 			}
 
-			// Only update if the leader has changed or the pool scope metadata has changed
+			// Only update if the leader has changed or the pool scope metadata has changed,
+			// or nodes of the pool or their readiness have changed when nodes can access with each other.
 			return nodepoolutil.HasSliceContentChanged(
 				oldPool.Status.LeaderEndpoints,
 				newPool.Status.LeaderEndpoints,
 			) || nodepoolutil.HasSliceContentChanged(
 				oldPool.Spec.PoolScopeMetadata,
 				newPool.Spec.PoolScopeMetadata,
-			)
+			) || (newPool.Spec.InterConnectivity && (nodepoolutil.HasSliceContentChanged(
+				oldPool.Status.Nodes,
+				newPool.Status.Nodes,
+			) || oldPool.Status.ReadyNodeNum != newPool.Status.ReadyNodeNum ||
+				oldPool.Status.UnreadyNodeNum != newPool.Status.UnreadyNodeNum))
 		},
 	}
 
@@ -133,6 +139,7 @@ type ReconcileHubLeaderConfig struct {
 // +kubebuilder:rbac:groups=apps.openyurt.io,resources=nodepools,verbs=get
 // +kubebuilder:rbac:groups=apps.openyurt.io,resources=nodepools/status,verbs=get
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;update;patch;create
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=list
 
 // Reconcile reads that state of the cluster nodepool leader status and updates the leader configmap object
 func (r *ReconcileHubLeaderConfig) Reconcile(
@@ -202,6 +209,17 @@ func (r *ReconcileHubLeaderConfig) reconcileHubLeaderConfig(
 		"enable-leader-election": strconv.FormatBool(nodepool.Spec.EnableLeaderElection),
 	}
 
+	// Add candidates for electing temporary leader by yurthubs while the pool is disconnected from cloud
+	if nodepool.Spec.InterConnectivity && nodepool.Spec.EnableLeaderElection {
+		candidates, err := r.getLeaderCandidates(ctx, nodepool)
+		if err != nil {
+			return err
+		}
+		if len(candidates) != 0 {
+			data["candidates"] = strings.Join(candidates, ",")
+		}
+	}
+
 	// If the ConfigMap does not exist, create it
 	if errors.IsNotFound(err) {
 		leaderConfigMap = &v1.ConfigMap{
@@ -236,6 +254,27 @@ func (r *ReconcileHubLeaderConfig) reconcileHubLeaderConfig(
 	return nil
 }
 
+// getLeaderCandidates returns sorted nodes of the nodepool in the format of name/internalIP, nodes which are
+// not ready or without internal IP are skipped. yurthubs use them for electing a temporary leader through the
+// pool network.
+func (r *ReconcileHubLeaderConfig) getLeaderCandidates(ctx context.Context, nodepool *appsv1beta2.NodePool) ([]string, error) {
+	var nodeList v1.NodeList
+	if err := r.List(ctx, &nodeList, client.MatchingLabels{projectinfo.GetNodePoolLabel(): nodepool.Name}); err != nil {
+		return nil, err
+	}
+
+	candidates := make([]string, 0, len(nodeList.Items))
+	for i := range nodeList.Items {
+		internalIP, ok := nodeutil.GetInternalIP(&nodeList.Items[i])
+		if !ok || !nodeutil.IsNodeReady(nodeList.Items[i]) {
+			continue
+		}
+		candidates = append(candidates, nodeList.Items[i].Name+"/"+internalIP)
+	}
+	slices.Sort(candidates)
+	return candidates, nil
+}
+
 // getGVRString	returns a string representation of the GroupVersionResource
 func getGVRString(gvr metav1.GroupVersionResource) string {
 	return fmt.Sprintf("%s/%s/%s", gvr.Group, gvr.Version, gvr.Resource)
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -47,6 +48,7 @@ func TestReconcile(t *testing.T) {
 
 	testCases := map[string]struct {
 		pool              *appsv1beta2.NodePool
+		nodes             []client.Object
 		existingConfigMap *v1.ConfigMap
 		expectedConfigMap *v1.ConfigMap
 		expectErr         bool
@@ -360,6 +362,62 @@ func TestReconcile(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		"candidates of interconnected pool": {
+			pool: &appsv1beta2.NodePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "shenzhen",
+				},
+				Spec: appsv1beta2.NodePoolSpec{
+					Type:                   appsv1beta2.Edge,
+					LeaderReplicas:         1,
+					LeaderElectionStrategy: string(appsv1beta2.ElectionStrategyRandom),
+					InterConnectivity:      true,
+					EnableLeaderElection:   true,
+				},
+				Status: appsv1beta2.NodePoolStatus{
+					LeaderEndpoints: []appsv1beta2.Leader{
+						{
+							NodeName: "node2",
+							Address:  "10.0.0.2",
+						},
+					},
+				},
+			},
+			nodes: []client.Object{
+				newNode("node2", "shenzhen", "10.0.0.2"),
+				newNode("node1", "shenzhen", "10.0.0.1"),
+				newNode("node3", "shenzhen", ""),
+				newNode("node4", "guangzhou", "10.0.0.4"),
+				func() client.Object {
+					node := newNode("node5", "shenzhen", "10.0.0.5")
+					node.Status.Conditions[0].Status = v1.ConditionFalse
+					return node
+				}(),
+			},
+			existingConfigMap: nil,
+			expectedConfigMap: &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "leader-hub-shenzhen",
+					Namespace: metav1.NamespaceSystem,
+					Labels: map[string]string{
+						projectinfo.GetHubLeaderConfigMapLabel(): "leader-hub-shenzhen",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							Name: "shenzhen",
+						},
+					},
+				},
+				Data: map[string]string{
+					"leaders":                "node2/10.0.0.2",
+					"pool-scoped-metadata":   "",
+					"interconnectivity":      "true",
+					"enable-leader-election": "true",
+					"candidates":             "node1/10.0.0.1,node2/10.0.0.2",
+				},
+			},
+			expectErr: false,
+		},
 		"no leader election enabled": {
 			pool: &appsv1beta2.NodePool{
 				ObjectMeta: metav1.ObjectMeta{
@@ -419,7 +477,8 @@ func TestReconcile(t *testing.T) {
 			c := fakeclient.NewClientBuilder().
 				WithScheme(scheme).
 				WithObjects(tc.pool).
-				WithStatusSubresource(tc.pool)
+				WithStatusSubresource(tc.pool).
+				WithObjects(tc.nodes...)
 
 			// Add existing ConfigMap if it exists
 			if tc.existingConfigMap != nil {
@@ -464,3 +523,21 @@ func TestReconcile(t *testing.T) {
 		})
 	}
 }
+
+func newNode(name, pool, internalIP string) *v1.Node {
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				projectinfo.GetNodePoolLabel(): pool,
+			},
+		},
+		Status: v1.NodeStatus{
+			Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+		},
+	}
+	if len(internalIP) != 0 {
+		node.Status.Addresses = []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: internalIP}}
+	}
+	return node
+}
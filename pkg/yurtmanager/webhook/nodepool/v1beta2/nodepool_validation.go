@@ -131,16 +131,17 @@ func validateNodePoolSpec(spec *appsv1beta2.NodePoolSpec) field.ErrorList {
 		}
 	}
 
-	// Check leader election strategy has been set to Random or Mark
+	// Check leader election strategy has been set to Random, Mark or Capacity
 	switch spec.LeaderElectionStrategy {
-	case string(appsv1beta2.ElectionStrategyRandom), string(appsv1beta2.ElectionStrategyMark):
+	case string(appsv1beta2.ElectionStrategyRandom), string(appsv1beta2.ElectionStrategyMark),
+		string(appsv1beta2.ElectionStrategyCapacity):
 		return nil
 	default:
 		return []*field.Error{
 			field.Invalid(
 				field.NewPath("spec").Child("leaderElectionStrategy"),
 				spec.LeaderElectionStrategy,
-				"leaderElectionStrategy should be Random or Mark",
+				"leaderElectionStrategy should be Random, Mark or Capacity",
 			),
 		}
 	}
@@ -50,6 +50,15 @@ func TestValidateCreate(t *testing.T) {
 			},
 			errcode: 0,
 		},
+		"nodepool with capacity leader election strategy": {
+			pool: &appsv1beta2.NodePool{
+				Spec: appsv1beta2.NodePoolSpec{
+					Type:                   appsv1beta2.Edge,
+					LeaderElectionStrategy: string(appsv1beta2.ElectionStrategyCapacity),
+				},
+			},
+			errcode: 0,
+		},
 		"it is not a nodepool": {
 			pool:    &corev1.Node{},
 			errcode: http.StatusBadRequest,
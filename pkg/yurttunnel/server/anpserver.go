@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
@@ -32,6 +33,8 @@ import (
 	anpserver "sigs.k8s.io/apiserver-network-proxy/pkg/server"
 	anpagent "sigs.k8s.io/apiserver-network-proxy/proto/agent"
 
+	"github.com/openyurtio/openyurt/pkg/projectinfo"
+	"github.com/openyurtio/openyurt/pkg/util/tracing"
 	"github.com/openyurtio/openyurt/pkg/yurttunnel/constants"
 	hw "github.com/openyurtio/openyurt/pkg/yurttunnel/handlerwrapper"
 	wh "github.com/openyurtio/openyurt/pkg/yurttunnel/handlerwrapper/wraphandler"
@@ -50,6 +53,7 @@ type anpTunnelServer struct {
 	proxyClientTLSCfg        *tls.Config
 	wrappers                 hw.HandlerWrappers
 	proxyStrategy            string
+	tracerProvider           trace.TracerProvider
 }
 
 var _ TunnelServer = &anpTunnelServer{}
@@ -77,6 +81,9 @@ func (ats *anpTunnelServer) Run() error {
 	if err != nil {
 		return fmt.Errorf("could not wrap handler: %w", err)
 	}
+	if ats.tracerProvider != nil {
+		wrappedHandler = tracing.WithTracing(wrappedHandler, ats.tracerProvider, projectinfo.GetServerName())
+	}
 
 	// 2. start the master server
 	masterServerErr := runMasterServer(
@@ -28,11 +28,13 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/apimachinery/pkg/util/httpstream/wsstream"
 	"k8s.io/apiserver/pkg/util/flushwriter"
 	"k8s.io/klog/v2"
 
+	"github.com/openyurtio/openyurt/pkg/util/tracing"
 	"github.com/openyurtio/openyurt/pkg/yurttunnel/constants"
 )
 
@@ -141,15 +143,20 @@ func klogAndHTTPError(w http.ResponseWriter, errCode int, format string, i ...in
 // to the client
 func (ri *RequestInterceptor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// 1. setup the tunnel
+	_, span := tracing.Start(r.Context(), "TunnelDial", attribute.String(tracing.AttributeTunnelHost, r.Host))
 	tunnelConn, err := ri.contextDialer(r.Host, r.Header, r.TLS != nil)
 	if err != nil {
+		tracing.RecordError(span, err)
+		span.End()
 		klogAndHTTPError(w, http.StatusServiceUnavailable,
 			"could not setup the tunnel: %s", err)
 		return
 	}
+	span.End()
 	defer tunnelConn.Close()
 
-	// 2. proxy the request to tunnel
+	// 2. proxy the request to tunnel, and trace context is propagated to the server on the edge node.
+	tracing.InjectHeader(r.Context(), r.Header)
 	if err := r.Write(tunnelConn); err != nil {
 		klogAndHTTPError(w, http.StatusServiceUnavailable,
 			"could not write request to tls connection: %s", err)
@@ -19,6 +19,8 @@ package server
 import (
 	"crypto/tls"
 
+	"go.opentelemetry.io/otel/trace"
+
 	hw "github.com/openyurtio/openyurt/pkg/yurttunnel/handlerwrapper"
 )
 
@@ -39,7 +41,8 @@ func NewTunnelServer(
 	tlsCfg *tls.Config,
 	proxyClientTLSCfg *tls.Config,
 	wrappers hw.HandlerWrappers,
-	proxyStrategy string) TunnelServer {
+	proxyStrategy string,
+	tracerProvider trace.TracerProvider) TunnelServer {
 	ats := anpTunnelServer{
 		egressSelectorEnabled:    egressSelectorEnabled,
 		interceptorServerUDSFile: interceptorServerUDSFile,
@@ -51,6 +54,7 @@ func NewTunnelServer(
 		proxyClientTLSCfg:        proxyClientTLSCfg,
 		wrappers:                 wrappers,
 		proxyStrategy:            proxyStrategy,
+		tracerProvider:           tracerProvider,
 	}
 	return &ats
 }
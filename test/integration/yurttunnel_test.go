@@ -173,6 +173,7 @@ func startTunnelServer(t *testing.T) {
 		&tlsCfg,
 		wrappers,                                /* hw.HandlerWrappers */
 		string(anpserver.ProxyStrategyDestHost), /* proxyStrategy */
+		nil,                                     /* tracerProvider */
 	)
 	tunnelServer.Run()
 	klog.Info("[TEST] Yurttunnel Server is running")